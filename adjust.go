@@ -11,6 +11,11 @@
 
 package excelize
 
+import (
+	"strconv"
+	"strings"
+)
+
 type adjustDirection bool
 
 const (
@@ -27,7 +32,7 @@ const (
 // row: Index number of the row we're inserting/deleting before
 // offset: Number of rows/column to insert/delete negative values indicate deletion
 //
-// TODO: adjustPageBreaks, adjustComments, adjustDataValidations, adjustProtectedCells
+// TODO: adjustPageBreaks, adjustComments, adjustProtectedCells
 //
 func (f *File) adjustHelper(sheet string, dir adjustDirection, num, offset int) error {
 	ws, err := f.workSheetReader(sheet)
@@ -44,6 +49,9 @@ func (f *File) adjustHelper(sheet string, dir adjustDirection, num, offset int)
 	if err = f.adjustMergeCells(ws, dir, num, offset); err != nil {
 		return err
 	}
+	if err = f.adjustDataValidations(ws, dir, num, offset); err != nil {
+		return err
+	}
 	if err = f.adjustAutoFilter(ws, dir, num, offset); err != nil {
 		return err
 	}
@@ -109,7 +117,8 @@ func (f *File) adjustHyperlinks(ws *xlsxWorksheet, sheet string, dir adjustDirec
 			linkData := ws.Hyperlinks.Hyperlink[i]
 			colNum, rowNum, _ := CellNameToCoordinates(linkData.Ref)
 
-			if (dir == rows && num == rowNum) || (dir == columns && num == colNum) {
+			if (dir == rows && num <= rowNum && rowNum < num-offset) ||
+				(dir == columns && num <= colNum && colNum < num-offset) {
 				f.deleteSheetRelationships(sheet, linkData.RID)
 				if len(ws.Hyperlinks.Hyperlink) > 1 {
 					ws.Hyperlinks.Hyperlink = append(ws.Hyperlinks.Hyperlink[:i],
@@ -151,7 +160,8 @@ func (f *File) adjustAutoFilter(ws *xlsxWorksheet, dir adjustDirection, num, off
 	}
 	x1, y1, x2, y2 := coordinates[0], coordinates[1], coordinates[2], coordinates[3]
 
-	if (dir == rows && y1 == num && offset < 0) || (dir == columns && x1 == num && x2 == num) {
+	if (dir == rows && y1 == num && offset < 0) || (dir == columns && x1 == num &&
+		(x2 == num || (offset < 0 && x2 < num-offset))) {
 		ws.AutoFilter = nil
 		for rowIdx := range ws.SheetData.Row {
 			rowData := &ws.SheetData.Row[rowIdx]
@@ -184,7 +194,9 @@ func (f *File) adjustAutoFilterHelper(dir adjustDirection, coordinates []int, nu
 		}
 	} else {
 		if coordinates[2] >= num {
-			coordinates[2] += offset
+			if coordinates[2] += offset; coordinates[2] < num-1 {
+				coordinates[2] = num - 1
+			}
 		}
 	}
 	return coordinates
@@ -205,43 +217,68 @@ func (f *File) adjustMergeCells(ws *xlsxWorksheet, dir adjustDirection, num, off
 		}
 		x1, y1, x2, y2 := coordinates[0], coordinates[1], coordinates[2], coordinates[3]
 		if dir == rows {
-			if y1 == num && y2 == num && offset < 0 {
+			if y1 >= num && y2 < num-offset && offset < 0 {
 				f.deleteMergeCell(ws, i)
 				i--
+				continue
 			}
-			y1 = f.adjustMergeCellsHelper(y1, num, offset)
-			y2 = f.adjustMergeCellsHelper(y2, num, offset)
+			y1, y2 = f.adjustMergeCellsHelper(y1, y2, num, offset)
 		} else {
-			if x1 == num && x2 == num && offset < 0 {
+			if x1 >= num && x2 < num-offset && offset < 0 {
 				f.deleteMergeCell(ws, i)
 				i--
+				continue
 			}
-			x1 = f.adjustMergeCellsHelper(x1, num, offset)
-			x2 = f.adjustMergeCellsHelper(x2, num, offset)
+			x1, x2 = f.adjustMergeCellsHelper(x1, x2, num, offset)
 		}
-		if x1 == x2 && y1 == y2 && i >= 0 {
+		if x1 == x2 && y1 == y2 {
 			f.deleteMergeCell(ws, i)
 			i--
+			continue
 		}
 		if areaData.Ref, err = f.coordinatesToAreaRef([]int{x1, y1, x2, y2}); err != nil {
 			return err
 		}
+		areaData.rect = nil
 	}
 	return nil
 }
 
 // adjustMergeCellsHelper provides a function for adjusting merge cells to
-// compare and calculate cell axis by the given pivot, operation axis and
-// offset.
-func (f *File) adjustMergeCellsHelper(pivot, num, offset int) int {
-	if pivot > num {
-		pivot += offset
-		if pivot < 1 {
-			return 1
+// compare and calculate the start and end cell axis by the given operation
+// axis and offset. When deleting, the start axis in the deleted area will be
+// moved to the next one after the deleted area, and the end axis in the
+// deleted area will be moved to the previous one before the deleted area.
+func (f *File) adjustMergeCellsHelper(p1, p2, num, offset int) (int, int) {
+	if p1 > p2 {
+		p1, p2 = p2, p1
+	}
+	if offset > 0 {
+		if p1 >= num {
+			p1 += offset
+		}
+		if p2 >= num {
+			p2 += offset
 		}
-		return pivot
+		return p1, p2
 	}
-	return pivot
+	if p1 > num {
+		if p1 += offset; p1 < num {
+			p1 = num
+		}
+	}
+	if p2 >= num {
+		if p2 += offset; p2 < num-1 {
+			p2 = num - 1
+		}
+	}
+	if p1 < 1 {
+		p1 = 1
+	}
+	if p2 < p1 {
+		p2 = p1
+	}
+	return p1, p2
 }
 
 // deleteMergeCell provides a function to delete merged cell by given index.
@@ -267,15 +304,239 @@ func (f *File) adjustCalcChain(dir adjustDirection, num, offset, sheetID int) er
 			return err
 		}
 		if dir == rows && num <= rowNum {
-			if newRow := rowNum + offset; newRow > 0 {
+			if newRow := adjustCalcChainHelper(rowNum, num, offset); newRow > 0 {
 				f.CalcChain.C[index].R, _ = CoordinatesToCellName(colNum, newRow)
 			}
 		}
 		if dir == columns && num <= colNum {
-			if newCol := colNum + offset; newCol > 0 {
+			if newCol := adjustCalcChainHelper(colNum, num, offset); newCol > 0 {
 				f.CalcChain.C[index].R, _ = CoordinatesToCellName(newCol, rowNum)
 			}
 		}
 	}
 	return nil
 }
+
+// adjustCalcChainHelper provides a function for adjusting the calculation
+// chain to calculate cell axis by the given pivot, operation axis and offset.
+// The axis of the cells in the deleted area will be moved to the previous
+// one, and it won't be less than 1.
+func adjustCalcChainHelper(pivot, num, offset int) int {
+	if pivot += offset; offset < 0 && pivot < num-1 {
+		pivot = num - 1
+	}
+	if pivot < 1 {
+		return 1
+	}
+	return pivot
+}
+
+// adjustDataValidations provides a function to update the data validations
+// when inserting or deleting rows or columns. The data validation will be
+// deleted if all cells it applies to have been deleted.
+func (f *File) adjustDataValidations(ws *xlsxWorksheet, dir adjustDirection, num, offset int) error {
+	if ws.DataValidations == nil {
+		return nil
+	}
+	for i := 0; i < len(ws.DataValidations.DataValidation); i++ {
+		dv := ws.DataValidations.DataValidation[i]
+		if dv == nil {
+			continue
+		}
+		var sqref []string
+		for _, ref := range strings.Fields(dv.Sqref) {
+			newRef, err := f.adjustCellRef(ref, dir, num, offset)
+			if err != nil {
+				return err
+			}
+			if newRef != "" {
+				sqref = append(sqref, newRef)
+			}
+		}
+		if len(sqref) == 0 {
+			ws.DataValidations.DataValidation = append(ws.DataValidations.DataValidation[:i],
+				ws.DataValidations.DataValidation[i+1:]...)
+			i--
+			continue
+		}
+		dv.Sqref = strings.Join(sqref, " ")
+	}
+	ws.DataValidations.Count = len(ws.DataValidations.DataValidation)
+	if ws.DataValidations.Count == 0 {
+		ws.DataValidations = nil
+	}
+	return nil
+}
+
+// adjustFormulas provides a function to update the references in the
+// formulas of the worksheet when inserting rows, the references to the other
+// worksheets will be kept.
+func (f *File) adjustFormulas(ws *xlsxWorksheet, sheet string, num, offset int) {
+	for rowIdx := range ws.SheetData.Row {
+		for colIdx := range ws.SheetData.Row[rowIdx].C {
+			if formula := ws.SheetData.Row[rowIdx].C[colIdx].F; formula != nil {
+				formula.Content = adjustFormulaRows(formula.Content, sheet, num, offset)
+				if formula.Ref != "" {
+					formula.Ref = adjustFormulaRows(formula.Ref, sheet, num, offset)
+				}
+			}
+		}
+	}
+}
+
+// adjustFormulaRows provides a function to move the row references of the
+// given formula which are not less than the row number by the offset, both
+// the relative and absolute references will be moved. The string literals,
+// function names, structured references and the references to the other
+// worksheets will be kept.
+func adjustFormulaRows(formula, sheet string, num, offset int) string {
+	var (
+		res             strings.Builder
+		qualifier       string
+		qualified       bool
+		bracket         int
+		orig            = []byte(formula)
+		isRangeBoundary = func(i int) bool { return i >= 0 && i < len(orig) && orig[i] == ':' }
+	)
+	for i := 0; i < len(orig); {
+		c := orig[i]
+		if c == '"' || c == '\'' {
+			j := i + 1
+			for ; j < len(orig); j++ {
+				if orig[j] == c {
+					if j+1 < len(orig) && orig[j+1] == c {
+						j++
+						continue
+					}
+					break
+				}
+			}
+			if j >= len(orig) {
+				j = len(orig) - 1
+			}
+			res.Write(orig[i : j+1])
+			if c == '\'' && j+1 < len(orig) && orig[j+1] == '!' {
+				qualifier = strings.ReplaceAll(string(orig[i+1:j]), "''", "'")
+				qualified = true
+			}
+			i = j + 1
+			continue
+		}
+		if bracket > 0 || !isFormulaNameChar(c) {
+			switch c {
+			case '[':
+				bracket++
+			case ']':
+				bracket--
+			case '!', ':':
+			default:
+				qualifier, qualified = "", false
+			}
+			res.WriteByte(c)
+			i++
+			continue
+		}
+		j := i
+		for j < len(orig) && isFormulaNameChar(orig[j]) {
+			j++
+		}
+		token := string(orig[i:j])
+		switch {
+		case j < len(orig) && orig[j] == '!':
+			qualifier, qualified = token, true
+			res.WriteString(token)
+		case j < len(orig) && (orig[j] == '(' || orig[j] == '['):
+			res.WriteString(token)
+		case qualified && !strings.EqualFold(qualifier, sheet):
+			res.WriteString(token)
+		default:
+			res.WriteString(adjustFormulaRowRef(token, isRangeBoundary(j) || isRangeBoundary(i-1), num, offset))
+		}
+		i = j
+	}
+	return res.String()
+}
+
+// adjustFormulaRowRef provides a function to move the row number of the cell
+// reference or the row reference by the offset if the row number is not less
+// than the given row number. The row reference will be only moved if it's a
+// boundary of a range. The "#REF!" will be returned if the moved row is out
+// of the worksheet.
+func adjustFormulaRowRef(ref string, inRange bool, num, offset int) string {
+	colName, rowNum, absCol, absRow, ok := splitFormulaRef(ref)
+	if !ok || rowNum == "" || (colName == "" && !inRange) {
+		return ref
+	}
+	row, err := strconv.Atoi(rowNum)
+	if err != nil || row < num {
+		return ref
+	}
+	if row += offset; row < 1 || row > TotalRows {
+		return formulaErrorREF
+	}
+	signCol, signRow := "", ""
+	if absCol {
+		signCol = "$"
+	}
+	if absRow {
+		signRow = "$"
+	}
+	if colName == "" {
+		return signRow + strconv.Itoa(row)
+	}
+	return signCol + colName + signRow + strconv.Itoa(row)
+}
+
+// adjustCellRef provides a function to adjust the cell reference or the
+// range reference when inserting or deleting rows or columns. It returns an
+// empty string if all cells of the reference have been deleted.
+func (f *File) adjustCellRef(ref string, dir adjustDirection, num, offset int) (string, error) {
+	var (
+		coordinates []int
+		err         error
+	)
+	area := strings.Contains(ref, ":")
+	if area {
+		if coordinates, err = areaRefToCoordinates(ref); err != nil {
+			return ref, err
+		}
+		_ = sortCoordinates(coordinates)
+	} else {
+		col, row, err := CellNameToCoordinates(ref)
+		if err != nil {
+			return ref, err
+		}
+		coordinates = []int{col, row, col, row}
+	}
+	start, end := 0, 2
+	if dir == rows {
+		start, end = 1, 3
+	}
+	if offset > 0 {
+		if coordinates[start] >= num {
+			coordinates[start] += offset
+		}
+		if coordinates[end] >= num {
+			coordinates[end] += offset
+		}
+	} else {
+		last := num - offset - 1
+		if coordinates[start] >= num && coordinates[end] <= last {
+			return "", err
+		}
+		if coordinates[start] > last {
+			coordinates[start] += offset
+		} else if coordinates[start] >= num {
+			coordinates[start] = num
+		}
+		if coordinates[end] > last {
+			coordinates[end] += offset
+		} else if coordinates[end] >= num {
+			coordinates[end] = num - 1
+		}
+	}
+	if area {
+		return f.coordinatesToAreaRef(coordinates)
+	}
+	return CoordinatesToCellName(coordinates[0], coordinates[1])
+}
@@ -45,6 +45,58 @@ func TestAdjustMergeCells(t *testing.T) {
 			},
 		},
 	}, columns, 1, -1))
+
+	// Test delete multiple rows or columns at once gives the same merged
+	// cells as deleting them one by one.
+	mergeCells := func(refs ...string) *xlsxWorksheet {
+		ws := &xlsxWorksheet{MergeCells: &xlsxMergeCells{}}
+		for _, ref := range refs {
+			ws.MergeCells.Cells = append(ws.MergeCells.Cells, &xlsxMergeCell{Ref: ref})
+		}
+		return ws
+	}
+	for _, c := range []struct {
+		dir      adjustDirection
+		num, n   int
+		refs     []string
+		expected []string
+	}{
+		{columns, 4, 2, []string{"A1:B1", "D1:E1"}, []string{"A1:B1"}},
+		{columns, 3, 2, []string{"A1:B1", "B2:E2", "C3:G3", "D4:D5", "B6:C6"}, []string{"A1:B1", "B2:C2", "C3:E3"}},
+		{rows, 4, 2, []string{"A1:A2", "A4:A5"}, []string{"A1:A2"}},
+		{rows, 3, 2, []string{"A1:A2", "B2:B5", "C3:C7", "D4:E4", "F2:F3"}, []string{"A1:A2", "B2:B3", "C3:C5"}},
+	} {
+		expected, actual := mergeCells(c.refs...), mergeCells(c.refs...)
+		for i := 0; i < c.n; i++ {
+			assert.NoError(t, f.adjustMergeCells(expected, c.dir, c.num, -1))
+		}
+		assert.NoError(t, f.adjustMergeCells(actual, c.dir, c.num, -c.n))
+		var expectedRefs, actualRefs []string
+		for _, cell := range expected.MergeCells.Cells {
+			expectedRefs = append(expectedRefs, cell.Ref)
+		}
+		for _, cell := range actual.MergeCells.Cells {
+			actualRefs = append(actualRefs, cell.Ref)
+		}
+		assert.Equal(t, c.expected, expectedRefs)
+		assert.Equal(t, c.expected, actualRefs)
+	}
+}
+
+func TestAdjustFormulaRows(t *testing.T) {
+	for formula, expected := range map[string]string{
+		"A2+A4":                              "A2+A7",
+		"SUM($A$3:B10)*2":                    "SUM($A$6:B13)*2",
+		"SUM(3:5)+SUM(A:A)+5":                "SUM(6:8)+SUM(A:A)+5",
+		"Sheet1!A4+sheet1!B5":                "Sheet1!A7+sheet1!B8",
+		"Sheet2!A4+'Sheet 2'!A4:B5":          "Sheet2!A4+'Sheet 2'!A4:B5",
+		"'Sheet1'!A4:B5+A4":                  "'Sheet1'!A7:B8+A7",
+		`CONCATENATE("A4",A4)`:               `CONCATENATE("A4",A7)`,
+		"Table1[[#This Row],[A4]]+LOG10(A4)": "Table1[[#This Row],[A4]]+LOG10(A7)",
+		"A1048575":                           "#REF!",
+	} {
+		assert.Equal(t, expected, adjustFormulaRows(formula, "Sheet1", 3, 3), formula)
+	}
 }
 
 func TestAdjustAutoFilter(t *testing.T) {
@@ -85,7 +137,24 @@ func TestAdjustHelper(t *testing.T) {
 }
 
 func TestAdjustMergeCellsHelper(t *testing.T) {
-	assert.Equal(t, 1, NewFile().adjustMergeCellsHelper(1, 0, -2))
+	for _, c := range []struct {
+		p1, p2, num, offset int
+		expected            []int
+	}{
+		{1, 1, 0, -2, []int{1, 1}},
+		// Test the merged cell starts at the inserted row will be moved down.
+		{3, 4, 3, 2, []int{5, 6}},
+		{2, 4, 3, 2, []int{2, 6}},
+		// Test the axis in the deleted area will be moved to the boundary.
+		{2, 5, 3, -2, []int{2, 3}},
+		{3, 6, 3, -2, []int{3, 4}},
+		{2, 4, 3, -2, []int{2, 2}},
+		{1, 3, 3, -1, []int{1, 2}},
+		{5, 2, 3, -2, []int{2, 3}},
+	} {
+		p1, p2 := NewFile().adjustMergeCellsHelper(c.p1, c.p2, c.num, c.offset)
+		assert.Equal(t, c.expected, []int{p1, p2})
+	}
 }
 
 func TestAdjustCalcChain(t *testing.T) {
@@ -103,3 +172,28 @@ func TestAdjustCalcChain(t *testing.T) {
 	f.CalcChain = nil
 	assert.NoError(t, f.InsertCol("Sheet1", "A"))
 }
+
+func TestAdjustDataValidations(t *testing.T) {
+	f := NewFile()
+	// testing adjustDataValidations with illegal cell coordinates.
+	assert.EqualError(t, f.adjustDataValidations(&xlsxWorksheet{
+		DataValidations: &xlsxDataValidations{
+			DataValidation: []*DataValidation{{Sqref: "A:B1"}},
+		},
+	}, rows, 1, -1), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+	assert.EqualError(t, f.adjustDataValidations(&xlsxWorksheet{
+		DataValidations: &xlsxDataValidations{
+			DataValidation: []*DataValidation{{Sqref: "A"}},
+		},
+	}, rows, 1, -1), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+	ws := &xlsxWorksheet{
+		DataValidations: &xlsxDataValidations{
+			DataValidation: []*DataValidation{{Sqref: "A1:B2"}, {Sqref: "A3 A4:B5"}},
+		},
+	}
+	assert.NoError(t, f.adjustDataValidations(ws, rows, 1, -2))
+	assert.Len(t, ws.DataValidations.DataValidation, 1)
+	assert.Equal(t, "A1 A2:B3", ws.DataValidations.DataValidation[0].Sqref)
+	assert.NoError(t, f.adjustDataValidations(ws, rows, 1, -3))
+	assert.Nil(t, ws.DataValidations)
+}
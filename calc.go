@@ -56,6 +56,7 @@ const (
 	criteriaG
 	criteriaErr
 	criteriaRegexp
+	criteriaNe
 	maxFinancialIterations = 128
 	financialPercision     = 1.0e-08
 	// Date and time format regular expressions
@@ -165,6 +166,9 @@ var (
 			return fmt.Sprintf("R[%d]C[%d]", row, col), nil
 		},
 	}
+	// formulaFailureExp matches the error messages of the unsupported formula
+	// functions and the functions called with wrong number of arguments.
+	formulaFailureExp = regexp.MustCompile(`^(not support \S+ function|\S+ (requires|accepts|allows|takes|expects) (no|(exactly |at least |at most )?\d+( or \d+)?( numeric| number| string| boolean or numeric)?) arguments?)$`)
 )
 
 // cellRef defines the structure of a cell reference.
@@ -287,6 +291,35 @@ func (fa formulaArg) ToList() []formulaArg {
 type formulaFuncs struct {
 	f           *File
 	sheet, cell string
+	opts        *CalcOptions
+}
+
+// CalcOptions directly maps the settings of the formula calculation. Now
+// specifies the fixed time used by the volatile functions NOW and TODAY, the
+// current time will be used if it is zero. Rand specifies the random number
+// generator used by the volatile functions RAND and RANDBETWEEN, a generator
+// seeded with the current time will be used if it is nil. The same options
+// can be reused across multiple cell evaluations to get consistent results,
+// note that the Rand is not safe for concurrent use.
+type CalcOptions struct {
+	Now  time.Time
+	Rand *rand.Rand
+}
+
+// now returns the current time for the volatile functions.
+func (fn *formulaFuncs) now() time.Time {
+	if fn.opts != nil && !fn.opts.Now.IsZero() {
+		return fn.opts.Now
+	}
+	return time.Now()
+}
+
+// rand returns the random number generator for the volatile functions.
+func (fn *formulaFuncs) rand() *rand.Rand {
+	if fn.opts != nil && fn.opts.Rand != nil {
+		return fn.opts.Rand
+	}
+	return rand.New(rand.NewSource(time.Now().UnixNano()))
 }
 
 // CalcCellValue provides a function to get calculated cell value. This
@@ -315,6 +348,7 @@ type formulaFuncs struct {
 //    AVEDEV
 //    AVERAGE
 //    AVERAGEA
+//    AVERAGEIFS
 //    BASE
 //    BESSELI
 //    BESSELJ
@@ -352,6 +386,7 @@ type formulaFuncs struct {
 //    COUNT
 //    COUNTA
 //    COUNTBLANK
+//    COUNTIFS
 //    COUPDAYBS
 //    COUPDAYS
 //    COUPDAYSNC
@@ -380,8 +415,10 @@ type formulaFuncs struct {
 //    DOLLARDE
 //    DOLLARFR
 //    DURATION
+//    EDATE
 //    EFFECT
 //    ENCODEURL
+//    EOMONTH
 //    ERF
 //    ERF.PRECISE
 //    ERFC
@@ -570,6 +607,7 @@ type formulaFuncs struct {
 //    SUBSTITUTE
 //    SUM
 //    SUMIF
+//    SUMIFS
 //    SUMSQ
 //    SWITCH
 //    SYD
@@ -603,6 +641,7 @@ type formulaFuncs struct {
 //    WEIBULL
 //    WEIBULL.DIST
 //    XIRR
+//    XLOOKUP
 //    XNPV
 //    XOR
 //    YEAR
@@ -614,6 +653,28 @@ type formulaFuncs struct {
 //    ZTEST
 //
 func (f *File) CalcCellValue(sheet, cell string) (result string, err error) {
+	return f.CalcCellValueWithOptions(sheet, cell, nil)
+}
+
+// CalcCellValueWithOptions provides a function to get calculated cell value
+// like the CalcCellValue with the given calculation options, the options can
+// be nil. For example, calculate the cells A1 and A2 on Sheet1 with a fixed
+// time and a seeded random number generator:
+//
+//    opts := &excelize.CalcOptions{
+//        Now:  time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+//        Rand: rand.New(rand.NewSource(1)),
+//    }
+//    for _, cell := range []string{"A1", "A2"} {
+//        result, err := f.CalcCellValueWithOptions("Sheet1", cell, opts)
+//        if err != nil {
+//            fmt.Println(err)
+//            return
+//        }
+//        fmt.Println(result)
+//    }
+//
+func (f *File) CalcCellValueWithOptions(sheet, cell string, opts *CalcOptions) (result string, err error) {
 	var (
 		formula string
 		token   efp.Token
@@ -626,7 +687,7 @@ func (f *File) CalcCellValue(sheet, cell string) (result string, err error) {
 	if tokens == nil {
 		return
 	}
-	if token, err = f.evalInfixExp(sheet, cell, tokens); err != nil {
+	if token, err = f.evalInfixExp(sheet, cell, tokens, opts); err != nil {
 		return
 	}
 	result = token.TValue
@@ -638,6 +699,259 @@ func (f *File) CalcCellValue(sheet, cell string) (result string, err error) {
 	return
 }
 
+// CalcCellValueEx provides a function to get calculated cell value like the
+// CalcCellValue, but the Excel error value which formula evaluated, such as
+// "#DIV/0!" and "#N/A", will be returned as the cell value with nil error,
+// and only the failure of the calculation will be returned as error. For
+// example, get the calculated value of the cell A1 on Sheet1:
+//
+//    result, err := f.CalcCellValueEx("Sheet1", "A1")
+//    if err != nil {
+//        fmt.Println(err)
+//        return
+//    }
+//    if isErr := strings.HasPrefix(result, "#"); isErr {
+//        fmt.Println("the formula evaluated to error value", result)
+//    }
+//
+func (f *File) CalcCellValueEx(sheet, cell string) (result string, err error) {
+	if result, err = f.CalcCellValue(sheet, cell); err != nil {
+		if val, ok := getFormulaErrorValue(err); ok {
+			return val, nil
+		}
+	}
+	return
+}
+
+// CalcSheet provides a function to calculate all formula cells in the
+// worksheet by given worksheet name, and write the calculated values back to
+// the cells as the cached values. The formula cells will be evaluated once
+// in the dependency order, so the formula which references other formula
+// cells in the same worksheet will get the calculated values of them. The
+// volatile functions such as NOW and RAND will use the same options in the
+// calculation, and the circular reference will be returned as an error with
+// the cells in the cycle. If the formula uses an unsupported function or
+// calls a function with wrong number of arguments, the error will be
+// returned and the cached value of the cell will be kept. For example,
+// calculate all formula cells on Sheet1:
+//
+//    if err := f.CalcSheet("Sheet1"); err != nil {
+//        fmt.Println(err)
+//    }
+//
+func (f *File) CalcSheet(sheet string) error {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	cells, refs := map[string]*xlsxC{}, map[string][][]int{}
+	for rowIdx := range ws.SheetData.Row {
+		for colIdx := range ws.SheetData.Row[rowIdx].C {
+			c := &ws.SheetData.Row[rowIdx].C[colIdx]
+			if c.F == nil {
+				continue
+			}
+			formula, err := f.GetCellFormula(sheet, c.R)
+			if err != nil {
+				return err
+			}
+			if formula != "" {
+				cells[c.R], refs[c.R] = c, f.getFormulaRefs(sheet, formula)
+			}
+		}
+	}
+	order, err := sortFormulaCells(cells, refs)
+	if err != nil {
+		return err
+	}
+	opts := &CalcOptions{Now: time.Now(), Rand: rand.New(rand.NewSource(time.Now().UnixNano()))}
+	for _, cell := range order {
+		result, err := f.CalcCellValueWithOptions(sheet, cell, opts)
+		if err != nil {
+			var ok bool
+			if result, ok = getFormulaErrorValue(err); !ok {
+				return err
+			}
+		}
+		ws.Lock()
+		cells[cell].T, cells[cell].V = setFormulaResult(result)
+		ws.Unlock()
+	}
+	return nil
+}
+
+// getFormulaRefs returns the referenced areas on the given worksheet by the
+// formula, each area represented by the coordinates of the top-left and
+// bottom-right cells.
+func (f *File) getFormulaRefs(sheet, formula string) [][]int {
+	var areas [][]int
+	ps := efp.ExcelParser()
+	for _, token := range ps.Parse(formula) {
+		if token.TSubType != efp.TokenSubTypeRange {
+			continue
+		}
+		ref := token.TValue
+		if refTo := f.getDefinedNameRefTo(ref, sheet); refTo != "" {
+			ref = refTo
+		}
+		ref = strings.Replace(ref, "$", "", -1)
+		if i := strings.LastIndex(ref, "!"); i != -1 {
+			if strings.Trim(ref[:i], "'") != sheet {
+				continue
+			}
+			ref = ref[i+1:]
+		}
+		var area []int
+		for _, part := range strings.Split(ref, ":") {
+			if i := strings.LastIndex(part, "!"); i != -1 {
+				part = part[i+1:]
+			}
+			rng := []int{1, 1, TotalColumns, TotalRows}
+			if col, row, err := CellNameToCoordinates(part); err == nil {
+				rng = []int{col, row, col, row}
+			} else if col, err := ColumnNameToNumber(part); err == nil {
+				rng[0], rng[2] = col, col
+			} else if row, err := strconv.Atoi(part); err == nil {
+				rng[1], rng[3] = row, row
+			} else {
+				area = nil
+				break
+			}
+			if area == nil {
+				area = rng
+				continue
+			}
+			area = []int{area[0], area[1], rng[2], rng[3]}
+			_ = sortCoordinates(area)
+		}
+		if area != nil {
+			areas = append(areas, area)
+		}
+	}
+	return areas
+}
+
+// sortFormulaCells sorts the formula cells by the dependency order, the cells
+// referenced by other formula cells will be placed in front of them. The
+// circular reference error will be returned if the cycle was found.
+func sortFormulaCells(cells map[string]*xlsxC, refs map[string][][]int) ([]string, error) {
+	var (
+		order, stack []string
+		names        []string
+		states       = map[string]int{}
+		coordinates  = map[[2]int]string{}
+		visit        func(cell string) error
+	)
+	for cell := range cells {
+		col, row, _ := CellNameToCoordinates(cell)
+		coordinates[[2]int{col, row}] = cell
+		names = append(names, cell)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		iCol, iRow, _ := CellNameToCoordinates(names[i])
+		jCol, jRow, _ := CellNameToCoordinates(names[j])
+		return iRow < jRow || iRow == jRow && iCol < jCol
+	})
+	// getDeps returns the formula cells referenced by the given cell
+	getDeps := func(cell string) (deps []string) {
+		for _, area := range refs[cell] {
+			if (area[2]-area[0]+1)*(area[3]-area[1]+1) > len(names) {
+				for _, name := range names {
+					col, row, _ := CellNameToCoordinates(name)
+					if col >= area[0] && col <= area[2] && row >= area[1] && row <= area[3] {
+						deps = append(deps, name)
+					}
+				}
+				continue
+			}
+			for row := area[1]; row <= area[3]; row++ {
+				for col := area[0]; col <= area[2]; col++ {
+					if name, ok := coordinates[[2]int{col, row}]; ok {
+						deps = append(deps, name)
+					}
+				}
+			}
+		}
+		return
+	}
+	visit = func(cell string) error {
+		switch states[cell] {
+		case 1:
+			for i, name := range stack {
+				if name == cell {
+					return newCircularReferenceError(stack[i:])
+				}
+			}
+		case 2:
+			return nil
+		}
+		states[cell] = 1
+		stack = append(stack, cell)
+		for _, dep := range getDeps(cell) {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		stack = stack[:len(stack)-1]
+		states[cell] = 2
+		order = append(order, cell)
+		return nil
+	}
+	for _, cell := range names {
+		if err := visit(cell); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
+
+// setFormulaResult prepares cell type and cell value by given calculated
+// result of the formula.
+func setFormulaResult(result string) (t string, v string) {
+	switch {
+	case result == "TRUE" || result == "FALSE":
+		return setCellBool(result == "TRUE")
+	case isFormulaErrorValue(result):
+		return "e", result
+	}
+	if isNum, _ := isNumeric(result); isNum || result == "" {
+		return "", result
+	}
+	return "str", result
+}
+
+// formulaErr defined the error that the formula evaluated to an Excel error
+// value, the code is the Excel error value and the msg is the detail message
+// of the error.
+type formulaErr struct {
+	code, msg string
+}
+
+// Error returns the detail message of the formula error.
+func (e formulaErr) Error() string {
+	return e.msg
+}
+
+// getFormulaErrorValue returns the Excel error value if the given error is
+// caused by the formula evaluated to an error value.
+func getFormulaErrorValue(err error) (string, bool) {
+	if fErr, ok := err.(formulaErr); ok {
+		return fErr.code, true
+	}
+	return err.Error(), isFormulaErrorValue(err.Error())
+}
+
+// isFormulaErrorValue returns if the given string is an Excel error value.
+func isFormulaErrorValue(val string) bool {
+	switch val {
+	case formulaErrorDIV, formulaErrorNAME, formulaErrorNA, formulaErrorNUM,
+		formulaErrorVALUE, formulaErrorREF, formulaErrorNULL, formulaErrorSPILL,
+		formulaErrorCALC, formulaErrorGETTINGDATA:
+		return true
+	}
+	return false
+}
+
 // getPriority calculate arithmetic operator priority.
 func getPriority(token efp.Token) (pri int) {
 	pri = tokenPriority[token.TValue]
@@ -706,7 +1020,7 @@ func newEmptyFormulaArg() formulaArg {
 //
 // TODO: handle subtypes: Nothing, Text, Logical, Error, Concatenation, Intersection, Union
 //
-func (f *File) evalInfixExp(sheet, cell string, tokens []efp.Token) (efp.Token, error) {
+func (f *File) evalInfixExp(sheet, cell string, tokens []efp.Token, opts *CalcOptions) (efp.Token, error) {
 	var err error
 	opdStack, optStack, opfStack, opfdStack, opftStack, argsStack := NewStack(), NewStack(), NewStack(), NewStack(), NewStack(), NewStack()
 	for i := 0; i < len(tokens); i++ {
@@ -795,7 +1109,7 @@ func (f *File) evalInfixExp(sheet, cell string, tokens []efp.Token) (efp.Token,
 				argsStack.Peek().(*list.List).PushBack(newStringFormulaArg(token.TValue))
 			}
 
-			if err = f.evalInfixExpFunc(sheet, cell, token, nextToken, opfStack, opdStack, opftStack, opfdStack, argsStack); err != nil {
+			if err = f.evalInfixExpFunc(sheet, cell, token, nextToken, opts, opfStack, opdStack, opftStack, opfdStack, argsStack); err != nil {
 				return efp.Token{}, err
 			}
 		}
@@ -814,7 +1128,7 @@ func (f *File) evalInfixExp(sheet, cell string, tokens []efp.Token) (efp.Token,
 }
 
 // evalInfixExpFunc evaluate formula function in the infix expression.
-func (f *File) evalInfixExpFunc(sheet, cell string, token, nextToken efp.Token, opfStack, opdStack, opftStack, opfdStack, argsStack *Stack) error {
+func (f *File) evalInfixExpFunc(sheet, cell string, token, nextToken efp.Token, opts *CalcOptions, opfStack, opdStack, opftStack, opfdStack, argsStack *Stack) error {
 	if !isFunctionStopToken(token) {
 		return nil
 	}
@@ -833,11 +1147,14 @@ func (f *File) evalInfixExpFunc(sheet, cell string, token, nextToken efp.Token,
 		argsStack.Peek().(*list.List).PushBack(newStringFormulaArg(opfdStack.Pop().(efp.Token).TValue))
 	}
 	// call formula function to evaluate
-	arg := callFuncByName(&formulaFuncs{f: f, sheet: sheet, cell: cell}, strings.NewReplacer(
+	arg := callFuncByName(&formulaFuncs{f: f, sheet: sheet, cell: cell, opts: opts}, strings.NewReplacer(
 		"_xlfn.", "", ".", "dot").Replace(opfStack.Peek().(efp.Token).TValue),
 		[]reflect.Value{reflect.ValueOf(argsStack.Peek().(*list.List))})
 	if arg.Type == ArgError && opfStack.Len() == 1 {
-		return errors.New(arg.Value())
+		if formulaFailureExp.MatchString(arg.Value()) {
+			return errors.New(arg.Value())
+		}
+		return formulaErr{code: arg.String, msg: arg.Value()}
 	}
 	argsStack.Pop()
 	opfStack.Pop()
@@ -1409,6 +1726,10 @@ func formulaCriteriaParser(exp string) (fc *formulaCriteria) {
 		fc.Type, fc.Condition = criteriaEq, match[1]
 		return
 	}
+	if match := regexp.MustCompile(`^<>(.*)$`).FindStringSubmatch(exp); len(match) > 1 {
+		fc.Type, fc.Condition = criteriaNe, match[1]
+		return
+	}
 	if match := regexp.MustCompile(`^<=(.*)$`).FindStringSubmatch(exp); len(match) > 1 {
 		fc.Type, fc.Condition = criteriaLe, match[1]
 		return
@@ -1435,6 +1756,30 @@ func formulaCriteriaParser(exp string) (fc *formulaCriteria) {
 	return
 }
 
+// wildcardToRegexp converts the criteria with the wildcard characters to the
+// case-insensitive regular expression, the question mark (?) matches any
+// single character, the asterisk (*) matches any sequence of characters and
+// the tilde (~) escapes the next wildcard character.
+func wildcardToRegexp(exp string) string {
+	var re strings.Builder
+	re.WriteString("(?i)^")
+	for i := 0; i < len(exp); i++ {
+		switch c := exp[i]; {
+		case c == '~' && i+1 < len(exp) && (exp[i+1] == '*' || exp[i+1] == '?' || exp[i+1] == '~'):
+			i++
+			re.WriteString(regexp.QuoteMeta(exp[i : i+1]))
+		case c == '*':
+			re.WriteString(".*")
+		case c == '?':
+			re.WriteString(".")
+		default:
+			re.WriteString(regexp.QuoteMeta(exp[i : i+1]))
+		}
+	}
+	re.WriteString("$")
+	return re.String()
+}
+
 // formulaCriteriaEval evaluate formula criteria expression.
 func formulaCriteriaEval(val string, criteria *formulaCriteria) (result bool, err error) {
 	var value, expected float64
@@ -1451,6 +1796,11 @@ func formulaCriteriaEval(val string, criteria *formulaCriteria) (result bool, er
 	switch criteria.Type {
 	case criteriaEq:
 		return val == criteria.Condition, err
+	case criteriaNe:
+		if value, expected, e = prepareValue(val, criteria.Condition); e == nil {
+			return value != expected, err
+		}
+		return val != criteria.Condition, err
 	case criteriaLe:
 		value, expected, e = prepareValue(val, criteria.Condition)
 		return value <= expected && e == nil, err
@@ -4184,7 +4534,7 @@ func (fn *formulaFuncs) RAND(argsList *list.List) formulaArg {
 	if argsList.Len() != 0 {
 		return newErrorFormulaArg(formulaErrorVALUE, "RAND accepts no arguments")
 	}
-	return newNumberFormulaArg(rand.New(rand.NewSource(time.Now().UnixNano())).Float64())
+	return newNumberFormulaArg(fn.rand().Float64())
 }
 
 // RANDBETWEEN function generates a random integer between two supplied
@@ -4207,7 +4557,7 @@ func (fn *formulaFuncs) RANDBETWEEN(argsList *list.List) formulaArg {
 	if top.Number < bottom.Number {
 		return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
 	}
-	num := rand.New(rand.NewSource(time.Now().UnixNano())).Int63n(int64(top.Number - bottom.Number + 1))
+	num := fn.rand().Int63n(int64(top.Number - bottom.Number + 1))
 	return newNumberFormulaArg(float64(num + int64(bottom.Number)))
 }
 
@@ -4750,6 +5100,97 @@ func (fn *formulaFuncs) SUMIF(argsList *list.List) formulaArg {
 	return newNumberFormulaArg(sum)
 }
 
+// formulaIfsRange returns the matrix of the range argument of the functions
+// with multiple criteria, the single value will be treated as 1 x 1 matrix.
+func formulaIfsRange(arg formulaArg) [][]formulaArg {
+	if arg.Type == ArgMatrix {
+		return arg.Matrix
+	}
+	return [][]formulaArg{{arg}}
+}
+
+// formulaIfsMatch returns the coordinates of the cells which satisfy all of
+// the given criteria_range and criteria pairs, the ranges should have the
+// same size as the given matrix, or the #VALUE! error will be returned.
+func formulaIfsMatch(mtx [][]formulaArg, args []formulaArg) (cellRefs []cellRef, errArg formulaArg) {
+	var (
+		criteriaRanges [][][]formulaArg
+		criterias      []*formulaCriteria
+	)
+	for i := 0; i < len(args); i += 2 {
+		criteriaRange := formulaIfsRange(args[i])
+		if mtx == nil {
+			mtx = criteriaRange
+		}
+		if len(criteriaRange) != len(mtx) || len(criteriaRange[0]) != len(mtx[0]) {
+			return nil, newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
+		}
+		criteriaRanges = append(criteriaRanges, criteriaRange)
+		criteria := formulaCriteriaParser(args[i+1].Value())
+		if criteria.Type == criteriaRegexp {
+			criteria.Condition = wildcardToRegexp(args[i+1].Value())
+		}
+		criterias = append(criterias, criteria)
+	}
+	for rowIdx, row := range mtx {
+		for colIdx := range row {
+			ok := true
+			for i, criteriaRange := range criteriaRanges {
+				if ok, _ = formulaCriteriaEval(criteriaRange[rowIdx][colIdx].Value(), criterias[i]); !ok {
+					break
+				}
+			}
+			if ok {
+				cellRefs = append(cellRefs, cellRef{Col: colIdx, Row: rowIdx})
+			}
+		}
+	}
+	return
+}
+
+// prepareIfsArgs checks the arguments of the functions with the range to be
+// calculated and multiple criteria pairs, and returns the values in the range
+// to be calculated which satisfy all the criteria.
+func prepareIfsArgs(name string, argsList *list.List) ([]formulaArg, formulaArg) {
+	if argsList.Len() < 3 || argsList.Len()%2 != 1 {
+		return nil, newErrorFormulaArg(formulaErrorVALUE, fmt.Sprintf("%s requires at least 3 arguments", name))
+	}
+	var args []formulaArg
+	for arg := argsList.Front().Next(); arg != nil; arg = arg.Next() {
+		args = append(args, arg.Value.(formulaArg))
+	}
+	mtx := formulaIfsRange(argsList.Front().Value.(formulaArg))
+	cellRefs, errArg := formulaIfsMatch(mtx, args)
+	if errArg.Type == ArgError {
+		return nil, errArg
+	}
+	var values []formulaArg
+	for _, ref := range cellRefs {
+		values = append(values, mtx[ref.Row][ref.Col])
+	}
+	return values, newEmptyFormulaArg()
+}
+
+// SUMIFS function finds values in one or more supplied arrays, that satisfy a
+// set of criteria, and returns the sum of the corresponding values in a
+// further supplied array. The syntax of the function is:
+//
+//    SUMIFS(sum_range,criteria_range1,criteria1,[criteria_range2,criteria2],...)
+//
+func (fn *formulaFuncs) SUMIFS(argsList *list.List) formulaArg {
+	values, errArg := prepareIfsArgs("SUMIFS", argsList)
+	if errArg.Type == ArgError {
+		return errArg
+	}
+	var sum float64
+	for _, value := range values {
+		if num := value.ToNumber(); num.Type == ArgNumber {
+			sum += num.Number
+		}
+	}
+	return newNumberFormulaArg(sum)
+}
+
 // SUMSQ function returns the sum of squares of a supplied set of values. The
 // syntax of the function is:
 //
@@ -4917,6 +5358,31 @@ func (fn *formulaFuncs) AVERAGEA(argsList *list.List) formulaArg {
 	return newNumberFormulaArg(sum / count)
 }
 
+// AVERAGEIFS function finds values in one or more supplied arrays that
+// satisfy a set of criteria, and returns the average (i.e. the statistical
+// mean) of the corresponding values in a further supplied array. The syntax
+// of the function is:
+//
+//    AVERAGEIFS(average_range,criteria_range1,criteria1,[criteria_range2,criteria2],...)
+//
+func (fn *formulaFuncs) AVERAGEIFS(argsList *list.List) formulaArg {
+	values, errArg := prepareIfsArgs("AVERAGEIFS", argsList)
+	if errArg.Type == ArgError {
+		return errArg
+	}
+	var count, sum float64
+	for _, value := range values {
+		if num := value.ToNumber(); num.Type == ArgNumber {
+			count++
+			sum += num.Number
+		}
+	}
+	if count == 0 {
+		return newErrorFormulaArg(formulaErrorDIV, "AVERAGEIFS divide by zero")
+	}
+	return newNumberFormulaArg(sum / count)
+}
+
 // incompleteGamma is an implementation of the incomplete gamma function.
 func incompleteGamma(a, x float64) float64 {
 	max := 32
@@ -5115,6 +5581,26 @@ func (fn *formulaFuncs) COUNTA(argsList *list.List) formulaArg {
 	return newNumberFormulaArg(float64(count))
 }
 
+// COUNTIFS function returns the number of rows within a table, that satisfy
+// a set of given criteria. The syntax of the function is:
+//
+//    COUNTIFS(criteria_range1,criteria1,[criteria_range2,criteria2],...)
+//
+func (fn *formulaFuncs) COUNTIFS(argsList *list.List) formulaArg {
+	if argsList.Len() < 2 || argsList.Len()%2 != 0 {
+		return newErrorFormulaArg(formulaErrorVALUE, "COUNTIFS requires at least 2 arguments")
+	}
+	var args []formulaArg
+	for arg := argsList.Front(); arg != nil; arg = arg.Next() {
+		args = append(args, arg.Value.(formulaArg))
+	}
+	cellRefs, errArg := formulaIfsMatch(nil, args)
+	if errArg.Type == ArgError {
+		return errArg
+	}
+	return newNumberFormulaArg(float64(len(cellRefs)))
+}
+
 // COUNTBLANK function returns the number of blank cells in a supplied range.
 // The syntax of the function is:
 //
@@ -7476,6 +7962,74 @@ func (fn *formulaFuncs) DAYS(argsList *list.List) formulaArg {
 	return newNumberFormulaArg(end.Number - start.Number)
 }
 
+// serialToDate provides a function to convert the whole days part of the
+// Excel serial date to year, month and day in the 1900 date system, the
+// serial number 60 will be converted to the nonexistent date 1900-02-29 for
+// compatibility with the Excel 1900 leap year bug.
+func serialToDate(serial int) (int, time.Month, int) {
+	if serial == 60 {
+		return 1900, time.February, 29
+	}
+	if serial > 60 {
+		serial--
+	}
+	return excelMinTime1900.AddDate(0, 0, serial).Date()
+}
+
+// calcEDate is an implementation of the formula functions EDATE and EOMONTH,
+// which returns the serial number of the date that is the indicated number
+// of months before or after the start date. The last day of the month will
+// be used if the endOfMonth is true.
+func (fn *formulaFuncs) calcEDate(name string, argsList *list.List, endOfMonth bool) formulaArg {
+	if argsList.Len() != 2 {
+		return newErrorFormulaArg(formulaErrorVALUE, fmt.Sprintf("%s requires 2 arguments", name))
+	}
+	startDate := fn.prepareDataValueArgs(1, argsList)
+	if startDate.Type != ArgList {
+		return startDate
+	}
+	months := argsList.Back().Value.(formulaArg).ToNumber()
+	if months.Type != ArgNumber {
+		return months
+	}
+	if startDate.List[0].Number < 0 {
+		return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
+	}
+	y, m, d := serialToDate(int(startDate.List[0].Number))
+	totalMonths := y*12 + int(m) - 1 + int(months.Number)
+	y, m = totalMonths/12, time.Month(totalMonths%12+1)
+	if y < 1900 || y > 9999 {
+		return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
+	}
+	days := getDaysInMonth(y, int(m))
+	if y == 1900 && m == time.February {
+		days = 29
+	}
+	if endOfMonth || d > days {
+		d = days
+	}
+	return newNumberFormulaArg(daysBetween(excelMinTime1900.Unix(), makeDate(y, m, d)) + 1)
+}
+
+// EDATE function returns a date that is a specified number of months before
+// or after a supplied start date. The syntax of function is:
+//
+//    EDATE(start_date,months)
+//
+func (fn *formulaFuncs) EDATE(argsList *list.List) formulaArg {
+	return fn.calcEDate("EDATE", argsList, false)
+}
+
+// EOMONTH function returns the last day of the month, that is a specified
+// number of months before or after an initial supplied start date. The
+// syntax of the function is:
+//
+//    EOMONTH(start_date,months)
+//
+func (fn *formulaFuncs) EOMONTH(argsList *list.List) formulaArg {
+	return fn.calcEDate("EOMONTH", argsList, true)
+}
+
 // ISOWEEKNUM function returns the ISO week number of a supplied date. The
 // syntax of the function is:
 //
@@ -7751,7 +8305,7 @@ func (fn *formulaFuncs) NOW(argsList *list.List) formulaArg {
 	if argsList.Len() != 0 {
 		return newErrorFormulaArg(formulaErrorVALUE, "NOW accepts no arguments")
 	}
-	now := time.Now()
+	now := fn.now()
 	_, offset := now.Zone()
 	return newNumberFormulaArg(25569.0 + float64(now.Unix()+int64(offset))/86400)
 }
@@ -7789,7 +8343,7 @@ func (fn *formulaFuncs) TODAY(argsList *list.List) formulaArg {
 	if argsList.Len() != 0 {
 		return newErrorFormulaArg(formulaErrorVALUE, "TODAY accepts no arguments")
 	}
-	now := time.Now()
+	now := fn.now()
 	_, offset := now.Zone()
 	return newNumberFormulaArg(daysBetween(excelMinTime1900.Unix(), now.Unix()+int64(offset)) + 1)
 }
@@ -8401,7 +8955,9 @@ func (fn *formulaFuncs) SUBSTITUTE(argsList *list.List) formulaArg {
 
 // TEXTJOIN function joins together a series of supplied text strings into one
 // combined text string. The user can specify a delimiter to add between the
-// individual text items, if required. The syntax of the function is:
+// individual text items, if required. If the delimiter is a range, the
+// values in the range will be used as the delimiters cyclically. The syntax
+// of the function is:
 //
 //    TEXTJOIN([delimiter],[ignore_empty],text1,[text2],...)
 //
@@ -8412,35 +8968,51 @@ func (fn *formulaFuncs) TEXTJOIN(argsList *list.List) formulaArg {
 	if argsList.Len() > 252 {
 		return newErrorFormulaArg(formulaErrorVALUE, "TEXTJOIN accepts at most 252 arguments")
 	}
-	delimiter := argsList.Front().Value.(formulaArg)
+	delimiters, ok := textJoin(argsList.Front(), []string{}, false, true)
+	if ok.Type != ArgNumber {
+		return ok
+	}
 	ignoreEmpty := argsList.Front().Next().Value.(formulaArg).ToBool()
 	if ignoreEmpty.Type != ArgNumber {
 		return ignoreEmpty
 	}
-	args, ok := textJoin(argsList.Front().Next().Next(), []string{}, ignoreEmpty.Number != 0)
+	args, ok := textJoin(argsList.Front().Next().Next(), []string{}, ignoreEmpty.Number != 0, false)
 	if ok.Type != ArgNumber {
 		return ok
 	}
-	result := strings.Join(args, delimiter.Value())
+	var buf strings.Builder
+	for i, arg := range args {
+		if i > 0 && len(delimiters) > 0 {
+			buf.WriteString(delimiters[(i-1)%len(delimiters)])
+		}
+		buf.WriteString(arg)
+	}
+	result := buf.String()
 	if len(result) > TotalCellChars {
 		return newErrorFormulaArg(formulaErrorVALUE, fmt.Sprintf("TEXTJOIN function exceeds %d characters", TotalCellChars))
 	}
 	return newStringFormulaArg(result)
 }
 
-// textJoin is an implementation of the formula function TEXTJOIN.
-func textJoin(arg *list.Element, arr []string, ignoreEmpty bool) ([]string, formulaArg) {
-	for arg.Next(); arg != nil; arg = arg.Next() {
+// textJoin is an implementation of the formula function TEXTJOIN. The numeric
+// arguments will be converted to the displayed text, and only the first
+// argument will be used if the single parameter is true.
+func textJoin(arg *list.Element, arr []string, ignoreEmpty, single bool) ([]string, formulaArg) {
+	for ; arg != nil; arg = arg.Next() {
 		switch arg.Value.(formulaArg).Type {
 		case ArgError:
 			return arr, arg.Value.(formulaArg)
-		case ArgString:
+		case ArgString, ArgEmpty:
 			val := arg.Value.(formulaArg).Value()
 			if val != "" || !ignoreEmpty {
 				arr = append(arr, val)
 			}
 		case ArgNumber:
-			arr = append(arr, arg.Value.(formulaArg).Value())
+			val := arg.Value.(formulaArg).Value()
+			if !arg.Value.(formulaArg).Boolean {
+				val = roundPrecision(strconv.FormatFloat(arg.Value.(formulaArg).Number, 'f', -1, 64), -1)
+			}
+			arr = append(arr, val)
 		case ArgMatrix:
 			for _, row := range arg.Value.(formulaArg).Matrix {
 				argList := list.New().Init()
@@ -8448,11 +9020,17 @@ func textJoin(arg *list.Element, arr []string, ignoreEmpty bool) ([]string, form
 					argList.PushBack(ele)
 				}
 				if argList.Len() > 0 {
-					args, _ := textJoin(argList.Front(), []string{}, ignoreEmpty)
+					args, ok := textJoin(argList.Front(), []string{}, ignoreEmpty, false)
+					if ok.Type != ArgNumber {
+						return arr, ok
+					}
 					arr = append(arr, args...)
 				}
 			}
 		}
+		if single {
+			break
+		}
 	}
 	return arr, newBoolFormulaArg(true)
 }
@@ -9401,6 +9979,140 @@ func (fn *formulaFuncs) ROWS(argsList *list.List) formulaArg {
 	return newStringFormulaArg(strconv.Itoa(result))
 }
 
+// checkXlookupArgs checking arguments, prepare lookup value, lookup array,
+// return array, match mode and search mode for the formula function XLOOKUP.
+func checkXlookupArgs(argsList *list.List) (matchMode, searchMode int, lookupValue, lookupArray, returnArray, errArg formulaArg) {
+	if argsList.Len() < 3 {
+		errArg = newErrorFormulaArg(formulaErrorVALUE, "XLOOKUP requires at least 3 arguments")
+		return
+	}
+	if argsList.Len() > 6 {
+		errArg = newErrorFormulaArg(formulaErrorVALUE, "XLOOKUP allows at most 6 arguments")
+		return
+	}
+	lookupValue = argsList.Front().Value.(formulaArg)
+	if lookupValue.Type == ArgError {
+		errArg = lookupValue
+		return
+	}
+	lookupArray = argsList.Front().Next().Value.(formulaArg)
+	returnArray = argsList.Front().Next().Next().Value.(formulaArg)
+	for _, arg := range []*formulaArg{&lookupArray, &returnArray} {
+		if arg.Type != ArgMatrix {
+			*arg = newMatrixFormulaArg([][]formulaArg{{*arg}})
+		}
+	}
+	if len(lookupArray.Matrix) == 0 || len(returnArray.Matrix) == 0 ||
+		len(lookupArray.Matrix) != 1 && len(lookupArray.Matrix[0]) != 1 {
+		errArg = newErrorFormulaArg(formulaErrorVALUE, "XLOOKUP requires lookup_array to be one-dimensional")
+		return
+	}
+	if len(lookupArray.Matrix) > 1 && len(lookupArray.Matrix) != len(returnArray.Matrix) ||
+		len(lookupArray.Matrix) == 1 && len(lookupArray.Matrix[0]) != len(returnArray.Matrix[0]) {
+		errArg = newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
+		return
+	}
+	searchMode = 1
+	for i, arg := 0, argsList.Front().Next().Next().Next(); arg != nil; i, arg = i+1, arg.Next() {
+		if i == 0 {
+			continue
+		}
+		num := arg.Value.(formulaArg).ToNumber()
+		if num.Type != ArgNumber {
+			errArg = num
+			return
+		}
+		if i == 1 {
+			if matchMode = int(num.Number); matchMode < -1 || matchMode > 2 {
+				errArg = newErrorFormulaArg(formulaErrorVALUE, "XLOOKUP requires match_mode to be 0, -1, 1 or 2")
+			}
+			continue
+		}
+		if searchMode = int(num.Number); searchMode != 1 && searchMode != -1 && searchMode != 2 && searchMode != -2 {
+			errArg = newErrorFormulaArg(formulaErrorVALUE, "XLOOKUP requires search_mode to be 1, -1, 2 or -2")
+		}
+	}
+	return
+}
+
+// xlookupCompare compares the cell value of the lookup array and the lookup
+// value, the numeric text will be compared as number if the lookup value is
+// number, and the wildcard characters will be matched if the match mode is 2.
+func xlookupCompare(cell, lookupValue formulaArg, matchMode int) byte {
+	lhs, rhs := cell, lookupValue
+	if lookupValue.Type == ArgNumber && !lookupValue.Boolean || lookupValue.Type == ArgString {
+		num, cellNum := lookupValue.ToNumber(), cell.ToNumber()
+		if num.Type == ArgNumber && cellNum.Type == ArgNumber && (cell.Type == ArgString || cell.Type == ArgNumber) {
+			lhs, rhs = cellNum, num
+		}
+	}
+	if lhs.Type == ArgNumber && rhs.Type == ArgString && !lhs.Boolean {
+		lhs = newStringFormulaArg(lhs.Value())
+	}
+	return compareFormulaArg(lhs, rhs, false, matchMode == 2)
+}
+
+// XLOOKUP function searches a range or an array, and then returns the item
+// corresponding to the first match it finds. If no match exists, then
+// XLOOKUP can return the closest (approximate) match. The match_mode argument
+// can be 0 (exact match), -1 (exact match or next smaller item), 1 (exact
+// match or next larger item) or 2 (wildcard match). The search_mode argument
+// can be 1 (search first-to-last) or -1 (search last-to-first), the binary
+// search modes 2 and -2 are treated as 1 and -1. The syntax of the function
+// is:
+//
+//    XLOOKUP(lookup_value,lookup_array,return_array,[if_not_found],[match_mode],[search_mode])
+//
+func (fn *formulaFuncs) XLOOKUP(argsList *list.List) formulaArg {
+	matchMode, searchMode, lookupValue, lookupArray, returnArray, errArg := checkXlookupArgs(argsList)
+	if errArg.Type == ArgError {
+		return errArg
+	}
+	cells, matchIdx := lookupArray.ToList(), -1
+	// the next smaller item should be the largest one of the smaller items,
+	// and the next larger item should be the smallest one of the larger items
+	closer := criteriaG
+	if matchMode == 1 {
+		closer = criteriaL
+	}
+	for i := range cells {
+		idx := i
+		if searchMode < 0 {
+			idx = len(cells) - 1 - i
+		}
+		result := xlookupCompare(cells[idx], lookupValue, matchMode)
+		if result == criteriaEq {
+			matchIdx = idx
+			break
+		}
+		if matchMode == -1 && result == criteriaL || matchMode == 1 && result == criteriaG {
+			if matchIdx == -1 || xlookupCompare(cells[idx], cells[matchIdx], 0) == closer {
+				matchIdx = idx
+			}
+		}
+	}
+	if matchIdx == -1 {
+		if argsList.Len() > 3 {
+			return argsList.Front().Next().Next().Next().Value.(formulaArg)
+		}
+		return newErrorFormulaArg(formulaErrorNA, formulaErrorNA)
+	}
+	if len(lookupArray.Matrix) > 1 {
+		if row := returnArray.Matrix[matchIdx]; len(row) > 1 {
+			return newMatrixFormulaArg([][]formulaArg{row})
+		}
+		return returnArray.Matrix[matchIdx][0]
+	}
+	if len(returnArray.Matrix) > 1 {
+		var col [][]formulaArg
+		for _, row := range returnArray.Matrix {
+			col = append(col, []formulaArg{row[matchIdx]})
+		}
+		return newMatrixFormulaArg(col)
+	}
+	return returnArray.Matrix[0][matchIdx]
+}
+
 // Web Functions
 
 // ENCODEURL function returns a URL-encoded string, replacing certain
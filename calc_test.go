@@ -2,9 +2,11 @@ package excelize
 
 import (
 	"container/list"
+	"math/rand"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/xuri/efp"
@@ -737,6 +739,15 @@ func TestCalcCellValue(t *testing.T) {
 		`=SUMIF(D2:D9,"Feb",F2:F9)`:     "157559",
 		`=SUMIF(E2:E9,"North 1",F2:F9)`: "66582",
 		`=SUMIF(E2:E9,"North*",F2:F9)`:  "138772",
+		// SUMIFS
+		`=SUMIFS(F2:F9,D2:D9,"Jan",E2:E9,"North*")`:      "58793",
+		`=SUMIFS(F2:F9,D2:D9,"Feb",F2:F9,">30000")`:      "127670",
+		`=SUMIFS(F2:F9,E2:E9,"<>North 1")`:               "237531",
+		`=SUMIFS(F2:F9,E2:E9,"?orth ?",D2:D9,"<>Jan")`:   "79979",
+		`=SUMIFS(F2:F9,E2:E9,"north*")`:                  "138772",
+		`=SUMIFS(F2:F9,F2:F9,">=50000",F2:F9,"<=53321")`: "103411",
+		`=SUMIFS(F2:F9,F2:F9,"<50000",F2:F9,">50000")`:   "0",
+		`=SUMIFS(D2:D9,D2:D9,"Jan")`:                     "0",
 		// SUMSQ
 		"=SUMSQ(A1:A4)":            "14",
 		"=SUMSQ(A1,B1,A2,B2,6)":    "82",
@@ -774,6 +785,9 @@ func TestCalcCellValue(t *testing.T) {
 		"=AVERAGEA(A1)":     "1",
 		"=AVERAGEA(A1:A2)":  "1.5",
 		"=AVERAGEA(D2:F9)":  "12671.375",
+		// AVERAGEIFS
+		`=AVERAGEIFS(F2:F9,D2:D9,"Feb")`:                   "39389.75",
+		`=AVERAGEIFS(F2:F9,E2:E9,"North*",F2:F9,">30000")`: "43391.5",
 		// CHIDIST
 		"=CHIDIST(0.5,3)": "0.918891411654676",
 		"=CHIDIST(8,3)":   "0.0460117056892315",
@@ -793,6 +807,17 @@ func TestCalcCellValue(t *testing.T) {
 		"=COUNTBLANK(1)":        "0",
 		"=COUNTBLANK(B1:C1)":    "1",
 		"=COUNTBLANK(C1)":       "1",
+		// COUNTIFS
+		`=COUNTIFS(D2:D9,"Jan",F2:F9,">30000")`: "3",
+		`=COUNTIFS(E2:E9,"South*")`:             "4",
+		`=COUNTIFS(A1:A4,">0")`:                 "3",
+		`=COUNTIFS(A1:A4,0)`:                    "1",
+		`=COUNTIFS(D1:D9,"=Feb")`:               "4",
+		`=COUNTIFS("a*b","a~*b")`:               "1",
+		`=COUNTIFS("axb","a~*b")`:               "0",
+		`=COUNTIFS("1.0","<>1")`:                "0",
+		`=COUNTIFS("1.5","<>1")`:                "1",
+		`=COUNTIFS("a","<>1")`:                  "1",
 		// DEVSQ
 		"=DEVSQ(1,3,5,2,9,7)": "47.5",
 		"=DEVSQ(A1:D2)":       "10",
@@ -1104,6 +1129,19 @@ func TestCalcCellValue(t *testing.T) {
 		"=DAYS(2,1)":                           "1",
 		"=DAYS(INT(2),INT(1))":                 "1",
 		"=DAYS(\"02/02/2015\",\"01/01/2015\")": "32",
+		// EDATE
+		"=EDATE(\"01/31/2021\",1)": "44255",
+		"=EDATE(43861,1)":          "43890",
+		"=EDATE(44286,-1)":         "44255",
+		"=EDATE(43890,12.9)":       "44255",
+		"=EDATE(31,1)":             "60",
+		"=EDATE(60,1)":             "89",
+		"=EDATE(61,-1)":            "32",
+		// EOMONTH
+		"=EOMONTH(\"01/15/2021\",0)": "44227",
+		"=EOMONTH(44211,-13)":        "43830",
+		"=EOMONTH(44270,1.9)":        "44316",
+		"=EOMONTH(1,1)":              "60",
 		// ISOWEEKNUM
 		"=ISOWEEKNUM(42370)":          "53",
 		"=ISOWEEKNUM(\"42370\")":      "53",
@@ -1285,11 +1323,14 @@ func TestCalcCellValue(t *testing.T) {
 		"=SUBSTITUTE(\"John is 5 years old\",\"John\",\"Jack\")": "Jack is 5 years old",
 		"=SUBSTITUTE(\"John is 5 years old\",\"5\",\"6\")":       "John is 6 years old",
 		// TEXTJOIN
-		"=TEXTJOIN(\"-\",TRUE,1,2,3,4)":  "1-2-3-4",
-		"=TEXTJOIN(A4,TRUE,A1:B2)":       "1040205",
-		"=TEXTJOIN(\",\",FALSE,A1:C2)":   "1,4,,2,5,",
-		"=TEXTJOIN(\",\",TRUE,A1:C2)":    "1,4,2,5",
-		"=TEXTJOIN(\",\",TRUE,MUNIT(2))": "1,0,0,1",
+		"=TEXTJOIN(\"-\",TRUE,1,2,3,4)":              "1-2-3-4",
+		"=TEXTJOIN(A4,TRUE,A1:B2)":                   "1040205",
+		"=TEXTJOIN(\",\",FALSE,A1:C2)":               "1,4,,2,5,",
+		"=TEXTJOIN(\",\",TRUE,A1:C2)":                "1,4,2,5",
+		"=TEXTJOIN(\",\",TRUE,MUNIT(2))":             "1,0,0,1",
+		"=TEXTJOIN(\",\",TRUE,SQRT(2),1234567,TRUE)": "1.4142135623731,1234567,TRUE",
+		"=TEXTJOIN(D1:D2,TRUE,A1:A4)":                "1Month2Jan3Month0",
+		"=TEXTJOIN(D1:D2,TRUE,A1)":                   "1",
 		// TRIM
 		"=TRIM(\" trim text \")": "trim text",
 		"=TRIM(0)":               "0",
@@ -2131,6 +2172,12 @@ func TestCalcCellValue(t *testing.T) {
 		"=SUM(1/)": ErrInvalidFormula.Error(),
 		// SUMIF
 		"=SUMIF()": "SUMIF requires at least 2 argument",
+		// SUMIFS
+		"=SUMIFS()":                                 "SUMIFS requires at least 3 arguments",
+		"=SUMIFS(F2:F9,D2:D9)":                      "SUMIFS requires at least 3 arguments",
+		`=SUMIFS(F2:F9,D2:D8,"Jan")`:                "#VALUE!",
+		`=SUMIFS(F2:F9,D2:E9,"Jan")`:                "#VALUE!",
+		`=SUMIFS(F2:F9,D2:D9,"Jan",E2:E8,"North*")`: "#VALUE!",
 		// SUMSQ
 		`=SUMSQ("X")`:   "strconv.ParseFloat: parsing \"X\": invalid syntax",
 		"=SUMSQ(C1:D2)": "strconv.ParseFloat: parsing \"Month\": invalid syntax",
@@ -2153,6 +2200,10 @@ func TestCalcCellValue(t *testing.T) {
 		"=AVERAGE(H1)": "AVERAGE divide by zero",
 		// AVERAGE
 		"=AVERAGEA(H1)": "AVERAGEA divide by zero",
+		// AVERAGEIFS
+		"=AVERAGEIFS()":                  "AVERAGEIFS requires at least 3 arguments",
+		`=AVERAGEIFS(F2:F9,D2:D9,"Mar")`: "AVERAGEIFS divide by zero",
+		`=AVERAGEIFS(F2:F9,D2:D8,"Jan")`: "#VALUE!",
 		// CHIDIST
 		"=CHIDIST()":         "CHIDIST requires 2 numeric arguments",
 		"=CHIDIST(\"\",3)":   "strconv.ParseFloat: parsing \"\": invalid syntax",
@@ -2178,6 +2229,10 @@ func TestCalcCellValue(t *testing.T) {
 		// COUNTBLANK
 		"=COUNTBLANK()":    "COUNTBLANK requires 1 argument",
 		"=COUNTBLANK(1,2)": "COUNTBLANK requires 1 argument",
+		// COUNTIFS
+		"=COUNTIFS()":                "COUNTIFS requires at least 2 arguments",
+		"=COUNTIFS(D2:D9)":           "COUNTIFS requires at least 2 arguments",
+		`=COUNTIFS(A1:A2,1,B1:C2,1)`: "#VALUE!",
 		// DEVSQ
 		"=DEVSQ()":      "DEVSQ requires at least 1 numeric argument",
 		"=DEVSQ(D1:D2)": "#N/A",
@@ -2511,6 +2566,17 @@ func TestCalcCellValue(t *testing.T) {
 		"=DAYS(0,\"\")": "#VALUE!",
 		"=DAYS(NA(),0)": "#VALUE!",
 		"=DAYS(0,NA())": "#VALUE!",
+		// EDATE
+		"=EDATE()":          "EDATE requires 2 arguments",
+		"=EDATE(\"x\",1)":   "#VALUE!",
+		"=EDATE(1,\"x\")":   "strconv.ParseFloat: parsing \"x\": invalid syntax",
+		"=EDATE(-1,1)":      "#NUM!",
+		"=EDATE(1,-1)":      "#NUM!",
+		"=EDATE(2958435,1)": "#NUM!",
+		// EOMONTH
+		"=EOMONTH()":        "EOMONTH requires 2 arguments",
+		"=EOMONTH(\"x\",1)": "#VALUE!",
+		"=EOMONTH(1,-1)":    "#NUM!",
 		// ISOWEEKNUM
 		"=ISOWEEKNUM()":                    "ISOWEEKNUM requires 1 argument",
 		"=ISOWEEKNUM(\"\")":                "#VALUE!",
@@ -2655,6 +2721,7 @@ func TestCalcCellValue(t *testing.T) {
 		"=TEXTJOIN()":               "TEXTJOIN requires at least 3 arguments",
 		"=TEXTJOIN(\"\",\"\",1)":    "strconv.ParseBool: parsing \"\": invalid syntax",
 		"=TEXTJOIN(\"\",TRUE,NA())": "#N/A",
+		"=TEXTJOIN(NA(),TRUE,1)":    "#N/A",
 		"=TEXTJOIN(\"\",TRUE," + strings.Repeat("0,", 250) + ",0)": "TEXTJOIN accepts at most 252 arguments",
 		"=TEXTJOIN(\",\",FALSE,REPT(\"*\",32768))":                 "TEXTJOIN function exceeds 32767 characters",
 		// TRIM
@@ -3321,6 +3388,134 @@ func TestCalculate(t *testing.T) {
 	assert.EqualError(t, calculate(opd, opt), err)
 }
 
+func TestCalcCellValueEx(t *testing.T) {
+	f := prepareCalcData([][]interface{}{{1, 0, "text"}})
+	for formula, expected := range map[string]string{
+		"=A1/B1":                 "#DIV/0!",
+		"=ABS(C1)":               "#VALUE!",
+		"=NA()":                  "#N/A",
+		"=SQRT(-1)":              "#NUM!",
+		"=IFERROR(A1/B1,\"ok\")": "ok",
+		"=A1+1":                  "2",
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "D1", formula))
+		result, err := f.CalcCellValueEx("Sheet1", "D1")
+		assert.NoError(t, err, formula)
+		assert.Equal(t, expected, result, formula)
+	}
+	// Test the error message of the CalcCellValue will not be changed
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D1", "=ABS()"))
+	_, err := f.CalcCellValue("Sheet1", "D1")
+	assert.EqualError(t, err, "ABS requires 1 numeric argument")
+	// Test the calculation failure will be returned as error
+	for formula, expected := range map[string]string{
+		"=FOOBAR()":    "not support FOOBAR function",
+		"=ABS()":       "ABS requires 1 numeric argument",
+		"=ABS(1,2)":    "ABS requires 1 numeric argument",
+		"=IF()":        "IF requires at least 1 argument",
+		"=NA(1)":       "NA accepts no arguments",
+		"=1+FOOBAR(1)": "not support FOOBAR function",
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "D1", formula))
+		result, err := f.CalcCellValueEx("Sheet1", "D1")
+		assert.EqualError(t, err, expected, formula)
+		assert.Empty(t, result, formula)
+	}
+	_, err = f.CalcCellValueEx("SheetN", "D1")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D1", "=-C1"))
+	_, err = f.CalcCellValueEx("Sheet1", "D1")
+	assert.EqualError(t, err, `strconv.ParseFloat: parsing "text": invalid syntax`)
+}
+
+func TestCalcCellValueWithOptions(t *testing.T) {
+	f := NewFile()
+	now := time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC)
+	for formula, expected := range map[string]string{
+		"=NOW()":   "44197.5",
+		"=TODAY()": "44197",
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "A1", formula))
+		result, err := f.CalcCellValueWithOptions("Sheet1", "A1", &CalcOptions{Now: now})
+		assert.NoError(t, err, formula)
+		assert.Equal(t, expected, result, formula)
+	}
+	// Test calculate volatile functions with the seeded random number generator
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A1", "=RAND()"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A2", "=RANDBETWEEN(1,1000000)"))
+	calc := func(opts *CalcOptions) (results []string) {
+		for _, cell := range []string{"A1", "A2"} {
+			result, err := f.CalcCellValueWithOptions("Sheet1", cell, opts)
+			assert.NoError(t, err)
+			results = append(results, result)
+		}
+		return
+	}
+	expected := calc(&CalcOptions{Rand: rand.New(rand.NewSource(1))})
+	assert.Equal(t, expected, calc(&CalcOptions{Rand: rand.New(rand.NewSource(1))}))
+	// Test calculate with nil options
+	result, err := f.CalcCellValueWithOptions("Sheet1", "A2", nil)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, result)
+}
+
+func TestCalcSheet(t *testing.T) {
+	f := prepareCalcData([][]interface{}{{1}})
+	for cell, formula := range map[string]string{
+		"B1": "=C1*2",
+		"C1": "=$A$1+1",
+		"D1": "=SUM(B1:C1)",
+		"E1": "=1/0",
+		"F1": "=\"x\"&A1",
+		"G1": "=B1>1",
+		"H1": "=SUM(B1:D1)",
+		"I1": "=SUM(Sheet2!A1,Sheet1!D1)",
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", cell, formula))
+	}
+	f.NewSheet("Sheet2")
+	assert.NoError(t, f.SetCellFormula("Sheet2", "A1", "=Sheet1!I1"))
+	assert.NoError(t, f.CalcSheet("Sheet1"))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	for i, expected := range [][]string{
+		{"", "4"}, {"", "2"}, {"", "6"}, {"e", "#DIV/0!"}, {"str", "x1"}, {"b", "1"}, {"", "12"}, {"", "6"},
+	} {
+		c := ws.SheetData.Row[0].C[i+1]
+		assert.Equal(t, expected, []string{c.T, c.V}, c.R)
+	}
+	formula, err := f.GetCellFormula("Sheet1", "D1")
+	assert.NoError(t, err)
+	assert.Equal(t, "=SUM(B1:C1)", formula)
+	assert.Equal(t, [][]int{{1, 1, 2, TotalRows}, {1, 2, TotalColumns, 3}, {1, 1, 1, 1}},
+		f.getFormulaRefs("Sheet1", "=SUM(A:$B,2:3,'Sheet 2'!A1,Sheet1!A1,name)"))
+	// Test calculate worksheet with circular references
+	for _, formula := range [][]string{{"=B1", "=C1+1", "=A1"}, {"=A1+1"}} {
+		f = NewFile()
+		for col, content := range formula {
+			cell, _ := CoordinatesToCellName(col+1, 1)
+			assert.NoError(t, f.SetCellFormula("Sheet1", cell, content))
+		}
+		expected := "circular reference found in cells A1"
+		if len(formula) > 1 {
+			expected = "circular reference found in cells A1, B1, C1"
+		}
+		assert.EqualError(t, f.CalcSheet("Sheet1"), expected)
+	}
+	// Test calculate worksheet with the calculation failure
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A1", "=-\"text\""))
+	assert.EqualError(t, f.CalcSheet("Sheet1"), `strconv.ParseFloat: parsing "text": invalid syntax`)
+	assert.EqualError(t, f.CalcSheet("SheetN"), "sheet SheetN is not exist")
+	// Test calculate worksheet with the unsupported function keeps the cached value
+	f = NewFile()
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A1", "=FOOBAR()"))
+	ws, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.SheetData.Row[0].C[0].T, ws.SheetData.Row[0].C[0].V = "", "100"
+	assert.EqualError(t, f.CalcSheet("Sheet1"), "not support FOOBAR function")
+	assert.Equal(t, []string{"", "100"}, []string{ws.SheetData.Row[0].C[0].T, ws.SheetData.Row[0].C[0].V})
+}
+
 func TestCalcWithDefinedName(t *testing.T) {
 	cellData := [][]interface{}{
 		{"A1 value", "B1 value", nil},
@@ -3467,6 +3662,58 @@ func TestCalcVLOOKUP(t *testing.T) {
 	}
 }
 
+func TestCalcXLOOKUP(t *testing.T) {
+	cellData := [][]interface{}{
+		{"Name", "Score", "Grade"},
+		{"Alice", 85, "B"},
+		{"Bob", 72, "C"},
+		{"Carol", 91, "A"},
+		{"Alice", 60, "D"},
+	}
+	f := prepareCalcData(cellData)
+	calc := map[string]string{
+		"=XLOOKUP(\"Bob\",A2:A5,B2:B5)":                 "72",
+		"=XLOOKUP(\"alice\",A2:A5,B2:B5)":               "85",
+		"=XLOOKUP(\"Alice\",A2:A5,C2:C5,\"none\",0,-1)": "D",
+		"=XLOOKUP(\"Alice\",A2:A5,C2:C5,\"none\",0,-2)": "D",
+		"=XLOOKUP(80,B2:B5,A2:A5,\"none\",-1)":          "Bob",
+		"=XLOOKUP(80,B2:B5,A2:A5,\"none\",1)":           "Alice",
+		"=XLOOKUP(91,B2:B5,A2:A5,\"none\",1,2)":         "Carol",
+		"=XLOOKUP(100,B2:B5,A2:A5,\"none\",1)":          "none",
+		"=XLOOKUP(50,B2:B5,A2:A5,\"none\",-1)":          "none",
+		"=XLOOKUP(\"C*\",A2:A5,B2:B5,\"none\",2)":       "91",
+		"=XLOOKUP(\"Dave\",A2:A5,B2:B5,\"none\")":       "none",
+		"=XLOOKUP(\"Score\",A1:C1,A3:C3)":               "72",
+		"=XLOOKUP(\"Grade\",A1:C1,A2:C2,\"none\",0,-1)": "B",
+		"=XLOOKUP(\"Name\",A1,B1)":                      "Score",
+	}
+	for formula, expected := range calc {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "F1", formula))
+		result, err := f.CalcCellValue("Sheet1", "F1")
+		assert.NoError(t, err, formula)
+		assert.Equal(t, expected, result, formula)
+	}
+	calcError := map[string]string{
+		"=XLOOKUP()": "XLOOKUP requires at least 3 arguments",
+		"=XLOOKUP(\"Bob\",A2:A5,B2:B5,\"\",0,1,1)": "XLOOKUP allows at most 6 arguments",
+		"=XLOOKUP(NA(),A2:A5,B2:B5)":               "#N/A",
+		"=XLOOKUP(\"Bob\",A2:B5,B2:B5)":            "XLOOKUP requires lookup_array to be one-dimensional",
+		"=XLOOKUP(\"Bob\",A2:A5,B2:B4)":            "#VALUE!",
+		"=XLOOKUP(\"Name\",A1:C1,A2:B2)":           "#VALUE!",
+		"=XLOOKUP(\"Bob\",A2:A5,B2:B5,\"\",\"x\")": "strconv.ParseFloat: parsing \"x\": invalid syntax",
+		"=XLOOKUP(\"Bob\",A2:A5,B2:B5,\"\",3)":     "XLOOKUP requires match_mode to be 0, -1, 1 or 2",
+		"=XLOOKUP(\"Bob\",A2:A5,B2:B5,\"\",0,0)":   "XLOOKUP requires search_mode to be 1, -1, 2 or -2",
+		"=XLOOKUP(\"Dave\",A2:A5,B2:B5)":           "#N/A",
+		"=XLOOKUP(100,B2:B5,A2:A5)":                "#N/A",
+	}
+	for formula, expected := range calcError {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "F1", formula))
+		result, err := f.CalcCellValue("Sheet1", "F1")
+		assert.EqualError(t, err, expected, formula)
+		assert.Equal(t, "", result, formula)
+	}
+}
+
 func TestCalcBoolean(t *testing.T) {
 	cellData := [][]interface{}{
 		{0.5, "TRUE", -0.5, "FALSE"},
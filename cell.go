@@ -18,6 +18,7 @@ import (
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
 )
 
 // CellType is the type of cell value type.
@@ -59,7 +60,8 @@ var cellTypes = map[string]CellType{
 // worksheet name and axis in spreadsheet file. If it is possible to apply a
 // format to the cell value, it will do so, if not then an error will be
 // returned, along with the raw value of the cell. All cells' values will be
-// the same in a merged range.
+// the same in a merged range. The raw value of the cell will be returned
+// without applying the number format if the RawCellValue option is specified.
 func (f *File) GetCellValue(sheet, axis string, opts ...Options) (string, error) {
 	return f.getCellStringFunc(sheet, axis, func(x *xlsxWorksheet, c *xlsxC) (string, bool, error) {
 		val, err := c.getValueFrom(f, f.sharedStringsReader(), parseOptions(opts...).RawCellValue)
@@ -485,14 +487,23 @@ type FormulaOpts struct {
 //    err := f.SetCellFormula("Sheet1", "A3", "=A1:A2",
 //	      excelize.FormulaOpts{Ref: &ref, Type: &formulaType})
 //
-// Example 6, set shared formula "=A1+B1" for the cell "C1:C5"
+// Example 6, set multi-cell array formula "{=A1:A3*B1:B3}" for the cells
+// "C1:C3" on "Sheet1", the braces around the formula are optional. The master
+// cell "C1" carries the formula, and the formulas and values of the other
+// cells in the range will be cleared:
+//
+//    formulaType, ref := excelize.STCellFormulaTypeArray, "C1:C3"
+//    err := f.SetCellFormula("Sheet1", "C1", "{=A1:A3*B1:B3}",
+//        excelize.FormulaOpts{Ref: &ref, Type: &formulaType})
+//
+// Example 7, set shared formula "=A1+B1" for the cell "C1:C5"
 // on "Sheet1", "C1" is the master cell:
 //
 //    formulaType, ref := excelize.STCellFormulaTypeShared, "C1:C5"
 //    err := f.SetCellFormula("Sheet1", "C1", "=A1+B1",
 //        excelize.FormulaOpts{Ref: &ref, Type: &formulaType})
 //
-// Example 7, set table formula "=SUM(Table1[[A]:[B]])" for the cell "C2"
+// Example 8, set table formula "=SUM(Table1[[A]:[B]])" for the cell "C2"
 // on "Sheet1":
 //
 //    package main
@@ -532,6 +543,27 @@ func (f *File) SetCellFormula(sheet, axis, formula string, opts ...FormulaOpts)
 	if err != nil {
 		return err
 	}
+	var formulaType, ref string
+	for _, o := range opts {
+		if o.Type != nil {
+			formulaType = *o.Type
+		}
+		if o.Ref != nil {
+			ref = *o.Ref
+		}
+	}
+	if formulaType == STCellFormulaTypeArray && formula != "" {
+		if ref == "" {
+			ref = axis
+			opts = append(opts, FormulaOpts{Ref: &ref})
+		}
+		if strings.HasPrefix(formula, "{") && strings.HasSuffix(formula, "}") {
+			formula = formula[1 : len(formula)-1]
+		}
+		if err = ws.setArrayFormula(axis, ref); err != nil {
+			return err
+		}
+	}
 	cellData, _, _, err := f.prepareCell(ws, sheet, axis)
 	if err != nil {
 		return err
@@ -568,6 +600,39 @@ func (f *File) SetCellFormula(sheet, axis, formula string, opts ...FormulaOpts)
 	return err
 }
 
+// setArrayFormula prepare the cells for the array formula by given master
+// cell and range reference, the master cell should be the top-left cell of
+// the range, and the formula and value of the other cells in the range will
+// be cleared.
+func (ws *xlsxWorksheet) setArrayFormula(axis, ref string) error {
+	if !strings.Contains(ref, ":") {
+		ref += ":" + ref
+	}
+	coordinates, err := areaRefToCoordinates(ref)
+	if err != nil {
+		return err
+	}
+	_ = sortCoordinates(coordinates)
+	col, row, err := CellNameToCoordinates(axis)
+	if err != nil {
+		return err
+	}
+	if col != coordinates[0] || row != coordinates[1] {
+		return ErrParameterInvalid
+	}
+	for c := coordinates[0]; c <= coordinates[2]; c++ {
+		for r := coordinates[1]; r <= coordinates[3]; r++ {
+			prepareSheetXML(ws, c, r)
+			if c == col && r == row {
+				continue
+			}
+			cell := &ws.SheetData.Row[r-1].C[c-1]
+			cell.F, cell.T, cell.V, cell.IS = nil, "", "", nil
+		}
+	}
+	return err
+}
+
 // setSharedFormula set shared formula for the cells.
 func (ws *xlsxWorksheet) setSharedFormula(ref string) error {
 	coordinates, err := areaRefToCoordinates(ref)
@@ -611,28 +676,51 @@ func (ws *xlsxWorksheet) countSharedFormula() (count int) {
 //    link, target, err := f.GetCellHyperLink("Sheet1", "H6")
 //
 func (f *File) GetCellHyperLink(sheet, axis string) (bool, string, error) {
+	linkType, target, _, err := f.GetCellHyperLinkDetail(sheet, axis)
+	return linkType != "", target, err
+}
+
+// GetCellHyperLinkDetail provides a function to get cell hyperlink with the
+// link type, the display value and the tooltip by given worksheet name and
+// axis. The link type will be "External" for the hyperlink to a web site or
+// a file, and "Location" for the hyperlink to a location in this workbook,
+// and the target is the address or the location of the hyperlink. The link
+// type will be a blank string if the cell has no hyperlink. The returned
+// values could be used for SetCellHyperLink to set the same hyperlink. For
+// example get hyperlink of Sheet1!H6:
+//
+//    linkType, target, opts, err := f.GetCellHyperLinkDetail("Sheet1", "H6")
+//
+func (f *File) GetCellHyperLinkDetail(sheet, axis string) (string, string, HyperlinkOpts, error) {
+	var opts HyperlinkOpts
 	// Check for correct cell name
 	if _, _, err := SplitCellName(axis); err != nil {
-		return false, "", err
+		return "", "", opts, err
 	}
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
-		return false, "", err
+		return "", "", opts, err
 	}
 	if axis, err = f.mergeCellsParser(ws, axis); err != nil {
-		return false, "", err
+		return "", "", opts, err
 	}
 	if ws.Hyperlinks != nil {
 		for _, link := range ws.Hyperlinks.Hyperlink {
 			if link.Ref == axis {
+				if link.Display != "" {
+					opts.Display = stringPtr(link.Display)
+				}
+				if link.Tooltip != "" {
+					opts.Tooltip = stringPtr(link.Tooltip)
+				}
 				if link.RID != "" {
-					return true, f.getSheetRelationshipsTargetByID(sheet, link.RID), err
+					return "External", f.getSheetRelationshipsTargetByID(sheet, link.RID), opts, err
 				}
-				return true, link.Location, err
+				return "Location", link.Location, opts, err
 			}
 		}
 	}
-	return false, "", err
+	return "", "", opts, err
 }
 
 // HyperlinkOpts can be passed to SetCellHyperlink to set optional hyperlink
@@ -713,6 +801,156 @@ func (f *File) SetCellHyperLink(sheet, axis, link, linkType string, opts ...Hype
 	return nil
 }
 
+// SetRangeHyperLink provides a function to set the same hyperlink on each cell
+// of a range by given worksheet name, range reference and link URL address.
+// The link type and the optional display value and tooltip are the same as
+// SetCellHyperLink, and will be applied to all cells in the range. The
+// existing hyperlinks of the cells will be replaced, and the relationships of
+// the replaced external hyperlinks will be removed if they're not referenced
+// by other hyperlinks. An error will be returned if the hyperlinks count in
+// the worksheet exceeds the maximum limit 65530. For example, set external
+// hyperlink on the cells A2:A10 of Sheet1:
+//
+//    err := f.SetRangeHyperLink("Sheet1", "A2:A10", "https://github.com/xuri/excelize", "External")
+//
+func (f *File) SetRangeHyperLink(sheet, rangeRef, link, linkType string, opts ...HyperlinkOpts) error {
+	if linkType != "External" && linkType != "Location" {
+		return fmt.Errorf("invalid link type %q", linkType)
+	}
+	rng := strings.Split(strings.Replace(rangeRef, "$", "", -1), ":")
+	if len(rng) == 1 {
+		rng = append(rng, rng[0])
+	}
+	if len(rng) != 2 {
+		return ErrParameterInvalid
+	}
+	coordinates, err := areaRangeToCoordinates(rng[0], rng[1])
+	if err != nil {
+		return err
+	}
+	_ = sortCoordinates(coordinates)
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	var cells []string
+	count, existing, added := 0, make(map[string]int), make(map[string]bool)
+	if ws.Hyperlinks != nil {
+		for idx, hyperlink := range ws.Hyperlinks.Hyperlink {
+			existing[hyperlink.Ref] = idx
+		}
+		count = len(ws.Hyperlinks.Hyperlink)
+	}
+	for row := coordinates[1]; row <= coordinates[3]; row++ {
+		for col := coordinates[0]; col <= coordinates[2]; col++ {
+			cell, _ := CoordinatesToCellName(col, row)
+			if cell, err = f.mergeCellsParser(ws, cell); err != nil {
+				return err
+			}
+			if added[cell] {
+				continue
+			}
+			added[cell] = true
+			cells = append(cells, cell)
+			if _, ok := existing[cell]; !ok {
+				count++
+			}
+		}
+	}
+	if count > TotalSheetHyperlinks {
+		return ErrTotalSheetHyperlinks
+	}
+	if ws.Hyperlinks == nil {
+		ws.Hyperlinks = new(xlsxHyperlinks)
+	}
+	var linkData xlsxHyperlink
+	if linkType == "External" {
+		sheetPath := f.sheetMap[trimSheetName(sheet)]
+		sheetRels := "xl/worksheets/_rels/" + strings.TrimPrefix(sheetPath, "xl/worksheets/") + ".rels"
+		rID := f.addRels(sheetRels, SourceRelationshipHyperLink, link, linkType)
+		linkData.RID = "rId" + strconv.Itoa(rID)
+		f.addSheetNameSpace(sheet, SourceRelationship)
+	} else {
+		linkData.Location = link
+	}
+	for _, o := range opts {
+		if o.Display != nil {
+			linkData.Display = *o.Display
+		}
+		if o.Tooltip != nil {
+			linkData.Tooltip = *o.Tooltip
+		}
+	}
+	replaced := make(map[string]bool)
+	for _, cell := range cells {
+		linkData.Ref = cell
+		if idx, ok := existing[cell]; ok {
+			if rID := ws.Hyperlinks.Hyperlink[idx].RID; rID != "" {
+				replaced[rID] = true
+			}
+			ws.Hyperlinks.Hyperlink[idx] = linkData
+			continue
+		}
+		ws.Hyperlinks.Hyperlink = append(ws.Hyperlinks.Hyperlink, linkData)
+	}
+	// Remove the relationships of the replaced external hyperlinks which are
+	// not referenced by other hyperlinks.
+	for _, link := range ws.Hyperlinks.Hyperlink {
+		delete(replaced, link.RID)
+	}
+	for rID := range replaced {
+		f.deleteSheetRelationships(sheet, rID)
+	}
+	return err
+}
+
+// UnsetCellHyperLink provides a function to remove the hyperlink of the cell
+// by given worksheet name and axis, the value and the style of the cell will
+// be kept. The relationship of the external hyperlink will be removed if it's
+// not referenced by other hyperlinks. It does nothing if the cell has no
+// hyperlink. For example, remove the hyperlink of Sheet1!A3:
+//
+//    err := f.UnsetCellHyperLink("Sheet1", "A3")
+//
+func (f *File) UnsetCellHyperLink(sheet, axis string) error {
+	// Check for correct cell name
+	if _, _, err := SplitCellName(axis); err != nil {
+		return err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	if axis, err = f.mergeCellsParser(ws, axis); err != nil {
+		return err
+	}
+	if ws.Hyperlinks == nil {
+		return err
+	}
+	var rID string
+	hyperlinks := ws.Hyperlinks.Hyperlink[:0]
+	for _, link := range ws.Hyperlinks.Hyperlink {
+		if link.Ref == axis {
+			rID = link.RID
+			continue
+		}
+		hyperlinks = append(hyperlinks, link)
+	}
+	ws.Hyperlinks.Hyperlink = hyperlinks
+	for _, link := range ws.Hyperlinks.Hyperlink {
+		if rID != "" && link.RID == rID {
+			rID = ""
+		}
+	}
+	if rID != "" {
+		f.deleteSheetRelationships(sheet, rID)
+	}
+	if len(ws.Hyperlinks.Hyperlink) == 0 {
+		ws.Hyperlinks = nil
+	}
+	return err
+}
+
 // GetCellRichText provides a function to get rich text of cell by given
 // worksheet.
 func (f *File) GetCellRichText(sheet, cell string) (runs []RichTextRun, err error) {
@@ -738,32 +976,73 @@ func (f *File) GetCellRichText(sheet, cell string) (runs []RichTextRun, err erro
 			Text: v.T.Val,
 		}
 		if nil != v.RPr {
-			font := Font{Underline: "none"}
-			font.Bold = v.RPr.B != nil
-			font.Italic = v.RPr.I != nil
-			if v.RPr.U != nil {
-				font.Underline = "single"
-				if v.RPr.U.Val != nil {
-					font.Underline = *v.RPr.U.Val
-				}
-			}
-			if v.RPr.RFont != nil && v.RPr.RFont.Val != nil {
-				font.Family = *v.RPr.RFont.Val
-			}
-			if v.RPr.Sz != nil && v.RPr.Sz.Val != nil {
-				font.Size = *v.RPr.Sz.Val
-			}
-			font.Strike = v.RPr.Strike != nil
-			if nil != v.RPr.Color {
-				font.Color = strings.TrimPrefix(v.RPr.Color.RGB, "FF")
-			}
-			run.Font = &font
+			run.Font = newFont(v.RPr)
 		}
 		runs = append(runs, run)
 	}
 	return
 }
 
+// newFont provides a function to create the font settings by given run
+// properties of the rich text run.
+func newFont(rPr *xlsxRPr) *Font {
+	font := Font{Underline: "none"}
+	font.Bold = rPr.B != nil
+	font.Italic = rPr.I != nil
+	if rPr.U != nil {
+		font.Underline = "single"
+		if rPr.U.Val != nil {
+			font.Underline = *rPr.U.Val
+		}
+	}
+	if rPr.RFont != nil && rPr.RFont.Val != nil {
+		font.Family = *rPr.RFont.Val
+	}
+	if rPr.Sz != nil && rPr.Sz.Val != nil {
+		font.Size = *rPr.Sz.Val
+	}
+	font.Strike = rPr.Strike != nil
+	if rPr.VertAlign != nil && rPr.VertAlign.Val != nil {
+		font.VertAlign = *rPr.VertAlign.Val
+	}
+	if nil != rPr.Color {
+		font.Color = strings.TrimPrefix(rPr.Color.RGB, "FF")
+	}
+	return &font
+}
+
+// newRpr provides a function to create the run properties of the rich text
+// run by given font settings.
+func newRpr(fnt *Font) *xlsxRPr {
+	rpr := xlsxRPr{}
+	trueVal := ""
+	if fnt.Bold {
+		rpr.B = &trueVal
+	}
+	if fnt.Italic {
+		rpr.I = &trueVal
+	}
+	if fnt.Strike {
+		rpr.Strike = &trueVal
+	}
+	if fnt.Underline != "" {
+		rpr.U = &attrValString{Val: &fnt.Underline}
+	}
+	if fnt.VertAlign != "" {
+		rpr.VertAlign = &attrValString{Val: &fnt.VertAlign}
+	}
+	if fnt.Family != "" {
+		rpr.RFont = &attrValString{Val: &fnt.Family}
+	}
+	if fnt.Size > 0.0 {
+		rpr.Sz = &attrValFloat{Val: &fnt.Size}
+	}
+	if fnt.Color != "" {
+		rpr.Color = &xlsxColor{RGB: getPaletteColor(fnt.Color)}
+	}
+	return &rpr
+}
+
 // SetCellRichText provides a function to set cell with rich text by given
 // worksheet. For example, set rich text on the A1 cell of the worksheet named
 // Sheet1:
@@ -840,12 +1119,19 @@ func (f *File) GetCellRichText(sheet, cell string) (runs []RichTextRun, err erro
 //                },
 //            },
 //            {
-//                Text: "underline.",
+//                Text: "underline",
 //                Font: &excelize.Font{
 //                    Color:     "23e833",
 //                    Underline: "single",
 //                },
 //            },
+//            {
+//                Text: " and superscript.",
+//                Font: &excelize.Font{
+//                    Color:     "23e833",
+//                    VertAlign: "superscript",
+//                },
+//            },
 //        }); err != nil {
 //            fmt.Println(err)
 //            return
@@ -889,32 +1175,8 @@ func (f *File) SetCellRichText(sheet, cell string, runs []RichTextRun) error {
 		}
 		run := xlsxR{T: &xlsxT{}}
 		_, run.T.Val, run.T.Space = setCellStr(textRun.Text)
-		fnt := textRun.Font
-		if fnt != nil {
-			rpr := xlsxRPr{}
-			trueVal := ""
-			if fnt.Bold {
-				rpr.B = &trueVal
-			}
-			if fnt.Italic {
-				rpr.I = &trueVal
-			}
-			if fnt.Strike {
-				rpr.Strike = &trueVal
-			}
-			if fnt.Underline != "" {
-				rpr.U = &attrValString{Val: &fnt.Underline}
-			}
-			if fnt.Family != "" {
-				rpr.RFont = &attrValString{Val: &fnt.Family}
-			}
-			if fnt.Size > 0.0 {
-				rpr.Sz = &attrValFloat{Val: &fnt.Size}
-			}
-			if fnt.Color != "" {
-				rpr.Color = &xlsxColor{RGB: getPaletteColor(fnt.Color)}
-			}
-			run.RPr = &rpr
+		if textRun.Font != nil {
+			run.RPr = newRpr(textRun.Font)
 		}
 		textRuns = append(textRuns, run)
 	}
@@ -1146,56 +1408,64 @@ func cellInRef(cell, ref []int) bool {
 
 // isOverlap find if the given two rectangles overlap or not.
 func isOverlap(rect1, rect2 []int) bool {
-	return cellInRef([]int{rect1[0], rect1[1]}, rect2) ||
-		cellInRef([]int{rect1[2], rect1[1]}, rect2) ||
-		cellInRef([]int{rect1[0], rect1[3]}, rect2) ||
-		cellInRef([]int{rect1[2], rect1[3]}, rect2) ||
-		cellInRef([]int{rect2[0], rect2[1]}, rect1) ||
-		cellInRef([]int{rect2[2], rect2[1]}, rect1) ||
-		cellInRef([]int{rect2[0], rect2[3]}, rect1) ||
-		cellInRef([]int{rect2[2], rect2[3]}, rect1)
+	return rect1[0] <= rect2[2] && rect2[0] <= rect1[2] &&
+		rect1[1] <= rect2[3] && rect2[1] <= rect1[3]
 }
 
-// parseSharedFormula generate dynamic part of shared formula for target cell
-// by given column and rows distance and origin shared formula.
-func parseSharedFormula(dCol, dRow int, orig []byte) (res string, start int) {
+// parseSharedFormula generate the formula of the shared formula member cell
+// by given column and rows distance and origin shared formula. The relative
+// references in the formula will be shifted, and the string literals, quoted
+// sheet names, structured references, function names and sheet names will
+// be kept.
+func parseSharedFormula(dCol, dRow int, orig []byte) string {
 	var (
-		end           int
-		stringLiteral bool
+		res     strings.Builder
+		quote   byte
+		bracket int
 	)
-	for end = 0; end < len(orig); end++ {
-		c := orig[end]
-		if c == '"' {
-			stringLiteral = !stringLiteral
-		}
-		if stringLiteral {
-			continue // Skip characters in quotes
-		}
-		if c >= 'A' && c <= 'Z' || c == '$' {
-			res += string(orig[start:end])
-			start = end
-			end++
-			foundNum := false
-			for ; end < len(orig); end++ {
-				idc := orig[end]
-				if idc >= '0' && idc <= '9' || idc == '$' {
-					foundNum = true
-				} else if idc >= 'A' && idc <= 'Z' {
-					if foundNum {
-						break
-					}
-				} else {
-					break
+	for i := 0; i < len(orig); {
+		c := orig[i]
+		if quote != 0 || c == '"' || c == '\'' || c == '[' || c == ']' || bracket > 0 || !isFormulaNameChar(c) {
+			switch {
+			case quote != 0:
+				if c == quote {
+					quote = 0
 				}
+			case c == '"' || c == '\'':
+				quote = c
+			case c == '[':
+				bracket++
+			case c == ']':
+				bracket--
 			}
-			if foundNum {
-				cellID := string(orig[start:end])
-				res += shiftCell(cellID, dCol, dRow)
-				start = end
-			}
+			res.WriteByte(c)
+			i++
+			continue
+		}
+		j := i
+		for j < len(orig) && isFormulaNameChar(orig[j]) {
+			j++
+		}
+		token := string(orig[i:j])
+		if j < len(orig) && (orig[j] == '(' || orig[j] == '!' || orig[j] == '[') {
+			res.WriteString(token)
+		} else if inRange := j < len(orig) && orig[j] == ':' || i > 0 && orig[i-1] == ':'; inRange {
+			res.WriteString(shiftColRowRef(token, dCol, dRow))
+		} else {
+			// Shift the leading cell reference of the token, such as A1 of A1A
+			ref := strings.TrimRightFunc(token, unicode.IsLetter)
+			res.WriteString(shiftCell(ref, dCol, dRow) + token[len(ref):])
 		}
+		i = j
 	}
-	return
+	return res.String()
+}
+
+// isFormulaNameChar returns if the given character can be a part of cell
+// reference, function name, defined name or number in the formula.
+func isFormulaNameChar(c byte) bool {
+	return c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z' || c >= '0' && c <= '9' ||
+		c == '$' || c == '_' || c == '.' || c == '\\'
 }
 
 // getSharedForumula find a cell contains the same formula as another cell,
@@ -1212,37 +1482,107 @@ func getSharedForumula(ws *xlsxWorksheet, si int, axis string) string {
 			if c.F != nil && c.F.Ref != "" && c.F.T == STCellFormulaTypeShared && c.F.Si != nil && *c.F.Si == si {
 				col, row, _ := CellNameToCoordinates(axis)
 				sharedCol, sharedRow, _ := CellNameToCoordinates(c.R)
-				dCol := col - sharedCol
-				dRow := row - sharedRow
-				orig := []byte(c.F.Content)
-				res, start := parseSharedFormula(dCol, dRow, orig)
-				if start < len(orig) {
-					res += string(orig[start:])
-				}
-				return res
+				return parseSharedFormula(col-sharedCol, row-sharedRow, []byte(c.F.Content))
 			}
 		}
 	}
 	return ""
 }
 
+// splitFormulaRef splits the cell reference, column reference or row
+// reference into column name, row number and the absolute reference flags.
+// The column name or row number will be empty if it doesn't exist, and the
+// ok will be false if the given string is not a reference.
+func splitFormulaRef(ref string) (colName, rowNum string, absCol, absRow, ok bool) {
+	i := 0
+	if absCol = i < len(ref) && ref[i] == '$'; absCol {
+		i++
+	}
+	start := i
+	for i < len(ref) && (ref[i] >= 'A' && ref[i] <= 'Z' || ref[i] >= 'a' && ref[i] <= 'z') {
+		i++
+	}
+	if colName = strings.ToUpper(ref[start:i]); len(colName) > 3 {
+		return
+	}
+	if absRow = i < len(ref) && ref[i] == '$'; absRow {
+		i++
+	}
+	start = i
+	for i < len(ref) && ref[i] >= '0' && ref[i] <= '9' {
+		i++
+	}
+	rowNum = ref[start:i]
+	if colName == "" {
+		absRow, absCol = absCol, false
+	}
+	ok = i == len(ref) && (colName != "" || rowNum != "") && !(absRow && rowNum == "")
+	return
+}
+
+// shiftColRowRef returns the cell, column or row reference of the range
+// shifted according to dCol and dRow.
+func shiftColRowRef(ref string, dCol, dRow int) string {
+	colName, rowNum, absCol, absRow, ok := splitFormulaRef(ref)
+	if !ok || colName != "" && rowNum != "" {
+		return shiftCell(ref, dCol, dRow)
+	}
+	if colName != "" {
+		col, err := ColumnNameToNumber(colName)
+		if err != nil {
+			return ref
+		}
+		if !absCol {
+			col += dCol
+		}
+		if colName, err = ColumnNumberToName(col); err != nil {
+			return formulaErrorREF
+		}
+		if absCol {
+			return "$" + colName
+		}
+		return colName
+	}
+	row, _ := strconv.Atoi(rowNum)
+	if !absRow {
+		row += dRow
+	}
+	if row < 1 || row > TotalRows {
+		return formulaErrorREF
+	}
+	if absRow {
+		return "$" + strconv.Itoa(row)
+	}
+	return strconv.Itoa(row)
+}
+
 // shiftCell returns the cell shifted according to dCol and dRow taking into
-// consideration of absolute references with dollar sign ($)
+// consideration of absolute references with dollar sign ($). The given
+// string will be returned without change if it is not a cell reference, and
+// the "#REF!" will be returned if the shifted cell is out of the worksheet.
 func shiftCell(cellID string, dCol, dRow int) string {
-	fCol, fRow, _ := CellNameToCoordinates(cellID)
+	colName, rowNum, absCol, absRow, ok := splitFormulaRef(cellID)
+	if !ok || colName == "" || rowNum == "" {
+		return cellID
+	}
+	fCol, fRow, err := CellNameToCoordinates(colName + rowNum)
+	if err != nil {
+		return cellID
+	}
 	signCol, signRow := "", ""
-	if strings.Index(cellID, "$") == 0 {
+	if absCol {
 		signCol = "$"
 	} else {
-		// Shift column
 		fCol += dCol
 	}
-	if strings.LastIndex(cellID, "$") > 0 {
+	if absRow {
 		signRow = "$"
 	} else {
-		// Shift row
 		fRow += dRow
 	}
-	colName, _ := ColumnNumberToName(fCol)
+	colName, err = ColumnNumberToName(fCol)
+	if err != nil || fRow < 1 || fRow > TotalRows {
+		return formulaErrorREF
+	}
 	return signCol + colName + signRow + strconv.Itoa(fRow)
 }
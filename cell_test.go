@@ -355,9 +355,16 @@ func TestGetCellFormula(t *testing.T) {
 	sheetData := `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData><row r="1"><c r="A1"><v>1</v></c><c r="B1"><f>2*A1</f></c></row><row r="2"><c r="A2"><v>2</v></c><c r="B2"><f t="shared" ref="B2:B7" si="0">%s</f></c></row><row r="3"><c r="A3"><v>3</v></c><c r="B3"><f t="shared" si="0"/></c></row><row r="4"><c r="A4"><v>4</v></c><c r="B4"><f t="shared" si="0"/></c></row><row r="5"><c r="A5"><v>5</v></c><c r="B5"><f t="shared" si="0"/></c></row><row r="6"><c r="A6"><v>6</v></c><c r="B6"><f t="shared" si="0"/></c></row><row r="7"><c r="A7"><v>7</v></c><c r="B7"><f t="shared" si="0"/></c></row></sheetData></worksheet>`
 
 	for sharedFormula, expected := range map[string]string{
-		`2*A2`:           `2*A3`,
-		`2*A1A`:          `2*A2A`,
-		`2*$A$2+LEN("")`: `2*$A$2+LEN("")`,
+		`2*A2`:            `2*A3`,
+		`2*A1A`:           `2*A2A`,
+		`2*$A$2+LEN("")`:  `2*$A$2+LEN("")`,
+		`LOG10(A2)+a$2`:   `LOG10(A3)+A$2`,
+		`Sheet1!A2+$A2`:   `Sheet1!A3+$A3`,
+		`'My A2'!A2+"A2"`: `'My A2'!A3+"A2"`,
+		`SUM(A:A,2:$3)`:   `SUM(A:A,3:$3)`,
+		`SUM(A1:A2)*1.5`:  `SUM(A2:A3)*1.5`,
+		`Table1[A2]+A2`:   `Table1[A2]+A3`,
+		`A1048576+1`:      `#REF!+1`,
 	} {
 		f.Sheet.Delete("xl/worksheets/sheet1.xml")
 		f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(fmt.Sprintf(sheetData, sharedFormula)))
@@ -366,6 +373,11 @@ func TestGetCellFormula(t *testing.T) {
 		assert.Equal(t, expected, formula)
 	}
 
+	// Test shift column references of the shared formula
+	assert.Equal(t, "SUM(B:C,$A1)+XFD1", parseSharedFormula(1, 0, []byte("SUM(A:B,$A1)+XFC1")))
+	assert.Equal(t, "#REF!+B:#REF!", parseSharedFormula(1, 0, []byte("XFD1+A:XFD")))
+	assert.Equal(t, "#REF!", shiftColRowRef("1", 0, -1))
+
 	f.Sheet.Delete("xl/worksheets/sheet1.xml")
 	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData><row r="2"><c r="B2"><f t="shared" si="0"></f></c></row></sheetData></worksheet>`))
 	formula, err := f.GetCellFormula("Sheet1", "B2")
@@ -464,6 +476,40 @@ func TestSetCellFormula(t *testing.T) {
 	formulaType = STCellFormulaTypeDataTable
 	assert.NoError(t, f.SetCellFormula("Sheet1", "C2", "=SUM(Table1[[A]:[B]])", FormulaOpts{Type: &formulaType}))
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetCellFormula6.xlsx")))
+
+	// Test set array formula for the cells.
+	f = NewFile()
+	for r := 1; r <= 3; r++ {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", r), &[]interface{}{r, r + 1, "x"}))
+	}
+	formulaType, ref = STCellFormulaTypeArray, "C1:C3"
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C1", "{=A1:A3*B1:B3}", FormulaOpts{Ref: &ref, Type: &formulaType}))
+	formulaType, ref = STCellFormulaTypeArray, ""
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D1", "=SUM(A1:A3*B1:B3)", FormulaOpts{Type: &formulaType}))
+	arrayFormulaSpreadsheet := filepath.Join("test", "TestSetCellFormula7.xlsx")
+	assert.NoError(t, f.SaveAs(arrayFormulaSpreadsheet))
+
+	f, err = OpenFile(arrayFormulaSpreadsheet)
+	assert.NoError(t, err)
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, &xlsxF{Content: "=A1:A3*B1:B3", T: STCellFormulaTypeArray, Ref: "C1:C3"}, ws.SheetData.Row[0].C[2].F)
+	assert.Equal(t, &xlsxF{Content: "=SUM(A1:A3*B1:B3)", T: STCellFormulaTypeArray, Ref: "D1"}, ws.SheetData.Row[0].C[3].F)
+	for _, cell := range []string{"C2", "C3"} {
+		formula, err := f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Empty(t, formula)
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Empty(t, val)
+	}
+	// Test set array formula with invalid reference.
+	formulaType, ref = STCellFormulaTypeArray, "C2:C3"
+	assert.EqualError(t, f.SetCellFormula("Sheet1", "C1", "=A1:A3*B1:B3", FormulaOpts{Ref: &ref, Type: &formulaType}), ErrParameterInvalid.Error())
+	ref = "C1:C"
+	assert.EqualError(t, f.SetCellFormula("Sheet1", "C1", "=A1:A3*B1:B3", FormulaOpts{Ref: &ref, Type: &formulaType}), `cannot convert cell "C" to coordinates: invalid cell name "C"`)
+	assert.NoError(t, f.Close())
+	assert.EqualError(t, (&xlsxWorksheet{}).setArrayFormula("A", "A1:B2"), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
 }
 
 func TestGetCellRichText(t *testing.T) {
@@ -483,6 +529,7 @@ func TestGetCellRichText(t *testing.T) {
 				Family:    "Times New Roman",
 				Size:      100,
 				Strike:    true,
+				VertAlign: "superscript",
 			},
 		},
 	}
@@ -498,6 +545,18 @@ func TestGetCellRichText(t *testing.T) {
 	runsSource[1].Font.Color = strings.ToUpper(runsSource[1].Font.Color)
 	assert.True(t, reflect.DeepEqual(runsSource[1].Font, runs[1].Font), "should get the same font")
 
+	// Test get cell rich text after save and reopen the workbook
+	f2 := NewFile()
+	runsSource[1].Font.Underline, runsSource[1].Font.VertAlign = "double", "subscript"
+	assert.NoError(t, f2.SetCellRichText("Sheet1", "A2", runsSource))
+	assert.NoError(t, f2.SaveAs(filepath.Join("test", "TestGetCellRichText.xlsx")))
+	f2, err = OpenFile(filepath.Join("test", "TestGetCellRichText.xlsx"))
+	assert.NoError(t, err)
+	runs, err = f2.GetCellRichText("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.Equal(t, runsSource[1].Font, runs[1].Font)
+	assert.NoError(t, f2.Close())
+
 	// Test get cell rich text when string item index overflow
 	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
 	assert.True(t, ok)
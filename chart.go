@@ -15,6 +15,8 @@ import (
 	"encoding/json"
 	"encoding/xml"
 	"fmt"
+	"io"
+	"path"
 	"strconv"
 	"strings"
 )
@@ -467,6 +469,54 @@ var (
 		Contour:          "none",
 		WireframeContour: "none",
 	}
+	chartSeriesTrendlineType = map[string]string{
+		"linear":      "linear",
+		"exponential": "exp",
+		"logarithmic": "log",
+		"polynomial":  "poly",
+		"power":       "power",
+		"movingAvg":   "movingAvg",
+	}
+	chartDataLabelsPosition = map[string]string{
+		"bestFit":    "bestFit",
+		"center":     "ctr",
+		"insideBase": "inBase",
+		"insideEnd":  "inEnd",
+		"outsideEnd": "outEnd",
+		"left":       "l",
+		"right":      "r",
+		"above":      "t",
+		"below":      "b",
+	}
+	chartAxisTickMark = map[string]bool{
+		"none":  true,
+		"in":    true,
+		"out":   true,
+		"cross": true,
+	}
+	chartAxisTickLabelPosition = map[string]bool{
+		"high":   true,
+		"low":    true,
+		"nextTo": true,
+		"none":   true,
+	}
+	chartOfPieSplitType = map[string]string{
+		"position": "pos",
+		"value":    "val",
+		"percent":  "percent",
+	}
+	chartSeriesErrBarType = map[string]string{
+		"both":  "both",
+		"plus":  "plus",
+		"minus": "minus",
+	}
+	chartSeriesErrValType = map[string]string{
+		"fixed":              "fixedVal",
+		"percentage":         "percentage",
+		"standard_deviation": "stdDev",
+		"standard_error":     "stdErr",
+		"custom":             "cust",
+	}
 )
 
 // parseFormatChartSet provides a function to parse the format settings of the
@@ -647,16 +697,25 @@ func parseFormatChartSet(formatSet string) (*formatChart, error) {
 // The series options that can be set are:
 //
 //    name
+//    type
+//    secondary
 //    categories
 //    values
 //    line
 //    marker
+//    trendline
+//    error_bars
+//    data_labels
 //
 // name: Set the name for the series. The name is displayed in the chart legend and in the formula bar. The name property is optional and if it isn't supplied it will default to Series 1..n. The name can also be a formula such as Sheet1!$A$1
 //
+// type: Specifies the chart type of the series, which should be one of the chart types listed above. The type property is optional and if it isn't supplied the series will use the type of the chart. The series with different type will be plotted together with the other series in a combo chart and share the same category axis.
+//
+// secondary: Specifies that the series should be plotted on the secondary value axis, which will be placed on the right side of the chart. The secondary property is optional. The default value is false.
+//
 // categories: This sets the chart category labels. The category is more or less the same as the X axis. In most chart types the categories property is optional and the chart will just assume a sequential series from 1..n.
 //
-// values: This is the most important property of a series and is the only mandatory option for every chart object. This option links the chart with the worksheet data that it displays.
+// values: This is the most important property of a series and is the only mandatory option for every chart object. This option links the chart with the worksheet data that it displays. The data can be referenced on other worksheet such as Data!$B$2:$D$2, and the reference without the worksheet name will be resolved to the worksheet where the chart is placed.
 //
 // line: This sets the line format of the line chart. The line property is optional and if it isn't supplied it will default style. The options that can be set is width. The range of width is 0.25pt - 999pt. If the value of width is outside the range, the default width of the line is 2pt.
 //
@@ -675,6 +734,47 @@ func parseFormatChartSet(formatSet string) (*formatChart, error) {
 //    x
 //    auto
 //
+// trendline: This sets the trendline of the series. The trendline property is optional and it is not supported for the pie, doughnut, radar and surface charts. The options that can be set are:
+//
+//    type
+//    order
+//    period
+//    display_equation
+//    display_r_squared
+//
+// type: Specifies the type of the trendline, the enumeration value are linear, exponential, logarithmic, polynomial, power and movingAvg.
+//
+// order: Specifies the order of the polynomial trendline, the range is 2 - 6. The default value is 2.
+//
+// period: Specifies the period of the moving average trendline, the range is 2 - 255. The default value is 2.
+//
+// display_equation: Specifies that the equation of the trendline shall be displayed on the chart. The default value is false.
+//
+// display_r_squared: Specifies that the R-squared value of the trendline shall be displayed on the chart. The default value is false.
+//
+// error_bars: This sets the vertical error bars of the series. The error_bars property is optional and it is not supported for the pie, doughnut, radar and surface charts. The options that can be set are:
+//
+//    type
+//    direction
+//    value
+//    plus
+//    minus
+//    no_end_cap
+//
+// type: Specifies the type of the error bars, the enumeration value are fixed, percentage, standard_deviation, standard_error and custom.
+//
+// direction: Specifies the direction of the error bars, the enumeration value are plus, minus and both. The default value is both.
+//
+// value: Specifies the value of the fixed, percentage and standard_deviation error bars. The default value is 1 for fixed and standard_deviation error bars, and 5 for percentage error bars.
+//
+// plus: Specifies the reference of the positive error values for the custom error bars, such as Sheet1!$D$2:$D$5. This property is required when the type is custom and the direction is plus or both.
+//
+// minus: Specifies the reference of the negative error values for the custom error bars. This property is required when the type is custom and the direction is minus or both.
+//
+// no_end_cap: Specifies that the end caps of the error bars shall not be drawn. The default value is false.
+//
+// data_labels: This sets the data labels of the series, which overrides the data labels settings of the plot area. The data_labels property is optional. The options that can be set are show_val, show_cat_name, show_series_name, position and num_format, the position and num_format has the same enumeration value as label_position and label_num_format of the plot area.
+//
 // Set properties of the chart legend. The options that can be set are:
 //
 //    none
@@ -723,6 +823,8 @@ func parseFormatChartSet(formatSet string) (*formatChart, error) {
 //    show_percent
 //    show_series_name
 //    show_val
+//    label_position
+//    label_num_format
 //
 // show_bubble_size: Specifies the bubble size shall be shown in a data label. The show_bubble_size property is optional. The default value is false.
 //
@@ -736,12 +838,30 @@ func parseFormatChartSet(formatSet string) (*formatChart, error) {
 //
 // show_val: Specifies that the value shall be shown in a data label. The show_val property is optional. The default value is false.
 //
+// label_position: Specifies the position of the data labels. The label_position property is optional. The default position is decided by the chart type. Note that the available positions are depends on the chart type, for example, the bestFit is only available for pie charts, and the left, right, above and below are available for line and scatter charts. The enumeration value are:
+//
+//    bestFit
+//    center
+//    insideBase
+//    insideEnd
+//    outsideEnd
+//    left
+//    right
+//    above
+//    below
+//
+// label_num_format: Specifies the number format code of the data labels. The label_num_format property is optional. The default value is linked to the source data.
+//
 // Set the primary horizontal and vertical axis options by x_axis and y_axis. The properties of x_axis that can be set are:
 //
 //    none
+//    name
 //    major_grid_lines
 //    minor_grid_lines
+//    major_tick_mark
+//    minor_tick_mark
 //    tick_label_skip
+//    tick_label_position
 //    reverse_order
 //    maximum
 //    minimum
@@ -749,31 +869,69 @@ func parseFormatChartSet(formatSet string) (*formatChart, error) {
 // The properties of y_axis that can be set are:
 //
 //    none
+//    name
 //    major_grid_lines
 //    minor_grid_lines
 //    major_unit
+//    minor_unit
+//    major_tick_mark
+//    minor_tick_mark
+//    tick_label_position
+//    num_format
 //    reverse_order
 //    maximum
 //    minimum
+//    logbase
+//
+// Set the secondary vertical axis options by y2_axis, the secondary axis will be created when any series has the secondary property enabled. The properties of y2_axis that can be set are same as y_axis.
 //
 // none: Disable axes.
 //
+// name: Specifies the title of the axis. The name property is optional. The default is to have no axis title.
+//
+// num_format: Specifies the number format code of the axis labels. The num_format property is optional. The default value is linked to the source data.
+//
 // major_grid_lines: Specifies major gridlines.
 //
 // minor_grid_lines: Specifies minor gridlines.
 //
 // major_unit: Specifies the distance between major ticks. Shall contain a positive floating-point number. The major_unit property is optional. The default value is auto.
 //
+// minor_unit: Specifies the distance between minor ticks. Shall contain a positive floating-point number. The minor_unit property is optional. The default value is auto.
+//
+// major_tick_mark: Specifies the major tick marks of the axis, the enumeration value are none, in, out and cross. The major_tick_mark property is optional. The default value is none.
+//
+// minor_tick_mark: Specifies the minor tick marks of the axis, the enumeration value are same as major_tick_mark. The minor_tick_mark property is optional. The default value is none.
+//
 // tick_label_skip: Specifies how many tick labels to skip between label that is drawn. The tick_label_skip property is optional. The default value is auto.
 //
+// tick_label_position: Specifies the position of the tick labels of the axis, the enumeration value are high, low, nextTo and none. The tick_label_position property is optional. The default value is nextTo.
+//
 // reverse_order: Specifies that the categories or values on reverse order (orientation of the chart). The reverse_order property is optional. The default value is false.
 //
 // maximum: Specifies that the fixed maximum, 0 is auto. The maximum property is optional. The default value is auto.
 //
 // minimum: Specifies that the fixed minimum, 0 is auto. The minimum property is optional. The default value is auto.
 //
+// logbase: Specifies that the value axis is a logarithmic axis by the base of the logarithm, the range is 2 - 1000. The logbase property is optional. The default is to use the linear axis.
+//
 // Set chart size by dimension property. The dimension property is optional. The default width is 480, and height is 290.
 //
+// Set the split and the second plot options of the pie of pie and bar of pie chart by of_pie. The properties of of_pie that can be set are:
+//
+//    split_type
+//    split_value
+//    second_plot_size
+//    gap_width
+//
+// split_type: Specifies how to split the data points between the first pie and the second plot. The enumeration value are position, value and percent. The split_type property is optional. The default value is decided by the spreadsheet application.
+//
+// split_value: Specifies the threshold for the split. The data points in the last split_value positions, or which values or percentages are less than split_value will be shown in the second plot.
+//
+// second_plot_size: Specifies the size of the second plot as a percentage of the size of the first pie, the range is 5 - 200. The second_plot_size property is optional. The default value is 75.
+//
+// gap_width: Specifies the space between the first pie and the second plot as a percentage of the size of the second plot, the range is 0 - 500. The gap_width property is optional. The default value is 100.
+//
 // combo: Specifies the create a chart that combines two or more chart types
 // in a single chart. For example, create a clustered column - line chart with
 // data Sheet1!$E$1:$L$15:
@@ -897,6 +1055,7 @@ func (f *File) AddChart(sheet, cell, format string, combo ...string) error {
 	if err != nil {
 		return err
 	}
+	setChartSeriesRefs(sheet, append([]*formatChart{formatSet}, comboCharts...))
 	// Add first picture for given sheet, create xl/drawings/ and xl/drawings/_rels/ folder.
 	drawingID := f.countDrawings() + 1
 	chartID := f.countCharts() + 1
@@ -928,6 +1087,7 @@ func (f *File) AddChartSheet(sheet, format string, combo ...string) error {
 	if err != nil {
 		return err
 	}
+	setChartSeriesRefs("", append([]*formatChart{formatSet}, comboCharts...))
 	cs := xlsxChartsheet{
 		SheetViews: &xlsxChartsheetViews{
 			SheetView: []*xlsxChartsheetView{{ZoomScaleAttr: 100, ZoomToFitAttr: true}},
@@ -987,11 +1147,251 @@ func (f *File) getFormatChart(format string, combo []string) (*formatChart, []*f
 	if _, ok := chartValAxNumFmtFormatCode[formatSet.Type]; !ok {
 		return formatSet, comboCharts, newUnsupportChartType(formatSet.Type)
 	}
-	return formatSet, comboCharts, err
+	for _, chart := range append([]*formatChart{formatSet}, comboCharts...) {
+		if err = checkChartOfPie(chart); err != nil {
+			return formatSet, comboCharts, err
+		}
+		for _, axis := range []formatChartAxis{chart.XAxis, chart.YAxis, chart.Y2Axis} {
+			if err = checkChartAxis(axis); err != nil {
+				return formatSet, comboCharts, err
+			}
+		}
+		if _, ok := chartDataLabelsPosition[chart.Plotarea.LabelPosition]; !ok && chart.Plotarea.LabelPosition != "" {
+			return formatSet, comboCharts, ErrParameterInvalid
+		}
+		for _, ser := range chart.Series {
+			if ser.DataLabels != nil && ser.DataLabels.Position != "" {
+				if _, ok := chartDataLabelsPosition[ser.DataLabels.Position]; !ok {
+					return formatSet, comboCharts, ErrParameterInvalid
+				}
+			}
+			if err = checkChartSeriesTrendline(ser); err != nil {
+				return formatSet, comboCharts, err
+			}
+			if err = checkChartSeriesErrorBars(ser); err != nil {
+				return formatSet, comboCharts, err
+			}
+		}
+	}
+	var secondaryCharts []*formatChart
+	for _, chart := range append([]*formatChart{formatSet}, comboCharts...) {
+		seriesCharts, err := splitChartSeries(chart)
+		if err != nil {
+			return formatSet, comboCharts, err
+		}
+		for _, seriesChart := range seriesCharts {
+			if seriesChart.secondary {
+				secondaryCharts = append(secondaryCharts, seriesChart)
+				continue
+			}
+			comboCharts = append(comboCharts, seriesChart)
+		}
+	}
+	return formatSet, append(comboCharts, secondaryCharts...), err
+}
+
+// checkChartAxis provides a function to check the units, tick marks and
+// tick labels position settings of the chart axis.
+func checkChartAxis(axis formatChartAxis) error {
+	if axis.MajorUnit < 0 || axis.MinorUnit < 0 {
+		return ErrParameterInvalid
+	}
+	for _, tickMark := range []string{axis.MajorTickMark, axis.MinorTickMark} {
+		if _, ok := chartAxisTickMark[tickMark]; !ok && tickMark != "" {
+			return ErrParameterInvalid
+		}
+	}
+	if _, ok := chartAxisTickLabelPosition[axis.TickLabelPosition]; !ok && axis.TickLabelPosition != "" {
+		return ErrParameterInvalid
+	}
+	return nil
+}
+
+// checkChartOfPie provides a function to check the split and second plot
+// settings of the pie of pie and bar of pie chart.
+func checkChartOfPie(formatSet *formatChart) error {
+	ofPie := formatSet.OfPie
+	if _, ok := chartOfPieSplitType[ofPie.SplitType]; !ok && ofPie.SplitType != "" {
+		return ErrParameterInvalid
+	}
+	if ofPie.SecondPlotSize != 0 && (ofPie.SecondPlotSize < 5 || ofPie.SecondPlotSize > 200) {
+		return ErrParameterInvalid
+	}
+	if ofPie.GapWidth != nil && (*ofPie.GapWidth < 0 || *ofPie.GapWidth > 500) {
+		return ErrParameterInvalid
+	}
+	return nil
+}
+
+// checkChartSeriesTrendline provides a function to check the trendline
+// settings of the chart series.
+func checkChartSeriesTrendline(ser formatChartSeries) error {
+	if ser.Trendline.Type == "" {
+		return nil
+	}
+	if _, ok := chartSeriesTrendlineType[ser.Trendline.Type]; !ok {
+		return ErrParameterInvalid
+	}
+	if ser.Trendline.Order != 0 && (ser.Trendline.Order < 2 || ser.Trendline.Order > 6) {
+		return ErrParameterInvalid
+	}
+	if ser.Trendline.Period != 0 && (ser.Trendline.Period < 2 || ser.Trendline.Period > 255) {
+		return ErrParameterInvalid
+	}
+	return nil
+}
+
+// checkChartSeriesErrorBars provides a function to check the error bars
+// settings of the chart series.
+func checkChartSeriesErrorBars(ser formatChartSeries) error {
+	errorBars := ser.ErrorBars
+	if errorBars.Type == "" {
+		return nil
+	}
+	if _, ok := chartSeriesErrValType[errorBars.Type]; !ok {
+		return ErrParameterInvalid
+	}
+	if _, ok := chartSeriesErrBarType[errorBars.Direction]; !ok && errorBars.Direction != "" {
+		return ErrParameterInvalid
+	}
+	if errorBars.Type != "custom" {
+		return nil
+	}
+	if (errorBars.Direction != "minus" && errorBars.Plus == "") || (errorBars.Direction != "plus" && errorBars.Minus == "") {
+		return ErrParameterRequired
+	}
+	return nil
+}
+
+// setChartSeriesRefs provides a function to normalize the name, categories
+// and values references of the chart series by given worksheet name which
+// the chart placed on.
+func setChartSeriesRefs(sheet string, charts []*formatChart) {
+	for _, chart := range charts {
+		for i := range chart.Series {
+			chart.Series[i].Name = getChartSeriesRef(sheet, chart.Series[i].Name)
+			chart.Series[i].Categories = getChartSeriesRef(sheet, chart.Series[i].Categories)
+			chart.Series[i].Values = getChartSeriesRef(sheet, chart.Series[i].Values)
+			chart.Series[i].ErrorBars.Plus = getChartSeriesRef(sheet, chart.Series[i].ErrorBars.Plus)
+			chart.Series[i].ErrorBars.Minus = getChartSeriesRef(sheet, chart.Series[i].ErrorBars.Minus)
+		}
+	}
+}
+
+// getChartSeriesRef provides a function to get the formula of the chart
+// series reference by given worksheet name and reference, such as
+// Sheet1!$A$1:$A$10. The cell reference without worksheet name will be
+// resolved to the given worksheet, and the worksheet name will be quoted if
+// it contains special characters or looks like a cell reference. The text
+// which is not a reference will be returned as is.
+func getChartSeriesRef(sheet, ref string) string {
+	cellRef := strings.TrimPrefix(ref, "=")
+	if i := strings.LastIndex(cellRef, "!"); i != -1 {
+		sheet, cellRef = cellRef[:i], cellRef[i+1:]
+		if len(sheet) > 1 && strings.HasPrefix(sheet, "'") && strings.HasSuffix(sheet, "'") {
+			sheet = strings.Replace(sheet[1:len(sheet)-1], "''", "'", -1)
+		}
+	}
+	if sheet == "" || cellRef == "" {
+		return ref
+	}
+	for _, cell := range strings.Split(cellRef, ":") {
+		if _, _, err := CellNameToCoordinates(strings.Replace(cell, "$", "", -1)); err != nil {
+			return ref
+		}
+	}
+	return quoteSheetName(sheet) + "!" + cellRef
+}
+
+// splitChartSeries provides a function to move the series which specified
+// their own chart type or plotted on the secondary axis out of the given
+// chart format set, and create combo chart format sets for them.
+func splitChartSeries(formatSet *formatChart) ([]*formatChart, error) {
+	var charts []*formatChart
+	series := []formatChartSeries{}
+	for _, ser := range formatSet.Series {
+		chartType := ser.Type
+		if chartType == "" {
+			chartType = formatSet.Type
+		}
+		if chartType == formatSet.Type && ser.Secondary == formatSet.secondary {
+			series = append(series, ser)
+			continue
+		}
+		if _, ok := chartValAxNumFmtFormatCode[chartType]; !ok {
+			return charts, newUnsupportChartType(chartType)
+		}
+		var seriesChart *formatChart
+		for _, chart := range charts {
+			if chart.Type == chartType && chart.secondary == ser.Secondary {
+				seriesChart = chart
+				break
+			}
+		}
+		if seriesChart == nil {
+			chart := *formatSet
+			chart.Type, chart.Series, chart.secondary = chartType, nil, ser.Secondary
+			if chart.secondary {
+				chart.YAxis = chart.Y2Axis
+			}
+			seriesChart = &chart
+			charts = append(charts, seriesChart)
+		}
+		seriesChart.Series = append(seriesChart.Series, ser)
+	}
+	formatSet.Series = series
+	return charts, nil
+}
+
+// GetCharts provides a function to get the anchor cells of the charts in a
+// worksheet by given worksheet name. For example, get the cells of the charts
+// on Sheet1:
+//
+//    cells, err := f.GetCharts("Sheet1")
+//
+func (f *File) GetCharts(sheet string) ([]string, error) {
+	cells := []string{}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return cells, err
+	}
+	if ws.Drawing == nil {
+		return cells, err
+	}
+	target := f.getSheetRelationshipsTargetByID(sheet, ws.Drawing.RID)
+	drawingXML := strings.Replace(target, "..", "xl", -1)
+	if _, ok := f.Drawings.Load(drawingXML); !ok {
+		if _, ok = f.Pkg.Load(drawingXML); !ok {
+			return cells, err
+		}
+	}
+	wsDr, _ := f.drawingParser(drawingXML)
+	wsDr.Lock()
+	anchors := append(append([]*xdrCellAnchor{}, wsDr.OneCellAnchor...), wsDr.TwoCellAnchor...)
+	wsDr.Unlock()
+	for _, anchor := range anchors {
+		output, _ := xml.Marshal(anchor)
+		deCellAnchor := new(decodeCellAnchor)
+		if err = f.xmlNewDecoder(strings.NewReader(string(output))).
+			Decode(deCellAnchor); err != nil && err != io.EOF {
+			return cells, fmt.Errorf("xml decode error: %s", err)
+		}
+		if deCellAnchor.From == nil || deCellAnchor.GraphicFrame == nil || deCellAnchor.GraphicFrame.Graphic.GraphicData.Chart == nil {
+			continue
+		}
+		cell, err := CoordinatesToCellName(deCellAnchor.From.Col+1, deCellAnchor.From.Row+1)
+		if err != nil {
+			return cells, err
+		}
+		cells = append(cells, cell)
+	}
+	return cells, nil
 }
 
 // DeleteChart provides a function to delete chart in XLSX by given worksheet
-// and cell name.
+// and cell name. The chart part, its relationships and the media which no
+// longer referenced will be removed, and other charts and pictures in the
+// same drawing will be kept.
 func (f *File) DeleteChart(sheet, cell string) (err error) {
 	col, row, err := CellNameToCoordinates(cell)
 	if err != nil {
@@ -1006,17 +1406,65 @@ func (f *File) DeleteChart(sheet, cell string) (err error) {
 	if ws.Drawing == nil {
 		return
 	}
-	drawingXML := strings.Replace(f.getSheetRelationshipsTargetByID(sheet, ws.Drawing.RID), "..", "xl", -1)
-	return f.deleteDrawing(col, row, drawingXML, "Chart")
+	target := f.getSheetRelationshipsTargetByID(sheet, ws.Drawing.RID)
+	drawingXML := strings.Replace(target, "..", "xl", -1)
+	drawingRelationships := strings.Replace(
+		strings.Replace(target, "../drawings", "xl/drawings/_rels", -1), ".xml", ".xml.rels", -1)
+	rIDs, err := f.deleteChartAnchors(col, row, drawingXML)
+	if err != nil {
+		return
+	}
+	for _, rID := range rIDs {
+		if drawRel := f.getDrawingRelationships(drawingRelationships, rID); drawRel != nil {
+			f.deleteRelationship(drawingRelationships, rID)
+			f.deleteChartPart(path.Join(path.Dir(drawingXML), drawRel.Target))
+		}
+	}
+	return
+}
+
+// deleteChartPart provides a function to remove the chart part, the
+// relationships part of the chart and the parts which only referenced by the
+// chart, such as the chart style and images, by given chart part path.
+func (f *File) deleteChartPart(chartXML string) {
+	var parts []string
+	chartRelationships := path.Join(path.Dir(chartXML), "_rels", path.Base(chartXML)+".rels")
+	if rels := f.relsReader(chartRelationships); rels != nil {
+		rels.Lock()
+		for _, rel := range rels.Relationships {
+			if rel.TargetMode == "External" {
+				continue
+			}
+			part := path.Join(path.Dir(chartXML), rel.Target)
+			if strings.HasPrefix(rel.Target, "/") {
+				part = strings.TrimPrefix(rel.Target, "/")
+			}
+			parts = append(parts, part)
+		}
+		rels.Unlock()
+	}
+	f.Relationships.Delete(chartRelationships)
+	f.Pkg.Delete(chartRelationships)
+	f.Pkg.Delete(chartXML)
+	f.deleteSheetFromContentTypes("/" + chartXML)
+	for _, part := range parts {
+		if !f.isMediaReferenced(part) {
+			f.Pkg.Delete(part)
+			f.deleteSheetFromContentTypes("/" + part)
+		}
+	}
 }
 
-// countCharts provides a function to get chart files count storage in the
-// folder xl/charts.
+// countCharts provides a function to get the largest index of the chart
+// files storage in the folder xl/charts, the index of the deleted charts
+// will not be reused.
 func (f *File) countCharts() int {
 	count := 0
 	f.Pkg.Range(func(k, v interface{}) bool {
-		if strings.Contains(k.(string), "xl/charts/chart") {
-			count++
+		if strings.HasPrefix(k.(string), "xl/charts/chart") {
+			if idx, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(k.(string), "xl/charts/chart"), ".xml")); err == nil && idx > count {
+				count = idx
+			}
 		}
 		return true
 	})
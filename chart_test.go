@@ -209,6 +209,327 @@ func TestAddChart(t *testing.T) {
 	assert.NoError(t, f.Close())
 }
 
+func TestAddChartSeriesCombo(t *testing.T) {
+	f := NewFile()
+	for cell, v := range map[string]interface{}{"A2": "Small", "A3": "Normal", "A4": "Large", "B1": "Apple", "C1": "Orange", "D1": "Pear", "B2": 2, "C2": 3, "D2": 3, "B3": 5, "C3": 2, "D3": 4, "B4": 60, "C4": 70, "D4": 80} {
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, v))
+	}
+	assert.NoError(t, f.AddChart("Sheet1", "E1", `{"type":"col","series":[{"name":"Sheet1!$A$2","categories":"Sheet1!$B$1:$D$1","values":"Sheet1!$B$2:$D$2"},{"name":"Sheet1!$A$3","type":"line","categories":"Sheet1!$B$1:$D$1","values":"Sheet1!$B$3:$D$3"},{"name":"Sheet1!$A$4","type":"line","secondary":true,"categories":"Sheet1!$B$1:$D$1","values":"Sheet1!$B$4:$D$4"}],"title":{"name":"Column - Line Chart"}}`))
+	assert.NoError(t, f.AddChart("Sheet1", "E20", `{"type":"col","series":[{"name":"Sheet1!$A$2","categories":"Sheet1!$B$1:$D$1","values":"Sheet1!$B$2:$D$2"},{"name":"Sheet1!$A$3","type":"line","categories":"Sheet1!$B$1:$D$1","values":"Sheet1!$B$3:$D$3"}],"title":{"name":"Column - Line Chart"}}`))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddChartSeriesCombo.xlsx")))
+
+	var chartSpace xlsxChartSpace
+	content, ok := f.Pkg.Load("xl/charts/chart1.xml")
+	assert.True(t, ok)
+	assert.NoError(t, xml.Unmarshal(content.([]byte), &chartSpace))
+	plotArea := chartSpace.Chart.PlotArea
+	assert.Len(t, *plotArea.BarChart[0].Ser, 1)
+	// Test the primary and secondary groups of the same chart type keep all
+	// series.
+	if assert.Len(t, plotArea.LineChart, 2) {
+		for idx, lineChart := range plotArea.LineChart {
+			if assert.Len(t, *lineChart.Ser, 1) {
+				assert.Equal(t, idx+1, *(*lineChart.Ser)[0].IDx.Val)
+			}
+		}
+	}
+	assert.Equal(t, 754001152, *plotArea.BarChart[0].AxID[0].Val)
+	assert.Equal(t, 754001152, *plotArea.LineChart[0].AxID[0].Val)
+	assert.Equal(t, 754001153, *plotArea.LineChart[1].AxID[0].Val)
+	assert.Equal(t, 753999905, *plotArea.LineChart[1].AxID[1].Val)
+	assert.Len(t, plotArea.CatAx, 2)
+	assert.Len(t, plotArea.ValAx, 2)
+	assert.True(t, *plotArea.CatAx[1].Delete.Val)
+	assert.Equal(t, "r", *plotArea.ValAx[1].AxPos.Val)
+	assert.Equal(t, "max", *plotArea.ValAx[1].Crosses.Val)
+	assert.Equal(t, 754001153, *plotArea.ValAx[1].CrossAx.Val)
+
+	content, ok = f.Pkg.Load("xl/charts/chart2.xml")
+	assert.True(t, ok)
+	chartSpace = xlsxChartSpace{}
+	assert.NoError(t, xml.Unmarshal(content.([]byte), &chartSpace))
+	plotArea = chartSpace.Chart.PlotArea
+	assert.Len(t, *plotArea.BarChart[0].Ser, 1)
+	assert.Equal(t, 1, *(*plotArea.LineChart[0].Ser)[0].IDx.Val)
+	assert.Equal(t, 754001152, *plotArea.LineChart[0].AxID[0].Val)
+	assert.Len(t, plotArea.CatAx, 1)
+	assert.Len(t, plotArea.ValAx, 1)
+
+	// Test add chart with secondary axis options
+	assert.NoError(t, f.AddChart("Sheet1", "E40", `{"type":"line","series":[{"name":"Sheet1!$A$2","categories":"Sheet1!$B$1:$D$1","values":"Sheet1!$B$2:$D$2"},{"name":"Sheet1!$A$4","secondary":true,"categories":"Sheet1!$B$1:$D$1","values":"Sheet1!$B$4:$D$4"}],"y_axis":{"name":"Amount"},"y2_axis":{"name":"Percent","num_format":"0%","maximum":100,"major_grid_lines":true}}`))
+	content, ok = f.Pkg.Load("xl/charts/chart3.xml")
+	assert.True(t, ok)
+	chartSpace = xlsxChartSpace{}
+	assert.NoError(t, xml.Unmarshal(content.([]byte), &chartSpace))
+	plotArea = chartSpace.Chart.PlotArea
+	if assert.Len(t, plotArea.LineChart, 2) {
+		assert.Len(t, *plotArea.LineChart[0].Ser, 1)
+		assert.Len(t, *plotArea.LineChart[1].Ser, 1)
+	}
+	assert.Len(t, plotArea.CatAx, 2)
+	assert.Len(t, plotArea.ValAx, 2)
+	assert.NotNil(t, plotArea.ValAx[0].Title)
+	assert.Nil(t, plotArea.ValAx[0].MajorGridlines)
+	assert.NotNil(t, plotArea.ValAx[1].Title)
+	assert.Contains(t, string(content.([]byte)), "<a:t>Amount</a:t>")
+	assert.Contains(t, string(content.([]byte)), "<a:t>Percent</a:t>")
+	assert.Equal(t, "0%", plotArea.ValAx[1].NumFmt.FormatCode)
+	assert.Equal(t, 100.0, *plotArea.ValAx[1].Scaling.Max.Val)
+	assert.NotNil(t, plotArea.ValAx[1].MajorGridlines)
+	assert.Nil(t, plotArea.CatAx[1].Title)
+
+	// Test add chart with primary and secondary groups of the same chart type
+	assert.NoError(t, f.AddChart("Sheet1", "E60", `{"type":"col","series":[{"name":"Sheet1!$A$2","categories":"Sheet1!$B$1:$D$1","values":"Sheet1!$B$2:$D$2"},{"name":"Sheet1!$A$3","categories":"Sheet1!$B$1:$D$1","values":"Sheet1!$B$3:$D$3"},{"name":"Sheet1!$A$4","secondary":true,"categories":"Sheet1!$B$1:$D$1","values":"Sheet1!$B$4:$D$4"}]}`))
+	content, ok = f.Pkg.Load("xl/charts/chart4.xml")
+	assert.True(t, ok)
+	chartSpace = xlsxChartSpace{}
+	assert.NoError(t, xml.Unmarshal(content.([]byte), &chartSpace))
+	plotArea = chartSpace.Chart.PlotArea
+	if assert.Len(t, plotArea.BarChart, 2) {
+		assert.Len(t, *plotArea.BarChart[0].Ser, 2)
+		assert.Len(t, *plotArea.BarChart[1].Ser, 1)
+	}
+
+	// Test add chart with unsupported series chart type
+	assert.EqualError(t, f.AddChart("Sheet1", "E60", `{"type":"col","series":[{"name":"Sheet1!$A$2","type":"unknown","categories":"Sheet1!$B$1:$D$1","values":"Sheet1!$B$2:$D$2"}]}`), "unsupported chart type unknown")
+	assert.NoError(t, f.Close())
+}
+
+func TestAddChartCrossSheetRefs(t *testing.T) {
+	f := NewFile()
+	f.NewSheet("Data")
+	f.NewSheet("Dashboard")
+	for cell, v := range map[string]interface{}{"A2": "Small", "B1": "Apple", "C1": "Orange", "D1": "Pear", "B2": 2, "C2": 3, "D2": 3} {
+		assert.NoError(t, f.SetCellValue("Data", cell, v))
+	}
+	assert.NoError(t, f.AddChart("Dashboard", "A1", `{"type":"col","series":[{"name":"Data!$A$2","categories":"Data!$B$1:$D$1","values":"Data!$B$2:$D$2"},{"name":"Total","categories":"$B$1:$D$1","values":"$B$2:$D$2"}]}`))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddChartCrossSheetRefs.xlsx")))
+	var chartSpace xlsxChartSpace
+	content, ok := f.Pkg.Load("xl/charts/chart1.xml")
+	assert.True(t, ok)
+	assert.NoError(t, xml.Unmarshal(content.([]byte), &chartSpace))
+	ser := *chartSpace.Chart.PlotArea.BarChart[0].Ser
+	assert.Equal(t, "Data!$A$2", ser[0].Tx.StrRef.F)
+	assert.Equal(t, "Data!$B$1:$D$1", ser[0].Cat.StrRef.F)
+	assert.Equal(t, "Data!$B$2:$D$2", ser[0].Val.NumRef.F)
+	assert.Equal(t, "Total", ser[1].Tx.StrRef.F)
+	assert.Equal(t, "Dashboard!$B$1:$D$1", ser[1].Cat.StrRef.F)
+	assert.Equal(t, "Dashboard!$B$2:$D$2", ser[1].Val.NumRef.F)
+	assert.NoError(t, f.Close())
+}
+
+func TestGetChartSeriesRef(t *testing.T) {
+	for _, c := range []struct{ sheet, ref, expected string }{
+		{"Sheet1", "", ""},
+		{"Sheet1", "Series", "Series"},
+		{"Sheet1", "$A$1:$A$10", "Sheet1!$A$1:$A$10"},
+		{"Sheet1", "=A1", "Sheet1!A1"},
+		{"Sheet1", "Data!$A$1:$A$10", "Data!$A$1:$A$10"},
+		{"Sheet1", "Raw Data!$A$1", "'Raw Data'!$A$1"},
+		{"Sheet1", "'Raw Data'!$A$1", "'Raw Data'!$A$1"},
+		{"Sheet1", "'Bob''s'!$A$1", "'Bob''s'!$A$1"},
+		{"Sheet1", "2021!$A$1", "'2021'!$A$1"},
+		{"Sheet1", "A1!$A$1", "'A1'!$A$1"},
+		{"Sheet1", "'R1C1'!$A$1", "'R1C1'!$A$1"},
+		{"Sheet1", "Data!Name", "Data!Name"},
+		{"My Sheet", "$A$1", "'My Sheet'!$A$1"},
+		{"", "$A$1", "$A$1"},
+	} {
+		assert.Equal(t, c.expected, getChartSeriesRef(c.sheet, c.ref))
+	}
+}
+
+func TestAddChartTrendline(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{{1, 2, 3}, {2, 4, 5}, {3, 7, 8}, {4, 8, 12}, {5, 11, 13}} {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", idx+1), &row))
+	}
+	assert.NoError(t, f.AddChart("Sheet1", "E1", `{"type":"scatter","series":[{"name":"Sheet1!$B$1","categories":"Sheet1!$A$1:$A$5","values":"Sheet1!$B$1:$B$5","trendline":{"type":"linear","display_equation":true,"display_r_squared":true}},{"name":"Sheet1!$C$1","categories":"Sheet1!$A$1:$A$5","values":"Sheet1!$C$1:$C$5","trendline":{"type":"polynomial","order":3}},{"name":"Sheet1!$C$1","categories":"Sheet1!$A$1:$A$5","values":"Sheet1!$C$1:$C$5","trendline":{"type":"movingAvg"}},{"name":"Sheet1!$C$1","categories":"Sheet1!$A$1:$A$5","values":"Sheet1!$C$1:$C$5"}]}`))
+	assert.NoError(t, f.AddChart("Sheet1", "E20", `{"type":"pie","series":[{"name":"Sheet1!$B$1","categories":"Sheet1!$A$1:$A$5","values":"Sheet1!$B$1:$B$5","trendline":{"type":"linear"}}]}`))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddChartTrendline.xlsx")))
+
+	var chartSpace xlsxChartSpace
+	content, ok := f.Pkg.Load("xl/charts/chart1.xml")
+	assert.True(t, ok)
+	assert.NoError(t, xml.Unmarshal(content.([]byte), &chartSpace))
+	ser := *chartSpace.Chart.PlotArea.ScatterChart[0].Ser
+	assert.Equal(t, "linear", *ser[0].Trendline.TrendlineType.Val)
+	assert.True(t, *ser[0].Trendline.DispEq.Val)
+	assert.True(t, *ser[0].Trendline.DispRSqr.Val)
+	assert.Nil(t, ser[0].Trendline.Order)
+	assert.Equal(t, "poly", *ser[1].Trendline.TrendlineType.Val)
+	assert.Equal(t, 3, *ser[1].Trendline.Order.Val)
+	assert.False(t, *ser[1].Trendline.DispEq.Val)
+	assert.Equal(t, "movingAvg", *ser[2].Trendline.TrendlineType.Val)
+	assert.Equal(t, 2, *ser[2].Trendline.Period.Val)
+	assert.Nil(t, ser[3].Trendline)
+
+	chartSpace = xlsxChartSpace{}
+	content, ok = f.Pkg.Load("xl/charts/chart2.xml")
+	assert.True(t, ok)
+	assert.NoError(t, xml.Unmarshal(content.([]byte), &chartSpace))
+	assert.Nil(t, (*chartSpace.Chart.PlotArea.PieChart[0].Ser)[0].Trendline)
+
+	// Test add chart with invalid trendline settings
+	for _, trendline := range []string{`{"type":"unknown"}`, `{"type":"polynomial","order":7}`, `{"type":"movingAvg","period":1}`} {
+		assert.EqualError(t, f.AddChart("Sheet1", "E40", `{"type":"scatter","series":[{"name":"Sheet1!$B$1","categories":"Sheet1!$A$1:$A$5","values":"Sheet1!$B$1:$B$5","trendline":`+trendline+`}]}`), ErrParameterInvalid.Error())
+	}
+	assert.NoError(t, f.Close())
+}
+
+func TestAddChartErrorBars(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{{1, 2, 0.5}, {2, 4, 0.3}, {3, 7, 0.6}, {4, 8, 0.2}, {5, 11, 0.4}} {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", idx+1), &row))
+	}
+	assert.NoError(t, f.AddChart("Sheet1", "E1", `{"type":"scatter","series":[{"name":"Sheet1!$B$1","categories":"Sheet1!$A$1:$A$5","values":"Sheet1!$B$1:$B$5","error_bars":{"type":"fixed","value":0.5}},{"name":"Sheet1!$B$1","categories":"Sheet1!$A$1:$A$5","values":"Sheet1!$B$1:$B$5","error_bars":{"type":"percentage","direction":"plus"}},{"name":"Sheet1!$B$1","categories":"Sheet1!$A$1:$A$5","values":"Sheet1!$B$1:$B$5","error_bars":{"type":"standard_error","no_end_cap":true}},{"name":"Sheet1!$B$1","categories":"Sheet1!$A$1:$A$5","values":"Sheet1!$B$1:$B$5","error_bars":{"type":"custom","plus":"$C$1:$C$5","minus":"Sheet1!$C$1:$C$5"}}]}`))
+	assert.NoError(t, f.AddChart("Sheet1", "E20", `{"type":"line","series":[{"name":"Sheet1!$B$1","categories":"Sheet1!$A$1:$A$5","values":"Sheet1!$B$1:$B$5","error_bars":{"type":"custom","direction":"minus","minus":"Sheet1!$C$1:$C$5"}}]}`))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddChartErrorBars.xlsx")))
+
+	var chartSpace xlsxChartSpace
+	content, ok := f.Pkg.Load("xl/charts/chart1.xml")
+	assert.True(t, ok)
+	assert.NoError(t, xml.Unmarshal(content.([]byte), &chartSpace))
+	ser := *chartSpace.Chart.PlotArea.ScatterChart[0].Ser
+	assert.Equal(t, "y", *ser[0].ErrBars.ErrDir.Val)
+	assert.Equal(t, "both", *ser[0].ErrBars.ErrBarType.Val)
+	assert.Equal(t, "fixedVal", *ser[0].ErrBars.ErrValType.Val)
+	assert.Equal(t, 0.5, *ser[0].ErrBars.Val.Val)
+	assert.Equal(t, "plus", *ser[1].ErrBars.ErrBarType.Val)
+	assert.Equal(t, "percentage", *ser[1].ErrBars.ErrValType.Val)
+	assert.Equal(t, 5.0, *ser[1].ErrBars.Val.Val)
+	assert.Equal(t, "stdErr", *ser[2].ErrBars.ErrValType.Val)
+	assert.Nil(t, ser[2].ErrBars.Val)
+	assert.True(t, *ser[2].ErrBars.NoEndCap.Val)
+	assert.Equal(t, "cust", *ser[3].ErrBars.ErrValType.Val)
+	assert.Equal(t, "Sheet1!$C$1:$C$5", ser[3].ErrBars.Plus.NumRef.F)
+	assert.Equal(t, "Sheet1!$C$1:$C$5", ser[3].ErrBars.Minus.NumRef.F)
+
+	chartSpace = xlsxChartSpace{}
+	content, ok = f.Pkg.Load("xl/charts/chart2.xml")
+	assert.True(t, ok)
+	assert.NoError(t, xml.Unmarshal(content.([]byte), &chartSpace))
+	errBars := (*chartSpace.Chart.PlotArea.LineChart[0].Ser)[0].ErrBars
+	assert.Nil(t, errBars.ErrDir)
+	assert.Equal(t, "minus", *errBars.ErrBarType.Val)
+	assert.Nil(t, errBars.Plus)
+	assert.Equal(t, "Sheet1!$C$1:$C$5", errBars.Minus.NumRef.F)
+
+	// Test add chart with invalid error bars settings
+	for errorBars, expected := range map[string]error{
+		`{"type":"unknown"}`:                                  ErrParameterInvalid,
+		`{"type":"fixed","direction":"unknown"}`:              ErrParameterInvalid,
+		`{"type":"custom","plus":"Sheet1!$C$1:$C$5"}`:         ErrParameterRequired,
+		`{"type":"custom","direction":"plus","minus":"$C$1"}`: ErrParameterRequired,
+	} {
+		assert.EqualError(t, f.AddChart("Sheet1", "E40", `{"type":"scatter","series":[{"name":"Sheet1!$B$1","categories":"Sheet1!$A$1:$A$5","values":"Sheet1!$B$1:$B$5","error_bars":`+errorBars+`}]}`), expected.Error())
+	}
+	assert.NoError(t, f.Close())
+}
+
+func TestAddChartDataLabels(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{{"A", 0.25, 3}, {"B", 0.5, 5}, {"C", 0.25, 8}} {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", idx+1), &row))
+	}
+	assert.NoError(t, f.AddChart("Sheet1", "E1", `{"type":"col","series":[{"name":"Sheet1!$B$1","categories":"Sheet1!$A$1:$A$3","values":"Sheet1!$B$1:$B$3","data_labels":{"show_val":true,"show_series_name":true,"position":"insideEnd","num_format":"0.0%"}},{"name":"Sheet1!$C$1","categories":"Sheet1!$A$1:$A$3","values":"Sheet1!$C$1:$C$3"}],"plotarea":{"show_val":true,"show_cat_name":true,"label_position":"outsideEnd","label_num_format":"#,##0"}}`))
+	assert.NoError(t, f.AddChart("Sheet1", "E20", `{"type":"scatter","series":[{"name":"Sheet1!$B$1","categories":"Sheet1!$C$1:$C$3","values":"Sheet1!$B$1:$B$3","data_labels":{"show_val":true,"position":"above"}},{"name":"Sheet1!$C$1","categories":"Sheet1!$C$1:$C$3","values":"Sheet1!$C$1:$C$3"}]}`))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddChartDataLabels.xlsx")))
+
+	var chartSpace xlsxChartSpace
+	content, ok := f.Pkg.Load("xl/charts/chart1.xml")
+	assert.True(t, ok)
+	assert.NoError(t, xml.Unmarshal(content.([]byte), &chartSpace))
+	dLbls := chartSpace.Chart.PlotArea.BarChart[0].DLbls
+	assert.Equal(t, "outEnd", *dLbls.DLblPos.Val)
+	assert.Equal(t, "#,##0", dLbls.NumFmt.FormatCode)
+	ser := *chartSpace.Chart.PlotArea.BarChart[0].Ser
+	assert.Equal(t, "inEnd", *ser[0].DLbls.DLblPos.Val)
+	assert.Equal(t, "0.0%", ser[0].DLbls.NumFmt.FormatCode)
+	assert.True(t, *ser[0].DLbls.ShowVal.Val)
+	assert.True(t, *ser[0].DLbls.ShowSerName.Val)
+	assert.False(t, *ser[0].DLbls.ShowCatName.Val)
+	assert.Equal(t, "outEnd", *ser[1].DLbls.DLblPos.Val)
+	assert.Equal(t, "#,##0", ser[1].DLbls.NumFmt.FormatCode)
+	assert.True(t, *ser[1].DLbls.ShowCatName.Val)
+
+	chartSpace = xlsxChartSpace{}
+	content, ok = f.Pkg.Load("xl/charts/chart2.xml")
+	assert.True(t, ok)
+	assert.NoError(t, xml.Unmarshal(content.([]byte), &chartSpace))
+	ser = *chartSpace.Chart.PlotArea.ScatterChart[0].Ser
+	assert.Equal(t, "t", *ser[0].DLbls.DLblPos.Val)
+	assert.Nil(t, ser[0].DLbls.NumFmt)
+	assert.Nil(t, ser[1].DLbls)
+
+	// Test add chart with invalid data labels position
+	assert.EqualError(t, f.AddChart("Sheet1", "E40", `{"type":"col","series":[{"name":"Sheet1!$B$1","categories":"Sheet1!$A$1:$A$3","values":"Sheet1!$B$1:$B$3"}],"plotarea":{"label_position":"unknown"}}`), ErrParameterInvalid.Error())
+	assert.EqualError(t, f.AddChart("Sheet1", "E40", `{"type":"col","series":[{"name":"Sheet1!$B$1","categories":"Sheet1!$A$1:$A$3","values":"Sheet1!$B$1:$B$3","data_labels":{"position":"unknown"}}]}`), ErrParameterInvalid.Error())
+	assert.NoError(t, f.Close())
+}
+
+func TestAddChartOfPie(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{{"A", 50}, {"B", 30}, {"C", 10}, {"D", 6}, {"E", 4}} {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", idx+1), &row))
+	}
+	assert.NoError(t, f.AddChart("Sheet1", "D1", `{"type":"pieOfPie","series":[{"name":"Sheet1!$A$1","categories":"Sheet1!$A$1:$A$5","values":"Sheet1!$B$1:$B$5"}],"of_pie":{"split_type":"value","split_value":10,"second_plot_size":60,"gap_width":0}}`))
+	assert.NoError(t, f.AddChart("Sheet1", "D20", `{"type":"barOfPie","series":[{"name":"Sheet1!$A$1","categories":"Sheet1!$A$1:$A$5","values":"Sheet1!$B$1:$B$5"}],"of_pie":{"split_type":"position"}}`))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddChartOfPie.xlsx")))
+
+	var chartSpace xlsxChartSpace
+	content, ok := f.Pkg.Load("xl/charts/chart1.xml")
+	assert.True(t, ok)
+	assert.NoError(t, xml.Unmarshal(content.([]byte), &chartSpace))
+	ofPieChart := chartSpace.Chart.PlotArea.OfPieChart[0]
+	assert.Equal(t, "pie", *ofPieChart.OfPieType.Val)
+	assert.Equal(t, "val", *ofPieChart.SplitType.Val)
+	assert.Equal(t, 10.0, *ofPieChart.SplitPos.Val)
+	assert.Equal(t, 60, *ofPieChart.SecondPieSize.Val)
+	assert.Equal(t, 0, *ofPieChart.GapWidth.Val)
+
+	chartSpace = xlsxChartSpace{}
+	content, ok = f.Pkg.Load("xl/charts/chart2.xml")
+	assert.True(t, ok)
+	assert.NoError(t, xml.Unmarshal(content.([]byte), &chartSpace))
+	ofPieChart = chartSpace.Chart.PlotArea.OfPieChart[0]
+	assert.Equal(t, "bar", *ofPieChart.OfPieType.Val)
+	assert.Equal(t, "pos", *ofPieChart.SplitType.Val)
+	assert.Nil(t, ofPieChart.SplitPos)
+	assert.Nil(t, ofPieChart.SecondPieSize)
+	assert.Nil(t, ofPieChart.GapWidth)
+
+	// Test add chart with invalid pie of pie settings
+	for _, ofPie := range []string{`{"split_type":"unknown"}`, `{"second_plot_size":4}`, `{"gap_width":501}`} {
+		assert.EqualError(t, f.AddChart("Sheet1", "D40", `{"type":"pieOfPie","series":[{"name":"Sheet1!$A$1","categories":"Sheet1!$A$1:$A$5","values":"Sheet1!$B$1:$B$5"}],"of_pie":`+ofPie+`}`), ErrParameterInvalid.Error())
+	}
+	assert.NoError(t, f.Close())
+}
+
+func TestAddChartAxisTicks(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddChart("Sheet1", "E1", `{"type":"line","series":[{"name":"Sheet1!$A$2","categories":"Sheet1!$B$1:$D$1","values":"Sheet1!$B$2:$D$2"}],"x_axis":{"major_tick_mark":"out","tick_label_position":"low"},"y_axis":{"major_unit":10,"minor_unit":2,"major_tick_mark":"cross","minor_tick_mark":"in","tick_label_position":"high","logbase":10}}`))
+	var chartSpace xlsxChartSpace
+	content, ok := f.Pkg.Load("xl/charts/chart1.xml")
+	assert.True(t, ok)
+	assert.NoError(t, xml.Unmarshal(content.([]byte), &chartSpace))
+	catAx, valAx := chartSpace.Chart.PlotArea.CatAx[0], chartSpace.Chart.PlotArea.ValAx[0]
+	assert.Equal(t, "out", *catAx.MajorTickMark.Val)
+	assert.Equal(t, "none", *catAx.MinorTickMark.Val)
+	assert.Equal(t, "low", *catAx.TickLblPos.Val)
+	assert.Equal(t, 10.0, *valAx.MajorUnit.Val)
+	assert.Equal(t, 2.0, *valAx.MinorUnit.Val)
+	assert.Equal(t, "cross", *valAx.MajorTickMark.Val)
+	assert.Equal(t, "in", *valAx.MinorTickMark.Val)
+	assert.Equal(t, "high", *valAx.TickLblPos.Val)
+	assert.Equal(t, 10.0, *valAx.Scaling.LogBase.Val)
+	// Test add chart with invalid axis settings
+	for _, axis := range []string{`"x_axis":{"major_tick_mark":"unknown"}`, `"y_axis":{"minor_tick_mark":"unknown"}`, `"y_axis":{"tick_label_position":"unknown"}`, `"y2_axis":{"minor_unit":-1}`} {
+		assert.EqualError(t, f.AddChart("Sheet1", "E20", `{"type":"line","series":[{"name":"Sheet1!$A$2","categories":"Sheet1!$B$1:$D$1","values":"Sheet1!$B$2:$D$2"}],`+axis+`}`), ErrParameterInvalid.Error())
+	}
+	assert.NoError(t, f.Close())
+}
+
 func TestAddChartSheet(t *testing.T) {
 	categories := map[string]string{"A2": "Small", "A3": "Normal", "A4": "Large", "B1": "Apple", "C1": "Orange", "D1": "Pear"}
 	values := map[string]int{"B2": 2, "C2": 3, "D2": 3, "B3": 5, "C3": 2, "D3": 4, "B4": 6, "C4": 7, "D4": 8}
@@ -256,6 +577,75 @@ func TestDeleteChart(t *testing.T) {
 	// Test delete chart on no chart worksheet.
 	assert.NoError(t, NewFile().DeleteChart("Sheet1", "A1"))
 	assert.NoError(t, f.Close())
+
+	// Test delete chart with other charts and pictures in the same drawing
+	f = NewFile()
+	format := `{"type":"col","series":[{"name":"Sheet1!$A$2","categories":"Sheet1!$B$1:$D$1","values":"Sheet1!$B$2:$D$2"}]}`
+	assert.NoError(t, f.AddChart("Sheet1", "E1", format))
+	assert.NoError(t, f.AddPicture("Sheet1", "E10", filepath.Join("test", "images", "excel.png"), ""))
+	assert.NoError(t, f.AddChart("Sheet1", "E20", format))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDeleteChart2.xlsx")))
+	f, err = OpenFile(filepath.Join("test", "TestDeleteChart2.xlsx"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.DeleteChart("Sheet1", "E1"))
+	_, ok := f.Pkg.Load("xl/charts/chart1.xml")
+	assert.False(t, ok)
+	_, ok = f.Pkg.Load("xl/charts/chart2.xml")
+	assert.True(t, ok)
+	for _, override := range f.contentTypesReader().Overrides {
+		assert.NotEqual(t, "/xl/charts/chart1.xml", override.PartName)
+	}
+	for _, rel := range f.relsReader("xl/drawings/_rels/drawing1.xml.rels").Relationships {
+		assert.NotEqual(t, "../charts/chart1.xml", rel.Target)
+	}
+	cells, err := f.GetCharts("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"E20"}, cells)
+	pic, _, err := f.GetPicture("Sheet1", "E10")
+	assert.NoError(t, err)
+	assert.Equal(t, "image1.png", pic)
+	// Test add chart after delete chart
+	assert.NoError(t, f.AddChart("Sheet1", "E30", format))
+	_, ok = f.Pkg.Load("xl/charts/chart3.xml")
+	assert.True(t, ok)
+	cells, err = f.GetCharts("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"E20", "E30"}, cells)
+	assert.NoError(t, f.DeleteChart("Sheet1", "E20"))
+	assert.NoError(t, f.DeleteChart("Sheet1", "E30"))
+	cells, err = f.GetCharts("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, cells)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDeleteChart2.xlsx")))
+	assert.NoError(t, f.Close())
+}
+
+func TestGetCharts(t *testing.T) {
+	f := NewFile()
+	format := `{"type":"col","series":[{"name":"Sheet1!$A$2","categories":"Sheet1!$B$1:$D$1","values":"Sheet1!$B$2:$D$2"}]}`
+	assert.NoError(t, f.AddChart("Sheet1", "E1", format))
+	assert.NoError(t, f.AddPicture("Sheet1", "E10", filepath.Join("test", "images", "excel.png"), ""))
+	assert.NoError(t, f.AddShape("Sheet1", "E15", `{"type":"rect","paragraph":[{"text":"Rectangle"}]}`))
+	assert.NoError(t, f.AddChart("Sheet1", "E20", format))
+	cells, err := f.GetCharts("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"E1", "E20"}, cells)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGetCharts.xlsx")))
+
+	f, err = OpenFile(filepath.Join("test", "TestGetCharts.xlsx"))
+	assert.NoError(t, err)
+	cells, err = f.GetCharts("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"E1", "E20"}, cells)
+	// Test get charts on the worksheet without charts
+	f.NewSheet("Sheet2")
+	cells, err = f.GetCharts("Sheet2")
+	assert.NoError(t, err)
+	assert.Empty(t, cells)
+	// Test get charts on not exists worksheet
+	_, err = f.GetCharts("SheetN")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+	assert.NoError(t, f.Close())
 }
 
 func TestChartWithLogarithmicBase(t *testing.T) {
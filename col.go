@@ -19,12 +19,14 @@ import (
 	"strings"
 
 	"github.com/mohae/deepcopy"
+	"golang.org/x/text/width"
 )
 
 // Define the default cell size and EMU unit of measurement.
 const (
 	defaultColWidth        float64 = 9.140625
 	defaultColWidthPixels  float64 = 64
+	defaultFontSize        float64 = 11
 	defaultRowHeight       float64 = 15
 	defaultRowHeightPixels float64 = 20
 	EMU                    int     = 9525
@@ -91,17 +93,69 @@ func (cols *Cols) Error() error {
 
 // Rows return the current column's row values.
 func (cols *Cols) Rows(opts ...Options) ([]string, error) {
-	var (
-		err              error
-		inElement        string
-		cellCol, cellRow int
-		rows             []string
-	)
+	var rows []string
 	if cols.stashCol >= cols.curCol {
-		return rows, err
+		return rows, nil
 	}
 	cols.rawCellValue = parseOptions(opts...).RawCellValue
 	d := cols.f.sharedStringsReader()
+	cells, _, err := cols.currentColCells()
+	for _, colCell := range cells {
+		if colCell == nil {
+			rows = append(rows, "")
+			continue
+		}
+		val, _ := colCell.getValueFrom(cols.f, d, cols.rawCellValue)
+		rows = append(rows, val)
+	}
+	return rows, err
+}
+
+// CurrentColStyles return the style index of each cell in the current
+// column, the cell without style will use the style of the column. The
+// returned styles are the same as the GetCellStyle function returns for the
+// cells, and aligned with the values returned by the Rows function. For
+// example:
+//
+//    cols, err := f.Cols("Sheet1")
+//    if err != nil {
+//        fmt.Println(err)
+//        return
+//    }
+//    for cols.Next() {
+//        styles, err := cols.CurrentColStyles()
+//        if err != nil {
+//            fmt.Println(err)
+//        }
+//        fmt.Println(styles)
+//    }
+//
+func (cols *Cols) CurrentColStyles() ([]int, error) {
+	var styles []int
+	if cols.stashCol >= cols.curCol {
+		return styles, nil
+	}
+	cells, colStyle, err := cols.currentColCells()
+	for _, colCell := range cells {
+		if colCell == nil || colCell.S == 0 {
+			styles = append(styles, colStyle)
+			continue
+		}
+		styles = append(styles, colCell.S)
+	}
+	return styles, err
+}
+
+// currentColCells provides a function to parse the cells of the current
+// column in the worksheet, and returns the cells and the style index of the
+// column. The blank cells before the last cell will be filled with nil.
+func (cols *Cols) currentColCells() ([]*xlsxC, int, error) {
+	var (
+		err                        error
+		inElement                  string
+		cellCol, cellRow, colStyle int
+		cells                      []*xlsxC
+	)
 	decoder := cols.f.xmlNewDecoder(bytes.NewReader(cols.sheetXML))
 	for {
 		token, _ := decoder.Token()
@@ -111,6 +165,13 @@ func (cols *Cols) Rows(opts ...Options) ([]string, error) {
 		switch xmlElement := token.(type) {
 		case xml.StartElement:
 			inElement = xmlElement.Name.Local
+			if inElement == "col" {
+				col := xlsxCol{}
+				_ = decoder.DecodeElement(&col, &xmlElement)
+				if col.Min <= cols.curCol && cols.curCol <= col.Max {
+					colStyle = col.Style
+				}
+			}
 			if inElement == "row" {
 				cellCol = 0
 				cellRow++
@@ -124,28 +185,27 @@ func (cols *Cols) Rows(opts ...Options) ([]string, error) {
 				for _, attr := range xmlElement.Attr {
 					if attr.Name.Local == "r" {
 						if cellCol, cellRow, err = CellNameToCoordinates(attr.Value); err != nil {
-							return rows, err
+							return cells, colStyle, err
 						}
 					}
 				}
-				blank := cellRow - len(rows)
+				blank := cellRow - len(cells)
 				for i := 1; i < blank; i++ {
-					rows = append(rows, "")
+					cells = append(cells, nil)
 				}
 				if cellCol == cols.curCol {
 					colCell := xlsxC{}
 					_ = decoder.DecodeElement(&colCell, &xmlElement)
-					val, _ := colCell.getValueFrom(cols.f, d, cols.rawCellValue)
-					rows = append(rows, val)
+					cells = append(cells, &colCell)
 				}
 			}
 		case xml.EndElement:
 			if xmlElement.Name.Local == "sheetData" {
-				return rows, err
+				return cells, colStyle, err
 			}
 		}
 	}
-	return rows, err
+	return cells, colStyle, err
 }
 
 // columnXMLIterator defined runtime use field for the worksheet column SAX parser.
@@ -243,6 +303,213 @@ func (f *File) Cols(sheet string) (*Cols, error) {
 	return &colIterator.cols, nil
 }
 
+// StreamColReader defines a streaming columns reader of a worksheet, which
+// indexes the position of cells in the worksheet XML in a single pass, and
+// decodes the cells of the current column and the shared strings on demand.
+type StreamColReader struct {
+	err                        error
+	curCol, totalCols, maxRows int
+	sheet                      string
+	f                          *File
+	sheetXML, sstXML           []byte
+	cells                      [][]streamCellPos
+	sst                        *xlsxSST
+	sstPos                     []streamCellPos
+	sstCache                   map[int]string
+}
+
+// streamCellPos defined the row number and the byte offsets of a cell or a
+// shared string item in the XML.
+type streamCellPos struct {
+	row, start, end int
+}
+
+// StreamColumns returns a streaming columns reader, used for reading data
+// column by column for a worksheet with a large data. Unlike the Cols
+// function, the worksheet will be parsed only once, and the shared strings
+// will be read lazily when needed. For example:
+//
+//    cols, err := f.StreamColumns("Sheet1")
+//    if err != nil {
+//        fmt.Println(err)
+//        return
+//    }
+//    for cols.Next() {
+//        col, err := cols.Rows()
+//        if err != nil {
+//            fmt.Println(err)
+//        }
+//        for _, rowCell := range col {
+//            fmt.Print(rowCell, "\t")
+//        }
+//        fmt.Println()
+//    }
+//
+func (f *File) StreamColumns(sheet string) (*StreamColReader, error) {
+	name, ok := f.sheetMap[trimSheetName(sheet)]
+	if !ok {
+		return nil, ErrSheetNotExist{sheet}
+	}
+	if ws, ok := f.Sheet.Load(name); ok && ws != nil {
+		worksheet := ws.(*xlsxWorksheet)
+		worksheet.Lock()
+		defer worksheet.Unlock()
+		output, _ := xml.Marshal(worksheet)
+		f.saveFileList(name, f.replaceNameSpaceBytes(name, output))
+	}
+	var (
+		err          error
+		cellCol, row int
+		cols         = StreamColReader{f: f, sheet: trimSheetName(sheet), sheetXML: f.readBytes(name)}
+	)
+	decoder := f.xmlNewDecoder(bytes.NewReader(cols.sheetXML))
+	for {
+		start := int(decoder.InputOffset())
+		token, _ := decoder.Token()
+		if token == nil {
+			break
+		}
+		switch xmlElement := token.(type) {
+		case xml.StartElement:
+			if xmlElement.Name.Local == "row" {
+				row++
+				if attrR, _ := attrValToInt("r", xmlElement.Attr); attrR != 0 {
+					row = attrR
+				}
+				cellCol = 0
+			}
+			if xmlElement.Name.Local == "c" {
+				cellCol++
+				for _, attr := range xmlElement.Attr {
+					if attr.Name.Local == "r" {
+						if cellCol, _, err = CellNameToCoordinates(attr.Value); err != nil {
+							return &cols, err
+						}
+					}
+				}
+				if err = decoder.Skip(); err != nil {
+					return &cols, err
+				}
+				for len(cols.cells) < cellCol {
+					cols.cells = append(cols.cells, nil)
+				}
+				cols.cells[cellCol-1] = append(cols.cells[cellCol-1],
+					streamCellPos{row: row, start: start, end: int(decoder.InputOffset())})
+				if row > cols.maxRows {
+					cols.maxRows = row
+				}
+			}
+		case xml.EndElement:
+			if xmlElement.Name.Local == "sheetData" {
+				cols.totalCols = len(cols.cells)
+				return &cols, err
+			}
+		}
+	}
+	cols.totalCols = len(cols.cells)
+	return &cols, err
+}
+
+// CurrentCol returns the column number that represents the current column.
+func (cols *StreamColReader) CurrentCol() int {
+	return cols.curCol
+}
+
+// TotalCols returns the total columns count in the worksheet.
+func (cols *StreamColReader) TotalCols() int {
+	return cols.totalCols
+}
+
+// Next will return true if the next column is found.
+func (cols *StreamColReader) Next() bool {
+	cols.curCol++
+	return cols.curCol <= cols.totalCols
+}
+
+// Error will return an error when the error occurs.
+func (cols *StreamColReader) Error() error {
+	return cols.err
+}
+
+// Rows return the current column's row values.
+func (cols *StreamColReader) Rows(opts ...Options) ([]string, error) {
+	var rows []string
+	if cols.curCol < 1 || cols.curCol > cols.totalCols {
+		return rows, nil
+	}
+	raw := parseOptions(opts...).RawCellValue
+	for _, pos := range cols.cells[cols.curCol-1] {
+		colCell := xlsxC{}
+		if cols.err = xml.Unmarshal(cols.sheetXML[pos.start:pos.end], &colCell); cols.err != nil {
+			return rows, cols.err
+		}
+		if colCell.T == "s" && colCell.V != "" {
+			idx, _ := strconv.Atoi(colCell.V)
+			if colCell.V, cols.err = cols.sharedString(idx); cols.err != nil {
+				return rows, cols.err
+			}
+			colCell.T = "str"
+		}
+		val, _ := colCell.getValueFrom(cols.f, nil, raw)
+		rows = append(appendSpace(pos.row-len(rows), rows), val)
+	}
+	// keep the same length with the values returned by the Cols iterator
+	return appendSpace(cols.maxRows-len(rows), rows), cols.err
+}
+
+// sharedString provides a function to get the shared string item by given
+// index. The shared strings part will be indexed in the first call, and the
+// decoded items will be cached.
+func (cols *StreamColReader) sharedString(idx int) (string, error) {
+	if cols.sstCache == nil {
+		cols.sstCache = make(map[int]string)
+		if cols.sst = cols.f.SharedStrings; cols.sst == nil {
+			if err := cols.indexSharedStrings(); err != nil {
+				return "", err
+			}
+		}
+	}
+	if cols.sst != nil {
+		if idx < len(cols.sst.SI) {
+			return cols.sst.SI[idx].String(), nil
+		}
+		return strconv.Itoa(idx), nil
+	}
+	if val, ok := cols.sstCache[idx]; ok {
+		return val, nil
+	}
+	if idx >= len(cols.sstPos) {
+		return strconv.Itoa(idx), nil
+	}
+	var si xlsxSI
+	pos := cols.sstPos[idx]
+	if err := xml.Unmarshal(cols.sstXML[pos.start:pos.end], &si); err != nil {
+		return "", err
+	}
+	cols.sstCache[idx] = si.String()
+	return cols.sstCache[idx], nil
+}
+
+// indexSharedStrings provides a function to index the position of each shared
+// string item in the shared strings part.
+func (cols *StreamColReader) indexSharedStrings() error {
+	cols.sstXML = namespaceStrictToTransitional(cols.f.readBytes("xl/sharedStrings.xml"))
+	decoder := cols.f.xmlNewDecoder(bytes.NewReader(cols.sstXML))
+	for {
+		start := int(decoder.InputOffset())
+		token, _ := decoder.Token()
+		if token == nil {
+			return nil
+		}
+		if xmlElement, ok := token.(xml.StartElement); ok && xmlElement.Name.Local == "si" {
+			if err := decoder.Skip(); err != nil {
+				return err
+			}
+			cols.sstPos = append(cols.sstPos, streamCellPos{start: start, end: int(decoder.InputOffset())})
+		}
+	}
+}
+
 // GetColVisible provides a function to get visible of a single column by given
 // worksheet name and column name. For example, get visible state of column D
 // in Sheet1:
@@ -296,7 +563,7 @@ func (f *File) SetColVisible(sheet, columns string, visible bool) error {
 	colData := xlsxCol{
 		Min:         start,
 		Max:         end,
-		Width:       defaultColWidth, // default width
+		Width:       f.getDefaultColWidth(ws), // default width
 		Hidden:      !visible,
 		CustomWidth: true,
 	}
@@ -409,6 +676,135 @@ func (f *File) SetColOutlineLevel(sheet, col string, level uint8) error {
 	return err
 }
 
+// getColOutlineLevel provides a function to get outline level of a single
+// column by given worksheet and column number.
+func (f *File) getColOutlineLevel(ws *xlsxWorksheet, colNum int) (level uint8) {
+	if ws.Cols == nil {
+		return
+	}
+	for c := range ws.Cols.Col {
+		colData := &ws.Cols.Col[c]
+		if colData.Min <= colNum && colNum <= colData.Max {
+			level = colData.OutlineLevel
+		}
+	}
+	return
+}
+
+// getColGroup provides a function to get the first and last column number of
+// the columns group in the given outline level which contains the given
+// column number, and the column number of the summary column on the right of
+// the group.
+func (f *File) getColGroup(ws *xlsxWorksheet, colNum int, level uint8) (start, end, summary int) {
+	start, end = colNum, colNum
+	for start > 1 && f.getColOutlineLevel(ws, start-1) >= level {
+		start--
+	}
+	for end < TotalColumns && f.getColOutlineLevel(ws, end+1) >= level {
+		end++
+	}
+	if summary = end; end < TotalColumns {
+		summary = end + 1
+	}
+	return
+}
+
+// isColGroupCollapsed provides a function to get the collapsed state of the
+// columns group by given worksheet and summary column number.
+func (f *File) isColGroupCollapsed(ws *xlsxWorksheet, summary int) (collapsed bool) {
+	if ws.Cols == nil {
+		return
+	}
+	for c := range ws.Cols.Col {
+		colData := &ws.Cols.Col[c]
+		if colData.Min <= summary && summary <= colData.Max {
+			collapsed = colData.Collapsed
+		}
+	}
+	return
+}
+
+// SetColGroupCollapsed provides a function to collapse or expand the outline
+// group of columns which contains the given column by given worksheet name
+// and column name. The columns inside the group will be hidden when
+// collapsed, and the collapsed state will be marked on the summary column
+// next to the right of the group. The columns inside the nested collapsed
+// groups will be kept hidden when expanding. For example, collapse the group
+// which contains column D in Sheet1:
+//
+//    err := f.SetColGroupCollapsed("Sheet1", "D", true)
+//
+func (f *File) SetColGroupCollapsed(sheet, col string, collapsed bool) error {
+	colNum, err := ColumnNameToNumber(col)
+	if err != nil {
+		return err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	level := f.getColOutlineLevel(ws, colNum)
+	if level == 0 {
+		return ErrOutlineLevel
+	}
+	start, end, summary := f.getColGroup(ws, colNum, level)
+	ws.Cols.Col = flatCols(xlsxCol{
+		Min:       summary,
+		Max:       summary,
+		Width:     f.getDefaultColWidth(ws),
+		Collapsed: collapsed,
+	}, ws.Cols.Col, func(fc, c xlsxCol) xlsxCol {
+		fc.BestFit = c.BestFit
+		fc.CustomWidth = c.CustomWidth
+		fc.Hidden = c.Hidden
+		fc.OutlineLevel = c.OutlineLevel
+		fc.Phonetic = c.Phonetic
+		fc.Style = c.Style
+		fc.Width = c.Width
+		return fc
+	})
+	for c := range ws.Cols.Col {
+		colData := &ws.Cols.Col[c]
+		if colData.Min < start || colData.Max > end {
+			continue
+		}
+		colData.Hidden = collapsed
+		for l := level + 1; !collapsed && l <= colData.OutlineLevel; l++ {
+			if _, _, nested := f.getColGroup(ws, colData.Min, l); f.isColGroupCollapsed(ws, nested) {
+				colData.Hidden = true
+				break
+			}
+		}
+	}
+	return err
+}
+
+// GetColGroupCollapsed provides a function to get the collapsed state of the
+// outline group of columns which contains the given column by given
+// worksheet name and column name. This function returns false if the column
+// doesn't belong to any group. For example, get collapsed state of the group
+// which contains column D in Sheet1:
+//
+//    collapsed, err := f.GetColGroupCollapsed("Sheet1", "D")
+//
+func (f *File) GetColGroupCollapsed(sheet, col string) (bool, error) {
+	var collapsed bool
+	colNum, err := ColumnNameToNumber(col)
+	if err != nil {
+		return collapsed, err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return collapsed, err
+	}
+	level := f.getColOutlineLevel(ws, colNum)
+	if level == 0 {
+		return collapsed, err
+	}
+	_, _, summary := f.getColGroup(ws, colNum, level)
+	return f.isColGroupCollapsed(ws, summary), err
+}
+
 // SetColStyle provides a function to set style of columns by given worksheet
 // name, columns range and style ID. Note that this will overwrite the
 // existing styles for the columns, it won't append or merge style with
@@ -437,7 +833,7 @@ func (f *File) SetColStyle(sheet, columns string, styleID int) error {
 	ws.Cols.Col = flatCols(xlsxCol{
 		Min:   start,
 		Max:   end,
-		Width: defaultColWidth,
+		Width: f.getDefaultColWidth(ws),
 		Style: styleID,
 	}, ws.Cols.Col, func(fc, c xlsxCol) xlsxCol {
 		fc.BestFit = c.BestFit
@@ -459,6 +855,34 @@ func (f *File) SetColStyle(sheet, columns string, styleID int) error {
 	return err
 }
 
+// GetColStyle provides a function to get column style ID by given worksheet
+// name and column name. This function returns 0 if the column has no
+// explicit style. For example, get style ID of column D in Sheet1:
+//
+//    styleID, err := f.GetColStyle("Sheet1", "D")
+//
+func (f *File) GetColStyle(sheet, col string) (int, error) {
+	var styleID int
+	colNum, err := ColumnNameToNumber(col)
+	if err != nil {
+		return styleID, err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return styleID, err
+	}
+	if ws.Cols == nil {
+		return styleID, err
+	}
+	for c := range ws.Cols.Col {
+		colData := &ws.Cols.Col[c]
+		if colData.Min <= colNum && colNum <= colData.Max {
+			styleID = colData.Style
+		}
+	}
+	return styleID, err
+}
+
 // SetColWidth provides a function to set the width of a single column or
 // multiple columns. For example:
 //
@@ -509,6 +933,151 @@ func (f *File) SetColWidth(sheet, startcol, endcol string, width float64) error
 	return err
 }
 
+// SetColWidthAuto provides a function to set the width of the columns to fit
+// the widest cell value by given worksheet name and columns. The width of
+// each value is measured with the font size and weight of the cell, the
+// cells within the merged cells spanning multiple columns will be excluded
+// from the measurement, and the width of the columns without any value will
+// be left unchanged. The width won't exceed the MaxColumnWidth. For example,
+// set best fit width of the column A and columns C:F on Sheet1:
+//
+//    err := f.SetColWidthAuto("Sheet1", "A", "C:F")
+//
+func (f *File) SetColWidthAuto(sheet string, cols ...string) error {
+	var colNums []int
+	for _, col := range cols {
+		start, end, err := f.parseColRange(col)
+		if err != nil {
+			return err
+		}
+		for colNum := start; colNum <= end; colNum++ {
+			colNums = append(colNums, colNum)
+		}
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	var mergeRects [][]int
+	if ws.MergeCells != nil {
+		for _, mergeCell := range ws.MergeCells.Cells {
+			if mergeCell == nil {
+				continue
+			}
+			rect, err := mergeCell.Rect()
+			if err != nil {
+				return err
+			}
+			if rect[0] != rect[2] {
+				mergeRects = append(mergeRects, rect)
+			}
+		}
+	}
+	inMergeCell := func(col, row int) bool {
+		for _, rect := range mergeRects {
+			if rect[0] <= col && col <= rect[2] && rect[1] <= row && row <= rect[3] {
+				return true
+			}
+		}
+		return false
+	}
+	measureCols := make(map[int]bool, len(colNums))
+	for _, colNum := range colNums {
+		measureCols[colNum] = true
+	}
+	widths, sst := map[int]float64{}, f.sharedStringsReader()
+	for rowIdx := range ws.SheetData.Row {
+		for cellIdx := range ws.SheetData.Row[rowIdx].C {
+			c := &ws.SheetData.Row[rowIdx].C[cellIdx]
+			col, row, err := CellNameToCoordinates(c.R)
+			if err != nil {
+				return err
+			}
+			if !measureCols[col] || inMergeCell(col, row) {
+				continue
+			}
+			val, _ := c.getValueFrom(f, sst, false)
+			if val == "" {
+				continue
+			}
+			if w := f.measureCellTextWidth(c.S, val); w > widths[col] {
+				widths[col] = w
+			}
+		}
+	}
+	for _, colNum := range colNums {
+		w, ok := widths[colNum]
+		if !ok {
+			continue
+		}
+		colName, _ := ColumnNumberToName(colNum)
+		if err = f.SetColWidth(sheet, colName, colName, math.Min(w, MaxColumnWidth)); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// measureCellTextWidth provides a function to measure the width of the text
+// in the number of characters of the maximum digit width by given style ID
+// and text. The East Asian wide characters are treated as two characters,
+// and the width of the multiple lines text is measured by the longest line.
+func (f *File) measureCellTextWidth(styleID int, text string) float64 {
+	size, bold := f.getCellFontMetrics(styleID)
+	var chars float64
+	for _, line := range strings.Split(text, "\n") {
+		chars = math.Max(chars, measureTextChars(line))
+	}
+	scale := size / defaultFontSize
+	if bold {
+		scale *= 1.1
+	}
+	// Add 5 pixels padding with the maximum digit width of the default font.
+	digitWidth := defaultColWidthPixels / defaultColWidth
+	return math.Trunc((chars*scale*digitWidth+5)/digitWidth*256) / 256
+}
+
+// measureTextChars provides a function to measure the width of a single line
+// text in the number of characters, the East Asian wide characters are
+// treated as two characters.
+func measureTextChars(line string) float64 {
+	var chars float64
+	for _, r := range line {
+		switch width.LookupRune(r).Kind() {
+		case width.EastAsianWide, width.EastAsianFullwidth:
+			chars += 2
+		default:
+			chars++
+		}
+	}
+	return chars
+}
+
+// getCellFontMetrics provides a function to get the font size and weight of
+// the cell by given style ID.
+func (f *File) getCellFontMetrics(styleID int) (float64, bool) {
+	size, bold := defaultFontSize, false
+	s := f.stylesReader()
+	if s.CellXfs == nil || s.Fonts == nil || styleID < 0 || styleID >= len(s.CellXfs.Xf) {
+		return size, bold
+	}
+	var fontID int
+	if s.CellXfs.Xf[styleID].FontID != nil {
+		fontID = *s.CellXfs.Xf[styleID].FontID
+	}
+	if fontID < 0 || fontID >= len(s.Fonts.Font) || s.Fonts.Font[fontID] == nil {
+		return size, bold
+	}
+	font := s.Fonts.Font[fontID]
+	if font.Sz != nil && font.Sz.Val != nil && *font.Sz.Val > 0 {
+		size = *font.Sz.Val
+	}
+	if font.B != nil {
+		bold = font.B.Val == nil || *font.B.Val
+	}
+	return size, bold
+}
+
 // flatCols provides a method for the column's operation functions to flatten
 // and check the worksheet columns.
 func flatCols(col xlsxCol, cols []xlsxCol, replacer func(fc, c xlsxCol) xlsxCol) []xlsxCol {
@@ -642,10 +1211,23 @@ func (f *File) getColWidth(sheet string, col int) int {
 			return int(convertColWidthToPixels(width))
 		}
 	}
+	if ws.SheetFormatPr != nil && ws.SheetFormatPr.DefaultColWidth != 0 {
+		return int(convertColWidthToPixels(ws.SheetFormatPr.DefaultColWidth))
+	}
 	// Optimisation for when the column widths haven't changed.
 	return int(defaultColWidthPixels)
 }
 
+// getDefaultColWidth provides a function to get the default column width of
+// the worksheet, the column width will be used if the column doesn't have an
+// explicit width.
+func (f *File) getDefaultColWidth(ws *xlsxWorksheet) float64 {
+	if ws.SheetFormatPr != nil && ws.SheetFormatPr.DefaultColWidth != 0 {
+		return ws.SheetFormatPr.DefaultColWidth
+	}
+	return defaultColWidth
+}
+
 // GetColWidth provides a function to get column width by given worksheet name
 // and column name.
 func (f *File) GetColWidth(sheet, col string) (float64, error) {
@@ -657,6 +1239,13 @@ func (f *File) GetColWidth(sheet, col string) (float64, error) {
 	if err != nil {
 		return defaultColWidth, err
 	}
+	return f.getColWidthByNum(ws, colNum), err
+}
+
+// getColWidthByNum provides a function to get the column width by given
+// worksheet and column number, the default column width of the worksheet will
+// be returned if the column doesn't have an explicit width.
+func (f *File) getColWidthByNum(ws *xlsxWorksheet, colNum int) float64 {
 	if ws.Cols != nil {
 		var width float64
 		for _, v := range ws.Cols.Col {
@@ -665,11 +1254,46 @@ func (f *File) GetColWidth(sheet, col string) (float64, error) {
 			}
 		}
 		if width != 0 {
-			return width, err
+			return width
 		}
 	}
-	// Optimisation for when the column widths haven't changed.
-	return defaultColWidth, err
+	return f.getDefaultColWidth(ws)
+}
+
+// SetSheetDefaultColWidth provides a function to set the default column width
+// of the worksheet by given worksheet name and width. The default column
+// width will be used for the columns which don't have an explicit width. For
+// example, set the default column width of Sheet1 to 15:
+//
+//    err := f.SetSheetDefaultColWidth("Sheet1", 15)
+//
+func (f *File) SetSheetDefaultColWidth(sheet string, width float64) error {
+	if width <= 0 || width > MaxColumnWidth {
+		return ErrColumnWidth
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	if ws.SheetFormatPr == nil {
+		ws.SheetFormatPr = &xlsxSheetFormatPr{DefaultRowHeight: defaultRowHeight}
+	}
+	ws.SheetFormatPr.DefaultColWidth = width
+	return err
+}
+
+// GetSheetDefaultColWidth provides a function to get the default column width
+// of the worksheet by given worksheet name. For example, get the default
+// column width of Sheet1:
+//
+//    width, err := f.GetSheetDefaultColWidth("Sheet1")
+//
+func (f *File) GetSheetDefaultColWidth(sheet string) (float64, error) {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return defaultColWidth, err
+	}
+	return f.getDefaultColWidth(ws), err
 }
 
 // InsertCol provides a function to insert a new column before given column
@@ -678,11 +1302,25 @@ func (f *File) GetColWidth(sheet, col string) (float64, error) {
 //    err := f.InsertCol("Sheet1", "C")
 //
 func (f *File) InsertCol(sheet, col string) error {
+	return f.InsertCols(sheet, col, 1)
+}
+
+// InsertCols provides a function to insert new columns before given column
+// index and number of columns. The columns will be inserted in a single pass,
+// and produce the same result as calling the InsertCol function repeatedly.
+// For example, create two new columns before column C in Sheet1:
+//
+//    err := f.InsertCols("Sheet1", "C", 2)
+//
+func (f *File) InsertCols(sheet, col string, n int) error {
 	num, err := ColumnNameToNumber(col)
 	if err != nil {
 		return err
 	}
-	return f.adjustHelper(sheet, columns, num, 1)
+	if n < 1 || n > TotalColumns {
+		return ErrColumnNumber
+	}
+	return f.adjustHelper(sheet, columns, num, n)
 }
 
 // RemoveCol provides a function to remove single column by given worksheet
@@ -695,26 +1333,48 @@ func (f *File) InsertCol(sheet, col string) error {
 // worksheet, it will cause a file error when you open it. The excelize only
 // partially updates these references currently.
 func (f *File) RemoveCol(sheet, col string) error {
-	num, err := ColumnNameToNumber(col)
+	return f.RemoveCols(sheet, col, col)
+}
+
+// RemoveCols provides a function to remove columns by given worksheet name
+// and columns range. The columns will be removed in a single pass, and
+// produce the same result as calling the RemoveCol function repeatedly. For
+// example, remove columns C:E in Sheet1:
+//
+//    err := f.RemoveCols("Sheet1", "C", "E")
+//
+// Use this method with caution, which will affect changes in references such
+// as formulas, charts, and so on. If there is any referenced value of the
+// worksheet, it will cause a file error when you open it. The excelize only
+// partially updates these references currently.
+func (f *File) RemoveCols(sheet, startCol, endCol string) error {
+	start, err := ColumnNameToNumber(startCol)
 	if err != nil {
 		return err
 	}
-
+	end, err := ColumnNameToNumber(endCol)
+	if err != nil {
+		return err
+	}
+	if start > end {
+		start, end = end, start
+	}
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return err
 	}
 	for rowIdx := range ws.SheetData.Row {
 		rowData := &ws.SheetData.Row[rowIdx]
-		for colIdx := range rowData.C {
-			colName, _, _ := SplitCellName(rowData.C[colIdx].R)
-			if colName == col {
-				rowData.C = append(rowData.C[:colIdx], rowData.C[colIdx+1:]...)[:len(rowData.C)-1]
-				break
+		cells := rowData.C[:0]
+		for _, c := range rowData.C {
+			if colNum, _, err := CellNameToCoordinates(c.R); err == nil && start <= colNum && colNum <= end {
+				continue
 			}
+			cells = append(cells, c)
 		}
+		rowData.C = cells
 	}
-	return f.adjustHelper(sheet, columns, num, -1)
+	return f.adjustHelper(sheet, columns, start, start-end-1)
 }
 
 // convertColWidthToPixels provieds function to convert the width of a cell
@@ -1,7 +1,9 @@
 package excelize
 
 import (
+	"encoding/xml"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -133,6 +135,86 @@ func TestGetColsError(t *testing.T) {
 	assert.EqualError(t, err, `cannot convert cell "A" to coordinates: invalid cell name "A"`)
 }
 
+func TestStreamColumns(t *testing.T) {
+	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	for _, sheet := range f.GetSheetList() {
+		cols, err := f.StreamColumns(sheet)
+		assert.NoError(t, err)
+		var collectedCols [][]string
+		for cols.Next() {
+			rows, err := cols.Rows()
+			assert.NoError(t, err)
+			collectedCols = append(collectedCols, rows)
+		}
+		assert.NoError(t, cols.Error())
+		assert.Equal(t, len(collectedCols), cols.TotalCols())
+		// Test read shared strings lazily without loading the shared strings part.
+		assert.Nil(t, f.SharedStrings)
+		expected, err := f.GetCols(sheet)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, collectedCols)
+		f.SharedStrings = nil
+	}
+	assert.NoError(t, f.Close())
+
+	f = NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "B2", &[]interface{}{"a", 1, true}))
+	assert.NoError(t, f.SetCellValue("Sheet1", "D5", "b"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B4", 2.5))
+	cols, err := f.StreamColumns("Sheet1")
+	assert.NoError(t, err)
+	// Test get rows before move to the first column.
+	rows, err := cols.Rows()
+	assert.NoError(t, err)
+	assert.Nil(t, rows)
+	expected, err := f.GetCols("Sheet1")
+	assert.NoError(t, err)
+	for cols.Next() {
+		rows, err := cols.Rows(Options{RawCellValue: true})
+		assert.NoError(t, err)
+		assert.Equal(t, expected[cols.CurrentCol()-1], rows)
+	}
+	// Test get rows after the last column.
+	rows, err = cols.Rows()
+	assert.NoError(t, err)
+	assert.Nil(t, rows)
+
+	// Test stream columns on not exists worksheet.
+	_, err = f.StreamColumns("SheetN")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+
+	f = NewFile()
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(`<worksheet><sheetData><row r="2"><c r="A" t="str"><v>B</v></c></row></sheetData></worksheet>`))
+	f.checked = nil
+	_, err = f.StreamColumns("Sheet1")
+	assert.EqualError(t, err, `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+	// Test get rows with invalid cell and shared strings.
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(`<worksheet><sheetData><row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>2</v></c><c r="C1" s="x"></c></row></sheetData></worksheet>`))
+	f.Pkg.Store("xl/sharedStrings.xml", []byte(`<sst><si><t>A</t></si><si><t>B</t></si></sst>`))
+	f.SharedStrings = nil
+	cols, err = f.StreamColumns("Sheet1")
+	assert.NoError(t, err)
+	for _, expected := range []string{"A", "2"} {
+		assert.True(t, cols.Next())
+		rows, err := cols.Rows()
+		assert.NoError(t, err)
+		assert.Equal(t, []string{expected}, rows)
+	}
+	assert.True(t, cols.Next())
+	_, err = cols.Rows()
+	assert.EqualError(t, err, `strconv.ParseInt: parsing "x": invalid syntax`)
+	assert.EqualError(t, cols.Error(), `strconv.ParseInt: parsing "x": invalid syntax`)
+	assert.False(t, cols.Next())
+	cols.sstPos, cols.sstCache = []streamCellPos{{start: 0, end: 5}}, map[int]string{}
+	cols.sstXML = []byte(`<si><t>`)
+	_, err = cols.sharedString(0)
+	assert.EqualError(t, err, "XML syntax error on line 1: unexpected EOF")
+}
+
 func TestColsRows(t *testing.T) {
 	f := NewFile()
 	f.NewSheet("Sheet1")
@@ -167,6 +249,49 @@ func TestColsRows(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestColsCurrentColStyles(t *testing.T) {
+	f := NewFile()
+	dateStyle, err := f.NewStyle(`{"number_format": 14}`)
+	assert.NoError(t, err)
+	numStyle, err := f.NewStyle(`{"number_format": 2}`)
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetColStyle("Sheet1", "B", numStyle))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", 1))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A3", 44197))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A3", "A3", dateStyle))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B2", 1.5))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B4", 2.5))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B4", "B4", dateStyle))
+	assert.NoError(t, f.SetCellValue("Sheet1", "C4", "C4"))
+	cols, err := f.Cols("Sheet1")
+	assert.NoError(t, err)
+	// Test get styles before iterate the columns.
+	styles, err := cols.CurrentColStyles()
+	assert.NoError(t, err)
+	assert.Empty(t, styles)
+	expected := [][]int{{0, 0, dateStyle, 0}, {numStyle, numStyle, numStyle, dateStyle}, {0, 0, 0, 0}}
+	for cols.Next() {
+		rows, err := cols.Rows()
+		assert.NoError(t, err)
+		styles, err := cols.CurrentColStyles()
+		assert.NoError(t, err)
+		assert.Equal(t, expected[cols.CurrentCol()-1], styles)
+		assert.Len(t, styles, len(rows))
+		for rowIdx, style := range styles {
+			cell, err := CoordinatesToCellName(cols.CurrentCol(), rowIdx+1)
+			assert.NoError(t, err)
+			cellStyle, err := f.GetCellStyle("Sheet1", cell)
+			assert.NoError(t, err)
+			assert.Equal(t, cellStyle, style, cell)
+		}
+	}
+	// Test get styles with illegal cell coordinates.
+	cols.curCol = 1
+	cols.sheetXML = []byte(`<worksheet><sheetData><row r="1"><c r="A"></c></row></sheetData></worksheet>`)
+	_, err = cols.CurrentColStyles()
+	assert.EqualError(t, err, `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+}
+
 func TestColumnVisibility(t *testing.T) {
 	t.Run("TestBook1", func(t *testing.T) {
 		f, err := prepareTestBook1()
@@ -285,6 +410,75 @@ func TestOutlineLevel(t *testing.T) {
 	assert.NoError(t, f.Close())
 }
 
+func TestColGroupCollapsed(t *testing.T) {
+	f := NewFile()
+	for _, col := range []string{"C", "D", "E"} {
+		assert.NoError(t, f.SetColOutlineLevel("Sheet1", col, 1))
+	}
+	assert.NoError(t, f.SetColOutlineLevel("Sheet1", "D", 2))
+	collapsed, err := f.GetColGroupCollapsed("Sheet1", "D")
+	assert.NoError(t, err)
+	assert.False(t, collapsed)
+
+	assert.NoError(t, f.SetColGroupCollapsed("Sheet1", "D", true))
+	for col, expected := range map[string]bool{"C": true, "D": false, "E": true, "F": true} {
+		visible, err := f.GetColVisible("Sheet1", col)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, visible, col)
+	}
+	assert.NoError(t, f.SetColGroupCollapsed("Sheet1", "C", true))
+	for col, expected := range map[string]bool{"B": true, "C": false, "D": false, "E": false, "F": true} {
+		visible, err := f.GetColVisible("Sheet1", col)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, visible, col)
+	}
+	level, err := f.GetColOutlineLevel("Sheet1", "D")
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(2), level)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestColGroupCollapsed.xlsx")))
+
+	f, err = OpenFile(filepath.Join("test", "TestColGroupCollapsed.xlsx"))
+	assert.NoError(t, err)
+	for _, col := range []string{"C", "D", "E"} {
+		collapsed, err = f.GetColGroupCollapsed("Sheet1", col)
+		assert.NoError(t, err)
+		assert.True(t, collapsed, col)
+	}
+	// Test expand the group and keep the nested collapsed group hidden.
+	assert.NoError(t, f.SetColGroupCollapsed("Sheet1", "E", false))
+	for col, expected := range map[string]bool{"C": false, "D": true, "E": false} {
+		collapsed, err = f.GetColGroupCollapsed("Sheet1", col)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, collapsed, col)
+		visible, err := f.GetColVisible("Sheet1", col)
+		assert.NoError(t, err)
+		assert.Equal(t, !expected, visible, col)
+	}
+	assert.NoError(t, f.SetColGroupCollapsed("Sheet1", "D", false))
+	visible, err := f.GetColVisible("Sheet1", "D")
+	assert.NoError(t, err)
+	assert.True(t, visible)
+	// Test get collapsed state of the column which doesn't belong to any group.
+	collapsed, err = f.GetColGroupCollapsed("Sheet1", "A")
+	assert.NoError(t, err)
+	assert.False(t, collapsed)
+	// Test set collapsed state of the column which doesn't belong to any group.
+	assert.EqualError(t, f.SetColGroupCollapsed("Sheet1", "A", true), ErrOutlineLevel.Error())
+	// Test set and get collapsed state with illegal column name.
+	assert.EqualError(t, f.SetColGroupCollapsed("Sheet1", "*", true), `invalid column name "*"`)
+	_, err = f.GetColGroupCollapsed("Sheet1", "*")
+	assert.EqualError(t, err, `invalid column name "*"`)
+	// Test set and get collapsed state on not exists worksheet.
+	assert.EqualError(t, f.SetColGroupCollapsed("SheetN", "C", true), "sheet SheetN is not exist")
+	_, err = f.GetColGroupCollapsed("SheetN", "C")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+	assert.NoError(t, f.Close())
+	// Test get collapsed state without columns definition.
+	collapsed, err = NewFile().GetColGroupCollapsed("Sheet1", "C")
+	assert.NoError(t, err)
+	assert.False(t, collapsed)
+}
+
 func TestSetColStyle(t *testing.T) {
 	f := NewFile()
 	assert.NoError(t, f.SetCellValue("Sheet1", "B2", "Hello"))
@@ -303,6 +497,29 @@ func TestSetColStyle(t *testing.T) {
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetColStyle.xlsx")))
 }
 
+func TestGetColStyle(t *testing.T) {
+	f := NewFile()
+	styleID, err := f.GetColStyle("Sheet1", "A")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, styleID)
+
+	style, err := f.NewStyle(`{"fill":{"type":"pattern","color":["#94d3a2"],"pattern":1}}`)
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetColStyle("Sheet1", "B:D", style))
+	assert.NoError(t, f.SetColWidth("Sheet1", "F", "F", 12))
+	for col, expected := range map[string]int{"A": 0, "B": style, "C": style, "D": style, "E": 0, "F": 0} {
+		styleID, err = f.GetColStyle("Sheet1", col)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, styleID, col)
+	}
+	// Test get column style on not exists worksheet.
+	_, err = f.GetColStyle("SheetN", "A")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+	// Test get column style with illegal column name.
+	_, err = f.GetColStyle("Sheet1", "*")
+	assert.EqualError(t, err, `invalid column name "*"`)
+}
+
 func TestColWidth(t *testing.T) {
 	f := NewFile()
 	assert.NoError(t, f.SetColWidth("Sheet1", "B", "A", 12))
@@ -332,6 +549,91 @@ func TestColWidth(t *testing.T) {
 	convertRowHeightToPixels(0)
 }
 
+func TestSheetDefaultColWidth(t *testing.T) {
+	f := NewFile()
+	width, err := f.GetSheetDefaultColWidth("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, defaultColWidth, width)
+
+	assert.NoError(t, f.SetSheetDefaultColWidth("Sheet1", 15))
+	assert.NoError(t, f.SetColWidth("Sheet1", "B", "B", 12))
+	assert.NoError(t, f.SetColVisible("Sheet1", "D", false))
+	for col, expected := range map[string]float64{"A": 15, "B": 12, "C": 15, "D": 15} {
+		width, err = f.GetColWidth("Sheet1", col)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, width, col)
+	}
+	assert.Equal(t, 111, f.getColWidth("Sheet1", 1))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSheetDefaultColWidth.xlsx")))
+
+	f, err = OpenFile(filepath.Join("test", "TestSheetDefaultColWidth.xlsx"))
+	assert.NoError(t, err)
+	width, err = f.GetSheetDefaultColWidth("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, 15.0, width)
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, defaultRowHeight, ws.SheetFormatPr.DefaultRowHeight)
+
+	// Test set default column width with invalid width.
+	assert.EqualError(t, f.SetSheetDefaultColWidth("Sheet1", 0), ErrColumnWidth.Error())
+	assert.EqualError(t, f.SetSheetDefaultColWidth("Sheet1", MaxColumnWidth+1), ErrColumnWidth.Error())
+	// Test set and get default column width on not exists worksheet.
+	assert.EqualError(t, f.SetSheetDefaultColWidth("SheetN", 15), "sheet SheetN is not exist")
+	_, err = f.GetSheetDefaultColWidth("SheetN")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+	assert.NoError(t, f.Close())
+}
+
+func TestSetColWidthAuto(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "Hello"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A2", "Hello, World"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B1", "Hello, World"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "C1", "你好"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "D1", "Hello\nWorld"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "E1", strings.Repeat("s", TotalCellChars)))
+	assert.NoError(t, f.SetCellValue("Sheet1", "G1", "Merged cell value"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "G2", "G2"))
+	assert.NoError(t, f.MergeCell("Sheet1", "G1", "H1"))
+	style, err := f.NewStyle(`{"font":{"bold":true,"size":22}}`)
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B1", "B1", style))
+	assert.NoError(t, f.SetColWidth("Sheet1", "F", "F", 20))
+	assert.NoError(t, f.SetColWidthAuto("Sheet1", "A", "B:H"))
+	for col, expected := range map[string]float64{
+		"A": 12.7109375, "B": 27.11328125, "C": 4.7109375, "D": 5.7109375,
+		"E": MaxColumnWidth, "F": 20, "G": 2.7109375, "H": defaultColWidth,
+	} {
+		width, err := f.GetColWidth("Sheet1", col)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, width, col)
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetColWidthAuto.xlsx")))
+	// Test set best fit width on not exists worksheet.
+	assert.EqualError(t, f.SetColWidthAuto("SheetN", "A"), "sheet SheetN is not exist")
+	// Test set best fit width with illegal column name.
+	assert.EqualError(t, f.SetColWidthAuto("Sheet1", "*"), `invalid column name "*"`)
+	// Test set best fit width with illegal cell reference.
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.SheetData.Row[0].C[0].R = "A"
+	assert.EqualError(t, f.SetColWidthAuto("Sheet1", "A"), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+	ws.MergeCells.Cells[0].Ref, ws.MergeCells.Cells[0].rect = "G1:H", nil
+	assert.EqualError(t, f.SetColWidthAuto("Sheet1", "A"), `cannot convert cell "H" to coordinates: invalid cell name "H"`)
+}
+
+func TestGetCellFontMetrics(t *testing.T) {
+	f := NewFile()
+	size, bold := f.getCellFontMetrics(-1)
+	assert.Equal(t, defaultFontSize, size)
+	assert.False(t, bold)
+	f.Styles.CellXfs.Xf = append(f.Styles.CellXfs.Xf, xlsxXf{FontID: intPtr(len(f.Styles.Fonts.Font))})
+	size, bold = f.getCellFontMetrics(len(f.Styles.CellXfs.Xf) - 1)
+	assert.Equal(t, defaultFontSize, size)
+	assert.False(t, bold)
+}
+
 func TestInsertCol(t *testing.T) {
 	f := NewFile()
 	sheet1 := f.GetSheetName(0)
@@ -374,6 +676,103 @@ func TestRemoveCol(t *testing.T) {
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestRemoveCol.xlsx")))
 }
 
+func TestInsertCols(t *testing.T) {
+	prepare := func() *File {
+		f := NewFile()
+		fillCells(f, "Sheet1", 10, 10)
+		assert.NoError(t, f.SetCellHyperLink("Sheet1", "C5", "https://github.com/xuri/excelize", "External"))
+		assert.NoError(t, f.MergeCell("Sheet1", "A1", "C3"))
+		dvRange := NewDataValidation(true)
+		dvRange.Sqref = "B2:D4"
+		assert.NoError(t, dvRange.SetRange(10, 20, DataValidationTypeWhole, DataValidationOperatorBetween))
+		assert.NoError(t, f.AddDataValidation("Sheet1", dvRange))
+		return f
+	}
+	expected, actual := prepare(), prepare()
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, expected.InsertCol("Sheet1", "B"))
+	}
+	assert.NoError(t, actual.InsertCols("Sheet1", "B", 3))
+	assertSameSheet(t, expected, actual, "Sheet1")
+	ws, err := actual.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "E2:G4", ws.DataValidations.DataValidation[0].Sqref)
+
+	// Test insert columns with illegal parameters.
+	assert.EqualError(t, actual.InsertCols("Sheet1", "*", 1), `invalid column name "*"`)
+	assert.EqualError(t, actual.InsertCols("Sheet1", "A", 0), ErrColumnNumber.Error())
+	assert.EqualError(t, actual.InsertCols("Sheet1", "A", TotalColumns+1), ErrColumnNumber.Error())
+	// Test insert columns on not exists worksheet.
+	assert.EqualError(t, actual.InsertCols("SheetN", "A", 1), "sheet SheetN is not exist")
+
+	assert.NoError(t, actual.SaveAs(filepath.Join("test", "TestInsertCols.xlsx")))
+}
+
+func TestRemoveCols(t *testing.T) {
+	prepare := func() *File {
+		f := NewFile()
+		fillCells(f, "Sheet1", 10, 15)
+		assert.NoError(t, f.SetCellHyperLink("Sheet1", "A5", "https://github.com/xuri/excelize", "External"))
+		assert.NoError(t, f.SetCellHyperLink("Sheet1", "C5", "https://github.com", "External"))
+		assert.NoError(t, f.SetCellHyperLink("Sheet1", "F5", "https://github.com", "External"))
+		assert.NoError(t, f.MergeCell("Sheet1", "A1", "B1"))
+		assert.NoError(t, f.MergeCell("Sheet1", "B2", "E2"))
+		assert.NoError(t, f.MergeCell("Sheet1", "C3", "G3"))
+		assert.NoError(t, f.AutoFilter("Sheet1", "A4", "H4", ""))
+		for _, sqref := range []string{"B6:D7", "C8", "A9:F9 H10"} {
+			dvRange := NewDataValidation(true)
+			dvRange.Sqref = sqref
+			assert.NoError(t, dvRange.SetRange(10, 20, DataValidationTypeWhole, DataValidationOperatorBetween))
+			assert.NoError(t, f.AddDataValidation("Sheet1", dvRange))
+		}
+		return f
+	}
+	expected, actual := prepare(), prepare()
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, expected.RemoveCol("Sheet1", "B"))
+	}
+	assert.NoError(t, actual.RemoveCols("Sheet1", "D", "B"))
+	assertSameSheet(t, expected, actual, "Sheet1")
+	ws, err := actual.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, ws.DataValidations.DataValidation, 1)
+	assert.Equal(t, "A9:C9 E10", ws.DataValidations.DataValidation[0].Sqref)
+
+	// Test remove columns with illegal cell coordinates.
+	assert.EqualError(t, actual.RemoveCols("Sheet1", "*", "B"), `invalid column name "*"`)
+	assert.EqualError(t, actual.RemoveCols("Sheet1", "A", "*"), `invalid column name "*"`)
+	// Test remove columns on not exists worksheet.
+	assert.EqualError(t, actual.RemoveCols("SheetN", "A", "B"), "sheet SheetN is not exist")
+
+	assert.NoError(t, actual.SaveAs(filepath.Join("test", "TestRemoveCols.xlsx")))
+}
+
+func TestRemoveColsMergeCells(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.MergeCell("Sheet1", "A1", "B1"))
+	assert.NoError(t, f.MergeCell("Sheet1", "D1", "E1"))
+	assert.NoError(t, f.RemoveCols("Sheet1", "D", "E"))
+	mergeCells, err := f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	if assert.Len(t, mergeCells, 1) {
+		assert.Equal(t, "A1", mergeCells[0].GetStartAxis())
+		assert.Equal(t, "B1", mergeCells[0].GetEndAxis())
+	}
+}
+
+// assertSameSheet asserts two workbooks have the same worksheet content.
+func assertSameSheet(t *testing.T, expected, actual *File, sheet string) {
+	expectedWs, err := expected.workSheetReader(sheet)
+	assert.NoError(t, err)
+	actualWs, err := actual.workSheetReader(sheet)
+	assert.NoError(t, err)
+	expectedXML, err := xml.Marshal(expectedWs)
+	assert.NoError(t, err)
+	actualXML, err := xml.Marshal(actualWs)
+	assert.NoError(t, err)
+	assert.Equal(t, string(expectedXML), string(actualXML))
+}
+
 func TestConvertColWidthToPixels(t *testing.T) {
 	assert.Equal(t, -11.0, convertColWidthToPixels(-1))
 }
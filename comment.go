@@ -21,6 +21,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // parseFormatCommentsSet provides a function to parse the format settings of
@@ -59,9 +60,15 @@ func (f *File) GetComments() (comments map[string][]Comment) {
 					sheetComment.Text += *comment.Text.T
 				}
 				for _, text := range comment.Text.R {
-					if text.T != nil {
-						sheetComment.Text += text.T.Val
+					if text.T == nil {
+						continue
 					}
+					sheetComment.Text += text.T.Val
+					run := RichTextRun{Text: text.T.Val}
+					if text.RPr != nil {
+						run.Font = newFont(text.RPr)
+					}
+					sheetComment.Runs = append(sheetComment.Runs, run)
 				}
 				sheetComments = append(sheetComments, sheetComment)
 			}
@@ -94,11 +101,37 @@ func (f *File) getSheetComments(sheetFile string) string {
 //
 //    err := f.AddComment("Sheet1", "A30", `{"author":"Excelize: ","text":"This is a comment."}`)
 //
+// The comment box is hidden until the mouse hovers on the cell by default, set
+// the "visible" field to make the comment box always shown, and set the
+// "width" and "height" fields to specify the size of the comment box in
+// points. For example, add an always shown comment with 200 points width and
+// 100 points height in Sheet1!$A$30:
+//
+//    err := f.AddComment("Sheet1", "A30", `{"author":"Excelize: ","text":"This is a comment.","visible":true,"width":200,"height":100}`)
+//
+// The comment text could be set with rich text runs by the "runs" field
+// instead of the "text" field, the font settings of each run are the same as
+// the RichTextRun used in SetCellRichText. For example, add a comment with a
+// bold label followed by a plain description in Sheet1!$A$30:
+//
+//    err := f.AddComment("Sheet1", "A30", `{"author":"Excelize: ","runs":[
+//        {"text":"Label: ","font":{"bold":true}},
+//        {"text":"description of the cell."}
+//    ]}`)
+//
+// GetComments returns the author run followed by these runs in the Runs
+// field of the comment.
 func (f *File) AddComment(sheet, cell, format string) error {
 	formatSet, err := parseFormatCommentsSet(format)
 	if err != nil {
 		return err
 	}
+	return f.addSheetComment(sheet, cell, formatSet)
+}
+
+// addSheetComment provides a function to add comment in a sheet by given
+// worksheet name, cell and format set.
+func (f *File) addSheetComment(sheet, cell string, formatSet *formatComment) error {
 	// Read sheet data.
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
@@ -122,6 +155,12 @@ func (f *File) AddComment(sheet, cell, format string) error {
 		f.addSheetLegacyDrawing(sheet, rID)
 	}
 	commentsXML := "xl/comments" + strconv.Itoa(commentID) + ".xml"
+	if len(formatSet.Runs) > 0 {
+		formatSet.Text = ""
+		for _, run := range formatSet.Runs {
+			formatSet.Text += run.Text
+		}
+	}
 	var colCount int
 	for i, l := range strings.Split(formatSet.Text, "\n") {
 		if ll := len(l); ll > colCount {
@@ -131,24 +170,46 @@ func (f *File) AddComment(sheet, cell, format string) error {
 			colCount = ll
 		}
 	}
-	err = f.addDrawingVML(commentID, drawingVML, cell, strings.Count(formatSet.Text, "\n")+1, colCount)
+	err = f.addDrawingVML(commentID, drawingVML, cell, strings.Count(formatSet.Text, "\n")+1, colCount, formatSet)
 	if err != nil {
 		return err
 	}
 	f.addComment(commentsXML, cell, formatSet)
 	f.addContentTypePart(commentID, "comments")
-	return err
+	return nil
 }
 
 // addDrawingVML provides a function to create comment as
-// xl/drawings/vmlDrawing%d.vml by given commit ID and cell.
-func (f *File) addDrawingVML(commentID int, drawingVML, cell string, lineCount, colCount int) error {
+// xl/drawings/vmlDrawing%d.vml by given commit ID, cell and format set. The
+// size of the comment box will be calculated by the given line and column
+// count of the comment text if the width or height is not specified.
+func (f *File) addDrawingVML(commentID int, drawingVML, cell string, lineCount, colCount int, formatSet *formatComment) error {
 	col, row, err := CellNameToCoordinates(cell)
 	if err != nil {
 		return err
 	}
 	yAxis := col - 1
 	xAxis := row - 1
+	rightCol, rightOffset, bottomRow, bottomOffset := 2+yAxis+lineCount, colCount+yAxis, 2+xAxis+lineCount, 5
+	width, height, visibility := 108.0, 59.25, "hidden"
+	if formatSet.Width > 0 {
+		// Convert the width in points to pixels, based on the default
+		// column width 64 pixels and the left offset 23 pixels.
+		width = formatSet.Width
+		px := 23 + int(width*4/3)
+		rightCol, rightOffset = 1+yAxis+px/64, px%64
+	}
+	if formatSet.Height > 0 {
+		// Convert the height in points to pixels, based on the default
+		// row height 20 pixels.
+		height = formatSet.Height
+		px := int(height * 4 / 3)
+		bottomRow, bottomOffset = 1+xAxis+px/20, px%20
+	}
+	var visible *string
+	if formatSet.Visible {
+		visible, visibility = stringPtr(""), "visible"
+	}
 	vml := f.VMLDrawing[drawingVML]
 	if vml == nil {
 		vml = &vmlDrawing{
@@ -205,18 +266,19 @@ func (f *File) addDrawingVML(commentID int, drawingVML, cell string, lineCount,
 		ClientData: &xClientData{
 			ObjectType: "Note",
 			Anchor: fmt.Sprintf(
-				"%d, 23, %d, 0, %d, %d, %d, 5",
-				1+yAxis, 1+xAxis, 2+yAxis+lineCount, colCount+yAxis, 2+xAxis+lineCount),
+				"%d, 23, %d, 0, %d, %d, %d, %d",
+				1+yAxis, 1+xAxis, rightCol, rightOffset, bottomRow, bottomOffset),
 			AutoFill: "True",
 			Row:      xAxis,
 			Column:   yAxis,
+			Visible:  visible,
 		},
 	}
 	s, _ := xml.Marshal(sp)
 	shape := xlsxShape{
 		ID:          "_x0000_s1025",
 		Type:        "#_x0000_t202",
-		Style:       "position:absolute;73.5pt;width:108pt;height:59.25pt;z-index:1;visibility:hidden",
+		Style:       fmt.Sprintf("position:absolute;73.5pt;width:%gpt;height:%gpt;z-index:1;visibility:%s", width, height, visibility),
 		Fillcolor:   "#fbf6d6",
 		Strokecolor: "#edeaa1",
 		Val:         string(s[13 : len(s)-14]),
@@ -224,10 +286,14 @@ func (f *File) addDrawingVML(commentID int, drawingVML, cell string, lineCount,
 	d := f.decodeVMLDrawingReader(drawingVML)
 	if d != nil {
 		for _, v := range d.Shape {
+			style := v.Style
+			if style == "" {
+				style = "position:absolute;73.5pt;width:108pt;height:59.25pt;z-index:1;visibility:hidden"
+			}
 			s := xlsxShape{
 				ID:          "_x0000_s1025",
 				Type:        "#_x0000_t202",
-				Style:       "position:absolute;73.5pt;width:108pt;height:59.25pt;z-index:1;visibility:hidden",
+				Style:       style,
 				Fillcolor:   "#fbf6d6",
 				Strokecolor: "#edeaa1",
 				Val:         v.Val,
@@ -240,9 +306,63 @@ func (f *File) addDrawingVML(commentID int, drawingVML, cell string, lineCount,
 	return err
 }
 
+// UpdateComment provides the method to update the author and text of the
+// existing comment in a sheet by given worksheet name, cell and format set,
+// the format set is the same as AddComment. The anchor and the size of the
+// comment box will be kept. For example, update the comment in Sheet1!$A$30:
+//
+//    err := f.UpdateComment("Sheet1", "A30", `{"author":"Excelize: ","text":"This is an updated comment."}`)
+//
+// An error will be returned if there is no comment at the cell.
+func (f *File) UpdateComment(sheet, cell, format string) error {
+	formatSet, err := parseFormatCommentsSet(format)
+	if err != nil {
+		return err
+	}
+	if _, _, err = CellNameToCoordinates(cell); err != nil {
+		return err
+	}
+	sheetXML, ok := f.sheetMap[trimSheetName(sheet)]
+	if !ok {
+		return ErrSheetNotExist{sheet}
+	}
+	target := f.getSheetComments(filepath.Base(sheetXML))
+	if target == "" {
+		return newNoCommentError(cell)
+	}
+	if !strings.HasPrefix(target, "/") {
+		target = "xl" + strings.TrimPrefix(target, "..")
+	}
+	comments := f.commentsReader(strings.TrimPrefix(target, "/"))
+	if comments == nil {
+		return newNoCommentError(cell)
+	}
+	for idx := range comments.CommentList.Comment {
+		if cmt := &comments.CommentList.Comment[idx]; cmt.Ref == cell {
+			f.setCommentContent(comments, cmt, formatSet)
+			return err
+		}
+	}
+	return newNoCommentError(cell)
+}
+
 // addComment provides a function to create chart as xl/comments%d.xml by
 // given cell and format sets.
 func (f *File) addComment(commentsXML, cell string, formatSet *formatComment) {
+	comments := f.commentsReader(commentsXML)
+	if comments == nil {
+		comments = &xlsxComments{}
+	}
+	cmt := xlsxComment{Ref: cell}
+	f.setCommentContent(comments, &cmt, formatSet)
+	comments.CommentList.Comment = append(comments.CommentList.Comment, cmt)
+	f.Comments[commentsXML] = comments
+}
+
+// setCommentContent provides a function to set the author and the text runs
+// of the comment by given format sets, the author will be added into the
+// authors list of the comments if not exists.
+func (f *File) setCommentContent(comments *xlsxComments, cmt *xlsxComment, formatSet *formatComment) {
 	a := formatSet.Author
 	t := formatSet.Text
 	if len(a) > MaxFieldLength {
@@ -251,50 +371,291 @@ func (f *File) addComment(commentsXML, cell string, formatSet *formatComment) {
 	if len(t) > 32512 {
 		t = t[:32512]
 	}
-	comments := f.commentsReader(commentsXML)
-	authorID := 0
-	if comments == nil {
-		comments = &xlsxComments{Authors: xlsxAuthor{Author: []string{formatSet.Author}}}
-	}
-	if inStrSlice(comments.Authors.Author, formatSet.Author) == -1 {
-		comments.Authors.Author = append(comments.Authors.Author, formatSet.Author)
+	authorID := inStrSlice(comments.Authors.Author, a)
+	if authorID == -1 {
+		comments.Authors.Author = append(comments.Authors.Author, a)
 		authorID = len(comments.Authors.Author) - 1
 	}
 	defaultFont := f.GetDefaultFont()
+	newCommentRpr := func() *xlsxRPr {
+		return &xlsxRPr{
+			Sz: &attrValFloat{Val: float64Ptr(9)},
+			Color: &xlsxColor{
+				Indexed: 81,
+			},
+			RFont:  &attrValString{Val: stringPtr(defaultFont)},
+			Family: &attrValInt{Val: intPtr(2)},
+		}
+	}
 	bold := ""
-	cmt := xlsxComment{
+	authorRun := xlsxR{RPr: newCommentRpr(), T: &xlsxT{Val: a}}
+	authorRun.RPr.B = &bold
+	cmt.AuthorID = authorID
+	cmt.Text = xlsxText{R: []xlsxR{authorRun}}
+	if len(formatSet.Runs) == 0 {
+		cmt.Text.R = append(cmt.Text.R, xlsxR{RPr: newCommentRpr(), T: &xlsxT{Val: t}})
+	}
+	for _, run := range formatSet.Runs {
+		r := xlsxR{RPr: newCommentRpr(), T: &xlsxT{}}
+		_, r.T.Val, r.T.Space = setCellStr(run.Text)
+		if run.Font != nil {
+			r.RPr = newRpr(run.Font)
+		}
+		cmt.Text.R = append(cmt.Text.R, r)
+	}
+}
+
+// threadedCommentLegacyText defined the text of the legacy comment which
+// Excel requires for the compatibility of the threaded comment.
+const threadedCommentLegacyText = "[Threaded comment]\n\nYour version of Excel allows you to read this threaded comment; however, any edits to it will get removed if the file is opened in a newer version of Excel. Learn more: https://go.microsoft.com/fwlink/?linkid=870924\n\nComment:\n    "
+
+// threadedCommentTimeLayout defined the layout of the date time of the
+// threaded comment.
+const threadedCommentTimeLayout = "2006-01-02T15:04:05.00"
+
+// AddThreadedComment provides the method to add a threaded comment in a sheet
+// by given worksheet name, cell and comment settings. The author of the
+// comment will be added into the person list of the workbook if not exists,
+// and the current time will be used if the DateTime of the comment is not
+// specified. If the cell already has a threaded comment, the new comment will
+// be added as a reply of it. For example, add a threaded comment and a reply
+// in Sheet1!$A$30:
+//
+//    err := f.AddThreadedComment("Sheet1", "A30", excelize.ThreadedComment{
+//        Author: "Excelize",
+//        Text:   "This is a threaded comment.",
+//    })
+//    err = f.AddThreadedComment("Sheet1", "A30", excelize.ThreadedComment{
+//        Author: "Reviewer",
+//        Text:   "This is a reply.",
+//    })
+//
+// A legacy comment will also be added in the cell for the compatibility of
+// the versions of Excel which doesn't support threaded comments, so the
+// threaded comment can't be added in the cell which already has a comment.
+func (f *File) AddThreadedComment(sheet, cell string, comment ThreadedComment) error {
+	if _, _, err := CellNameToCoordinates(cell); err != nil {
+		return err
+	}
+	sheetXML, ok := f.sheetMap[trimSheetName(sheet)]
+	if !ok {
+		return ErrSheetNotExist{sheet}
+	}
+	if len(comment.Author) > MaxFieldLength {
+		comment.Author = comment.Author[:MaxFieldLength]
+	}
+	threadedXML := f.getSheetThreadedComments(filepath.Base(sheetXML))
+	threadedComments, err := f.threadedCommentsReader(threadedXML)
+	if err != nil {
+		return err
+	}
+	var rootID string
+	for _, tc := range threadedComments.ThreadedComment {
+		if tc.Ref == cell && tc.ParentID == "" {
+			rootID = tc.ID
+			break
+		}
+	}
+	legacy := f.getSheetComment(sheetXML, cell)
+	if rootID == "" && legacy != nil {
+		return newCommentExistsError(cell)
+	}
+	personID, err := f.addPerson(comment.Author)
+	if err != nil {
+		return err
+	}
+	if comment.DateTime.IsZero() {
+		comment.DateTime = time.Now()
+	}
+	tc := xlsxThreadedComment{
 		Ref:      cell,
-		AuthorID: authorID,
-		Text: xlsxText{
-			R: []xlsxR{
-				{
-					RPr: &xlsxRPr{
-						B:  &bold,
-						Sz: &attrValFloat{Val: float64Ptr(9)},
-						Color: &xlsxColor{
-							Indexed: 81,
-						},
-						RFont:  &attrValString{Val: stringPtr(defaultFont)},
-						Family: &attrValInt{Val: intPtr(2)},
-					},
-					T: &xlsxT{Val: a},
-				},
-				{
-					RPr: &xlsxRPr{
-						Sz: &attrValFloat{Val: float64Ptr(9)},
-						Color: &xlsxColor{
-							Indexed: 81,
-						},
-						RFont:  &attrValString{Val: stringPtr(defaultFont)},
-						Family: &attrValInt{Val: intPtr(2)},
-					},
-					T: &xlsxT{Val: t},
-				},
-			},
-		},
+		DT:       comment.DateTime.Format(threadedCommentTimeLayout),
+		PersonID: personID,
+		ID:       newGUID(),
+		ParentID: rootID,
+		Text:     comment.Text,
 	}
-	comments.CommentList.Comment = append(comments.CommentList.Comment, cmt)
-	f.Comments[commentsXML] = comments
+	if rootID == "" {
+		text := threadedCommentLegacyText + comment.Text
+		if err = f.addSheetComment(sheet, cell, &formatComment{Author: "tc=" + tc.ID, Text: text}); err != nil {
+			return err
+		}
+		if legacy = f.getSheetComment(sheetXML, cell); legacy != nil {
+			legacy.Text = xlsxText{T: stringPtr(text)}
+		}
+	} else if legacy != nil {
+		var text string
+		if legacy.Text.T != nil {
+			text = *legacy.Text.T
+		}
+		for _, r := range legacy.Text.R {
+			if r.T != nil {
+				text += r.T.Val
+			}
+		}
+		legacy.Text = xlsxText{T: stringPtr(text + "\nReply:\n    " + comment.Text)}
+	}
+	if threadedXML == "" {
+		idx := f.countThreadedComments() + 1
+		threadedXML = "xl/threadedComments/threadedComment" + strconv.Itoa(idx) + ".xml"
+		sheetRels := "xl/worksheets/_rels/" + strings.TrimPrefix(sheetXML, "xl/worksheets/") + ".rels"
+		f.addRels(sheetRels, SourceRelationshipThreadedComment, "../threadedComments/threadedComment"+strconv.Itoa(idx)+".xml", "")
+		f.addContentTypePart(idx, "threadedComments")
+	}
+	threadedComments.ThreadedComment = append(threadedComments.ThreadedComment, tc)
+	output, _ := xml.Marshal(threadedComments)
+	f.saveFileList(threadedXML, output)
+	return err
+}
+
+// GetThreadedComments provides the method to get all threaded comments and
+// the replies of the threaded comments in a sheet by given worksheet name.
+// The replies are returned after the threaded comment in the order of they
+// were added, and linked to the threaded comment by the ParentID.
+func (f *File) GetThreadedComments(sheet string) ([]ThreadedComment, error) {
+	var comments []ThreadedComment
+	sheetXML, ok := f.sheetMap[trimSheetName(sheet)]
+	if !ok {
+		return comments, ErrSheetNotExist{sheet}
+	}
+	threadedComments, err := f.threadedCommentsReader(f.getSheetThreadedComments(filepath.Base(sheetXML)))
+	if err != nil {
+		return comments, err
+	}
+	persons, err := f.personsReader()
+	if err != nil {
+		return comments, err
+	}
+	authors := map[string]string{}
+	for _, person := range persons.Person {
+		authors[person.ID] = person.DisplayName
+	}
+	for _, tc := range threadedComments.ThreadedComment {
+		comment := ThreadedComment{
+			ID:       tc.ID,
+			ParentID: tc.ParentID,
+			Ref:      tc.Ref,
+			Author:   authors[tc.PersonID],
+			Text:     tc.Text,
+		}
+		comment.DateTime, _ = time.Parse("2006-01-02T15:04:05", tc.DT)
+		comments = append(comments, comment)
+	}
+	return comments, err
+}
+
+// getSheetThreadedComments provides the method to get the path of the
+// threaded comments part by given worksheet file path, returns empty string
+// if the worksheet has no threaded comments.
+func (f *File) getSheetThreadedComments(sheetFile string) string {
+	var target string
+	if sheetRels := f.relsReader("xl/worksheets/_rels/" + sheetFile + ".rels"); sheetRels != nil {
+		sheetRels.Lock()
+		for _, v := range sheetRels.Relationships {
+			if v.Type == SourceRelationshipThreadedComment {
+				target = v.Target
+			}
+		}
+		sheetRels.Unlock()
+	}
+	if target == "" {
+		return target
+	}
+	if !strings.HasPrefix(target, "/") {
+		target = "xl" + strings.TrimPrefix(target, "..")
+	}
+	return strings.TrimPrefix(target, "/")
+}
+
+// getSheetComment provides a function to get the legacy comment by given
+// worksheet file path and cell, returns nil if the cell has no comment.
+func (f *File) getSheetComment(sheetXML, cell string) *xlsxComment {
+	target := f.getSheetComments(filepath.Base(sheetXML))
+	if target == "" {
+		return nil
+	}
+	if !strings.HasPrefix(target, "/") {
+		target = "xl" + strings.TrimPrefix(target, "..")
+	}
+	if comments := f.commentsReader(strings.TrimPrefix(target, "/")); comments != nil {
+		for idx := range comments.CommentList.Comment {
+			if comments.CommentList.Comment[idx].Ref == cell {
+				return &comments.CommentList.Comment[idx]
+			}
+		}
+	}
+	return nil
+}
+
+// addPerson provides a function to add the author of the threaded comment
+// into xl/persons/person.xml if not exists, and returns the ID of the person.
+func (f *File) addPerson(author string) (string, error) {
+	persons, err := f.personsReader()
+	if err != nil {
+		return "", err
+	}
+	for _, person := range persons.Person {
+		if person.DisplayName == author {
+			return person.ID, err
+		}
+	}
+	person := xlsxPerson{DisplayName: author, ID: newGUID(), UserID: author, ProviderID: "None"}
+	persons.Person = append(persons.Person, person)
+	output, _ := xml.Marshal(persons)
+	f.saveFileList("xl/persons/person.xml", output)
+	var exist bool
+	if rels := f.relsReader(f.getWorkbookRelsPath()); rels != nil {
+		rels.Lock()
+		for _, rel := range rels.Relationships {
+			if rel.Type == SourceRelationshipPerson {
+				exist = true
+			}
+		}
+		rels.Unlock()
+	}
+	if !exist {
+		f.addRels(f.getWorkbookRelsPath(), SourceRelationshipPerson, "/xl/persons/person.xml", "")
+	}
+	f.addContentTypePart(0, "person")
+	return person.ID, err
+}
+
+// threadedCommentsReader provides a function to get the pointer to the
+// structure after deserialization of xl/threadedComments/threadedComment%d.xml.
+func (f *File) threadedCommentsReader(path string) (*xlsxThreadedComments, error) {
+	var threadedComments xlsxThreadedComments
+	if path == "" {
+		return &threadedComments, nil
+	}
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(path)))).
+		Decode(&threadedComments); err != nil && err != io.EOF {
+		return &threadedComments, err
+	}
+	return &threadedComments, nil
+}
+
+// personsReader provides a function to get the pointer to the structure
+// after deserialization of xl/persons/person.xml.
+func (f *File) personsReader() (*xlsxPersonList, error) {
+	var persons xlsxPersonList
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML("xl/persons/person.xml")))).
+		Decode(&persons); err != nil && err != io.EOF {
+		return &persons, err
+	}
+	return &persons, nil
+}
+
+// countThreadedComments provides a function to get threaded comments files
+// count storage in the folder xl/threadedComments.
+func (f *File) countThreadedComments() int {
+	count := 0
+	f.Pkg.Range(func(k, v interface{}) bool {
+		if strings.HasPrefix(k.(string), "xl/threadedComments/threadedComment") {
+			count++
+		}
+		return true
+	})
+	return count
 }
 
 // countComments provides a function to get comments files count storage in
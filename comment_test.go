@@ -15,6 +15,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -45,6 +46,171 @@ func TestAddComments(t *testing.T) {
 	assert.EqualValues(t, len(NewFile().GetComments()), 0)
 }
 
+func TestAddCommentRichText(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddComment("Sheet1", "A1", `{"author":"Excelize: ","text":"This is a comment."}`))
+	assert.NoError(t, f.AddComment("Sheet1", "B2", `{"author":"Reviewer: ","runs":[
+		{"text":"Label: ","font":{"bold":true,"color":"2354e8"}},
+		{"text":"description"}
+	]}`))
+	assert.NoError(t, f.AddComment("Sheet1", "C3", `{"author":"Excelize: ","text":"Another comment."}`))
+	comments := f.GetComments()["Sheet1"]
+	if !assert.Len(t, comments, 3) {
+		t.FailNow()
+	}
+	assert.Equal(t, "Reviewer: ", comments[1].Author)
+	assert.Equal(t, 1, comments[1].AuthorID)
+	assert.Equal(t, "Reviewer: Label: description", comments[1].Text)
+	if assert.Len(t, comments[1].Runs, 3) {
+		assert.Equal(t, "Label: ", comments[1].Runs[1].Text)
+		assert.True(t, comments[1].Runs[1].Font.Bold)
+		assert.Equal(t, "2354E8", comments[1].Runs[1].Font.Color)
+		assert.Equal(t, "description", comments[1].Runs[2].Text)
+		assert.False(t, comments[1].Runs[2].Font.Bold)
+	}
+	// Test the existing author should be reused in the authors list.
+	assert.Equal(t, "Excelize: ", comments[2].Author)
+	assert.Equal(t, 0, comments[2].AuthorID)
+	assert.Equal(t, []string{"Excelize: ", "Reviewer: "}, f.Comments["xl/comments1.xml"].Authors.Author)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddCommentRichText.xlsx")))
+}
+
+func TestAddCommentVisibleAndSize(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddComment("Sheet1", "A1", `{"author":"Excelize: ","text":"This is a comment."}`))
+	assert.NoError(t, f.AddComment("Sheet1", "C3", `{"author":"Excelize: ","text":"This is a comment.","visible":true,"width":200,"height":100}`))
+	vml := f.VMLDrawing["xl/drawings/vmlDrawing1.vml"]
+	if !assert.Len(t, vml.Shape, 2) {
+		t.FailNow()
+	}
+	assert.Equal(t, "position:absolute;73.5pt;width:108pt;height:59.25pt;z-index:1;visibility:hidden", vml.Shape[0].Style)
+	assert.NotContains(t, vml.Shape[0].Val, "<x:Visible>")
+	assert.Equal(t, "position:absolute;73.5pt;width:200pt;height:100pt;z-index:1;visibility:visible", vml.Shape[1].Style)
+	assert.Contains(t, vml.Shape[1].Val, "<x:Anchor>3, 23, 3, 0, 7, 33, 9, 13</x:Anchor>")
+	assert.Contains(t, vml.Shape[1].Val, "<x:Visible></x:Visible>")
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddCommentVisibleAndSize.xlsx")))
+
+	// Test the style of the existing comment shape should be kept.
+	f, err := OpenFile(filepath.Join("test", "TestAddCommentVisibleAndSize.xlsx"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddComment("Sheet1", "E5", `{"author":"Excelize: ","text":"This is a comment."}`))
+	vml = f.VMLDrawing["xl/drawings/vmlDrawing1.vml"]
+	if assert.Len(t, vml.Shape, 3) {
+		assert.Equal(t, "position:absolute;73.5pt;width:200pt;height:100pt;z-index:1;visibility:visible", vml.Shape[1].Style)
+	}
+	assert.NoError(t, f.Close())
+}
+
+func TestUpdateComment(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddComment("Sheet1", "A1", `{"author":"Excelize: ","text":"This is a comment."}`))
+	assert.NoError(t, f.AddComment("Sheet1", "B2", `{"author":"Excelize: ","text":"Another comment."}`))
+	vml := f.VMLDrawing["xl/drawings/vmlDrawing1.vml"]
+	shapes := append([]xlsxShape{}, vml.Shape...)
+
+	assert.NoError(t, f.UpdateComment("Sheet1", "B2", `{"author":"Reviewer: ","text":"Updated comment."}`))
+	comments := f.GetComments()["Sheet1"]
+	if assert.Len(t, comments, 2) {
+		assert.Equal(t, "Excelize: This is a comment.", comments[0].Text)
+		assert.Equal(t, "B2", comments[1].Ref)
+		assert.Equal(t, "Reviewer: ", comments[1].Author)
+		assert.Equal(t, "Reviewer: Updated comment.", comments[1].Text)
+	}
+	// Test the VML shapes of the comments should be kept.
+	assert.Equal(t, shapes, vml.Shape)
+
+	// Test update comment on the cell without comment.
+	assert.EqualError(t, f.UpdateComment("Sheet1", "C3", `{"text":"comment"}`), "no comment at cell C3")
+	f.NewSheet("Sheet2")
+	assert.EqualError(t, f.UpdateComment("Sheet2", "A1", `{"text":"comment"}`), "no comment at cell A1")
+	// Test update comment on not exists worksheet.
+	assert.EqualError(t, f.UpdateComment("SheetN", "A1", `{"text":"comment"}`), "sheet SheetN is not exist")
+	// Test update comment with illegal cell coordinates.
+	assert.EqualError(t, f.UpdateComment("Sheet1", "A", `{"text":"comment"}`), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+	// Test update comment with invalid format set.
+	assert.EqualError(t, f.UpdateComment("Sheet1", "A1", `{`), "unexpected end of JSON input")
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestUpdateComment.xlsx")))
+}
+
+func TestThreadedComments(t *testing.T) {
+	f := NewFile()
+	dateTime := time.Date(2021, 3, 18, 10, 37, 19, 0, time.UTC)
+	assert.NoError(t, f.AddThreadedComment("Sheet1", "A1", ThreadedComment{Author: "Excelize", Text: "Comment", DateTime: dateTime}))
+	assert.NoError(t, f.AddThreadedComment("Sheet1", "A1", ThreadedComment{Author: "Reviewer", Text: "Reply 1"}))
+	assert.NoError(t, f.AddThreadedComment("Sheet1", "B2", ThreadedComment{Author: "Reviewer", Text: "Another comment"}))
+	assert.NoError(t, f.AddThreadedComment("Sheet1", "A1", ThreadedComment{Author: "Excelize", Text: "Reply 2"}))
+
+	comments, err := f.GetThreadedComments("Sheet1")
+	assert.NoError(t, err)
+	if !assert.Len(t, comments, 4) {
+		t.FailNow()
+	}
+	assert.Equal(t, "A1", comments[0].Ref)
+	assert.Equal(t, "Excelize", comments[0].Author)
+	assert.Equal(t, "Comment", comments[0].Text)
+	assert.Equal(t, "", comments[0].ParentID)
+	assert.Equal(t, dateTime, comments[0].DateTime)
+	assert.Equal(t, "Reviewer", comments[1].Author)
+	assert.Equal(t, "Reply 1", comments[1].Text)
+	assert.Equal(t, comments[0].ID, comments[1].ParentID)
+	assert.Equal(t, "B2", comments[2].Ref)
+	assert.Equal(t, "", comments[2].ParentID)
+	assert.Equal(t, "Reply 2", comments[3].Text)
+	assert.Equal(t, comments[0].ID, comments[3].ParentID)
+
+	// Test the person list and the legacy comments for compatibility.
+	persons, err := f.personsReader()
+	assert.NoError(t, err)
+	assert.Len(t, persons.Person, 2)
+	legacy := f.GetComments()["Sheet1"]
+	if assert.Len(t, legacy, 2) {
+		assert.Equal(t, "tc="+comments[0].ID, legacy[0].Author)
+		assert.Equal(t, threadedCommentLegacyText+"Comment\nReply:\n    Reply 1\nReply:\n    Reply 2", legacy[0].Text)
+		assert.Equal(t, "tc="+comments[2].ID, legacy[1].Author)
+	}
+	// Test add threaded comment on the cell which has a legacy comment.
+	assert.NoError(t, f.AddComment("Sheet1", "C3", `{"author":"Excelize: ","text":"This is a comment."}`))
+	assert.EqualError(t, f.AddThreadedComment("Sheet1", "C3", ThreadedComment{Text: "Comment"}), "comment already exists at cell C3")
+	// Test add threaded comment on not exists worksheet.
+	assert.EqualError(t, f.AddThreadedComment("SheetN", "A1", ThreadedComment{}), "sheet SheetN is not exist")
+	// Test add threaded comment with illegal cell coordinates.
+	assert.EqualError(t, f.AddThreadedComment("Sheet1", "A", ThreadedComment{}), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestThreadedComments.xlsx")))
+
+	// Test get threaded comments from the saved workbook.
+	f, err = OpenFile(filepath.Join("test", "TestThreadedComments.xlsx"))
+	assert.NoError(t, err)
+	saved, err := f.GetThreadedComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, comments, saved)
+	// Test add reply to the threaded comment which legacy comment text is
+	// stored in the rich text runs.
+	legacyComment := f.getSheetComment(f.sheetMap["Sheet1"], "B2")
+	if assert.NotNil(t, legacyComment) {
+		legacyComment.Text = xlsxText{R: []xlsxR{{T: &xlsxT{Val: threadedCommentLegacyText}}, {T: &xlsxT{Val: "Another comment"}}}}
+	}
+	assert.NoError(t, f.AddThreadedComment("Sheet1", "B2", ThreadedComment{Author: "Excelize", Text: "Reply 3"}))
+	legacy = f.GetComments()["Sheet1"]
+	if assert.Len(t, legacy, 3) {
+		assert.Equal(t, threadedCommentLegacyText+"Another comment\nReply:\n    Reply 3", legacy[1].Text)
+	}
+	assert.NoError(t, f.Close())
+
+	// Test get threaded comments on the worksheet without threaded comments.
+	f = NewFile()
+	comments, err = f.GetThreadedComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 0)
+	// Test get threaded comments on not exists worksheet.
+	_, err = f.GetThreadedComments("SheetN")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+	// Test get threaded comments with unsupported charset.
+	f.Pkg.Store("xl/persons/person.xml", MacintoshCyrillicCharset)
+	_, err = f.GetThreadedComments("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.EqualError(t, f.AddThreadedComment("Sheet1", "A1", ThreadedComment{}), "XML syntax error on line 1: invalid UTF-8")
+}
+
 func TestDecodeVMLDrawingReader(t *testing.T) {
 	f := NewFile()
 	path := "xl/drawings/vmlDrawing1.xml"
@@ -26,7 +26,9 @@ import (
 	"encoding/xml"
 	"hash"
 	"reflect"
+	"sort"
 	"strings"
+	"unicode/utf16"
 
 	"github.com/richardlehane/mscfb"
 	"golang.org/x/crypto/md4"
@@ -48,10 +50,13 @@ var (
 	}
 )
 
+// keyEncryptorPasswordURI defined the URI of the password key encryptor.
+const keyEncryptorPasswordURI = "http://schemas.microsoft.com/office/2006/keyEncryptor/password"
+
 // Encryption specifies the encryption structure, streams, and storages are
 // required when encrypting ECMA-376 documents.
 type Encryption struct {
-	XMLName       xml.Name      `xml:"encryption"`
+	XMLName       xml.Name      `xml:"http://schemas.microsoft.com/office/2006/encryption encryption"`
 	KeyData       KeyData       `xml:"keyData"`
 	DataIntegrity DataIntegrity `xml:"dataIntegrity"`
 	KeyEncryptors KeyEncryptors `xml:"keyEncryptors"`
@@ -151,7 +156,9 @@ func Decrypt(raw []byte, opt *Options) (packageBuf []byte, err error) {
 	return
 }
 
-// Encrypt API encrypt data with the password.
+// Encrypt API encrypt data with the password by ECMA-376 agile encryption,
+// and returns the encrypted data in the CFB file format. The package will be
+// encrypted with a random key by AES-256 and SHA-512 hash algorithm.
 func Encrypt(raw []byte, opt *Options) (packageBuf []byte, err error) {
 	// Generate a random key to use to encrypt the document. Excel uses 32 bytes. We'll use the password to encrypt this key.
 	packageKey, _ := randomBytes(32)
@@ -159,6 +166,7 @@ func Encrypt(raw []byte, opt *Options) (packageBuf []byte, err error) {
 	keyEncryptors, _ := randomBytes(16)
 	encryptionInfo := Encryption{
 		KeyData: KeyData{
+			SaltSize:        len(keyDataSaltValue),
 			BlockSize:       16,
 			KeyBits:         len(packageKey) * 8,
 			HashSize:        64,
@@ -168,7 +176,9 @@ func Encrypt(raw []byte, opt *Options) (packageBuf []byte, err error) {
 			SaltValue:       base64.StdEncoding.EncodeToString(keyDataSaltValue),
 		},
 		KeyEncryptors: KeyEncryptors{KeyEncryptor: []KeyEncryptor{{
+			URI: keyEncryptorPasswordURI,
 			EncryptedKey: EncryptedKey{SpinCount: 100000, KeyData: KeyData{
+				SaltSize:        len(keyEncryptors),
 				CipherAlgorithm: "AES",
 				CipherChaining:  "ChainingModeCBC",
 				HashAlgorithm:   "SHA512",
@@ -204,10 +214,8 @@ func Encrypt(raw []byte, opt *Options) (packageBuf []byte, err error) {
 	// Use the package key and the IV to encrypt the HMAC key.
 	encryptedHmacKey, _ := crypt(true, encryptionInfo.KeyData.CipherAlgorithm, encryptionInfo.KeyData.CipherChaining, packageKey, hmacKeyIV, hmacKey)
 	// Create the HMAC.
-	h := hmac.New(sha512.New, append(hmacKey, encryptedPackage...))
-	for _, buf := range [][]byte{hmacKey, encryptedPackage} {
-		_, _ = h.Write(buf)
-	}
+	h := hmac.New(sha512.New, hmacKey)
+	_, _ = h.Write(encryptedPackage)
 	hmacValue := h.Sum(nil)
 	// Generate an initialization vector for encrypting the resulting HMAC value.
 	hmacValueIV, err := createIV(blockKeyHmacValue, encryptionInfo)
@@ -264,10 +272,17 @@ func Encrypt(raw []byte, opt *Options) (packageBuf []byte, err error) {
 	if err != nil {
 		return
 	}
-	// TODO: Create a new CFB.
-	_, _ = encryptedPackage, encryptionInfoBuffer
-	err = ErrEncrypt
-	return
+	// Create a new CFB with the data spaces, encryption info and encrypted
+	// package streams.
+	var encryptionInfoStream bytes.Buffer
+	encryptionInfoStream.Write([]byte{0x04, 0x00, 0x04, 0x00, 0x40, 0x00, 0x00, 0x00})
+	encryptionInfoStream.WriteString(xml.Header)
+	encryptionInfoStream.Write(encryptionInfoBuffer)
+	doc := newCFBStorage("Root Entry")
+	doc.add(newDataSpacesStorage())
+	doc.add(newCFBStream("EncryptionInfo", encryptionInfoStream.Bytes()))
+	doc.add(newCFBStream("EncryptedPackage", encryptedPackage))
+	return doc.write(), err
 }
 
 // extractPart extract data from storage by specified part name.
@@ -507,6 +522,46 @@ func createUInt32LEBuffer(value int, bufferSize int) []byte {
 	return buf
 }
 
+// protectionSpinCount defined the number of times the hashing function will
+// be iteratively run for the password of the workbook and sheet protection.
+const protectionSpinCount = 100000
+
+// genISOPasswdHash generate the hash value, salt value of the password for
+// the workbook and sheet protection by given password, hash algorithm name,
+// base64 encoded salt value and spin count. A random salt value will be
+// generated if the given salt value is empty. The password will be encoded
+// as UTF-16LE, and the hash value is calculated by the iterative hashing of
+// the salt and password as defined in the ISO/IEC 29500.
+func genISOPasswdHash(passwd, algorithmName, salt string, spinCount int) (hashValue, saltValue string, err error) {
+	hashAlgorithm, ok := map[string]string{"SHA-512": "sha512"}[algorithmName]
+	if !ok {
+		return hashValue, saltValue, ErrUnsupportedHashAlgorithm
+	}
+	var s []byte
+	if salt == "" {
+		if s, err = randomBytes(16); err != nil {
+			return
+		}
+	} else if s, err = base64.StdEncoding.DecodeString(salt); err != nil {
+		return
+	}
+	encoder := unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM).NewEncoder()
+	passwordBuffer, err := encoder.Bytes([]byte(passwd))
+	if err != nil {
+		return
+	}
+	var b bytes.Buffer
+	b.Write(s)
+	b.Write(passwordBuffer)
+	// Generate the initial hash.
+	key := hashing(hashAlgorithm, b.Bytes())
+	// Now regenerate until spin count.
+	for i := 0; i < spinCount; i++ {
+		key = hashing(hashAlgorithm, key, createUInt32LEBuffer(i, 4))
+	}
+	return base64.StdEncoding.EncodeToString(key), base64.StdEncoding.EncodeToString(s), err
+}
+
 // parseEncryptionInfo parse the encryption info XML into an object.
 func parseEncryptionInfo(encryptionInfo []byte) (encryption Encryption, err error) {
 	err = xml.Unmarshal(encryptionInfo, &encryption)
@@ -526,8 +581,9 @@ func crypt(encrypt bool, cipherAlgorithm, cipherChaining string, key, iv, input
 	} else {
 		stream = cipher.NewCBCDecrypter(block, iv)
 	}
-	stream.CryptBlocks(input, input)
-	return input, nil
+	output := make([]byte, len(input))
+	stream.CryptBlocks(output, input)
+	return output, nil
 }
 
 // cryptPackage encrypt / decrypt package by given packageKey and encryption
@@ -613,3 +669,312 @@ func randomBytes(n int) ([]byte, error) {
 	_, err := rand.Read(b)
 	return b, err
 }
+
+// Compound File Binary File Format
+
+// The constants of the compound file binary file format with 512 bytes
+// sector size.
+const (
+	cfbSectorSize     = 512
+	cfbMiniSectorSize = 64
+	cfbMiniCutoff     = 4096
+	cfbDirEntrySize   = 128
+	cfbHeaderDIFATLen = 109
+	cfbMaxRegSect     = 0xFFFFFFFA
+	cfbDIFATSect      = 0xFFFFFFFC
+	cfbFATSect        = 0xFFFFFFFD
+	cfbEndOfChain     = 0xFFFFFFFE
+	cfbFreeSect       = 0xFFFFFFFF
+	cfbNoStream       = 0xFFFFFFFF
+)
+
+// cfbEntry directly maps the storage object or stream object in the compound
+// file.
+type cfbEntry struct {
+	name                   string
+	objectType             byte
+	data                   []byte
+	children               []*cfbEntry
+	id, left, right, child uint32
+	startSector            uint32
+}
+
+// newCFBStorage provides a function to create a storage object by given name.
+func newCFBStorage(name string) *cfbEntry {
+	return &cfbEntry{name: name, objectType: 1}
+}
+
+// newCFBStream provides a function to create a stream object by given name
+// and data.
+func newCFBStream(name string, data []byte) *cfbEntry {
+	return &cfbEntry{name: name, objectType: 2, data: data}
+}
+
+// add provides a function to add a storage or stream object into the storage
+// and returns the storage.
+func (e *cfbEntry) add(entry *cfbEntry) *cfbEntry {
+	e.children = append(e.children, entry)
+	return e
+}
+
+// cfbUnicodeLPP4 provides a function to encode the string as UNICODE-LP-P4
+// structure which is a length-prefixed UTF-16LE string padded to a multiple
+// of 4 bytes.
+func cfbUnicodeLPP4(s string) []byte {
+	var b bytes.Buffer
+	str := utf16.Encode([]rune(s))
+	b.Write(createUInt32LEBuffer(len(str)*2, 4))
+	for _, c := range str {
+		b.Write([]byte{byte(c), byte(c >> 8)})
+	}
+	if len(str)%2 != 0 {
+		b.Write([]byte{0, 0})
+	}
+	return b.Bytes()
+}
+
+// newDataSpacesStorage provides a function to create the \x06DataSpaces
+// storage which specifies the data space of the encrypted package.
+func newDataSpacesStorage() *cfbEntry {
+	versions := []byte{1, 0, 0, 0, 1, 0, 0, 0, 1, 0, 0, 0}
+	version := append(cfbUnicodeLPP4("Microsoft.Container.DataSpaces"), versions...)
+	var mapEntry bytes.Buffer
+	mapEntry.Write(createUInt32LEBuffer(1, 4))
+	mapEntry.Write(createUInt32LEBuffer(0, 4))
+	mapEntry.Write(cfbUnicodeLPP4("EncryptedPackage"))
+	mapEntry.Write(cfbUnicodeLPP4("StrongEncryptionDataSpace"))
+	var dataSpaceMap bytes.Buffer
+	dataSpaceMap.Write(createUInt32LEBuffer(8, 4))
+	dataSpaceMap.Write(createUInt32LEBuffer(1, 4))
+	dataSpaceMap.Write(createUInt32LEBuffer(mapEntry.Len()+4, 4))
+	dataSpaceMap.Write(mapEntry.Bytes())
+	dataSpaceDefinition := append(append(createUInt32LEBuffer(8, 4), createUInt32LEBuffer(1, 4)...),
+		cfbUnicodeLPP4("StrongEncryptionTransform")...)
+	transformID := cfbUnicodeLPP4("{FF9A3F03-56EF-4613-BDD5-5A41C1D07246}")
+	var primary bytes.Buffer
+	primary.Write(createUInt32LEBuffer(len(transformID)+8, 4))
+	primary.Write(createUInt32LEBuffer(1, 4))
+	primary.Write(transformID)
+	primary.Write(cfbUnicodeLPP4("Microsoft.Container.EncryptionTransform"))
+	primary.Write(versions)
+	primary.Write([]byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 4, 0, 0, 0})
+	return newCFBStorage("\x06DataSpaces").
+		add(newCFBStream("Version", version)).
+		add(newCFBStream("DataSpaceMap", dataSpaceMap.Bytes())).
+		add(newCFBStorage("DataSpaceInfo").add(newCFBStream("StrongEncryptionDataSpace", dataSpaceDefinition))).
+		add(newCFBStorage("TransformInfo").add(newCFBStorage("StrongEncryptionTransform").add(newCFBStream("\x06Primary", primary.Bytes()))))
+}
+
+// cfbCompareName provides a function to compare the names of the directory
+// entries, the shorter name is less than the longer name, and the names with
+// the same length are compared by the upper-case characters.
+func cfbCompareName(a, b string) bool {
+	la, lb := len(utf16.Encode([]rune(a))), len(utf16.Encode([]rune(b)))
+	if la != lb {
+		return la < lb
+	}
+	return strings.ToUpper(a) < strings.ToUpper(b)
+}
+
+// flatten provides a function to get all directory entries of the root
+// storage in the order of the entry IDs, and build the red-black tree of the
+// children of each storage. All nodes of the tree are black, and the tree is balanced by
+// taking the middle entry of the sorted children as the root.
+func (e *cfbEntry) flatten() []*cfbEntry {
+	e.objectType, e.left, e.right, e.child = 5, cfbNoStream, cfbNoStream, cfbNoStream
+	entries := []*cfbEntry{e}
+	var walk func(storage *cfbEntry)
+	walk = func(storage *cfbEntry) {
+		children := make([]*cfbEntry, len(storage.children))
+		copy(children, storage.children)
+		sort.SliceStable(children, func(i, j int) bool {
+			return cfbCompareName(children[i].name, children[j].name)
+		})
+		for _, child := range children {
+			child.id = uint32(len(entries))
+			child.left, child.right, child.child = cfbNoStream, cfbNoStream, cfbNoStream
+			entries = append(entries, child)
+		}
+		var build func(nodes []*cfbEntry) uint32
+		build = func(nodes []*cfbEntry) uint32 {
+			if len(nodes) == 0 {
+				return cfbNoStream
+			}
+			mid := len(nodes) / 2
+			nodes[mid].left = build(nodes[:mid])
+			nodes[mid].right = build(nodes[mid+1:])
+			return nodes[mid].id
+		}
+		storage.child = build(children)
+		for _, child := range children {
+			if child.objectType == 1 {
+				walk(child)
+			}
+		}
+	}
+	walk(e)
+	return entries
+}
+
+// write provides a function to serialize the storage as the root storage of
+// the compound file binary file format. The streams smaller than 4096 bytes
+// are stored in the mini stream, and the sectors are allocated in the order
+// of the regular streams, the mini stream, the mini FAT, the directory, the
+// FAT and the DIFAT.
+func (e *cfbEntry) write() []byte {
+	entries := e.flatten()
+	var (
+		fat                 []uint32
+		miniFAT             []uint32
+		miniStream, sectors bytes.Buffer
+		chain               = func(table *[]uint32, count int) uint32 {
+			start := len(*table)
+			for i := 0; i < count; i++ {
+				next := uint32(start + i + 1)
+				if i == count-1 {
+					next = cfbEndOfChain
+				}
+				*table = append(*table, next)
+			}
+			return uint32(start)
+		}
+		sectorCount = func(size, sectorSize int) int {
+			return (size + sectorSize - 1) / sectorSize
+		}
+		pad = func(b *bytes.Buffer, sectorSize int) {
+			if remainder := b.Len() % sectorSize; remainder != 0 {
+				b.Write(make([]byte, sectorSize-remainder))
+			}
+		}
+	)
+	for _, entry := range entries[1:] {
+		entry.startSector = cfbEndOfChain
+		if entry.objectType != 2 || len(entry.data) == 0 {
+			continue
+		}
+		if len(entry.data) < cfbMiniCutoff {
+			entry.startSector = chain(&miniFAT, sectorCount(len(entry.data), cfbMiniSectorSize))
+			miniStream.Write(entry.data)
+			pad(&miniStream, cfbMiniSectorSize)
+			continue
+		}
+		entry.startSector = chain(&fat, sectorCount(len(entry.data), cfbSectorSize))
+		sectors.Write(entry.data)
+		pad(&sectors, cfbSectorSize)
+	}
+	e.startSector, e.data = cfbEndOfChain, miniStream.Bytes()
+	if miniStream.Len() > 0 {
+		e.startSector = chain(&fat, sectorCount(miniStream.Len(), cfbSectorSize))
+		sectors.Write(miniStream.Bytes())
+		pad(&sectors, cfbSectorSize)
+	}
+	firstMiniFATSector, miniFATSectors := uint32(cfbEndOfChain), sectorCount(len(miniFAT)*4, cfbSectorSize)
+	if miniFATSectors > 0 {
+		firstMiniFATSector = chain(&fat, miniFATSectors)
+		for _, next := range miniFAT {
+			sectors.Write(createUInt32LEBuffer(int(next), 4))
+		}
+		for i := len(miniFAT); i < miniFATSectors*cfbSectorSize/4; i++ {
+			sectors.Write(createUInt32LEBuffer(cfbFreeSect, 4))
+		}
+	}
+	dirSectors := sectorCount(len(entries)*cfbDirEntrySize, cfbSectorSize)
+	firstDirSector := chain(&fat, dirSectors)
+	for _, entry := range entries {
+		sectors.Write(entry.marshal())
+	}
+	for i := len(entries); i < dirSectors*cfbSectorSize/cfbDirEntrySize; i++ {
+		sectors.Write((&cfbEntry{left: cfbNoStream, right: cfbNoStream, child: cfbNoStream}).marshal())
+	}
+	// Calculate the number of FAT sectors and DIFAT sectors, the FAT should
+	// contain the entries of the FAT sectors and DIFAT sectors themselves.
+	fatSectors, difatSectors := 0, 0
+	for {
+		total := len(fat) + fatSectors + difatSectors
+		if fatSectors*cfbSectorSize/4 >= total {
+			break
+		}
+		fatSectors = sectorCount(total, cfbSectorSize/4)
+		if fatSectors > cfbHeaderDIFATLen {
+			difatSectors = sectorCount(fatSectors-cfbHeaderDIFATLen, cfbSectorSize/4-1)
+		}
+	}
+	var difat []uint32
+	for i := 0; i < fatSectors; i++ {
+		difat = append(difat, uint32(len(fat)))
+		fat = append(fat, cfbFATSect)
+	}
+	firstDIFATSector := uint32(cfbEndOfChain)
+	if difatSectors > 0 {
+		firstDIFATSector = uint32(len(fat))
+	}
+	for i := 0; i < difatSectors; i++ {
+		fat = append(fat, cfbDIFATSect)
+	}
+	for _, next := range fat {
+		sectors.Write(createUInt32LEBuffer(int(next), 4))
+	}
+	for i := len(fat); i < fatSectors*cfbSectorSize/4; i++ {
+		sectors.Write(createUInt32LEBuffer(cfbFreeSect, 4))
+	}
+	for i := 0; i < difatSectors; i++ {
+		for j := 0; j < cfbSectorSize/4-1; j++ {
+			next := uint32(cfbFreeSect)
+			if idx := cfbHeaderDIFATLen + i*(cfbSectorSize/4-1) + j; idx < len(difat) {
+				next = difat[idx]
+			}
+			sectors.Write(createUInt32LEBuffer(int(next), 4))
+		}
+		next := uint32(cfbEndOfChain)
+		if i < difatSectors-1 {
+			next = firstDIFATSector + uint32(i) + 1
+		}
+		sectors.Write(createUInt32LEBuffer(int(next), 4))
+	}
+	// Write the header of the compound file.
+	var header bytes.Buffer
+	header.Write(oleIdentifier)
+	header.Write(make([]byte, 16))
+	header.Write([]byte{0x3E, 0x00, 0x03, 0x00, 0xFE, 0xFF, 0x09, 0x00, 0x06, 0x00})
+	header.Write(make([]byte, 10))
+	for _, value := range []int{
+		fatSectors, int(firstDirSector), 0, cfbMiniCutoff, int(firstMiniFATSector),
+		miniFATSectors, int(firstDIFATSector), difatSectors,
+	} {
+		header.Write(createUInt32LEBuffer(value, 4))
+	}
+	for i := 0; i < cfbHeaderDIFATLen; i++ {
+		next := uint32(cfbFreeSect)
+		if i < len(difat) {
+			next = difat[i]
+		}
+		header.Write(createUInt32LEBuffer(int(next), 4))
+	}
+	return append(header.Bytes(), sectors.Bytes()...)
+}
+
+// marshal provides a function to serialize the directory entry.
+func (e *cfbEntry) marshal() []byte {
+	var b bytes.Buffer
+	name := make([]byte, 64)
+	nameLen := 0
+	if e.objectType != 0 {
+		for i, c := range utf16.Encode([]rune(e.name)) {
+			name[i*2], name[i*2+1] = byte(c), byte(c>>8)
+			nameLen = (i + 2) * 2
+		}
+	}
+	b.Write(name)
+	b.Write([]byte{byte(nameLen), byte(nameLen >> 8), e.objectType, 1})
+	for _, value := range []uint32{e.left, e.right, e.child} {
+		b.Write(createUInt32LEBuffer(int(value), 4))
+	}
+	b.Write(make([]byte, 36))
+	startSector, size := e.startSector, len(e.data)
+	if e.objectType == 0 || e.objectType == 1 {
+		startSector, size = 0, 0
+	}
+	b.Write(createUInt32LEBuffer(int(startSector), 4))
+	b.Write(createUInt32LEBuffer(size, 8))
+	return b.Bytes()
+}
@@ -12,17 +12,66 @@
 package excelize
 
 import (
+	"bytes"
 	"path/filepath"
 	"testing"
 
+	"github.com/richardlehane/mscfb"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestEncrypt(t *testing.T) {
 	f, err := OpenFile(filepath.Join("test", "encryptSHA1.xlsx"), Options{Password: "password"})
 	assert.NoError(t, err)
-	assert.EqualError(t, f.SaveAs(filepath.Join("test", "BadEncrypt.xlsx"), Options{Password: "password"}), "not support encryption currently")
+	cell, err := f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestEncrypt.xlsx"), Options{Password: "passwd"}))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestEncrypt.xlsx"), Options{Password: "passwd"})
+	assert.NoError(t, err)
+	val, err := f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, cell, val)
 	assert.NoError(t, f.Close())
+	// Test open the encrypted file with incorrect password.
+	_, err = OpenFile(filepath.Join("test", "TestEncrypt.xlsx"), Options{Password: "password"})
+	assert.EqualError(t, err, "zip: not a valid zip file")
+}
+
+func TestCFBWrite(t *testing.T) {
+	large := make([]byte, 8<<20)
+	for i := range large {
+		large[i] = byte(i % 251)
+	}
+	streams := map[string][]byte{
+		"Small":  []byte("small stream"),
+		"Medium": bytes.Repeat([]byte{1, 2, 3}, cfbMiniCutoff/3+1),
+		"Large":  large,
+		"Empty":  {},
+	}
+	root := newCFBStorage("Root Entry").add(newDataSpacesStorage())
+	for _, name := range []string{"Small", "Medium", "Large", "Empty"} {
+		root.add(newCFBStream(name, streams[name]))
+	}
+	doc, err := mscfb.New(bytes.NewReader(root.write()))
+	assert.NoError(t, err)
+	names := map[string]bool{}
+	for entry, err := doc.Next(); err == nil; entry, err = doc.Next() {
+		if entry.Initial == 0x06 {
+			names["\x06"+entry.Name] = true
+			continue
+		}
+		names[entry.Name] = true
+		if expected, ok := streams[entry.Name]; ok {
+			buf := make([]byte, entry.Size)
+			_, _ = doc.Read(buf)
+			assert.Equal(t, expected, buf, entry.Name)
+		}
+	}
+	for _, name := range []string{"\x06DataSpaces", "Version", "DataSpaceMap", "StrongEncryptionDataSpace", "\x06Primary", "Small", "Medium", "Large", "Empty"} {
+		assert.True(t, names[name], name)
+	}
 }
 
 func TestEncryptionMechanism(t *testing.T) {
@@ -36,3 +85,20 @@ func TestEncryptionMechanism(t *testing.T) {
 func TestHashing(t *testing.T) {
 	assert.Equal(t, hashing("unsupportHashAlgorithm", []byte{}), []uint8([]byte(nil)))
 }
+
+func TestGenISOPasswdHash(t *testing.T) {
+	hashValue, saltValue, err := genISOPasswdHash("password", "SHA-512", "ZbGlb/Gpv5Ug5KIRh6xh0g==", protectionSpinCount)
+	assert.NoError(t, err)
+	assert.Equal(t, "+qNTw+AXoE93KTBW3h2KORVWIhpRLhpq/YfFjKJtc/6B2qY3PIdzuXUx9eufyD+m2Ahf0G4zgkuSFor7HSDVew==", hashValue)
+	assert.Equal(t, "ZbGlb/Gpv5Ug5KIRh6xh0g==", saltValue)
+	// Test generate password hash with random salt value.
+	_, saltValue, err = genISOPasswdHash("password", "SHA-512", "", 1)
+	assert.NoError(t, err)
+	assert.Len(t, saltValue, 24)
+	// Test generate password hash with unsupported hash algorithm.
+	_, _, err = genISOPasswdHash("password", "MD5", "", 1)
+	assert.EqualError(t, err, ErrUnsupportedHashAlgorithm.Error())
+	// Test generate password hash with invalid salt value.
+	_, _, err = genISOPasswdHash("password", "SHA-512", "*", 1)
+	assert.EqualError(t, err, "illegal base64 data at input byte 0")
+}
@@ -12,9 +12,14 @@
 package excelize
 
 import (
+	"bytes"
+	"encoding/xml"
 	"fmt"
+	"io"
 	"math"
+	"strconv"
 	"strings"
+	"time"
 	"unicode/utf16"
 )
 
@@ -124,39 +129,55 @@ func (dd *DataValidation) SetDropList(keys []string) error {
 }
 
 // SetRange provides function to set data validation range in drop list, only
-// accepts int, float64, or string data type formula argument.
+// accepts int, float64, string or time.Time data type formula argument. The
+// time.Time argument will be converted to the date serial number for the
+// DataValidationTypeDate type, and to the fraction of the day for the
+// DataValidationTypeTime type.
 func (dd *DataValidation) SetRange(f1, f2 interface{}, t DataValidationType, o DataValidationOperator) error {
-	var formula1, formula2 string
-	switch v := f1.(type) {
-	case int:
-		formula1 = fmt.Sprintf("<formula1>%d</formula1>", int(v))
-	case float64:
-		if math.Abs(float64(v)) > math.MaxFloat32 {
-			return ErrDataValidationRange
-		}
-		formula1 = fmt.Sprintf("<formula1>%.17g</formula1>", float64(v))
-	case string:
-		formula1 = fmt.Sprintf("<formula1>%s</formula1>", string(v))
-	default:
-		return ErrParameterInvalid
+	formula1, err := dataValidationFormula("formula1", f1, t)
+	if err != nil {
+		return err
 	}
-	switch v := f2.(type) {
+	formula2, err := dataValidationFormula("formula2", f2, t)
+	if err != nil {
+		return err
+	}
+	dd.Formula1, dd.Formula2 = formula1, formula2
+	dd.Type = convDataValidationType(t)
+	dd.Operator = convDataValidationOperatior(o)
+	return nil
+}
+
+// dataValidationFormula provides a function to get the formula element of
+// the data validation by given element name, formula argument and data
+// validation type.
+func dataValidationFormula(name string, v interface{}, t DataValidationType) (string, error) {
+	var formula string
+	switch v := v.(type) {
 	case int:
-		formula2 = fmt.Sprintf("<formula2>%d</formula2>", int(v))
+		formula = strconv.Itoa(v)
 	case float64:
 		if math.Abs(float64(v)) > math.MaxFloat32 {
-			return ErrDataValidationRange
+			return formula, ErrDataValidationRange
 		}
-		formula2 = fmt.Sprintf("<formula2>%.17g</formula2>", float64(v))
+		formula = fmt.Sprintf("%.17g", float64(v))
 	case string:
-		formula2 = fmt.Sprintf("<formula2>%s</formula2>", string(v))
+		formula = v
+	case time.Time:
+		if t == DataValidationTypeTime {
+			hour, min, sec := v.Clock()
+			formula = strconv.FormatFloat(float64(hour*3600+min*60+sec)/86400, 'f', -1, 64)
+			break
+		}
+		if v.Before(excelMinTime1900) {
+			return formula, ErrDataValidationRange
+		}
+		excelTime, _ := timeToExcelTime(v)
+		formula = strconv.FormatFloat(excelTime, 'f', -1, 64)
 	default:
-		return ErrParameterInvalid
+		return formula, ErrParameterInvalid
 	}
-	dd.Formula1, dd.Formula2 = formula1, formula2
-	dd.Type = convDataValidationType(t)
-	dd.Operator = convDataValidationOperatior(o)
-	return nil
+	return fmt.Sprintf("<%s>%s</%s>", name, formula, name), nil
 }
 
 // SetSqrefDropList provides set data validation on a range with source
@@ -171,8 +192,18 @@ func (dd *DataValidation) SetRange(f1, f2 interface{}, t DataValidationType, o D
 //     dvRange.SetSqrefDropList("$E$1:$E$3", true)
 //     f.AddDataValidation("Sheet1", dvRange)
 //
+// The source reference range on the other worksheet should be qualified with
+// the worksheet name, and the data validation will be added in the
+// worksheet extension list. For example, set data validation on Sheet1!A9:B10
+// with validation criteria source Sheet2!A1:A10:
+//
+//     dvRange = excelize.NewDataValidation(true)
+//     dvRange.Sqref = "A9:B10"
+//     dvRange.SetSqrefDropList("Sheet2!$A$1:$A$10", false)
+//     f.AddDataValidation("Sheet1", dvRange)
+//
 func (dd *DataValidation) SetSqrefDropList(sqref string, isCurrentSheet bool) error {
-	if isCurrentSheet {
+	if isCurrentSheet || strings.Contains(sqref, "!") {
 		dd.Formula1 = fmt.Sprintf("<formula1>%s</formula1>", sqref)
 		dd.Type = convDataValidationType(typeList)
 		return nil
@@ -254,11 +285,29 @@ func convDataValidationOperatior(o DataValidationOperator) string {
 //     dvRange.SetDropList([]string{"1", "2", "3"})
 //     err = f.AddDataValidation("Sheet1", dvRange)
 //
+// Example 4, set data validation on Sheet1!A7:B8 to only allow the dates in
+// the year 2021, and show error alert after invalid data is entered:
+//
+//     dvRange = excelize.NewDataValidation(true)
+//     dvRange.Sqref = "A7:B8"
+//     dvRange.SetRange(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+//         time.Date(2021, 12, 31, 0, 0, 0, 0, time.UTC),
+//         excelize.DataValidationTypeDate, excelize.DataValidationOperatorBetween)
+//     dvRange.SetError(excelize.DataValidationErrorStyleStop, "Invalid date", "Please enter a date in 2021")
+//     err = f.AddDataValidation("Sheet1", dvRange)
+//
 func (f *File) AddDataValidation(sheet string, dv *DataValidation) error {
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return err
 	}
+	if formula, ok := getCrossSheetDropListFormula(dv); ok {
+		if err = f.appendDataValidationExt(ws, dv, formula); err != nil {
+			return err
+		}
+		f.addSheetNameSpace(sheet, NameSpaceSpreadSheetX14)
+		return err
+	}
 	if nil == ws.DataValidations {
 		ws.DataValidations = new(xlsxDataValidations)
 	}
@@ -267,49 +316,311 @@ func (f *File) AddDataValidation(sheet string, dv *DataValidation) error {
 	return err
 }
 
+// getCrossSheetDropListFormula provides a function to get the source
+// reference range of the drop list data validation which the source on the
+// other worksheet.
+func getCrossSheetDropListFormula(dv *DataValidation) (string, bool) {
+	if dv == nil || dv.Type != convDataValidationType(typeList) {
+		return "", false
+	}
+	formula := strings.TrimSuffix(strings.TrimPrefix(dv.Formula1, "<formula1>"), "</formula1>")
+	return formula, !strings.HasPrefix(formula, `"`) && strings.Contains(formula, "!")
+}
+
+// appendDataValidationExt provides a function to append the data validation
+// into the x14:dataValidations of the worksheet extension list by given
+// worksheet, data validation and the source reference range.
+func (f *File) appendDataValidationExt(ws *xlsxWorksheet, dv *DataValidation, formula string) error {
+	var (
+		err                                                    error
+		idx                                                    = -1
+		decodeExtLst                                           = new(decodeWorksheetExt)
+		decodeDataValidations                                  = new(decodeX14DataValidations)
+		dataValidationBytes, dataValidationsBytes, extLstBytes []byte
+	)
+	if ws.ExtLst != nil {
+		if err = f.xmlNewDecoder(strings.NewReader("<extLst>" + ws.ExtLst.Ext + "</extLst>")).
+			Decode(decodeExtLst); err != nil && err != io.EOF {
+			return err
+		}
+	}
+	for i, ext := range decodeExtLst.Ext {
+		if ext.URI == ExtURIDataValidations {
+			if err = f.xmlNewDecoder(strings.NewReader(ext.Content)).
+				Decode(decodeDataValidations); err != nil && err != io.EOF {
+				return err
+			}
+			idx = i
+		}
+	}
+	if dataValidationBytes, err = xml.Marshal(&xlsxX14DataValidation{
+		AllowBlank:       dv.AllowBlank,
+		Error:            dv.Error,
+		ErrorStyle:       dv.ErrorStyle,
+		ErrorTitle:       dv.ErrorTitle,
+		Operator:         dv.Operator,
+		Prompt:           dv.Prompt,
+		PromptTitle:      dv.PromptTitle,
+		ShowDropDown:     dv.ShowDropDown,
+		ShowErrorMessage: dv.ShowErrorMessage,
+		ShowInputMessage: dv.ShowInputMessage,
+		Type:             dv.Type,
+		Formula1:         &xlsxX14Formula{F: formula},
+		Sqref:            dv.Sqref,
+	}); err != nil {
+		return err
+	}
+	if dataValidationsBytes, err = xml.Marshal(&xlsxX14DataValidations{
+		Count:   decodeDataValidations.Count + 1,
+		XMLNSXM: NameSpaceSpreadSheetExcel2006Main.Value,
+		Content: decodeDataValidations.Content + string(dataValidationBytes),
+	}); err != nil {
+		return err
+	}
+	if idx == -1 {
+		decodeExtLst.Ext = append(decodeExtLst.Ext, &xlsxWorksheetExt{URI: ExtURIDataValidations})
+		idx = len(decodeExtLst.Ext) - 1
+	}
+	decodeExtLst.Ext[idx].Content = string(dataValidationsBytes)
+	if extLstBytes, err = xml.Marshal(decodeExtLst); err != nil {
+		return err
+	}
+	ws.ExtLst = &xlsxExtLst{
+		Ext: strings.TrimSuffix(strings.TrimPrefix(string(extLstBytes), "<extLst>"), "</extLst>"),
+	}
+	return err
+}
+
+// GetDataValidations returns data validations list by given worksheet name,
+// the data validations in the worksheet extension list will also be
+// returned. For example, get data validations on Sheet1:
+//
+//     dvs, err := f.GetDataValidations("Sheet1")
+//
+func (f *File) GetDataValidations(sheet string) ([]*DataValidation, error) {
+	var dataValidations []*DataValidation
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return dataValidations, err
+	}
+	if ws.DataValidations != nil {
+		for _, dv := range ws.DataValidations.DataValidation {
+			if dv == nil {
+				continue
+			}
+			dataValidation := *dv
+			formulas := dv.Formula1 + dv.Formula2
+			if idx := strings.Index(formulas, "<formula2>"); idx != -1 {
+				dataValidation.Formula1, dataValidation.Formula2 = formulas[:idx], formulas[idx:]
+			} else {
+				dataValidation.Formula1, dataValidation.Formula2 = formulas, ""
+			}
+			dataValidations = append(dataValidations, &dataValidation)
+		}
+	}
+	if ws.ExtLst == nil {
+		return dataValidations, err
+	}
+	decodeExtLst := new(decodeWorksheetExt)
+	if err = f.xmlNewDecoder(strings.NewReader("<extLst>" + ws.ExtLst.Ext + "</extLst>")).
+		Decode(decodeExtLst); err != nil && err != io.EOF {
+		return dataValidations, err
+	}
+	for _, ext := range decodeExtLst.Ext {
+		if ext.URI != ExtURIDataValidations {
+			continue
+		}
+		decodeDataValidations := new(decodeX14DataValidations)
+		if err = f.xmlNewDecoder(strings.NewReader(ext.Content)).
+			Decode(decodeDataValidations); err != nil && err != io.EOF {
+			return dataValidations, err
+		}
+		for _, dv := range decodeDataValidations.DataValidation {
+			dataValidations = append(dataValidations, &DataValidation{
+				AllowBlank:       dv.AllowBlank,
+				Error:            dv.Error,
+				ErrorStyle:       dv.ErrorStyle,
+				ErrorTitle:       dv.ErrorTitle,
+				Operator:         dv.Operator,
+				Prompt:           dv.Prompt,
+				PromptTitle:      dv.PromptTitle,
+				ShowDropDown:     dv.ShowDropDown,
+				ShowErrorMessage: dv.ShowErrorMessage,
+				ShowInputMessage: dv.ShowInputMessage,
+				Sqref:            dv.Sqref,
+				Type:             dv.Type,
+				Formula1:         getX14DataValidationFormula("formula1", dv.Formula1),
+				Formula2:         getX14DataValidationFormula("formula2", dv.Formula2),
+			})
+		}
+	}
+	return dataValidations, nil
+}
+
+// getX14DataValidationFormula provides a function to convert the formula of
+// the data validation in the worksheet extension list to the formula element
+// by given element name and formula.
+func getX14DataValidationFormula(name string, formula *decodeX14Formula) string {
+	if formula == nil {
+		return ""
+	}
+	var buf bytes.Buffer
+	_ = xml.EscapeText(&buf, []byte(formula.F))
+	return fmt.Sprintf("<%s>%s</%s>", name, buf.String(), name)
+}
+
 // DeleteDataValidation delete data validation by given worksheet name and
-// reference sequence.
-func (f *File) DeleteDataValidation(sheet, sqref string) error {
+// reference sequence. The data validations which overlap with the reference
+// sequence will be split, and all data validations in the worksheet will be
+// deleted if the reference sequence is not specified. For example, delete
+// the data validation on Sheet1!B2:B100:
+//
+//     err := f.DeleteDataValidation("Sheet1", "B2:B100")
+//
+// Delete all data validations on Sheet1:
+//
+//     err := f.DeleteDataValidation("Sheet1")
+//
+func (f *File) DeleteDataValidation(sheet string, sqref ...string) error {
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return err
 	}
-	if ws.DataValidations == nil {
-		return nil
+	if len(sqref) == 0 {
+		ws.DataValidations = nil
+		return f.deleteDataValidationExt(ws, nil)
+	}
+	delCells, err := f.flatSqref(strings.Join(sqref, " "))
+	if err != nil {
+		return err
+	}
+	if dv := ws.DataValidations; dv != nil {
+		for i := 0; i < len(dv.DataValidation); i++ {
+			applySqref, err := f.deleteSqrefCells(dv.DataValidation[i].Sqref, delCells)
+			if err != nil {
+				return err
+			}
+			dv.DataValidation[i].Sqref = applySqref
+			if applySqref == "" {
+				dv.DataValidation = append(dv.DataValidation[:i], dv.DataValidation[i+1:]...)
+				i--
+			}
+		}
+		dv.Count = len(dv.DataValidation)
+		if dv.Count == 0 {
+			ws.DataValidations = nil
+		}
 	}
-	delCells, err := f.flatSqref(sqref)
+	return f.deleteDataValidationExt(ws, delCells)
+}
+
+// deleteSqrefCells provides a function to remove the cells from the reference
+// sequence, and returns the remaining reference sequence by given reference
+// sequence and cells to be removed.
+func (f *File) deleteSqrefCells(sqref string, delCells map[int][][]int) (string, error) {
+	applySqref := []string{}
+	colCells, err := f.flatSqref(sqref)
 	if err != nil {
+		return sqref, err
+	}
+	for col, cells := range delCells {
+		for _, cell := range cells {
+			idx := inCoordinates(colCells[col], cell)
+			if idx != -1 {
+				colCells[col] = append(colCells[col][:idx], colCells[col][idx+1:]...)
+			}
+		}
+	}
+	for _, col := range colCells {
+		applySqref = append(applySqref, f.squashSqref(col)...)
+	}
+	return strings.Join(applySqref, " "), err
+}
+
+// deleteDataValidationExt provides a function to delete the data validations
+// in the worksheet extension list by given worksheet and cells. All data
+// validations in the worksheet extension list will be deleted if the cells
+// is nil.
+func (f *File) deleteDataValidationExt(ws *xlsxWorksheet, delCells map[int][][]int) error {
+	if ws.ExtLst == nil {
+		return nil
+	}
+	var (
+		err                              error
+		exts                             []*xlsxWorksheetExt
+		decodeExtLst                     = new(decodeWorksheetExt)
+		dataValidationBytes, extLstBytes []byte
+	)
+	if err = f.xmlNewDecoder(strings.NewReader("<extLst>" + ws.ExtLst.Ext + "</extLst>")).
+		Decode(decodeExtLst); err != nil && err != io.EOF {
 		return err
 	}
-	dv := ws.DataValidations
-	for i := 0; i < len(dv.DataValidation); i++ {
-		applySqref := []string{}
-		colCells, err := f.flatSqref(dv.DataValidation[i].Sqref)
-		if err != nil {
+	for _, ext := range decodeExtLst.Ext {
+		if ext.URI != ExtURIDataValidations {
+			exts = append(exts, ext)
+			continue
+		}
+		if delCells == nil {
+			continue
+		}
+		decodeDataValidations := new(decodeX14DataValidations)
+		if err = f.xmlNewDecoder(strings.NewReader(ext.Content)).
+			Decode(decodeDataValidations); err != nil && err != io.EOF {
 			return err
 		}
-		for col, cells := range delCells {
-			for _, cell := range cells {
-				idx := inCoordinates(colCells[col], cell)
-				if idx != -1 {
-					colCells[col] = append(colCells[col][:idx], colCells[col][idx+1:]...)
-				}
+		dataValidations := &xlsxX14DataValidations{XMLNSXM: NameSpaceSpreadSheetExcel2006Main.Value}
+		for _, dv := range decodeDataValidations.DataValidation {
+			if dv.Sqref, err = f.deleteSqrefCells(dv.Sqref, delCells); err != nil {
+				return err
+			}
+			if dv.Sqref == "" {
+				continue
+			}
+			dataValidation := &xlsxX14DataValidation{
+				AllowBlank:       dv.AllowBlank,
+				Error:            dv.Error,
+				ErrorStyle:       dv.ErrorStyle,
+				ErrorTitle:       dv.ErrorTitle,
+				Operator:         dv.Operator,
+				Prompt:           dv.Prompt,
+				PromptTitle:      dv.PromptTitle,
+				ShowDropDown:     dv.ShowDropDown,
+				ShowErrorMessage: dv.ShowErrorMessage,
+				ShowInputMessage: dv.ShowInputMessage,
+				Type:             dv.Type,
+				Sqref:            dv.Sqref,
+			}
+			if dv.Formula1 != nil {
+				dataValidation.Formula1 = &xlsxX14Formula{F: dv.Formula1.F}
 			}
+			if dv.Formula2 != nil {
+				dataValidation.Formula2 = &xlsxX14Formula{F: dv.Formula2.F}
+			}
+			if dataValidationBytes, err = xml.Marshal(dataValidation); err != nil {
+				return err
+			}
+			dataValidations.Count++
+			dataValidations.Content += string(dataValidationBytes)
 		}
-		for _, col := range colCells {
-			applySqref = append(applySqref, f.squashSqref(col)...)
+		if dataValidations.Count == 0 {
+			continue
 		}
-		dv.DataValidation[i].Sqref = strings.Join(applySqref, " ")
-		if len(applySqref) == 0 {
-			dv.DataValidation = append(dv.DataValidation[:i], dv.DataValidation[i+1:]...)
-			i--
+		if dataValidationBytes, err = xml.Marshal(dataValidations); err != nil {
+			return err
 		}
+		exts = append(exts, &xlsxWorksheetExt{URI: ExtURIDataValidations, Content: string(dataValidationBytes)})
 	}
-	dv.Count = len(dv.DataValidation)
-	if dv.Count == 0 {
-		ws.DataValidations = nil
+	if len(exts) == 0 {
+		ws.ExtLst = nil
+		return err
 	}
-	return nil
+	if extLstBytes, err = xml.Marshal(&decodeWorksheetExt{Ext: exts}); err != nil {
+		return err
+	}
+	ws.ExtLst = &xlsxExtLst{
+		Ext: strings.TrimSuffix(strings.TrimPrefix(string(extLstBytes), "<extLst>"), "</extLst>"),
+	}
+	return err
 }
 
 // squashSqref generates cell reference sequence by given cells coordinates list.
@@ -12,10 +12,12 @@
 package excelize
 
 import (
+	"fmt"
 	"math"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -68,6 +70,26 @@ func TestDataValidation(t *testing.T) {
 	assert.Equal(t, `<formula1>"A&lt;,B&gt;,C"",D	,E',F"</formula1>`, dvRange.Formula1)
 	assert.NoError(t, f.AddDataValidation("Sheet1", dvRange))
 	assert.NoError(t, f.SaveAs(resultFile))
+
+	// Test set data validation with date and time range
+	dvRange = NewDataValidation(true)
+	dvRange.Sqref = "A7:B8"
+	assert.NoError(t, dvRange.SetRange(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2021, 12, 31, 0, 0, 0, 0, time.UTC), DataValidationTypeDate, DataValidationOperatorNotBetween))
+	dvRange.SetError(DataValidationErrorStyleStop, "error title", "error body")
+	dvRange.SetInput("input title", "input body")
+	assert.Equal(t, "date", dvRange.Type)
+	assert.Equal(t, "notBetween", dvRange.Operator)
+	assert.Equal(t, "<formula1>44197</formula1>", dvRange.Formula1)
+	assert.Equal(t, "<formula2>44561</formula2>", dvRange.Formula2)
+	assert.NoError(t, f.AddDataValidation("Sheet1", dvRange))
+	dvRange = NewDataValidation(true)
+	dvRange.Sqref = "A9:B10"
+	assert.NoError(t, dvRange.SetRange(time.Date(2021, 1, 1, 9, 0, 0, 0, time.UTC), time.Date(2021, 1, 1, 18, 30, 0, 0, time.UTC), DataValidationTypeTime, DataValidationOperatorBetween))
+	assert.Equal(t, "time", dvRange.Type)
+	assert.Equal(t, "<formula1>0.375</formula1>", dvRange.Formula1)
+	assert.Equal(t, "<formula2>0.7708333333333334</formula2>", dvRange.Formula2)
+	assert.NoError(t, f.AddDataValidation("Sheet1", dvRange))
+	assert.NoError(t, f.SaveAs(resultFile))
 }
 
 func TestDataValidationError(t *testing.T) {
@@ -126,6 +148,9 @@ func TestDataValidationError(t *testing.T) {
 	assert.EqualError(t, dvRange.SetRange(
 		math.SmallestNonzeroFloat64, math.MaxFloat64,
 		DataValidationTypeWhole, DataValidationOperatorGreaterThan), ErrDataValidationRange.Error())
+	assert.EqualError(t, dvRange.SetRange(
+		time.Date(1899, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2021, 12, 31, 0, 0, 0, 0, time.UTC),
+		DataValidationTypeDate, DataValidationOperatorBetween), ErrDataValidationRange.Error())
 	assert.NoError(t, f.SaveAs(resultFile))
 
 	// Test add data validation on no exists worksheet.
@@ -133,6 +158,103 @@ func TestDataValidationError(t *testing.T) {
 	assert.EqualError(t, f.AddDataValidation("SheetN", nil), "sheet SheetN is not exist")
 }
 
+func TestDataValidationCrossSheetDropList(t *testing.T) {
+	f := NewFile()
+	f.NewSheet("Sheet2")
+	for r := 1; r <= 3; r++ {
+		assert.NoError(t, f.SetCellValue("Sheet2", fmt.Sprintf("A%d", r), r))
+	}
+	dvRange := NewDataValidation(true)
+	dvRange.Sqref = "A1:B2"
+	assert.NoError(t, dvRange.SetSqrefDropList("$E$1:$E$3", true))
+	assert.NoError(t, f.AddDataValidation("Sheet1", dvRange))
+	for _, sqref := range []string{"C1:C2", "D1:D2"} {
+		dvRange = NewDataValidation(true)
+		dvRange.Sqref = sqref
+		assert.NoError(t, dvRange.SetSqrefDropList("Sheet2!$A$1:$A$3", false))
+		dvRange.SetError(DataValidationErrorStyleStop, "error title", "error body")
+		assert.NoError(t, f.AddDataValidation("Sheet1", dvRange))
+	}
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, ws.DataValidations.Count)
+	assert.Equal(t, "<formula1>$E$1:$E$3</formula1>", ws.DataValidations.DataValidation[0].Formula1)
+	assert.Equal(t, `<ext uri="{CCE6A557-97BC-4B89-ADB6-D9C93CAAB3DF}"><x14:dataValidations count="2" xmlns:xm="http://schemas.microsoft.com/office/excel/2006/main">`+
+		`<x14:dataValidation allowBlank="true" error="error body" errorStyle="stop" errorTitle="error title" showErrorMessage="true" type="list"><x14:formula1><xm:f>Sheet2!$A$1:$A$3</xm:f></x14:formula1><xm:sqref>C1:C2</xm:sqref></x14:dataValidation>`+
+		`<x14:dataValidation allowBlank="true" error="error body" errorStyle="stop" errorTitle="error title" showErrorMessage="true" type="list"><x14:formula1><xm:f>Sheet2!$A$1:$A$3</xm:f></x14:formula1><xm:sqref>D1:D2</xm:sqref></x14:dataValidation>`+
+		`</x14:dataValidations></ext>`, ws.ExtLst.Ext)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDataValidationCrossSheetDropList.xlsx")))
+}
+
+func TestGetDataValidations(t *testing.T) {
+	f := NewFile()
+	dvs, err := f.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, dvs, 0)
+
+	dvRange := NewDataValidation(true)
+	dvRange.Sqref = "A1:B2"
+	assert.NoError(t, dvRange.SetRange(10, 20, DataValidationTypeWhole, DataValidationOperatorBetween))
+	dvRange.SetError(DataValidationErrorStyleStop, "error title", "error body")
+	dvRange.SetInput("input title", "input body")
+	assert.NoError(t, f.AddDataValidation("Sheet1", dvRange))
+	dvRange = NewDataValidation(false)
+	dvRange.Sqref = "A3:B4"
+	assert.NoError(t, dvRange.SetDropList([]string{"A", "B&C"}))
+	assert.NoError(t, f.AddDataValidation("Sheet1", dvRange))
+	dvRange = NewDataValidation(true)
+	dvRange.Sqref = "C1:C2"
+	assert.NoError(t, dvRange.SetSqrefDropList("Sheet2!$A$1:$A$3", false))
+	dvRange.SetInput("input title", "input body")
+	assert.NoError(t, f.AddDataValidation("Sheet1", dvRange))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGetDataValidations.xlsx")))
+
+	errTitle, errBody, errStyle, inputTitle, inputBody := "error title", "error body", "stop", "input title", "input body"
+	expected := []*DataValidation{
+		{
+			AllowBlank:       true,
+			Error:            &errBody,
+			ErrorStyle:       &errStyle,
+			ErrorTitle:       &errTitle,
+			Operator:         "between",
+			Prompt:           &inputBody,
+			PromptTitle:      &inputTitle,
+			ShowErrorMessage: true,
+			ShowInputMessage: true,
+			Sqref:            "A1:B2",
+			Type:             "whole",
+			Formula1:         "<formula1>10</formula1>",
+			Formula2:         "<formula2>20</formula2>",
+		},
+		{
+			Sqref:    "A3:B4",
+			Type:     "list",
+			Formula1: `<formula1>"A,B&amp;C"</formula1>`,
+		},
+		{
+			AllowBlank:       true,
+			Prompt:           &inputBody,
+			PromptTitle:      &inputTitle,
+			ShowInputMessage: true,
+			Sqref:            "C1:C2",
+			Type:             "list",
+			Formula1:         "<formula1>Sheet2!$A$1:$A$3</formula1>",
+		},
+	}
+	dvs, err = f.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, dvs)
+	// Test get data validations after save and reopen the workbook
+	f, err = OpenFile(filepath.Join("test", "TestGetDataValidations.xlsx"))
+	assert.NoError(t, err)
+	dvs, err = f.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, dvs)
+	// Test get data validations on not exists worksheet
+	_, err = f.GetDataValidations("SheetN")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+}
+
 func TestDeleteDataValidation(t *testing.T) {
 	f := NewFile()
 	assert.NoError(t, f.DeleteDataValidation("Sheet1", "A1:B2"))
@@ -174,4 +296,43 @@ func TestDeleteDataValidation(t *testing.T) {
 
 	// Test delete data validation on no exists worksheet.
 	assert.EqualError(t, f.DeleteDataValidation("SheetN", "A1:B2"), "sheet SheetN is not exist")
+
+	// Test delete data validations in the worksheet extension list
+	f = NewFile()
+	for _, sqref := range []string{"A1:A10", "B1:B10"} {
+		dvRange = NewDataValidation(true)
+		dvRange.Sqref = sqref
+		assert.NoError(t, dvRange.SetSqrefDropList("Sheet2!$A$1:$A$3", false))
+		assert.NoError(t, f.AddDataValidation("Sheet1", dvRange))
+	}
+	dvRange = NewDataValidation(true)
+	dvRange.Sqref = "C1:C10"
+	assert.NoError(t, dvRange.SetRange(10, 20, DataValidationTypeWhole, DataValidationOperatorBetween))
+	assert.NoError(t, f.AddDataValidation("Sheet1", dvRange))
+	assert.NoError(t, f.AddSparkline("Sheet1", &SparklineOption{Location: []string{"D1"}, Range: []string{"Sheet1!A1:C1"}}))
+	assert.NoError(t, f.DeleteDataValidation("Sheet1", "A5:A6", "B1:B10", "C1"))
+	dvs, err := f.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, dvs, 2)
+	assert.Equal(t, "C2:C10", dvs[0].Sqref)
+	assert.Equal(t, "A1:A4 A7:A10", dvs[1].Sqref)
+	assert.NoError(t, f.DeleteDataValidation("Sheet1", "A1:A10"))
+	dvs, err = f.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, dvs, 1)
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.NotContains(t, ws.(*xlsxWorksheet).ExtLst.Ext, ExtURIDataValidations)
+	assert.Contains(t, ws.(*xlsxWorksheet).ExtLst.Ext, ExtURISparklineGroups)
+	// Test delete all data validations in the worksheet
+	dvRange = NewDataValidation(true)
+	dvRange.Sqref = "A1:A10"
+	assert.NoError(t, dvRange.SetSqrefDropList("Sheet2!$A$1:$A$3", false))
+	assert.NoError(t, f.AddDataValidation("Sheet1", dvRange))
+	assert.NoError(t, f.DeleteDataValidation("Sheet1"))
+	dvs, err = f.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, dvs, 0)
+	assert.Contains(t, ws.(*xlsxWorksheet).ExtLst.Ext, ExtURISparklineGroups)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDeleteDataValidationExt.xlsx")))
 }
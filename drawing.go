@@ -238,21 +238,32 @@ func (f *File) addChart(formatSet *formatChart, comboCharts []*formatChart) {
 	if formatSet.Legend.None {
 		xlsxChartSpace.Chart.Legend = nil
 	}
-	addChart := func(c, p *cPlotArea) {
+	addChart := func(c, p *cPlotArea, secondary bool) {
 		immutable, mutable := reflect.ValueOf(c).Elem(), reflect.ValueOf(p).Elem()
 		for i := 0; i < mutable.NumField(); i++ {
 			field := mutable.Field(i)
 			if field.IsNil() {
 				continue
 			}
-			immutable.FieldByName(mutable.Type().Field(i).Name).Set(field)
+			fld := immutable.FieldByName(mutable.Type().Field(i).Name)
+			if secondary && field.Kind() == reflect.Slice {
+				if _, ok := field.Interface().([]*cCharts); ok {
+					fld.Set(reflect.AppendSlice(fld, field))
+				}
+				continue
+			}
+			fld.Set(field)
 		}
 	}
-	addChart(xlsxChartSpace.Chart.PlotArea, plotAreaFunc[formatSet.Type](formatSet))
+	addChart(xlsxChartSpace.Chart.PlotArea, plotAreaFunc[formatSet.Type](formatSet), false)
 	order := len(formatSet.Series)
 	for idx := range comboCharts {
 		comboCharts[idx].order = order
-		addChart(xlsxChartSpace.Chart.PlotArea, plotAreaFunc[comboCharts[idx].Type](comboCharts[idx]))
+		plotArea := plotAreaFunc[comboCharts[idx].Type](comboCharts[idx])
+		if comboCharts[idx].secondary {
+			f.drawPlotAreaSecondaryAxis(xlsxChartSpace.Chart.PlotArea, plotArea)
+		}
+		addChart(xlsxChartSpace.Chart.PlotArea, plotArea, comboCharts[idx].secondary)
 		order += len(comboCharts[idx].Series)
 	}
 	chart, _ := xml.Marshal(xlsxChartSpace)
@@ -296,212 +307,212 @@ func (f *File) drawBaseChart(formatSet *formatChart) *cPlotArea {
 	valAx := f.drawPlotAreaValAx(formatSet)
 	charts := map[string]*cPlotArea{
 		"area": {
-			AreaChart: &c,
+			AreaChart: []*cCharts{&c},
 			CatAx:     catAx,
 			ValAx:     valAx,
 		},
 		"areaStacked": {
-			AreaChart: &c,
+			AreaChart: []*cCharts{&c},
 			CatAx:     catAx,
 			ValAx:     valAx,
 		},
 		"areaPercentStacked": {
-			AreaChart: &c,
+			AreaChart: []*cCharts{&c},
 			CatAx:     catAx,
 			ValAx:     valAx,
 		},
 		"area3D": {
-			Area3DChart: &c,
+			Area3DChart: []*cCharts{&c},
 			CatAx:       catAx,
 			ValAx:       valAx,
 		},
 		"area3DStacked": {
-			Area3DChart: &c,
+			Area3DChart: []*cCharts{&c},
 			CatAx:       catAx,
 			ValAx:       valAx,
 		},
 		"area3DPercentStacked": {
-			Area3DChart: &c,
+			Area3DChart: []*cCharts{&c},
 			CatAx:       catAx,
 			ValAx:       valAx,
 		},
 		"bar": {
-			BarChart: &c,
+			BarChart: []*cCharts{&c},
 			CatAx:    catAx,
 			ValAx:    valAx,
 		},
 		"barStacked": {
-			BarChart: &c,
+			BarChart: []*cCharts{&c},
 			CatAx:    catAx,
 			ValAx:    valAx,
 		},
 		"barPercentStacked": {
-			BarChart: &c,
+			BarChart: []*cCharts{&c},
 			CatAx:    catAx,
 			ValAx:    valAx,
 		},
 		"bar3DClustered": {
-			Bar3DChart: &c,
+			Bar3DChart: []*cCharts{&c},
 			CatAx:      catAx,
 			ValAx:      valAx,
 		},
 		"bar3DStacked": {
-			Bar3DChart: &c,
+			Bar3DChart: []*cCharts{&c},
 			CatAx:      catAx,
 			ValAx:      valAx,
 		},
 		"bar3DPercentStacked": {
-			Bar3DChart: &c,
+			Bar3DChart: []*cCharts{&c},
 			CatAx:      catAx,
 			ValAx:      valAx,
 		},
 		"bar3DConeClustered": {
-			Bar3DChart: &c,
+			Bar3DChart: []*cCharts{&c},
 			CatAx:      catAx,
 			ValAx:      valAx,
 		},
 		"bar3DConeStacked": {
-			Bar3DChart: &c,
+			Bar3DChart: []*cCharts{&c},
 			CatAx:      catAx,
 			ValAx:      valAx,
 		},
 		"bar3DConePercentStacked": {
-			Bar3DChart: &c,
+			Bar3DChart: []*cCharts{&c},
 			CatAx:      catAx,
 			ValAx:      valAx,
 		},
 		"bar3DPyramidClustered": {
-			Bar3DChart: &c,
+			Bar3DChart: []*cCharts{&c},
 			CatAx:      catAx,
 			ValAx:      valAx,
 		},
 		"bar3DPyramidStacked": {
-			Bar3DChart: &c,
+			Bar3DChart: []*cCharts{&c},
 			CatAx:      catAx,
 			ValAx:      valAx,
 		},
 		"bar3DPyramidPercentStacked": {
-			Bar3DChart: &c,
+			Bar3DChart: []*cCharts{&c},
 			CatAx:      catAx,
 			ValAx:      valAx,
 		},
 		"bar3DCylinderClustered": {
-			Bar3DChart: &c,
+			Bar3DChart: []*cCharts{&c},
 			CatAx:      catAx,
 			ValAx:      valAx,
 		},
 		"bar3DCylinderStacked": {
-			Bar3DChart: &c,
+			Bar3DChart: []*cCharts{&c},
 			CatAx:      catAx,
 			ValAx:      valAx,
 		},
 		"bar3DCylinderPercentStacked": {
-			Bar3DChart: &c,
+			Bar3DChart: []*cCharts{&c},
 			CatAx:      catAx,
 			ValAx:      valAx,
 		},
 		"col": {
-			BarChart: &c,
+			BarChart: []*cCharts{&c},
 			CatAx:    catAx,
 			ValAx:    valAx,
 		},
 		"colStacked": {
-			BarChart: &c,
+			BarChart: []*cCharts{&c},
 			CatAx:    catAx,
 			ValAx:    valAx,
 		},
 		"colPercentStacked": {
-			BarChart: &c,
+			BarChart: []*cCharts{&c},
 			CatAx:    catAx,
 			ValAx:    valAx,
 		},
 		"col3D": {
-			Bar3DChart: &c,
+			Bar3DChart: []*cCharts{&c},
 			CatAx:      catAx,
 			ValAx:      valAx,
 		},
 		"col3DClustered": {
-			Bar3DChart: &c,
+			Bar3DChart: []*cCharts{&c},
 			CatAx:      catAx,
 			ValAx:      valAx,
 		},
 		"col3DStacked": {
-			Bar3DChart: &c,
+			Bar3DChart: []*cCharts{&c},
 			CatAx:      catAx,
 			ValAx:      valAx,
 		},
 		"col3DPercentStacked": {
-			Bar3DChart: &c,
+			Bar3DChart: []*cCharts{&c},
 			CatAx:      catAx,
 			ValAx:      valAx,
 		},
 		"col3DCone": {
-			Bar3DChart: &c,
+			Bar3DChart: []*cCharts{&c},
 			CatAx:      catAx,
 			ValAx:      valAx,
 		},
 		"col3DConeClustered": {
-			Bar3DChart: &c,
+			Bar3DChart: []*cCharts{&c},
 			CatAx:      catAx,
 			ValAx:      valAx,
 		},
 		"col3DConeStacked": {
-			Bar3DChart: &c,
+			Bar3DChart: []*cCharts{&c},
 			CatAx:      catAx,
 			ValAx:      valAx,
 		},
 		"col3DConePercentStacked": {
-			Bar3DChart: &c,
+			Bar3DChart: []*cCharts{&c},
 			CatAx:      catAx,
 			ValAx:      valAx,
 		},
 		"col3DPyramid": {
-			Bar3DChart: &c,
+			Bar3DChart: []*cCharts{&c},
 			CatAx:      catAx,
 			ValAx:      valAx,
 		},
 		"col3DPyramidClustered": {
-			Bar3DChart: &c,
+			Bar3DChart: []*cCharts{&c},
 			CatAx:      catAx,
 			ValAx:      valAx,
 		},
 		"col3DPyramidStacked": {
-			Bar3DChart: &c,
+			Bar3DChart: []*cCharts{&c},
 			CatAx:      catAx,
 			ValAx:      valAx,
 		},
 		"col3DPyramidPercentStacked": {
-			Bar3DChart: &c,
+			Bar3DChart: []*cCharts{&c},
 			CatAx:      catAx,
 			ValAx:      valAx,
 		},
 		"col3DCylinder": {
-			Bar3DChart: &c,
+			Bar3DChart: []*cCharts{&c},
 			CatAx:      catAx,
 			ValAx:      valAx,
 		},
 		"col3DCylinderClustered": {
-			Bar3DChart: &c,
+			Bar3DChart: []*cCharts{&c},
 			CatAx:      catAx,
 			ValAx:      valAx,
 		},
 		"col3DCylinderStacked": {
-			Bar3DChart: &c,
+			Bar3DChart: []*cCharts{&c},
 			CatAx:      catAx,
 			ValAx:      valAx,
 		},
 		"col3DCylinderPercentStacked": {
-			Bar3DChart: &c,
+			Bar3DChart: []*cCharts{&c},
 			CatAx:      catAx,
 			ValAx:      valAx,
 		},
 		"bubble": {
-			BubbleChart: &c,
+			BubbleChart: []*cCharts{&c},
 			CatAx:       catAx,
 			ValAx:       valAx,
 		},
 		"bubble3D": {
-			BubbleChart: &c,
+			BubbleChart: []*cCharts{&c},
 			CatAx:       catAx,
 			ValAx:       valAx,
 		},
@@ -513,13 +524,13 @@ func (f *File) drawBaseChart(formatSet *formatChart) *cPlotArea {
 // doughnut chart by given format sets.
 func (f *File) drawDoughnutChart(formatSet *formatChart) *cPlotArea {
 	return &cPlotArea{
-		DoughnutChart: &cCharts{
+		DoughnutChart: []*cCharts{{
 			VaryColors: &attrValBool{
 				Val: boolPtr(formatSet.VaryColors),
 			},
 			Ser:      f.drawChartSeries(formatSet),
 			HoleSize: &attrValInt{Val: intPtr(75)},
-		},
+		}},
 	}
 }
 
@@ -527,7 +538,7 @@ func (f *File) drawDoughnutChart(formatSet *formatChart) *cPlotArea {
 // chart by given format sets.
 func (f *File) drawLineChart(formatSet *formatChart) *cPlotArea {
 	return &cPlotArea{
-		LineChart: &cCharts{
+		LineChart: []*cCharts{{
 			Grouping: &attrValString{
 				Val: stringPtr(plotAreaChartGrouping[formatSet.Type]),
 			},
@@ -543,7 +554,7 @@ func (f *File) drawLineChart(formatSet *formatChart) *cPlotArea {
 				{Val: intPtr(754001152)},
 				{Val: intPtr(753999904)},
 			},
-		},
+		}},
 		CatAx: f.drawPlotAreaCatAx(formatSet),
 		ValAx: f.drawPlotAreaValAx(formatSet),
 	}
@@ -553,12 +564,12 @@ func (f *File) drawLineChart(formatSet *formatChart) *cPlotArea {
 // chart by given format sets.
 func (f *File) drawPieChart(formatSet *formatChart) *cPlotArea {
 	return &cPlotArea{
-		PieChart: &cCharts{
+		PieChart: []*cCharts{{
 			VaryColors: &attrValBool{
 				Val: boolPtr(formatSet.VaryColors),
 			},
 			Ser: f.drawChartSeries(formatSet),
-		},
+		}},
 	}
 }
 
@@ -566,12 +577,12 @@ func (f *File) drawPieChart(formatSet *formatChart) *cPlotArea {
 // pie chart by given format sets.
 func (f *File) drawPie3DChart(formatSet *formatChart) *cPlotArea {
 	return &cPlotArea{
-		Pie3DChart: &cCharts{
+		Pie3DChart: []*cCharts{{
 			VaryColors: &attrValBool{
 				Val: boolPtr(formatSet.VaryColors),
 			},
 			Ser: f.drawChartSeries(formatSet),
-		},
+		}},
 	}
 }
 
@@ -579,16 +590,7 @@ func (f *File) drawPie3DChart(formatSet *formatChart) *cPlotArea {
 // pie chart by given format sets.
 func (f *File) drawPieOfPieChart(formatSet *formatChart) *cPlotArea {
 	return &cPlotArea{
-		OfPieChart: &cCharts{
-			OfPieType: &attrValString{
-				Val: stringPtr("pie"),
-			},
-			VaryColors: &attrValBool{
-				Val: boolPtr(formatSet.VaryColors),
-			},
-			Ser:      f.drawChartSeries(formatSet),
-			SerLines: &attrValString{},
-		},
+		OfPieChart: []*cCharts{f.drawOfPieChart("pie", formatSet)},
 	}
 }
 
@@ -596,24 +598,43 @@ func (f *File) drawPieOfPieChart(formatSet *formatChart) *cPlotArea {
 // pie chart by given format sets.
 func (f *File) drawBarOfPieChart(formatSet *formatChart) *cPlotArea {
 	return &cPlotArea{
-		OfPieChart: &cCharts{
-			OfPieType: &attrValString{
-				Val: stringPtr("bar"),
-			},
-			VaryColors: &attrValBool{
-				Val: boolPtr(formatSet.VaryColors),
-			},
-			Ser:      f.drawChartSeries(formatSet),
-			SerLines: &attrValString{},
+		OfPieChart: []*cCharts{f.drawOfPieChart("bar", formatSet)},
+	}
+}
+
+// drawOfPieChart provides a function to draw the c:ofPieChart element by
+// given type of the second plot and format sets.
+func (f *File) drawOfPieChart(ofPieType string, formatSet *formatChart) *cCharts {
+	c := &cCharts{
+		OfPieType: &attrValString{
+			Val: stringPtr(ofPieType),
+		},
+		VaryColors: &attrValBool{
+			Val: boolPtr(formatSet.VaryColors),
 		},
+		Ser:      f.drawChartSeries(formatSet),
+		SerLines: &attrValString{},
+	}
+	if formatSet.OfPie.GapWidth != nil {
+		c.GapWidth = &attrValInt{Val: intPtr(*formatSet.OfPie.GapWidth)}
 	}
+	if splitType, ok := chartOfPieSplitType[formatSet.OfPie.SplitType]; ok {
+		c.SplitType = &attrValString{Val: stringPtr(splitType)}
+		if formatSet.OfPie.SplitValue != 0 {
+			c.SplitPos = &attrValFloat{Val: float64Ptr(formatSet.OfPie.SplitValue)}
+		}
+	}
+	if formatSet.OfPie.SecondPlotSize != 0 {
+		c.SecondPieSize = &attrValInt{Val: intPtr(formatSet.OfPie.SecondPlotSize)}
+	}
+	return c
 }
 
 // drawRadarChart provides a function to draw the c:plotArea element for radar
 // chart by given format sets.
 func (f *File) drawRadarChart(formatSet *formatChart) *cPlotArea {
 	return &cPlotArea{
-		RadarChart: &cCharts{
+		RadarChart: []*cCharts{{
 			RadarStyle: &attrValString{
 				Val: stringPtr("marker"),
 			},
@@ -626,7 +647,7 @@ func (f *File) drawRadarChart(formatSet *formatChart) *cPlotArea {
 				{Val: intPtr(754001152)},
 				{Val: intPtr(753999904)},
 			},
-		},
+		}},
 		CatAx: f.drawPlotAreaCatAx(formatSet),
 		ValAx: f.drawPlotAreaValAx(formatSet),
 	}
@@ -636,7 +657,7 @@ func (f *File) drawRadarChart(formatSet *formatChart) *cPlotArea {
 // scatter chart by given format sets.
 func (f *File) drawScatterChart(formatSet *formatChart) *cPlotArea {
 	return &cPlotArea{
-		ScatterChart: &cCharts{
+		ScatterChart: []*cCharts{{
 			ScatterStyle: &attrValString{
 				Val: stringPtr("smoothMarker"), // line,lineMarker,marker,none,smooth,smoothMarker
 			},
@@ -649,7 +670,7 @@ func (f *File) drawScatterChart(formatSet *formatChart) *cPlotArea {
 				{Val: intPtr(754001152)},
 				{Val: intPtr(753999904)},
 			},
-		},
+		}},
 		CatAx: f.drawPlotAreaCatAx(formatSet),
 		ValAx: f.drawPlotAreaValAx(formatSet),
 	}
@@ -659,20 +680,20 @@ func (f *File) drawScatterChart(formatSet *formatChart) *cPlotArea {
 // given format sets.
 func (f *File) drawSurface3DChart(formatSet *formatChart) *cPlotArea {
 	plotArea := &cPlotArea{
-		Surface3DChart: &cCharts{
+		Surface3DChart: []*cCharts{{
 			Ser: f.drawChartSeries(formatSet),
 			AxID: []*attrValInt{
 				{Val: intPtr(754001152)},
 				{Val: intPtr(753999904)},
 				{Val: intPtr(832256642)},
 			},
-		},
+		}},
 		CatAx: f.drawPlotAreaCatAx(formatSet),
 		ValAx: f.drawPlotAreaValAx(formatSet),
 		SerAx: f.drawPlotAreaSerAx(formatSet),
 	}
 	if formatSet.Type == WireframeSurface3D {
-		plotArea.Surface3DChart.Wireframe = &attrValBool{Val: boolPtr(true)}
+		plotArea.Surface3DChart[0].Wireframe = &attrValBool{Val: boolPtr(true)}
 	}
 	return plotArea
 }
@@ -681,20 +702,20 @@ func (f *File) drawSurface3DChart(formatSet *formatChart) *cPlotArea {
 // given format sets.
 func (f *File) drawSurfaceChart(formatSet *formatChart) *cPlotArea {
 	plotArea := &cPlotArea{
-		SurfaceChart: &cCharts{
+		SurfaceChart: []*cCharts{{
 			Ser: f.drawChartSeries(formatSet),
 			AxID: []*attrValInt{
 				{Val: intPtr(754001152)},
 				{Val: intPtr(753999904)},
 				{Val: intPtr(832256642)},
 			},
-		},
+		}},
 		CatAx: f.drawPlotAreaCatAx(formatSet),
 		ValAx: f.drawPlotAreaValAx(formatSet),
 		SerAx: f.drawPlotAreaSerAx(formatSet),
 	}
 	if formatSet.Type == WireframeContour {
-		plotArea.SurfaceChart.Wireframe = &attrValBool{Val: boolPtr(true)}
+		plotArea.SurfaceChart[0].Wireframe = &attrValBool{Val: boolPtr(true)}
 	}
 	return plotArea
 }
@@ -747,8 +768,10 @@ func (f *File) drawChartSeries(formatSet *formatChart) *[]cSer {
 			SpPr:             f.drawChartSeriesSpPr(k, formatSet),
 			Marker:           f.drawChartSeriesMarker(k, formatSet),
 			DPt:              f.drawChartSeriesDPt(k, formatSet),
-			DLbls:            f.drawChartSeriesDLbls(formatSet),
+			DLbls:            f.drawChartSeriesDLbls(formatSet.Series[k], formatSet),
 			InvertIfNegative: &attrValBool{Val: boolPtr(false)},
+			Trendline:        f.drawChartSeriesTrendline(formatSet.Series[k], formatSet),
+			ErrBars:          f.drawChartSeriesErrBars(formatSet.Series[k], formatSet),
 			Cat:              f.drawChartSeriesCat(formatSet.Series[k], formatSet),
 			Val:              f.drawChartSeriesVal(formatSet.Series[k], formatSet),
 			XVal:             f.drawChartSeriesXVal(formatSet.Series[k], formatSet),
@@ -811,6 +834,73 @@ func (f *File) drawChartSeriesDPt(i int, formatSet *formatChart) []*cDPt {
 	return chartSeriesDPt[formatSet.Type]
 }
 
+// drawChartSeriesTrendline provides a function to draw the c:trendline
+// element by given chart series and format sets.
+func (f *File) drawChartSeriesTrendline(v formatChartSeries, formatSet *formatChart) *cTrendline {
+	chartSeriesTrendline := map[string]*cTrendline{
+		Doughnut: nil, Pie: nil, Pie3D: nil, PieOfPieChart: nil, BarOfPieChart: nil, Radar: nil,
+		Surface3D: nil, WireframeSurface3D: nil, Contour: nil, WireframeContour: nil}
+	if _, ok := chartSeriesTrendline[formatSet.Type]; ok || v.Trendline.Type == "" {
+		return nil
+	}
+	trendline := &cTrendline{
+		TrendlineType: &attrValString{Val: stringPtr(chartSeriesTrendlineType[v.Trendline.Type])},
+		DispRSqr:      &attrValBool{Val: boolPtr(v.Trendline.DisplayRSquared)},
+		DispEq:        &attrValBool{Val: boolPtr(v.Trendline.DisplayEquation)},
+	}
+	switch v.Trendline.Type {
+	case "polynomial":
+		trendline.Order = &attrValInt{Val: intPtr(2)}
+		if v.Trendline.Order != 0 {
+			trendline.Order.Val = intPtr(v.Trendline.Order)
+		}
+	case "movingAvg":
+		trendline.Period = &attrValInt{Val: intPtr(2)}
+		if v.Trendline.Period != 0 {
+			trendline.Period.Val = intPtr(v.Trendline.Period)
+		}
+	}
+	return trendline
+}
+
+// drawChartSeriesErrBars provides a function to draw the c:errBars element
+// by given chart series and format sets.
+func (f *File) drawChartSeriesErrBars(v formatChartSeries, formatSet *formatChart) *cErrBars {
+	chartSeriesErrBars := map[string]*cErrBars{
+		Doughnut: nil, Pie: nil, Pie3D: nil, PieOfPieChart: nil, BarOfPieChart: nil, Radar: nil,
+		Surface3D: nil, WireframeSurface3D: nil, Contour: nil, WireframeContour: nil}
+	if _, ok := chartSeriesErrBars[formatSet.Type]; ok || v.ErrorBars.Type == "" {
+		return nil
+	}
+	errBars := &cErrBars{
+		ErrBarType: &attrValString{Val: stringPtr("both")},
+		ErrValType: &attrValString{Val: stringPtr(chartSeriesErrValType[v.ErrorBars.Type])},
+		NoEndCap:   &attrValBool{Val: boolPtr(v.ErrorBars.NoEndCap)},
+	}
+	if errBarType, ok := chartSeriesErrBarType[v.ErrorBars.Direction]; ok {
+		errBars.ErrBarType.Val = stringPtr(errBarType)
+	}
+	if _, ok := map[string]bool{Scatter: true, Bubble: true, Bubble3D: true}[formatSet.Type]; ok {
+		errBars.ErrDir = &attrValString{Val: stringPtr("y")}
+	}
+	switch v.ErrorBars.Type {
+	case "custom":
+		if *errBars.ErrBarType.Val != "minus" {
+			errBars.Plus = &cVal{NumRef: &cNumRef{F: v.ErrorBars.Plus}}
+		}
+		if *errBars.ErrBarType.Val != "plus" {
+			errBars.Minus = &cVal{NumRef: &cNumRef{F: v.ErrorBars.Minus}}
+		}
+	case "fixed", "percentage", "standard_deviation":
+		value := map[string]float64{"fixed": 1, "percentage": 5, "standard_deviation": 1}[v.ErrorBars.Type]
+		if v.ErrorBars.Value != 0 {
+			value = v.ErrorBars.Value
+		}
+		errBars.Val = &attrValFloat{Val: float64Ptr(value)}
+	}
+	return errBars
+}
+
 // drawChartSeriesCat provides a function to draw the c:cat element by given
 // chart series and format sets.
 func (f *File) drawChartSeriesCat(v formatChartSeries, formatSet *formatChart) *cCat {
@@ -925,7 +1015,7 @@ func (f *File) drawCharSeriesBubble3D(formatSet *formatChart) *attrValBool {
 // drawChartDLbls provides a function to draw the c:dLbls element by given
 // format sets.
 func (f *File) drawChartDLbls(formatSet *formatChart) *cDLbls {
-	return &cDLbls{
+	dLbls := &cDLbls{
 		ShowLegendKey:   &attrValBool{Val: boolPtr(formatSet.Legend.ShowLegendKey)},
 		ShowVal:         &attrValBool{Val: boolPtr(formatSet.Plotarea.ShowVal)},
 		ShowCatName:     &attrValBool{Val: boolPtr(formatSet.Plotarea.ShowCatName)},
@@ -934,17 +1024,39 @@ func (f *File) drawChartDLbls(formatSet *formatChart) *cDLbls {
 		ShowPercent:     &attrValBool{Val: boolPtr(formatSet.Plotarea.ShowPercent)},
 		ShowLeaderLines: &attrValBool{Val: boolPtr(formatSet.Plotarea.ShowLeaderLines)},
 	}
+	f.drawChartDLblsFormat(dLbls, formatSet.Plotarea.LabelPosition, formatSet.Plotarea.LabelNumFormat)
+	return dLbls
+}
+
+// drawChartDLblsFormat provides a function to set the position and number
+// format of the data labels by given c:dLbls element, label position and
+// number format code.
+func (f *File) drawChartDLblsFormat(dLbls *cDLbls, position, numFmt string) {
+	if pos, ok := chartDataLabelsPosition[position]; ok {
+		dLbls.DLblPos = &attrValString{Val: stringPtr(pos)}
+	}
+	if numFmt != "" {
+		dLbls.NumFmt = &cNumFmt{FormatCode: numFmt}
+	}
 }
 
 // drawChartSeriesDLbls provides a function to draw the c:dLbls element by
-// given format sets.
-func (f *File) drawChartSeriesDLbls(formatSet *formatChart) *cDLbls {
+// given chart series and format sets.
+func (f *File) drawChartSeriesDLbls(v formatChartSeries, formatSet *formatChart) *cDLbls {
 	dLbls := f.drawChartDLbls(formatSet)
-	chartSeriesDLbls := map[string]*cDLbls{
-		Scatter: nil, Surface3D: nil, WireframeSurface3D: nil, Contour: nil, WireframeContour: nil, Bubble: nil, Bubble3D: nil}
-	if _, ok := chartSeriesDLbls[formatSet.Type]; ok {
+	// The scatter and bubble charts only draw the data labels of the series
+	// which has its own data labels settings.
+	chartSeriesDLbls := map[string]bool{
+		Scatter: true, Surface3D: false, WireframeSurface3D: false, Contour: false, WireframeContour: false, Bubble: true, Bubble3D: true}
+	if custom, ok := chartSeriesDLbls[formatSet.Type]; ok && (!custom || v.DataLabels == nil) {
 		return nil
 	}
+	if v.DataLabels != nil {
+		dLbls.ShowVal = &attrValBool{Val: boolPtr(v.DataLabels.ShowVal)}
+		dLbls.ShowCatName = &attrValBool{Val: boolPtr(v.DataLabels.ShowCatName)}
+		dLbls.ShowSerName = &attrValBool{Val: boolPtr(v.DataLabels.ShowSerName)}
+		f.drawChartDLblsFormat(dLbls, v.DataLabels.Position, v.DataLabels.NumFormat)
+	}
 	return dLbls
 }
 
@@ -994,6 +1106,8 @@ func (f *File) drawPlotAreaCatAx(formatSet *formatChart) []*cAxs {
 	if formatSet.XAxis.TickLabelSkip != 0 {
 		axs[0].TickLblSkip = &attrValInt{Val: intPtr(formatSet.XAxis.TickLabelSkip)}
 	}
+	f.drawPlotAreaTickMarks(axs[0], &formatSet.XAxis)
+	axs[0].Title = f.drawPlotAreaTitle(formatSet.XAxis.Name, 0)
 	return axs
 }
 
@@ -1048,9 +1162,57 @@ func (f *File) drawPlotAreaValAx(formatSet *formatChart) []*cAxs {
 	if formatSet.YAxis.MajorUnit != 0 {
 		axs[0].MajorUnit = &attrValFloat{Val: float64Ptr(formatSet.YAxis.MajorUnit)}
 	}
+	if formatSet.YAxis.MinorUnit != 0 {
+		axs[0].MinorUnit = &attrValFloat{Val: float64Ptr(formatSet.YAxis.MinorUnit)}
+	}
+	f.drawPlotAreaTickMarks(axs[0], &formatSet.YAxis)
+	if formatSet.YAxis.NumFormat != "" {
+		axs[0].NumFmt = &cNumFmt{FormatCode: formatSet.YAxis.NumFormat}
+	}
+	axs[0].Title = f.drawPlotAreaTitle(formatSet.YAxis.Name, -5400000)
 	return axs
 }
 
+// drawPlotAreaSecondaryAxis provides a function to move the chart groups in
+// the given plot area onto the secondary axes. The secondary category axis
+// shares the categories of the primary one and will be hidden, the secondary
+// value axis will be placed on the right side of the plot area. The secondary
+// axes will be skipped if they already exist in the chart plot area.
+func (f *File) drawPlotAreaSecondaryAxis(chartPlotArea, plotArea *cPlotArea) {
+	fields := reflect.ValueOf(plotArea).Elem()
+	for i := 0; i < fields.NumField(); i++ {
+		if charts, ok := fields.Field(i).Interface().([]*cCharts); ok {
+			for _, c := range charts {
+				if c.AxID != nil {
+					c.AxID = []*attrValInt{
+						{Val: intPtr(754001153)},
+						{Val: intPtr(753999905)},
+					}
+				}
+			}
+		}
+	}
+	for _, valAx := range chartPlotArea.ValAx {
+		if *valAx.AxID.Val == 753999905 {
+			return
+		}
+	}
+	for _, catAx := range plotArea.CatAx {
+		catAx.AxID = &attrValInt{Val: intPtr(754001153)}
+		catAx.Delete = &attrValBool{Val: boolPtr(true)}
+		catAx.MajorGridlines, catAx.MinorGridlines = nil, nil
+		catAx.CrossAx = &attrValInt{Val: intPtr(753999905)}
+	}
+	for _, valAx := range plotArea.ValAx {
+		valAx.AxID = &attrValInt{Val: intPtr(753999905)}
+		valAx.AxPos = &attrValString{Val: stringPtr("r")}
+		valAx.CrossAx = &attrValInt{Val: intPtr(754001153)}
+		valAx.Crosses = &attrValString{Val: stringPtr("max")}
+	}
+	chartPlotArea.CatAx = append(chartPlotArea.CatAx, plotArea.CatAx...)
+	chartPlotArea.ValAx = append(chartPlotArea.ValAx, plotArea.ValAx...)
+}
+
 // drawPlotAreaSerAx provides a function to draw the c:serAx element.
 func (f *File) drawPlotAreaSerAx(formatSet *formatChart) []*cAxs {
 	min := &attrValFloat{Val: float64Ptr(formatSet.YAxis.Minimum)}
@@ -1079,6 +1241,44 @@ func (f *File) drawPlotAreaSerAx(formatSet *formatChart) []*cAxs {
 	}
 }
 
+// drawPlotAreaTickMarks provides a function to set the tick marks and tick
+// labels position of the axis by given axis format settings.
+func (f *File) drawPlotAreaTickMarks(axs *cAxs, axis *formatChartAxis) {
+	if axis.MajorTickMark != "" {
+		axs.MajorTickMark = &attrValString{Val: stringPtr(axis.MajorTickMark)}
+	}
+	if axis.MinorTickMark != "" {
+		axs.MinorTickMark = &attrValString{Val: stringPtr(axis.MinorTickMark)}
+	}
+	if axis.TickLabelPosition != "" {
+		axs.TickLblPos = &attrValString{Val: stringPtr(axis.TickLabelPosition)}
+	}
+}
+
+// drawPlotAreaTitle provides a function to draw the c:title element of the
+// axis by given title name and text rotation angle.
+func (f *File) drawPlotAreaTitle(name string, rot int) *cTitle {
+	if name == "" {
+		return nil
+	}
+	return &cTitle{
+		Tx: cTx{
+			Rich: &cRich{
+				BodyPr: aBodyPr{Rot: rot, Vert: "horz"},
+				P: aP{
+					PPr: &aPPr{DefRPr: aRPr{Sz: 1000, B: true}},
+					R: &aR{
+						RPr: aRPr{Lang: "en-US", AltLang: "en-US"},
+						T:   name,
+					},
+				},
+			},
+		},
+		Overlay: &attrValBool{Val: boolPtr(false)},
+		TxPr:    *f.drawPlotAreaTxPr(),
+	}
+}
+
 // drawPlotAreaSpPr provides a function to draw the c:spPr element.
 func (f *File) drawPlotAreaSpPr() *cSpPr {
 	return &cSpPr{
@@ -1280,44 +1480,40 @@ func (f *File) addSheetDrawingChart(drawingXML string, rID int, formatSet *forma
 	f.Drawings.Store(drawingXML, content)
 }
 
-// deleteDrawing provides a function to delete chart graphic frame by given by
-// given coordinates and graphic type.
-func (f *File) deleteDrawing(col, row int, drawingXML, drawingType string) (err error) {
-	var (
-		wsDr            *xlsxWsDr
-		deTwoCellAnchor *decodeTwoCellAnchor
-	)
-	xdrCellAnchorFuncs := map[string]func(anchor *xdrCellAnchor) bool{
-		"Chart": func(anchor *xdrCellAnchor) bool { return anchor.Pic == nil },
-		"Pic":   func(anchor *xdrCellAnchor) bool { return anchor.Pic != nil },
-	}
-	decodeTwoCellAnchorFuncs := map[string]func(anchor *decodeTwoCellAnchor) bool{
-		"Chart": func(anchor *decodeTwoCellAnchor) bool { return anchor.Pic == nil },
-		"Pic":   func(anchor *decodeTwoCellAnchor) bool { return anchor.Pic != nil },
-	}
-	wsDr, _ = f.drawingParser(drawingXML)
-	for idx := 0; idx < len(wsDr.TwoCellAnchor); idx++ {
-		if err = nil; wsDr.TwoCellAnchor[idx].From != nil && xdrCellAnchorFuncs[drawingType](wsDr.TwoCellAnchor[idx]) {
-			if wsDr.TwoCellAnchor[idx].From.Col == col && wsDr.TwoCellAnchor[idx].From.Row == row {
-				wsDr.TwoCellAnchor = append(wsDr.TwoCellAnchor[:idx], wsDr.TwoCellAnchor[idx+1:]...)
-				idx--
+// deleteChartAnchors provides a function to remove the anchors of the charts
+// in the drawing part by given coordinates. This function returns the chart
+// relationship IDs of the removed charts.
+func (f *File) deleteChartAnchors(col, row int, drawingXML string) ([]string, error) {
+	var rIDs []string
+	wsDr, _ := f.drawingParser(drawingXML)
+	wsDr.Lock()
+	defer wsDr.Unlock()
+	filter := func(anchors []*xdrCellAnchor) ([]*xdrCellAnchor, error) {
+		var kept []*xdrCellAnchor
+		for _, anchor := range anchors {
+			output, _ := xml.Marshal(anchor)
+			deCellAnchor := new(decodeCellAnchor)
+			if err := f.xmlNewDecoder(strings.NewReader(string(output))).
+				Decode(deCellAnchor); err != nil && err != io.EOF {
+				return anchors, fmt.Errorf("xml decode error: %s", err)
 			}
-		}
-	}
-	for idx := 0; idx < len(wsDr.TwoCellAnchor); idx++ {
-		deTwoCellAnchor = new(decodeTwoCellAnchor)
-		if err = f.xmlNewDecoder(strings.NewReader("<decodeTwoCellAnchor>" + wsDr.TwoCellAnchor[idx].GraphicFrame + "</decodeTwoCellAnchor>")).
-			Decode(deTwoCellAnchor); err != nil && err != io.EOF {
-			err = fmt.Errorf("xml decode error: %s", err)
-			return
-		}
-		if err = nil; deTwoCellAnchor.From != nil && decodeTwoCellAnchorFuncs[drawingType](deTwoCellAnchor) {
-			if deTwoCellAnchor.From.Col == col && deTwoCellAnchor.From.Row == row {
-				wsDr.TwoCellAnchor = append(wsDr.TwoCellAnchor[:idx], wsDr.TwoCellAnchor[idx+1:]...)
-				idx--
+			if deCellAnchor.From != nil && deCellAnchor.From.Col == col && deCellAnchor.From.Row == row &&
+				deCellAnchor.GraphicFrame != nil && deCellAnchor.GraphicFrame.Graphic.GraphicData.Chart != nil {
+				rIDs = append(rIDs, deCellAnchor.GraphicFrame.Graphic.GraphicData.Chart.RID)
+				continue
 			}
+			kept = append(kept, anchor)
 		}
+		return kept, nil
 	}
-	f.Drawings.Store(drawingXML, wsDr)
-	return err
+	oneCellAnchor, err := filter(wsDr.OneCellAnchor)
+	if err != nil {
+		return nil, err
+	}
+	twoCellAnchor, err := filter(wsDr.TwoCellAnchor)
+	if err != nil {
+		return nil, err
+	}
+	wsDr.OneCellAnchor, wsDr.TwoCellAnchor = oneCellAnchor, twoCellAnchor
+	return rIDs, nil
 }
@@ -14,6 +14,7 @@ package excelize
 import (
 	"errors"
 	"fmt"
+	"strings"
 )
 
 // newInvalidColumnNameError defined the error message on receiving the invalid column name.
@@ -46,11 +47,101 @@ func newUnzipSizeLimitError(unzipSizeLimit int64) error {
 	return fmt.Errorf("unzip size exceeds the %d bytes limit", unzipSizeLimit)
 }
 
+// newUnzipXMLSizeLimitError defined the error message on unzip size of the
+// XML part exceeds the limit.
+func newUnzipXMLSizeLimitError(partName string, unzipXMLSizeLimit int64) error {
+	return fmt.Errorf("unzip size of %s exceeds the %d bytes limit", partName, unzipXMLSizeLimit)
+}
+
+// newUnsupportedLocaleError defined the error message on receiving the
+// unsupported language tag of the locale number format.
+func newUnsupportedLocaleError(lang string) error {
+	return fmt.Errorf("unsupported locale %s", lang)
+}
+
+// newInvalidNumFmtLocaleError defined the error message on receiving the
+// invalid locale code in the custom number format.
+func newInvalidNumFmtLocaleError(code string) error {
+	return fmt.Errorf("invalid locale code %s in the number format", code)
+}
+
+// newInvalidStyleFieldError defined the error message on receiving the
+// unknown or out of range value of the style settings.
+func newInvalidStyleFieldError(field string, value interface{}) error {
+	return fmt.Errorf("invalid style %s %v", field, value)
+}
+
+// newStyleIndexOutOfRangeError defined the error message on receiving the out
+// of range index of the style or its components.
+func newStyleIndexOutOfRangeError(part string, ID int) error {
+	return fmt.Errorf("%s ID %d is out of range", part, ID)
+}
+
 // newInvalidStyleID defined the error message on receiving the invalid style ID.
 func newInvalidStyleID(styleID int) error {
 	return fmt.Errorf("invalid style ID %d, negative values are not supported", styleID)
 }
 
+// newNoPictureError defined the error message on the cell which has no
+// anchored picture.
+func newNoPictureError(cell string) error {
+	return fmt.Errorf("no picture at cell %s", cell)
+}
+
+// newNoCommentError defined the error message on the cell which has no
+// comment.
+func newNoCommentError(cell string) error {
+	return fmt.Errorf("no comment at cell %s", cell)
+}
+
+// newCommentExistsError defined the error message on the cell which already
+// has a comment.
+func newCommentExistsError(cell string) error {
+	return fmt.Errorf("comment already exists at cell %s", cell)
+}
+
+// newNoPivotTableError defined the error message on receiving the pivot
+// table name which doesn't exist.
+func newNoPivotTableError(name string) error {
+	return fmt.Errorf("pivot table %s does not exist", name)
+}
+
+// newProtectedRangeExistsError defined the error message on receiving the
+// protected range name which already exists in the worksheet.
+func newProtectedRangeExistsError(name string) error {
+	return fmt.Errorf("protected range %s already exists", name)
+}
+
+// newNoTableError defined the error message on receiving the table name
+// which doesn't exist.
+func newNoTableError(name string) error {
+	return fmt.Errorf("table %s does not exist", name)
+}
+
+// newNoTableColumnError defined the error message on receiving the table
+// column name which doesn't exist in the table.
+func newNoTableColumnError(name, table string) error {
+	return fmt.Errorf("column %s does not exist in table %s", name, table)
+}
+
+// newCircularReferenceError defined the error message on the circular
+// reference was found in the formula cells.
+func newCircularReferenceError(cells []string) error {
+	return fmt.Errorf("circular reference found in cells %s", strings.Join(cells, ", "))
+}
+
+// newMergeCellOverlapError defined the error message on the merged cell range
+// overlaps with an existing merged cell.
+func newMergeCellOverlapError(ref, existing string) error {
+	return fmt.Errorf("merged cell %s overlaps with existing merged cell %s", ref, existing)
+}
+
+// newViewIndexError defined the error message on receiving the sheet view
+// index which is out of range.
+func newViewIndexError(sheet string, viewIndex, views int) error {
+	return fmt.Errorf("view index %d out of range, sheet %s has %d views", viewIndex, sheet, views)
+}
+
 // newFieldLengthError defined the error message on receiving the field length overflow.
 func newFieldLengthError(name string) error {
 	return fmt.Errorf("field %s must be less or equal than 255 characters", name)
@@ -60,6 +151,9 @@ var (
 	// ErrStreamSetColWidth defined the error message on set column width in
 	// stream writing mode.
 	ErrStreamSetColWidth = errors.New("must call the SetColWidth function before the SetRow function")
+	// ErrStreamSetPanes defined the error message on set panes in stream
+	// writing mode.
+	ErrStreamSetPanes = errors.New("must call the SetPanes function before the SetRow function")
 	// ErrColumnNumber defined the error message on receive an invalid column
 	// number.
 	ErrColumnNumber = errors.New("column number exceeds maximum limit")
@@ -92,6 +186,15 @@ var (
 	// ErrImgExt defined the error message on receive an unsupported image
 	// extension.
 	ErrImgExt = errors.New("unsupported image extension")
+	// ErrPictureCrop defined the error message on receive an invalid crop
+	// percentage of the picture.
+	ErrPictureCrop = errors.New("the crop percentage of the picture must be between 0 and 100, and the total of the opposite edges must be less than 100")
+	// ErrImgEmpty defined the error message on receive an empty picture
+	// content.
+	ErrImgEmpty = errors.New("the picture content can not be empty")
+	// ErrCellNotEmpty defined the error message on the target cell already
+	// holds a value.
+	ErrCellNotEmpty = errors.New("the cell already holds a value")
 	// ErrMaxFileNameLength defined the error message on receive the file name
 	// length overflow.
 	ErrMaxFileNameLength = errors.New("file name length exceeds maximum limit")
@@ -139,4 +242,10 @@ var (
 	// ErrOptionsUnzipSizeLimit defined the error message for receiving
 	// invalid UnzipSizeLimit and WorksheetUnzipMemLimit.
 	ErrOptionsUnzipSizeLimit = errors.New("the value of UnzipSizeLimit should be greater than or equal to WorksheetUnzipMemLimit")
+	// ErrOptionsUnzipXMLSizeLimit defined the error message for receiving
+	// invalid UnzipSizeLimit and UnzipXMLSizeLimit.
+	ErrOptionsUnzipXMLSizeLimit = errors.New("the value of UnzipSizeLimit should be greater than or equal to UnzipXMLSizeLimit")
+	// ErrUnsupportedHashAlgorithm defined the error message on unsupported
+	// hash algorithm of the protection.
+	ErrUnsupportedHashAlgorithm = errors.New("unsupported hash algorithm")
 )
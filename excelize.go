@@ -61,7 +61,9 @@ type charsetTranscoderFn func(charset string, input io.Reader) (rdr io.Reader, e
 
 // Options define the options for open and reading spreadsheet.
 //
-// Password specifies the password of the spreadsheet in plain text.
+// Password specifies the password of the spreadsheet in plain text. The
+// spreadsheet will be encrypted with the password by the ECMA-376 agile
+// encryption on save if this option is specified on calling SaveAs or Write.
 //
 // RawCellValue specifies if apply the number format for the cell value or get
 // the raw value.
@@ -74,12 +76,18 @@ type charsetTranscoderFn func(charset string, input io.Reader) (rdr io.Reader, e
 // bytes, worksheet XML will be extracted to system temporary directory when
 // the file size is over this value, this value should be less than or equal
 // to UnzipSizeLimit, the default value is 16MB.
+//
+// UnzipXMLSizeLimit specifies the unzip size limit in bytes of each XML part
+// on open the spreadsheet, an error will be returned if the unzip size of any
+// XML part exceeds this value, this value should be less than or equal to
+// UnzipSizeLimit, the default value is equal to UnzipSizeLimit.
 type Options struct {
 	DisableSharedStringsTable bool
 	Password                  string
 	RawCellValue              bool
 	UnzipSizeLimit            int64
 	WorksheetUnzipMemLimit    int64
+	UnzipXMLSizeLimit         int64
 }
 
 // OpenFile take the name of an spreadsheet file and returns a populated
@@ -91,10 +99,9 @@ type Options struct {
 //        return
 //    }
 //
-// Note that the excelize just support decrypt and not support encrypt
-// currently, the spreadsheet saved by Save and SaveAs will be without
-// password unprotected. Close the file by Close after opening the
-// spreadsheet.
+// Note that the spreadsheet saved by Save and SaveAs will be encrypted with
+// the password given by the Password option. Close the file by Close after
+// opening the spreadsheet.
 func OpenFile(filename string, opt ...Options) (*File, error) {
 	file, err := os.Open(filepath.Clean(filename))
 	if err != nil {
@@ -112,7 +119,7 @@ func OpenFile(filename string, opt ...Options) (*File, error) {
 // newFile is object builder
 func newFile() *File {
 	return &File{
-		options:          &Options{UnzipSizeLimit: UnzipSizeLimit, WorksheetUnzipMemLimit: StreamChunkSize},
+		options:          &Options{UnzipSizeLimit: UnzipSizeLimit, WorksheetUnzipMemLimit: StreamChunkSize, UnzipXMLSizeLimit: UnzipSizeLimit},
 		xmlAttr:          make(map[string][]xml.Attr),
 		checked:          make(map[string]bool),
 		sheetMap:         make(map[string]string),
@@ -152,6 +159,12 @@ func OpenReader(r io.Reader, opt ...Options) (*File, error) {
 	if f.options.WorksheetUnzipMemLimit > f.options.UnzipSizeLimit {
 		return nil, ErrOptionsUnzipSizeLimit
 	}
+	if f.options.UnzipXMLSizeLimit == 0 {
+		f.options.UnzipXMLSizeLimit = f.options.UnzipSizeLimit
+	}
+	if f.options.UnzipXMLSizeLimit > f.options.UnzipSizeLimit {
+		return nil, ErrOptionsUnzipXMLSizeLimit
+	}
 	if bytes.Contains(b, oleIdentifier) {
 		b, err = Decrypt(b, f.options)
 		if err != nil {
@@ -193,6 +193,18 @@ func TestSaveAsWrongPath(t *testing.T) {
 	assert.NoError(t, f.Close())
 }
 
+func TestSetCellValueAfterSaveAs(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetCellValueAfterSaveAs.xlsx")))
+	assert.NotNil(t, f.options)
+	// Test set cell value after save the workbook without options.
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "Hello"))
+	val, err := f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello", val)
+	assert.NoError(t, f.Close())
+}
+
 func TestCharsetTranscoder(t *testing.T) {
 	f := NewFile()
 	f.CharsetTranscoder(*new(charsetTranscoderFn))
@@ -208,8 +220,20 @@ func TestOpenReader(t *testing.T) {
 	_, err = OpenFile(filepath.Join("test", "Book1.xlsx"), Options{UnzipSizeLimit: 100})
 	assert.EqualError(t, err, newUnzipSizeLimitError(100).Error())
 
+	// Test open spreadsheet with unzip XML part size limit.
+	_, err = OpenFile(filepath.Join("test", "Book1.xlsx"), Options{UnzipXMLSizeLimit: 100})
+	assert.EqualError(t, err, newUnzipXMLSizeLimitError("[Content_Types].xml", 100).Error())
+	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"), Options{UnzipXMLSizeLimit: UnzipSizeLimit})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(UnzipSizeLimit), f.options.UnzipXMLSizeLimit)
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(filepath.Join("test", "Book1.xlsx"), Options{UnzipSizeLimit: UnzipSizeLimit - 1})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(UnzipSizeLimit-1), f.options.UnzipXMLSizeLimit)
+	assert.NoError(t, f.Close())
+
 	// Test open password protected spreadsheet created by Microsoft Office Excel 2010.
-	f, err := OpenFile(filepath.Join("test", "encryptSHA1.xlsx"), Options{Password: "password"})
+	f, err = OpenFile(filepath.Join("test", "encryptSHA1.xlsx"), Options{Password: "password"})
 	assert.NoError(t, err)
 	val, err := f.GetCellValue("Sheet1", "A1")
 	assert.NoError(t, err)
@@ -227,6 +251,8 @@ func TestOpenReader(t *testing.T) {
 	// Test open spreadsheet with invalid optioins.
 	_, err = OpenReader(bytes.NewReader(oleIdentifier), Options{UnzipSizeLimit: 1, WorksheetUnzipMemLimit: 2})
 	assert.EqualError(t, err, ErrOptionsUnzipSizeLimit.Error())
+	_, err = OpenReader(bytes.NewReader(oleIdentifier), Options{UnzipSizeLimit: 1, UnzipXMLSizeLimit: 2})
+	assert.EqualError(t, err, ErrOptionsUnzipXMLSizeLimit.Error())
 
 	// Test unexpected EOF.
 	var b bytes.Buffer
@@ -324,7 +350,7 @@ func TestNewFile(t *testing.T) {
 func TestAddDrawingVML(t *testing.T) {
 	// Test addDrawingVML with illegal cell coordinates.
 	f := NewFile()
-	assert.EqualError(t, f.addDrawingVML(0, "", "*", 0, 0), `cannot convert cell "*" to coordinates: invalid cell name "*"`)
+	assert.EqualError(t, f.addDrawingVML(0, "", "*", 0, 0, &formatComment{}), `cannot convert cell "*" to coordinates: invalid cell name "*"`)
 }
 
 func TestSetCellHyperLink(t *testing.T) {
@@ -371,6 +397,63 @@ func TestSetCellHyperLink(t *testing.T) {
 	assert.EqualError(t, err, `cannot convert cell "A" to coordinates: invalid cell name "A"`)
 }
 
+func TestSetRangeHyperLink(t *testing.T) {
+	f := NewFile()
+	display, tooltip := "Display value", "Hover text"
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A2", "Sheet1!D8", "Location"))
+	assert.NoError(t, f.MergeCell("Sheet1", "B3", "C3"))
+	assert.NoError(t, f.SetRangeHyperLink("Sheet1", "C3:A2", "https://github.com/xuri/excelize", "External", HyperlinkOpts{
+		Display: &display,
+		Tooltip: &tooltip,
+	}))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	// Test the existing hyperlink replaced and the merged cells linked once.
+	if assert.Len(t, ws.Hyperlinks.Hyperlink, 5) {
+		for i, ref := range []string{"A2", "B2", "C2", "A3", "B3"} {
+			assert.Equal(t, ref, ws.Hyperlinks.Hyperlink[i].Ref)
+			assert.Equal(t, display, ws.Hyperlinks.Hyperlink[i].Display)
+			assert.Equal(t, tooltip, ws.Hyperlinks.Hyperlink[i].Tooltip)
+		}
+	}
+	link, target, err := f.GetCellHyperLink("Sheet1", "C3")
+	assert.NoError(t, err)
+	assert.True(t, link)
+	assert.Equal(t, "https://github.com/xuri/excelize", target)
+	// Test set location hyperlink on a single cell.
+	assert.NoError(t, f.SetRangeHyperLink("Sheet1", "$D$4", "Sheet1!A1", "Location"))
+	link, target, err = f.GetCellHyperLink("Sheet1", "D4")
+	assert.NoError(t, err)
+	assert.True(t, link)
+	assert.Equal(t, "Sheet1!A1", target)
+	// Test the relationship of the replaced external hyperlink is kept until
+	// it is not referenced by any hyperlinks.
+	sheetRels := "xl/worksheets/_rels/sheet1.xml.rels"
+	assert.NoError(t, f.SetRangeHyperLink("Sheet1", "A2:B2", "Sheet1!A1", "Location"))
+	assert.Len(t, f.relsReader(sheetRels).Relationships, 1)
+	assert.NoError(t, f.SetRangeHyperLink("Sheet1", "A2:C3", "https://github.com/xuri", "External"))
+	if assert.Len(t, f.relsReader(sheetRels).Relationships, 1) {
+		assert.Equal(t, "https://github.com/xuri", f.relsReader(sheetRels).Relationships[0].Target)
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetRangeHyperLink.xlsx")))
+
+	assert.EqualError(t, f.SetRangeHyperLink("Sheet1", "A1:B2", "Sheet1!D8", ""), `invalid link type ""`)
+	assert.EqualError(t, f.SetRangeHyperLink("Sheet1", "A1:B2:C3", "Sheet1!D8", "Location"), ErrParameterInvalid.Error())
+	assert.EqualError(t, f.SetRangeHyperLink("Sheet1", "A:B2", "Sheet1!D8", "Location"), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+	assert.EqualError(t, f.SetRangeHyperLink("SheetN", "A1:B2", "Sheet1!D8", "Location"), "sheet SheetN is not exist")
+	// Test set hyperlinks over the maximum limit in a worksheet.
+	ws, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.Hyperlinks = &xlsxHyperlinks{Hyperlink: make([]xlsxHyperlink, TotalSheetHyperlinks-1)}
+	assert.EqualError(t, f.SetRangeHyperLink("Sheet1", "A1:A2", "Sheet1!D8", "Location"), ErrTotalSheetHyperlinks.Error())
+	assert.Len(t, ws.Hyperlinks.Hyperlink, TotalSheetHyperlinks-1)
+	// Test set hyperlinks with invalid merged cell reference.
+	ws.MergeCells = &xlsxMergeCells{Cells: []*xlsxMergeCell{{Ref: "A:A"}}}
+	ws.Hyperlinks = nil
+	assert.EqualError(t, f.SetRangeHyperLink("Sheet1", "A1", "Sheet1!D8", "Location"), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+	assert.Nil(t, ws.Hyperlinks)
+}
+
 func TestGetCellHyperLink(t *testing.T) {
 	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
 	if !assert.NoError(t, err) {
@@ -413,6 +496,85 @@ func TestGetCellHyperLink(t *testing.T) {
 	assert.Equal(t, target, "")
 }
 
+func TestGetCellHyperLinkDetail(t *testing.T) {
+	f := NewFile()
+	display, tooltip := "Display value", "Hover text"
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A1", "https://github.com/xuri/excelize", "External", HyperlinkOpts{
+		Tooltip: &tooltip,
+	}))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A2", "Sheet1!D8", "Location", HyperlinkOpts{
+		Display: &display,
+		Tooltip: &tooltip,
+	}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGetCellHyperLinkDetail.xlsx")))
+
+	f, err := OpenFile(filepath.Join("test", "TestGetCellHyperLinkDetail.xlsx"))
+	assert.NoError(t, err)
+	linkType, target, opts, err := f.GetCellHyperLinkDetail("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "External", linkType)
+	assert.Equal(t, "https://github.com/xuri/excelize", target)
+	assert.Equal(t, HyperlinkOpts{Tooltip: &tooltip}, opts)
+	linkType, target, opts, err = f.GetCellHyperLinkDetail("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.Equal(t, "Location", linkType)
+	assert.Equal(t, "Sheet1!D8", target)
+	assert.Equal(t, HyperlinkOpts{Display: &display, Tooltip: &tooltip}, opts)
+	// Test get hyperlink detail on the cell without hyperlink.
+	linkType, target, opts, err = f.GetCellHyperLinkDetail("Sheet1", "A3")
+	assert.NoError(t, err)
+	assert.Equal(t, "", linkType)
+	assert.Equal(t, "", target)
+	assert.Equal(t, HyperlinkOpts{}, opts)
+	// Test get hyperlink detail with illegal cell coordinates.
+	_, _, _, err = f.GetCellHyperLinkDetail("Sheet1", "")
+	assert.EqualError(t, err, `invalid cell name ""`)
+	// Test get hyperlink detail on not exists worksheet.
+	_, _, _, err = f.GetCellHyperLinkDetail("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+	assert.NoError(t, f.Close())
+}
+
+func TestUnsetCellHyperLink(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "Excelize"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A1", "https://github.com/xuri/excelize", "External"))
+	assert.NoError(t, f.SetRangeHyperLink("Sheet1", "B1:B2", "https://github.com/xuri", "External"))
+	assert.NoError(t, f.UnsetCellHyperLink("Sheet1", "A1"))
+	link, _, err := f.GetCellHyperLink("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.False(t, link)
+	val, err := f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Excelize", val)
+	rels := f.relsReader("xl/worksheets/_rels/sheet1.xml.rels")
+	assert.Len(t, rels.Relationships, 1)
+	// Test the relationship referenced by other hyperlinks should be kept.
+	assert.NoError(t, f.UnsetCellHyperLink("Sheet1", "B1"))
+	assert.Len(t, rels.Relationships, 1)
+	assert.NoError(t, f.UnsetCellHyperLink("Sheet1", "B2"))
+	assert.Len(t, rels.Relationships, 0)
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Nil(t, ws.Hyperlinks)
+	// Test unset hyperlink on the cell without hyperlink.
+	assert.NoError(t, f.UnsetCellHyperLink("Sheet1", "C1"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "D1", "Sheet1!A1", "Location"))
+	assert.NoError(t, f.UnsetCellHyperLink("Sheet1", "C1"))
+	assert.Len(t, ws.Hyperlinks.Hyperlink, 1)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestUnsetCellHyperLink.xlsx")))
+
+	// Test unset hyperlink with illegal cell coordinates.
+	assert.EqualError(t, f.UnsetCellHyperLink("Sheet1", ""), `invalid cell name ""`)
+	// Test unset hyperlink on not exists worksheet.
+	assert.EqualError(t, f.UnsetCellHyperLink("SheetN", "A1"), "sheet SheetN is not exist")
+	// Test unset hyperlink with invalid merged cell reference.
+	ws, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.MergeCells = &xlsxMergeCells{Cells: []*xlsxMergeCell{{Ref: "A:A"}}}
+	assert.EqualError(t, f.UnsetCellHyperLink("Sheet1", "A1"), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+}
+
 func TestSetSheetBackground(t *testing.T) {
 	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
 	if !assert.NoError(t, err) {
@@ -1162,6 +1324,153 @@ func TestProtectSheet(t *testing.T) {
 	assert.EqualError(t, f.ProtectSheet("SheetN", nil), "sheet SheetN is not exist")
 }
 
+func TestGetSheetProtection(t *testing.T) {
+	f := NewFile()
+	settings, err := f.GetSheetProtection("Sheet1")
+	assert.NoError(t, err)
+	assert.Nil(t, settings)
+	expected := &FormatSheetProtection{
+		DeleteRows:        true,
+		EditObjects:       true,
+		FormatCells:       true,
+		InsertRows:        true,
+		SelectLockedCells: true,
+		Sort:              true,
+	}
+	assert.NoError(t, f.ProtectSheet("Sheet1", &FormatSheetProtection{
+		DeleteRows:        true,
+		EditObjects:       true,
+		FormatCells:       true,
+		InsertRows:        true,
+		Password:          "password",
+		SelectLockedCells: true,
+		Sort:              true,
+	}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGetSheetProtection.xlsx")))
+
+	f, err = OpenFile(filepath.Join("test", "TestGetSheetProtection.xlsx"))
+	assert.NoError(t, err)
+	settings, err = f.GetSheetProtection("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, settings)
+	// Test get sheet protection with default attributes.
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData/><sheetProtection algorithmName="SHA-512" hashValue="hash" saltValue="salt" spinCount="100000" sheet="1" objects="1" scenarios="1" formatColumns="0"/></worksheet>`))
+	settings, err = f.GetSheetProtection("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, &FormatSheetProtection{
+		AlgorithmName:    "SHA-512",
+		AutoFilter:       true,
+		DeleteColumns:    true,
+		DeleteRows:       true,
+		EditObjects:      true,
+		EditScenarios:    true,
+		FormatCells:      true,
+		FormatRows:       true,
+		InsertColumns:    true,
+		InsertHyperlinks: true,
+		InsertRows:       true,
+		PivotTables:      true,
+		Sort:             true,
+	}, settings)
+	// Test get sheet protection after unprotect the worksheet.
+	assert.NoError(t, f.UnprotectSheet("Sheet1"))
+	settings, err = f.GetSheetProtection("Sheet1")
+	assert.NoError(t, err)
+	assert.Nil(t, settings)
+	// Test get sheet protection on not exists worksheet.
+	_, err = f.GetSheetProtection("SheetN")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+}
+
+func TestProtectSheetWithHashAlgorithm(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.ProtectSheet("Sheet1", &FormatSheetProtection{ProtectionAlgorithm: "SHA-512", Password: "password"}))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, ws.SheetProtection.Password)
+	assert.Equal(t, "SHA-512", ws.SheetProtection.AlgorithmName)
+	assert.Equal(t, protectionSpinCount, ws.SheetProtection.SpinCount)
+	hashValue, _, err := genISOPasswdHash("password", "SHA-512", ws.SheetProtection.SaltValue, protectionSpinCount)
+	assert.NoError(t, err)
+	assert.Equal(t, hashValue, ws.SheetProtection.HashValue)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestProtectSheetWithHashAlgorithm.xlsx")))
+	// Test protect worksheet with unsupported hash algorithm.
+	assert.EqualError(t, f.ProtectSheet("Sheet1", &FormatSheetProtection{ProtectionAlgorithm: "MD4", Password: "password"}), ErrUnsupportedHashAlgorithm.Error())
+	assert.Equal(t, "SHA-512", ws.SheetProtection.AlgorithmName)
+}
+
+func TestProtectWorkbook(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.ProtectWorkbook(nil))
+	wb := f.workbookReader()
+	assert.Equal(t, &xlsxWorkbookProtection{LockStructure: true}, wb.WorkbookProtection)
+	assert.NoError(t, f.ProtectWorkbook(&FormatWorkbookProtection{Password: "password", LockWindows: true}))
+	assert.Equal(t, &xlsxWorkbookProtection{WorkbookPassword: "83AF", LockWindows: true}, wb.WorkbookProtection)
+	assert.NoError(t, f.ProtectWorkbook(&FormatWorkbookProtection{ProtectionAlgorithm: "SHA-512", Password: "password", LockStructure: true}))
+	assert.Empty(t, wb.WorkbookProtection.WorkbookPassword)
+	assert.Equal(t, "SHA-512", wb.WorkbookProtection.WorkbookAlgorithmName)
+	assert.Equal(t, protectionSpinCount, wb.WorkbookProtection.WorkbookSpinCount)
+	hashValue, _, err := genISOPasswdHash("password", "SHA-512", wb.WorkbookProtection.WorkbookSaltValue, protectionSpinCount)
+	assert.NoError(t, err)
+	assert.Equal(t, hashValue, wb.WorkbookProtection.WorkbookHashValue)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestProtectWorkbook.xlsx")))
+	// Test protect workbook with unsupported hash algorithm.
+	assert.EqualError(t, f.ProtectWorkbook(&FormatWorkbookProtection{ProtectionAlgorithm: "MD4", Password: "password"}), ErrUnsupportedHashAlgorithm.Error())
+	assert.NoError(t, f.UnprotectWorkbook())
+	assert.Nil(t, wb.WorkbookProtection)
+}
+
+func TestGetWorkbookProtection(t *testing.T) {
+	f := NewFile()
+	settings, err := f.GetWorkbookProtection()
+	assert.NoError(t, err)
+	assert.Nil(t, settings)
+	assert.NoError(t, f.ProtectWorkbook(&FormatWorkbookProtection{ProtectionAlgorithm: "SHA-512", Password: "password", LockStructure: true, LockWindows: true}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGetWorkbookProtection.xlsx")))
+
+	f, err = OpenFile(filepath.Join("test", "TestGetWorkbookProtection.xlsx"))
+	assert.NoError(t, err)
+	settings, err = f.GetWorkbookProtection()
+	assert.NoError(t, err)
+	assert.Equal(t, "SHA-512", settings.AlgorithmName)
+	assert.Len(t, settings.HashValue, 88)
+	assert.Len(t, settings.SaltValue, 24)
+	assert.Equal(t, protectionSpinCount, settings.SpinCount)
+	assert.Empty(t, settings.Password)
+	assert.Empty(t, settings.WorkbookPassword)
+	assert.True(t, settings.LockStructure)
+	assert.True(t, settings.LockWindows)
+	// Test get workbook protection with the legacy password hash.
+	assert.NoError(t, f.ProtectWorkbook(&FormatWorkbookProtection{Password: "password", LockWindows: true}))
+	settings, err = f.GetWorkbookProtection()
+	assert.NoError(t, err)
+	assert.Equal(t, &FormatWorkbookProtection{WorkbookPassword: "83AF", LockWindows: true}, settings)
+	// Test get workbook protection of the workbook protected by Excel.
+	f.WorkBook = nil
+	f.Pkg.Store("xl/workbook.xml", []byte(`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><workbookProtection workbookAlgorithmName="SHA-512" workbookHashValue="2iUFk1t+c4ABXgoplExMGB5MfgenvZLqfJcuFBqC5BpG9g5MptZwUsiz6PwYGTD40ZN7TQVAfqEdUxvJ23Htqg==" workbookSaltValue="ViGAG1jpl+MqGjbjS9u8fw==" workbookSpinCount="100000" lockStructure="1" lockWindows="0"/><sheets><sheet name="Sheet1" sheetId="1" r:id="rId1" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"/></sheets></workbook>`))
+	settings, err = f.GetWorkbookProtection()
+	assert.NoError(t, err)
+	expected := &FormatWorkbookProtection{
+		AlgorithmName: "SHA-512",
+		HashValue:     "2iUFk1t+c4ABXgoplExMGB5MfgenvZLqfJcuFBqC5BpG9g5MptZwUsiz6PwYGTD40ZN7TQVAfqEdUxvJ23Htqg==",
+		SaltValue:     "ViGAG1jpl+MqGjbjS9u8fw==",
+		SpinCount:     100000,
+		LockStructure: true,
+	}
+	assert.Equal(t, expected, settings)
+	// Test preserve the workbook protection settings.
+	assert.NoError(t, f.UnprotectWorkbook())
+	assert.NoError(t, f.ProtectWorkbook(expected))
+	settings, err = f.GetWorkbookProtection()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, settings)
+	assert.NoError(t, f.UnprotectWorkbook())
+	settings, err = f.GetWorkbookProtection()
+	assert.NoError(t, err)
+	assert.Nil(t, settings)
+}
+
 func TestUnprotectSheet(t *testing.T) {
 	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
 	if !assert.NoError(t, err) {
@@ -1175,6 +1484,39 @@ func TestUnprotectSheet(t *testing.T) {
 	assert.NoError(t, f.Close())
 }
 
+func TestAddProtectedRange(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddProtectedRange("Sheet1", ProtectedRangeOptions{Name: "Range1", Range: "$A$1:$B$5", Password: "password"}))
+	assert.NoError(t, f.AddProtectedRange("Sheet1", ProtectedRangeOptions{Name: "Range2", Range: "D1:D5 F1"}))
+	assert.NoError(t, f.ProtectSheet("Sheet1", nil))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddProtectedRange.xlsx")))
+
+	f, err := OpenFile(filepath.Join("test", "TestAddProtectedRange.xlsx"))
+	assert.NoError(t, err)
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(ws.ExtLst.Ext, ExtURIProtectedRanges))
+	assert.Contains(t, ws.ExtLst.Ext, `<x14:protectedRange password="83AF" name="Range1"><xm:sqref>A1:B5</xm:sqref></x14:protectedRange>`)
+	assert.Contains(t, ws.ExtLst.Ext, `<x14:protectedRange name="Range2"><xm:sqref>D1:D5 F1</xm:sqref></x14:protectedRange>`)
+	// Test add protected range after open the workbook.
+	assert.NoError(t, f.AddProtectedRange("Sheet1", ProtectedRangeOptions{Name: "Range3", Range: "H1"}))
+	assert.Equal(t, 3, strings.Count(ws.ExtLst.Ext, "<x14:protectedRange "))
+	// Test add protected range with duplicate name.
+	assert.EqualError(t, f.AddProtectedRange("Sheet1", ProtectedRangeOptions{Name: "range1", Range: "H1"}), "protected range range1 already exists")
+	// Test add protected range with invalid options.
+	assert.EqualError(t, f.AddProtectedRange("Sheet1", ProtectedRangeOptions{Range: "A1"}), ErrParameterRequired.Error())
+	assert.EqualError(t, f.AddProtectedRange("Sheet1", ProtectedRangeOptions{Name: "Range4"}), ErrParameterRequired.Error())
+	assert.EqualError(t, f.AddProtectedRange("Sheet1", ProtectedRangeOptions{Name: strings.Repeat("c", MaxFieldLength+1), Range: "A1"}), ErrParameterInvalid.Error())
+	assert.EqualError(t, f.AddProtectedRange("Sheet1", ProtectedRangeOptions{Name: "Range4", Range: "A1:B"}), `cannot convert cell "B" to coordinates: invalid cell name "B"`)
+	// Test add protected range on not exists worksheet.
+	assert.EqualError(t, f.AddProtectedRange("SheetN", ProtectedRangeOptions{Name: "Range4", Range: "A1"}), "sheet SheetN is not exist")
+	// Test add protected range with unsupported charset extension list.
+	ws.ExtLst.Ext = string(MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AddProtectedRange("Sheet1", ProtectedRangeOptions{Name: "Range4", Range: "A1"}), "XML syntax error on line 1: invalid UTF-8")
+	ws.ExtLst.Ext = `<ext uri="` + ExtURIProtectedRanges + `">` + string(MacintoshCyrillicCharset) + `</ext>`
+	assert.Error(t, f.AddProtectedRange("Sheet1", ProtectedRangeOptions{Name: "Range4", Range: "A1"}))
+}
+
 func TestSetDefaultTimeStyle(t *testing.T) {
 	f := NewFile()
 	// Test set default time style on not exists worksheet.
@@ -75,7 +75,7 @@ func (f *File) SaveAs(name string, opt ...Options) error {
 		return err
 	}
 	defer file.Close()
-	f.options = nil
+	f.options = &Options{}
 	for i := range opt {
 		f.options = &opt[i]
 	}
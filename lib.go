@@ -21,9 +21,11 @@ import (
 	"io"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
+	"unicode"
 )
 
 // ReadZipReader extract spreadsheet with given options.
@@ -45,6 +47,9 @@ func (f *File) ReadZipReader(r *zip.Reader) (map[string][]byte, int, error) {
 			return fileList, worksheets, newUnzipSizeLimitError(f.options.UnzipSizeLimit)
 		}
 		fileName := strings.Replace(v.Name, "\\", "/", -1)
+		if ext := strings.ToLower(filepath.Ext(fileName)); (ext == ".xml" || ext == ".rels") && fileSize > f.options.UnzipXMLSizeLimit {
+			return fileList, worksheets, newUnzipXMLSizeLimitError(fileName, f.options.UnzipXMLSizeLimit)
+		}
 		if partName, ok := docPart[strings.ToLower(fileName)]; ok {
 			fileName = partName
 		}
@@ -118,6 +123,15 @@ func (f *File) readTemp(name string) (file *os.File, err error) {
 	return
 }
 
+// newGUID provides a function to generate a random version 4 GUID in the
+// registry format, for example: {8D4E4C5A-0F8B-4D6A-9A1E-2B3C4D5E6F70}.
+func newGUID() string {
+	b, _ := randomBytes(16)
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("{%X-%X-%X-%X-%X}", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
 // saveFileList provides a function to update given file content in file list
 // of spreadsheet.
 func (f *File) saveFileList(name string, content []byte) {
@@ -393,6 +407,21 @@ func inFloat64Slice(a []float64, x float64) int {
 	return -1
 }
 
+// quoteSheetName provides a function to quote the worksheet name in the
+// reference if it contains special characters or looks like a cell reference
+// in the A1 or R1C1 style, such as Sheet1, 'Sheet 1', 'A1' and 'R1C1'.
+func quoteSheetName(sheet string) string {
+	if cellRefSheetNameExp.MatchString(sheet) {
+		return "'" + sheet + "'"
+	}
+	for i, r := range sheet {
+		if !unicode.IsLetter(r) && r != '_' && (i == 0 || !unicode.IsDigit(r) && r != '.') {
+			return "'" + strings.Replace(sheet, "'", "''", -1) + "'"
+		}
+	}
+	return sheet
+}
+
 // boolPtr returns a pointer to a bool with the given value.
 func boolPtr(b bool) *bool { return &b }
 
@@ -690,6 +719,9 @@ func isNumeric(s string) (bool, int) {
 var (
 	bstrExp       = regexp.MustCompile(`_x[a-zA-Z\d]{4}_`)
 	bstrEscapeExp = regexp.MustCompile(`x[a-zA-Z\d]{4}_`)
+	// cellRefSheetNameExp matches the worksheet names which look like the
+	// cell references in the A1 or R1C1 style.
+	cellRefSheetNameExp = regexp.MustCompile(`^(?i)([a-z]{1,3}\d+|r\d*(c\d*)?|c\d*)$`)
 )
 
 // bstrUnmarshal parses the binary basic string, this will trim escaped string
@@ -340,6 +340,26 @@ func TestReadBytes(t *testing.T) {
 	assert.Equal(t, []byte{}, f.readBytes(sheet))
 }
 
+func TestQuoteSheetName(t *testing.T) {
+	for sheet, expected := range map[string]string{
+		"Sheet1":  "Sheet1",
+		"Sheet 1": "'Sheet 1'",
+		"Bob's":   "'Bob''s'",
+		"2021":    "'2021'",
+		"A1":      "'A1'",
+		"xfd10":   "'xfd10'",
+		"R1C1":    "'R1C1'",
+		"rc":      "'rc'",
+		"R":       "'R'",
+		"C12":     "'C12'",
+		"ABCD1":   "ABCD1",
+		"Red":     "Red",
+		"R1C1D":   "R1C1D",
+	} {
+		assert.Equal(t, expected, quoteSheetName(sheet), sheet)
+	}
+}
+
 func TestUnzipToTemp(t *testing.T) {
 	os.Setenv("TMPDIR", "test")
 	defer os.Unsetenv("TMPDIR")
@@ -30,22 +30,10 @@ func (mc *xlsxMergeCell) Rect() ([]int, error) {
 //    err := f.MergeCell("Sheet1", "D3", "E9")
 //
 // If you create a merged cell that overlaps with another existing merged cell,
-// those merged cells that already exist will be removed. The cell coordinates
-// tuple after merging in the following range will be: A1(x3,y1) D1(x2,y1)
-// A8(x3,y4) D8(x2,y4)
-//
-//                 B1(x1,y1)      D1(x2,y1)
-//               +------------------------+
-//               |                        |
-//     A4(x3,y3) |    C4(x4,y3)           |
-//    +------------------------+          |
-//    |          |             |          |
-//    |          |B5(x1,y2)    | D5(x2,y2)|
-//    |          +------------------------+
-//    |                        |
-//    |A8(x3,y4)      C8(x4,y4)|
-//    +------------------------+
-//
+// including partial overlaps, full containment and identical ranges, an error
+// naming the conflicting existing merged cell will be returned and the
+// worksheet keeps unchanged. Use UnmergeCell to remove the existing merged
+// cell before merging the new range.
 func (f *File) MergeCell(sheet, hcell, vcell string) error {
 	rect, err := areaRefToCoordinates(hcell + ":" + vcell)
 	if err != nil {
@@ -62,6 +50,9 @@ func (f *File) MergeCell(sheet, hcell, vcell string) error {
 		return err
 	}
 	ref := hcell + ":" + vcell
+	if err = checkMergeCellOverlap(ws, ref, rect); err != nil {
+		return err
+	}
 	if ws.MergeCells != nil {
 		ws.MergeCells.Cells = append(ws.MergeCells.Cells, &xlsxMergeCell{Ref: ref, rect: rect})
 	} else {
@@ -71,6 +62,27 @@ func (f *File) MergeCell(sheet, hcell, vcell string) error {
 	return err
 }
 
+// checkMergeCellOverlap provides a function to check if the given merged cell
+// range overlaps with any existing merged cell in the worksheet.
+func checkMergeCellOverlap(ws *xlsxWorksheet, ref string, rect []int) error {
+	if ws.MergeCells == nil {
+		return nil
+	}
+	for _, mergeCell := range ws.MergeCells.Cells {
+		if mergeCell == nil {
+			continue
+		}
+		rect2, err := mergeCell.Rect()
+		if err != nil {
+			return err
+		}
+		if isOverlap(rect, rect2) {
+			return newMergeCellOverlapError(ref, mergeCell.Ref)
+		}
+	}
+	return nil
+}
+
 // UnmergeCell provides a function to unmerge a given coordinate area.
 // For example unmerge area D3:E9 on Sheet1:
 //
@@ -117,8 +129,78 @@ func (f *File) UnmergeCell(sheet string, hcell, vcell string) error {
 	return nil
 }
 
+// SplitMergedCell provides a function to unmerge the merged cell which
+// contains the given cell, and fill the value of the upper-left cell into
+// every cell of the merged range. For example, split the merged cell D3:E9
+// on Sheet1 and fill the value of D3 into each cell of the range:
+//
+//    err := f.SplitMergedCell("Sheet1", "E5")
+//
+// This function does nothing if the given cell isn't in a merged cell. Only
+// the cell value will be copied, the formula of the upper-left cell will be
+// kept in the upper-left cell.
+func (f *File) SplitMergedCell(sheet, hcell string) error {
+	col, row, err := CellNameToCoordinates(hcell)
+	if err != nil {
+		return err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	if ws.MergeCells == nil {
+		return nil
+	}
+	if err = f.mergeOverlapCells(ws); err != nil {
+		return err
+	}
+	var rect []int
+	for _, mergeCell := range ws.MergeCells.Cells {
+		if mergeCell == nil {
+			continue
+		}
+		if rect, err = mergeCell.Rect(); err != nil {
+			return err
+		}
+		if cellInRef([]int{col, row}, rect) {
+			break
+		}
+		rect = nil
+	}
+	if rect == nil {
+		return nil
+	}
+	hcell, _ = CoordinatesToCellName(rect[0], rect[1])
+	vcell, _ := CoordinatesToCellName(rect[2], rect[3])
+	if err = f.UnmergeCell(sheet, hcell, vcell); err != nil {
+		return err
+	}
+	for r := rect[1]; r <= rect[3]; r++ {
+		prepareSheetXML(ws, rect[2], r)
+	}
+	ws.Lock()
+	defer ws.Unlock()
+	master := ws.SheetData.Row[rect[1]-1].C[rect[0]-1]
+	for r := rect[1]; r <= rect[3]; r++ {
+		for c := rect[0]; c <= rect[2]; c++ {
+			if r == rect[1] && c == rect[0] {
+				continue
+			}
+			cell := &ws.SheetData.Row[r-1].C[c-1]
+			cell.T, cell.V, cell.F, cell.IS = master.T, master.V, nil, nil
+			if master.IS != nil {
+				si := *master.IS
+				cell.IS = &si
+			}
+		}
+	}
+	return nil
+}
+
 // GetMergeCells provides a function to get all merged cells from a worksheet
-// currently.
+// currently. Each returned merged cell holds the range reference and the
+// value of its top-left cell, which are read in a single scan of the
+// worksheet data, so there is no need to call GetCellValue for each range.
 func (f *File) GetMergeCells(sheet string) ([]MergeCell, error) {
 	var mergeCells []MergeCell
 	ws, err := f.workSheetReader(sheet)
@@ -130,16 +212,44 @@ func (f *File) GetMergeCells(sheet string) ([]MergeCell, error) {
 			return mergeCells, err
 		}
 		mergeCells = make([]MergeCell, 0, len(ws.MergeCells.Cells))
+		masters := make(map[string][]int, len(ws.MergeCells.Cells))
 		for i := range ws.MergeCells.Cells {
 			ref := ws.MergeCells.Cells[i].Ref
 			axis := strings.Split(ref, ":")[0]
-			val, _ := f.GetCellValue(sheet, axis)
-			mergeCells = append(mergeCells, []string{ref, val})
+			masters[axis] = append(masters[axis], len(mergeCells))
+			mergeCells = append(mergeCells, []string{ref, ""})
 		}
+		err = f.getMergeCellsValue(ws, masters, mergeCells)
 	}
 	return mergeCells, err
 }
 
+// getMergeCellsValue fills the value of the top-left cell of each merged
+// cell range by a single scan of the worksheet data, the masters map the
+// top-left cell reference to the indexes of merged cells in the given slice.
+func (f *File) getMergeCellsValue(ws *xlsxWorksheet, masters map[string][]int, mergeCells []MergeCell) error {
+	sst := f.sharedStringsReader()
+	ws.Lock()
+	defer ws.Unlock()
+	for rowIdx := range ws.SheetData.Row {
+		for colIdx := range ws.SheetData.Row[rowIdx].C {
+			c := &ws.SheetData.Row[rowIdx].C[colIdx]
+			idx, ok := masters[c.R]
+			if !ok {
+				continue
+			}
+			val, err := c.getValueFrom(f, sst, false)
+			if err != nil {
+				return err
+			}
+			for _, i := range idx {
+				mergeCells[i][1] = val
+			}
+		}
+	}
+	return nil
+}
+
 // overlapRange calculate overlap range of merged cells, and returns max
 // column and rows of the range.
 func overlapRange(ws *xlsxWorksheet) (row, col int, err error) {
@@ -14,21 +14,21 @@ func TestMergeCell(t *testing.T) {
 	}
 	assert.EqualError(t, f.MergeCell("Sheet1", "A", "B"), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
 	assert.NoError(t, f.MergeCell("Sheet1", "D9", "D9"))
-	assert.NoError(t, f.MergeCell("Sheet1", "D9", "E9"))
+	assert.EqualError(t, f.MergeCell("Sheet1", "D9", "E9"), "merged cell D9:E9 overlaps with existing merged cell D9:D9")
 	assert.NoError(t, f.MergeCell("Sheet1", "H14", "G13"))
-	assert.NoError(t, f.MergeCell("Sheet1", "C9", "D8"))
-	assert.NoError(t, f.MergeCell("Sheet1", "F11", "G13"))
-	assert.NoError(t, f.MergeCell("Sheet1", "H7", "B15"))
+	assert.EqualError(t, f.MergeCell("Sheet1", "C9", "D8"), "merged cell C8:D9 overlaps with existing merged cell D9:D9")
+	assert.EqualError(t, f.MergeCell("Sheet1", "F11", "G13"), "merged cell F11:G13 overlaps with existing merged cell G13:H14")
+	assert.EqualError(t, f.MergeCell("Sheet1", "H7", "B15"), "merged cell B7:H15 overlaps with existing merged cell D9:D9")
 	assert.NoError(t, f.MergeCell("Sheet1", "D11", "F13"))
 	assert.NoError(t, f.MergeCell("Sheet1", "G10", "K12"))
 	assert.NoError(t, f.SetCellValue("Sheet1", "G11", "set value in merged cell"))
 	assert.NoError(t, f.SetCellInt("Sheet1", "H11", 100))
-	assert.NoError(t, f.SetCellValue("Sheet1", "I11", float64(0.5)))
-	assert.NoError(t, f.SetCellHyperLink("Sheet1", "J11", "https://github.com/xuri/excelize", "External"))
-	assert.NoError(t, f.SetCellFormula("Sheet1", "G12", "SUM(Sheet1!B19,Sheet1!C19)"))
 	value, err := f.GetCellValue("Sheet1", "H11")
 	assert.Equal(t, "100", value)
 	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "I11", float64(0.5)))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "J11", "https://github.com/xuri/excelize", "External"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "G12", "SUM(Sheet1!B19,Sheet1!C19)"))
 	value, err = f.GetCellValue("Sheet2", "A6") // Merged cell ref is single coordinate.
 	assert.Equal(t, "", value)
 	assert.NoError(t, err)
@@ -40,29 +40,39 @@ func TestMergeCell(t *testing.T) {
 	assert.NoError(t, f.MergeCell("Sheet3", "D11", "F13"))
 	assert.NoError(t, f.MergeCell("Sheet3", "G10", "K12"))
 
-	assert.NoError(t, f.MergeCell("Sheet3", "B1", "D5")) // B1:D5
-	assert.NoError(t, f.MergeCell("Sheet3", "E1", "F5")) // E1:F5
+	assert.NoError(t, f.MergeCell("Sheet3", "B1", "D5"))
+	assert.NoError(t, f.MergeCell("Sheet3", "E1", "F5"))
 
+	// Test merge cells with partial overlaps.
 	assert.NoError(t, f.MergeCell("Sheet3", "H2", "I5"))
-	assert.NoError(t, f.MergeCell("Sheet3", "I4", "J6")) // H2:J6
+	assert.EqualError(t, f.MergeCell("Sheet3", "I4", "J6"), "merged cell I4:J6 overlaps with existing merged cell H2:I5")
 
 	assert.NoError(t, f.MergeCell("Sheet3", "M2", "N5"))
-	assert.NoError(t, f.MergeCell("Sheet3", "L4", "M6")) // L2:N6
+	assert.EqualError(t, f.MergeCell("Sheet3", "L4", "M6"), "merged cell L4:M6 overlaps with existing merged cell M2:N5")
 
 	assert.NoError(t, f.MergeCell("Sheet3", "P4", "Q7"))
-	assert.NoError(t, f.MergeCell("Sheet3", "O2", "P5")) // O2:Q7
+	assert.EqualError(t, f.MergeCell("Sheet3", "O2", "P5"), "merged cell O2:P5 overlaps with existing merged cell P4:Q7")
 
 	assert.NoError(t, f.MergeCell("Sheet3", "A9", "B12"))
-	assert.NoError(t, f.MergeCell("Sheet3", "B7", "C9")) // A7:C12
-
-	assert.NoError(t, f.MergeCell("Sheet3", "E9", "F10"))
-	assert.NoError(t, f.MergeCell("Sheet3", "D8", "G12"))
+	assert.EqualError(t, f.MergeCell("Sheet3", "B7", "C9"), "merged cell B7:C9 overlaps with existing merged cell A9:B12")
 
-	assert.NoError(t, f.MergeCell("Sheet3", "I8", "I12"))
-	assert.NoError(t, f.MergeCell("Sheet3", "I10", "K10"))
+	// Test merge cells with full containment.
+	assert.NoError(t, f.MergeCell("Sheet3", "E7", "F8"))
+	assert.EqualError(t, f.MergeCell("Sheet3", "D6", "G9"), "merged cell D6:G9 overlaps with existing merged cell E7:F8")
 
 	assert.NoError(t, f.MergeCell("Sheet3", "M8", "Q13"))
-	assert.NoError(t, f.MergeCell("Sheet3", "N10", "O11"))
+	assert.EqualError(t, f.MergeCell("Sheet3", "N10", "O11"), "merged cell N10:O11 overlaps with existing merged cell M8:Q13")
+
+	// Test merge cells with crossing ranges.
+	assert.NoError(t, f.MergeCell("Sheet3", "S2", "S6"))
+	assert.EqualError(t, f.MergeCell("Sheet3", "R4", "T4"), "merged cell R4:T4 overlaps with existing merged cell S2:S6")
+
+	// Test merge cells with identical range.
+	assert.EqualError(t, f.MergeCell("Sheet3", "M8", "Q13"), "merged cell M8:Q13 overlaps with existing merged cell M8:Q13")
+
+	mergeCells, err := f.GetMergeCells("Sheet3")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 11)
 
 	// Test get merged cells on not exists worksheet.
 	assert.EqualError(t, f.MergeCell("SheetN", "N10", "O11"), "sheet SheetN is not exist")
@@ -81,7 +91,11 @@ func TestMergeCell(t *testing.T) {
 func TestMergeCellOverlap(t *testing.T) {
 	f := NewFile()
 	assert.NoError(t, f.MergeCell("Sheet1", "A1", "C2"))
-	assert.NoError(t, f.MergeCell("Sheet1", "B2", "D3"))
+	assert.EqualError(t, f.MergeCell("Sheet1", "B2", "D3"), "merged cell B2:D3 overlaps with existing merged cell A1:C2")
+	// Test get merged cells with overlapped merged cells in the worksheet.
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).MergeCells.Cells = append(ws.(*xlsxWorksheet).MergeCells.Cells, &xlsxMergeCell{Ref: "B2:D3"})
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestMergeCellOverlap.xlsx")))
 
 	f, err := OpenFile(filepath.Join("test", "TestMergeCellOverlap.xlsx"))
@@ -95,6 +109,13 @@ func TestMergeCellOverlap(t *testing.T) {
 	assert.Equal(t, "D3", mc[0].GetEndAxis())
 	assert.Equal(t, "", mc[0].GetCellValue())
 	assert.NoError(t, f.Close())
+
+	// Test merge cells with invalid existing merged cell reference.
+	f = NewFile()
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).MergeCells = &xlsxMergeCells{Cells: []*xlsxMergeCell{{Ref: "A:B"}}}
+	assert.EqualError(t, f.MergeCell("Sheet1", "A1", "B2"), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
 }
 
 func TestGetMergeCells(t *testing.T) {
@@ -142,6 +163,17 @@ func TestGetMergeCells(t *testing.T) {
 	_, err = f.GetMergeCells("SheetN")
 	assert.EqualError(t, err, "sheet SheetN is not exist")
 	assert.NoError(t, f.Close())
+
+	// Test get merged cells with shared string, numeric and empty top-left cells.
+	f = NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "shared"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "D1", 100))
+	for _, ref := range [][]string{{"A1", "B2"}, {"D1", "E2"}, {"G1", "H2"}} {
+		assert.NoError(t, f.MergeCell("Sheet1", ref[0], ref[1]))
+	}
+	mergeCells, err = f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []MergeCell{{"A1:B2", "shared"}, {"D1:E2", "100"}, {"G1:H2", ""}}, mergeCells)
 }
 
 func TestUnmergeCell(t *testing.T) {
@@ -193,6 +225,59 @@ func TestUnmergeCell(t *testing.T) {
 	assert.EqualError(t, f.UnmergeCell("Sheet1", "A2", "B3"), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
 }
 
+func TestSplitMergedCell(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "B2", "header"))
+	assert.NoError(t, f.MergeCell("Sheet1", "B2", "C4"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "E1", 100))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "E1", "SUM(A10:B10)"))
+	assert.NoError(t, f.MergeCell("Sheet1", "E1", "F1"))
+
+	assert.NoError(t, f.SplitMergedCell("Sheet1", "C3"))
+	for _, cell := range []string{"B2", "B3", "B4", "C2", "C3", "C4"} {
+		value, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, "header", value)
+	}
+	mergeCells, err := f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []MergeCell{{"E1:F1", "100"}}, mergeCells)
+
+	// Test split merged cell with formula in the upper-left cell.
+	assert.NoError(t, f.SplitMergedCell("Sheet1", "E1"))
+	value, err := f.GetCellValue("Sheet1", "F1")
+	assert.NoError(t, err)
+	assert.Equal(t, "100", value)
+	formula, err := f.GetCellFormula("Sheet1", "F1")
+	assert.NoError(t, err)
+	assert.Equal(t, "", formula)
+	formula, err = f.GetCellFormula("Sheet1", "E1")
+	assert.NoError(t, err)
+	assert.Equal(t, "SUM(A10:B10)", formula)
+
+	// Test split merged cell on the cell which isn't in a merged cell.
+	assert.NoError(t, f.SplitMergedCell("Sheet1", "A1"))
+	assert.NoError(t, f.MergeCell("Sheet1", "A1", "A2"))
+	assert.NoError(t, f.SplitMergedCell("Sheet1", "H1"))
+
+	// Test split merged cell with inline string in the upper-left cell.
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row[0].C[0] = xlsxC{R: "A1", T: "inlineStr", IS: &xlsxSI{T: &xlsxT{Val: "inline"}}}
+	assert.NoError(t, f.SplitMergedCell("Sheet1", "A2"))
+	value, err = f.GetCellValue("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.Equal(t, "inline", value)
+
+	// Test split merged cell with invalid cell reference.
+	assert.EqualError(t, f.SplitMergedCell("Sheet1", "A"), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+	// Test split merged cell on not exists worksheet.
+	assert.EqualError(t, f.SplitMergedCell("SheetN", "A1"), "sheet SheetN is not exist")
+	// Test split merged cell with invalid merged cell reference.
+	ws.(*xlsxWorksheet).MergeCells = &xlsxMergeCells{Cells: []*xlsxMergeCell{nil, {Ref: "A:B"}}}
+	assert.EqualError(t, f.SplitMergedCell("Sheet1", "A1"), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+}
+
 func TestFlatMergedCells(t *testing.T) {
 	ws := &xlsxWorksheet{MergeCells: &xlsxMergeCells{Cells: []*xlsxMergeCell{{Ref: "A1"}}}}
 	assert.EqualError(t, flatMergedCells(ws, [][]*xlsxMergeCell{}), ErrParameterInvalid.Error())
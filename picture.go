@@ -17,11 +17,13 @@ import (
 	"encoding/xml"
 	"fmt"
 	"image"
+	"image/png"
 	"io"
 	"io/ioutil"
 	"os"
 	"path"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -112,6 +114,20 @@ func parseFormatPictureSet(formatSet string) (*formatPicture, error) {
 // The optional parameter "y_scale" specifies the vertical scale of images,
 // the default value of that is 1.0 which presents 100%.
 //
+// The optional parameters "crop_left", "crop_right", "crop_top" and
+// "crop_bottom" specifies the percentage to crop the image from each edge,
+// the value should be between 0 and 100, and the default value of that is 0
+// which presents no cropping. The size of the image will be reduced by the
+// cropped percentage before scaling.
+//
+// The optional parameter "rotation" specifies the clockwise rotation degrees
+// of the image, the default value of that is 0.
+//
+// The optional parameter "auto_convert" specifies if convert the image which
+// could not be rendered by Excel natively (such as WebP) to PNG, the default
+// value of that is 'false'. The image decoder of the format should be
+// registered by importing the package (such as "golang.org/x/image/webp").
+//
 func (f *File) AddPicture(sheet, cell, picture, format string) error {
 	var err error
 	// Check picture exists first.
@@ -127,6 +143,137 @@ func (f *File) AddPicture(sheet, cell, picture, format string) error {
 	return f.AddPictureFromBytes(sheet, cell, format, name, ext, file)
 }
 
+// ReplacePicture provides the method to replace the picture in a sheet by
+// given worksheet name, cell reference, picture file path and format set.
+// The anchor and extents of the existing picture which located at the cell
+// will be reused, and only the image content will be replaced, so only the
+// "auto_convert" of the format set will be applied on replacement, and the
+// other settings such as offset, scale and hyperlink will be ignored. The
+// image of the replaced picture will be deleted from the document when no
+// other part references it. If no picture exists at the cell, the picture
+// will be added by the AddPicture with given format set. This function
+// returns true if a replacement occurred. For example:
+//
+//    replaced, err := f.ReplacePicture("Sheet1", "A2", "image.png", "")
+//    if err != nil {
+//        fmt.Println(err)
+//    }
+//
+func (f *File) ReplacePicture(sheet, cell, picture, format string) (bool, error) {
+	var err error
+	// Check picture exists first.
+	if _, err = os.Stat(picture); os.IsNotExist(err) {
+		return false, err
+	}
+	ext, ok := supportImageTypes[path.Ext(picture)]
+	if !ok {
+		return false, ErrImgExt
+	}
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return false, err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return false, err
+	}
+	if ws.Drawing != nil {
+		formatSet, err := parseFormatPictureSet(format)
+		if err != nil {
+			return false, err
+		}
+		file, _ := ioutil.ReadFile(filepath.Clean(picture))
+		if file, ext, err = convertPicture(formatSet, file, ext); err != nil {
+			return false, err
+		}
+		if _, _, err = image.DecodeConfig(bytes.NewReader(file)); err != nil {
+			return false, err
+		}
+		_, name := filepath.Split(picture)
+		target := f.getSheetRelationshipsTargetByID(sheet, ws.Drawing.RID)
+		drawingXML := strings.Replace(target, "..", "xl", -1)
+		drawingRelationships := strings.Replace(
+			strings.Replace(target, "../drawings", "xl/drawings/_rels", -1), ".xml", ".xml.rels", -1)
+		replaced, err := f.replaceDrawingPicture(col-1, row-1, drawingXML, drawingRelationships, name, ext, file)
+		if err != nil || replaced {
+			return replaced, err
+		}
+	}
+	return false, f.AddPicture(sheet, cell, picture, format)
+}
+
+// replaceDrawingPicture provides a function to replace the image content of
+// the picture by given coordinates, drawing part, drawing relationships,
+// file base name, extension name and file bytes. The relationship of the
+// replaced image will be removed if no other picture uses it. This function
+// returns true if the picture at the coordinates was found and replaced.
+func (f *File) replaceDrawingPicture(col, row int, drawingXML, drawingRelationships, name, ext string, file []byte) (bool, error) {
+	addImageRels := func() string {
+		mediaStr := ".." + strings.TrimPrefix(f.addMedia(file, ext), "xl")
+		f.setContentTypePartImageExtensions()
+		return "rId" + strconv.Itoa(f.addRels(drawingRelationships, SourceRelationshipImage, mediaStr, ""))
+	}
+	wsDr, _ := f.drawingParser(drawingXML)
+	wsDr.Lock()
+	defer wsDr.Unlock()
+	var (
+		replaced func()
+		oldRID   string
+		used     = map[string]int{}
+		anchors  = append(append([]*xdrCellAnchor{}, wsDr.OneCellAnchor...), wsDr.TwoCellAnchor...)
+	)
+	for _, anchor := range anchors {
+		anchor := anchor
+		if anchor.Pic != nil {
+			used[anchor.Pic.BlipFill.Blip.Embed]++
+			if replaced == nil && anchor.From != nil && anchor.From.Col == col && anchor.From.Row == row {
+				oldRID, replaced = anchor.Pic.BlipFill.Blip.Embed, func() {
+					anchor.Pic.BlipFill.Blip.Embed = addImageRels()
+					anchor.Pic.NvPicPr.CNvPr.Descr = name
+				}
+			}
+			continue
+		}
+		deTwoCellAnchor := new(decodeTwoCellAnchor)
+		if err := f.xmlNewDecoder(strings.NewReader("<decodeTwoCellAnchor>" + anchor.GraphicFrame + "</decodeTwoCellAnchor>")).
+			Decode(deTwoCellAnchor); err != nil && err != io.EOF {
+			return false, fmt.Errorf("xml decode error: %s", err)
+		}
+		if deTwoCellAnchor.Pic != nil {
+			embed := deTwoCellAnchor.Pic.BlipFill.Blip.Embed
+			used[embed]++
+			if replaced == nil && deTwoCellAnchor.From != nil && deTwoCellAnchor.From.Col == col && deTwoCellAnchor.From.Row == row {
+				oldRID, replaced = embed, func() {
+					anchor.GraphicFrame = strings.Replace(anchor.GraphicFrame,
+						"embed=\""+embed+"\"", "embed=\""+addImageRels()+"\"", 1)
+				}
+			}
+		}
+	}
+	if replaced == nil {
+		return false, nil
+	}
+	replaced()
+	if used[oldRID] == 1 {
+		f.deleteDrawingImageRels(drawingXML, drawingRelationships, oldRID)
+	}
+	return true, nil
+}
+
+// deleteDrawingImageRels provides a function to remove the image relationship
+// of the drawing part by given drawing part, drawing relationships and
+// relationship ID, and delete the image file from the document when no other
+// part references it.
+func (f *File) deleteDrawingImageRels(drawingXML, drawingRelationships, rID string) {
+	if drawRel := f.getDrawingRelationships(drawingRelationships, rID); drawRel != nil {
+		f.deleteRelationship(drawingRelationships, rID)
+		media := path.Join(path.Dir(strings.TrimPrefix(drawingXML, "/")), drawRel.Target)
+		if !f.isMediaReferenced(media) {
+			f.Pkg.Delete(media)
+		}
+	}
+}
+
 // AddPictureFromBytes provides the method to add picture in a sheet by given
 // picture format set (such as offset, scale, aspect ratio setting and print
 // settings), file base name, extension name and file bytes. For example:
@@ -167,6 +314,12 @@ func (f *File) AddPictureFromBytes(sheet, cell, format, name, extension string,
 	if err != nil {
 		return err
 	}
+	if err = checkPictureCrop(formatSet); err != nil {
+		return err
+	}
+	if file, ext, err = convertPicture(formatSet, file, ext); err != nil {
+		return err
+	}
 	img, _, err := image.DecodeConfig(bytes.NewReader(file))
 	if err != nil {
 		return err
@@ -201,6 +354,451 @@ func (f *File) AddPictureFromBytes(sheet, cell, format, name, extension string,
 	return err
 }
 
+// AddPictureFromReader provides the method to add picture in a sheet by given
+// picture format set (such as offset, scale, aspect ratio setting and print
+// settings), file base name, extension name and the reader of the picture
+// content. The picture content will be read from the reader until EOF, and
+// the extension name will be used to validate the picture type. For example:
+//
+//    package main
+//
+//    import (
+//        "fmt"
+//        _ "image/jpeg"
+//        "os"
+//
+//        "github.com/xuri/excelize/v2"
+//    )
+//
+//    func main() {
+//        f := excelize.NewFile()
+//
+//        file, err := os.Open("image.jpg")
+//        if err != nil {
+//            fmt.Println(err)
+//            return
+//        }
+//        defer file.Close()
+//        if err := f.AddPictureFromReader("Sheet1", "A2", "", "Excel Logo", ".jpg", file); err != nil {
+//            fmt.Println(err)
+//        }
+//        if err := f.SaveAs("Book1.xlsx"); err != nil {
+//            fmt.Println(err)
+//        }
+//    }
+//
+func (f *File) AddPictureFromReader(sheet, cell, format, name, extension string, r io.Reader) error {
+	if _, ok := supportImageTypes[extension]; !ok {
+		return ErrImgExt
+	}
+	file, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if len(file) == 0 {
+		return ErrImgEmpty
+	}
+	return f.AddPictureFromBytes(sheet, cell, format, name, extension, file)
+}
+
+// AddPictureInCell provides the method to place a picture inside a cell by
+// given worksheet name, cell reference, picture file path and format set.
+// Unlike the AddPicture, the picture would be stored as the value of the cell
+// with the rich data parts just like the "Place in Cell" in Excel, so the
+// picture resizes with the cell and participates in sorting and filtering.
+// This function will return error if the target cell already holds a value.
+// The format set is supported the same settings as AddPicture, but the
+// position and size related settings will be ignored. For example:
+//
+//    package main
+//
+//    import (
+//        _ "image/png"
+//
+//        "github.com/xuri/excelize/v2"
+//    )
+//
+//    func main() {
+//        f := excelize.NewFile()
+//        if err := f.AddPictureInCell("Sheet1", "A2", "image.png", ""); err != nil {
+//            fmt.Println(err)
+//        }
+//        if err := f.SaveAs("Book1.xlsx"); err != nil {
+//            fmt.Println(err)
+//        }
+//    }
+//
+// Note that the picture placed in the cell could only be displayed in Excel
+// 365 or later, and the older version will show the #VALUE! error instead.
+func (f *File) AddPictureInCell(sheet, cell, picture, format string) error {
+	var err error
+	// Check picture exists first.
+	if _, err = os.Stat(picture); os.IsNotExist(err) {
+		return err
+	}
+	ext, ok := supportImageTypes[path.Ext(picture)]
+	if !ok {
+		return ErrImgExt
+	}
+	formatSet, err := parseFormatPictureSet(format)
+	if err != nil {
+		return err
+	}
+	file, _ := ioutil.ReadFile(filepath.Clean(picture))
+	if file, ext, err = convertPicture(formatSet, file, ext); err != nil {
+		return err
+	}
+	if _, _, err = image.DecodeConfig(bytes.NewReader(file)); err != nil {
+		return err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	cellData, _, _, err := f.prepareCell(ws, sheet, cell)
+	if err != nil {
+		return err
+	}
+	ws.Lock()
+	defer ws.Unlock()
+	if cellData.V != "" || cellData.F != nil || cellData.IS != nil {
+		return ErrCellNotEmpty
+	}
+	mediaStr := ".." + strings.TrimPrefix(f.addMedia(file, ext), "xl")
+	relIdx := f.addRichValueRel(mediaStr)
+	vm, err := f.addRichValueImage(relIdx)
+	if err != nil {
+		return err
+	}
+	cellData.T, cellData.V, cellData.Vm = "e", formulaErrorVALUE, vm
+	f.addRichDataParts()
+	f.setContentTypePartImageExtensions()
+	return err
+}
+
+// metadataReader provides a function to get the pointer to the structure
+// after deserialization of xl/metadata.xml.
+func (f *File) metadataReader() (*xlsxMetadata, error) {
+	var metadata xlsxMetadata
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML("xl/metadata.xml")))).
+		Decode(&metadata); err != nil && err != io.EOF {
+		return &metadata, err
+	}
+	return &metadata, nil
+}
+
+// richValueReader provides a function to get the pointer to the structure
+// after deserialization of xl/richData/rdrichvalue.xml.
+func (f *File) richValueReader() (*xlsxRichValueData, error) {
+	var richValue xlsxRichValueData
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML("xl/richData/rdrichvalue.xml")))).
+		Decode(&richValue); err != nil && err != io.EOF {
+		return &richValue, err
+	}
+	return &richValue, nil
+}
+
+// richValueStructureReader provides a function to get the pointer to the
+// structure after deserialization of xl/richData/rdrichvaluestructure.xml.
+func (f *File) richValueStructureReader() (*xlsxRichValueStructures, error) {
+	var richValueStructures xlsxRichValueStructures
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML("xl/richData/rdrichvaluestructure.xml")))).
+		Decode(&richValueStructures); err != nil && err != io.EOF {
+		return &richValueStructures, err
+	}
+	return &richValueStructures, nil
+}
+
+// richValueRelReader provides a function to get the pointer to the structure
+// after deserialization of xl/richData/richValueRel.xml.
+func (f *File) richValueRelReader() (*xlsxRichValueRels, error) {
+	var richValueRels xlsxRichValueRels
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML("xl/richData/richValueRel.xml")))).
+		Decode(&richValueRels); err != nil && err != io.EOF {
+		return &richValueRels, err
+	}
+	return &richValueRels, nil
+}
+
+// addRichValueRel provides a function to add the image relationship in the
+// rich value relationships part by given media path, and returns zero-based
+// index of the relationship. Images stored in the same media file share the
+// same relationship.
+func (f *File) addRichValueRel(media string) int {
+	rels := "xl/richData/_rels/richValueRel.xml.rels"
+	richValueRels, _ := f.richValueRelReader()
+	var rID string
+	if relationships := f.relsReader(rels); relationships != nil {
+		relationships.Lock()
+		for _, rel := range relationships.Relationships {
+			if rel.Type == SourceRelationshipImage && rel.Target == media {
+				rID = rel.ID
+			}
+		}
+		relationships.Unlock()
+	}
+	for idx, rel := range richValueRels.Rels {
+		if rID != "" && rel.ID == rID {
+			return idx
+		}
+	}
+	if rID == "" {
+		rID = "rId" + strconv.Itoa(f.addRels(rels, SourceRelationshipImage, media, ""))
+	}
+	richValueRels.XMLNSR = SourceRelationship.Value
+	richValueRels.Rels = append(richValueRels.Rels, xlsxRichValueRelRelation{ID: rID})
+	output, _ := xml.Marshal(richValueRels)
+	f.saveFileList("xl/richData/richValueRel.xml", replaceRelationshipsBytes(output))
+	return len(richValueRels.Rels) - 1
+}
+
+// addRichValueImage provides a function to add a local image rich value by
+// given zero-based index of the rich value relationship, and returns
+// one-based index of the value metadata for the cell.
+func (f *File) addRichValueImage(relIdx int) (int, error) {
+	richValueStructures, err := f.richValueStructureReader()
+	if err != nil {
+		return 0, err
+	}
+	structIdx := -1
+	for idx, s := range richValueStructures.S {
+		if s.T == "_localImage" && len(s.K) == 2 && s.K[0].N == "_rvRel:LocalImageIdentifier" && s.K[1].N == "CalcOrigin" {
+			structIdx = idx
+			break
+		}
+	}
+	if structIdx == -1 {
+		richValueStructures.S = append(richValueStructures.S, xlsxRichValueStructure{
+			T: "_localImage",
+			K: []xlsxRichValueStructureKey{
+				{N: "_rvRel:LocalImageIdentifier", T: "i"},
+				{N: "CalcOrigin", T: "i"},
+			},
+		})
+		structIdx = len(richValueStructures.S) - 1
+		richValueStructures.Count = len(richValueStructures.S)
+		output, _ := xml.Marshal(richValueStructures)
+		f.saveFileList("xl/richData/rdrichvaluestructure.xml", output)
+	}
+	richValue, err := f.richValueReader()
+	if err != nil {
+		return 0, err
+	}
+	// The calculation origin 5 indicates the image placed in the cell.
+	richValue.Rv = append(richValue.Rv, xlsxRichValue{S: structIdx, V: []string{strconv.Itoa(relIdx), "5"}})
+	richValue.Count = len(richValue.Rv)
+	output, _ := xml.Marshal(richValue)
+	f.saveFileList("xl/richData/rdrichvalue.xml", output)
+	return f.addRichValueMetadata(len(richValue.Rv) - 1)
+}
+
+// addRichValueMetadata provides a function to add value metadata for the
+// rich value by given zero-based index of the rich value, and returns
+// one-based index of the value metadata.
+func (f *File) addRichValueMetadata(richValueIdx int) (int, error) {
+	metadata, err := f.metadataReader()
+	if err != nil {
+		return 0, err
+	}
+	metadata.XMLNSXlrd, metadata.XMLNSXda = NameSpaceRichData, NameSpaceDynamicArray
+	if metadata.MetadataTypes == nil {
+		metadata.MetadataTypes = &xlsxMetadataTypes{}
+	}
+	typeIdx := -1
+	for idx, metadataType := range metadata.MetadataTypes.MetadataType {
+		if metadataType.Name == "XLRICHVALUE" {
+			typeIdx = idx
+		}
+	}
+	if typeIdx == -1 {
+		metadata.MetadataTypes.MetadataType = append(metadata.MetadataTypes.MetadataType, xlsxMetadataType{
+			Name: "XLRICHVALUE", MinSupportedVersion: 120000, Copy: true, PasteAll: true,
+			PasteValues: true, Merge: true, SplitFirst: true, RowColShift: true,
+			ClearFormats: true, ClearComments: true, Assign: true, Coerce: true,
+		})
+		typeIdx = len(metadata.MetadataTypes.MetadataType) - 1
+		metadata.MetadataTypes.Count = len(metadata.MetadataTypes.MetadataType)
+	}
+	futureIdx := -1
+	for idx, futureMetadata := range metadata.FutureMetadata {
+		if futureMetadata.Name == "XLRICHVALUE" {
+			futureIdx = idx
+		}
+	}
+	if futureIdx == -1 {
+		metadata.FutureMetadata = append(metadata.FutureMetadata, xlsxFutureMetadata{Name: "XLRICHVALUE"})
+		futureIdx = len(metadata.FutureMetadata) - 1
+	}
+	futureMetadata := &metadata.FutureMetadata[futureIdx]
+	futureMetadata.Bk = append(futureMetadata.Bk, xlsxFutureMetadataBlock{
+		ExtLst: &xlsxInnerXML{
+			Content: fmt.Sprintf(`<ext uri="%s"><xlrd:rvb i="%d"/></ext>`, ExtURIRichValueBlock, richValueIdx),
+		},
+	})
+	futureMetadata.Count = len(futureMetadata.Bk)
+	if metadata.ValueMetadata == nil {
+		metadata.ValueMetadata = &xlsxMetadataBlocks{}
+	}
+	metadata.ValueMetadata.Bk = append(metadata.ValueMetadata.Bk, xlsxMetadataBlock{
+		Rc: []xlsxMetadataRecord{{T: typeIdx + 1, V: len(futureMetadata.Bk) - 1}},
+	})
+	metadata.ValueMetadata.Count = len(metadata.ValueMetadata.Bk)
+	output, _ := xml.Marshal(metadata)
+	f.saveFileList("xl/metadata.xml", output)
+	return metadata.ValueMetadata.Count, err
+}
+
+// addRichDataParts provides a function to add the workbook relationships and
+// content types of the metadata and rich data parts if not exist.
+func (f *File) addRichDataParts() {
+	for _, part := range []struct{ relType, target, contentType string }{
+		{SourceRelationshipSheetMetadata, "/xl/metadata.xml", "metadata"},
+		{SourceRelationshipRichValue, "/xl/richData/rdrichvalue.xml", "richValue"},
+		{SourceRelationshipRichValueStructure, "/xl/richData/rdrichvaluestructure.xml", "richValueStructure"},
+		{SourceRelationshipRichValueRel, "/xl/richData/richValueRel.xml", "richValueRel"},
+	} {
+		var exist bool
+		if rels := f.relsReader(f.getWorkbookRelsPath()); rels != nil {
+			rels.Lock()
+			for _, rel := range rels.Relationships {
+				if rel.Type == part.relType {
+					exist = true
+				}
+			}
+			rels.Unlock()
+		}
+		if !exist {
+			f.addRels(f.getWorkbookRelsPath(), part.relType, part.target, "")
+		}
+		f.addContentTypePart(0, part.contentType)
+	}
+}
+
+// GetPictureInCell provides a function to get picture base name and raw
+// content of the picture placed in the cell by given worksheet and cell name.
+// This function returns the file name in spreadsheet and file contents as
+// []byte data types, and returns empty values if the cell doesn't contain a
+// picture. For example:
+//
+//    file, raw, err := f.GetPictureInCell("Sheet1", "A2")
+//    if err != nil {
+//        fmt.Println(err)
+//        return
+//    }
+//    if err := ioutil.WriteFile(file, raw, 0644); err != nil {
+//        fmt.Println(err)
+//    }
+//
+func (f *File) GetPictureInCell(sheet, cell string) (string, []byte, error) {
+	var vm int
+	if _, err := f.getCellStringFunc(sheet, cell, func(x *xlsxWorksheet, c *xlsxC) (string, bool, error) {
+		vm = c.Vm
+		return "", true, nil
+	}); err != nil || vm == 0 {
+		return "", nil, err
+	}
+	metadata, err := f.metadataReader()
+	if err != nil || metadata.ValueMetadata == nil || metadata.MetadataTypes == nil ||
+		len(metadata.ValueMetadata.Bk) < vm || len(metadata.ValueMetadata.Bk[vm-1].Rc) == 0 {
+		return "", nil, err
+	}
+	rc := metadata.ValueMetadata.Bk[vm-1].Rc[0]
+	if rc.T < 1 || len(metadata.MetadataTypes.MetadataType) < rc.T ||
+		metadata.MetadataTypes.MetadataType[rc.T-1].Name != "XLRICHVALUE" {
+		return "", nil, err
+	}
+	richValueIdx := -1
+	for _, futureMetadata := range metadata.FutureMetadata {
+		if futureMetadata.Name != "XLRICHVALUE" || len(futureMetadata.Bk) <= rc.V || futureMetadata.Bk[rc.V].ExtLst == nil {
+			continue
+		}
+		var extLst decodeFutureMetadataExtLst
+		if err = f.xmlNewDecoder(strings.NewReader("<extLst>" + futureMetadata.Bk[rc.V].ExtLst.Content + "</extLst>")).
+			Decode(&extLst); err != nil && err != io.EOF {
+			return "", nil, err
+		}
+		for _, ext := range extLst.Ext {
+			if ext.URI == ExtURIRichValueBlock && ext.Rvb != nil {
+				richValueIdx = ext.Rvb.I
+			}
+		}
+	}
+	return f.getRichValueImage(richValueIdx)
+}
+
+// getRichValueImage provides a function to get picture base name and raw
+// content of the local image rich value by given zero-based index of the rich
+// value.
+func (f *File) getRichValueImage(richValueIdx int) (string, []byte, error) {
+	richValue, err := f.richValueReader()
+	if err != nil || richValueIdx < 0 || len(richValue.Rv) <= richValueIdx {
+		return "", nil, err
+	}
+	richValueStructures, err := f.richValueStructureReader()
+	rv := richValue.Rv[richValueIdx]
+	if err != nil || len(richValueStructures.S) <= rv.S {
+		return "", nil, err
+	}
+	relIdx := -1
+	for idx, key := range richValueStructures.S[rv.S].K {
+		if key.N == "_rvRel:LocalImageIdentifier" && idx < len(rv.V) {
+			if relIdx, err = strconv.Atoi(rv.V[idx]); err != nil {
+				return "", nil, err
+			}
+		}
+	}
+	richValueRels, err := f.richValueRelReader()
+	if err != nil || relIdx < 0 || len(richValueRels.Rels) <= relIdx {
+		return "", nil, err
+	}
+	if drawRel := f.getDrawingRelationships("xl/richData/_rels/richValueRel.xml.rels",
+		richValueRels.Rels[relIdx].ID); drawRel != nil {
+		if buffer, _ := f.Pkg.Load(strings.Replace(drawRel.Target, "..", "xl", -1)); buffer != nil {
+			return filepath.Base(drawRel.Target), buffer.([]byte), err
+		}
+	}
+	return "", nil, err
+}
+
+// convertPicture provides a function to convert the image which could not
+// be rendered by Excel natively to PNG by given format set, file bytes and
+// extension name. The image will be kept as is if the "auto_convert" option
+// is not set, it returns the file bytes and extension name after converted.
+func convertPicture(formatSet *formatPicture, file []byte, ext string) ([]byte, string, error) {
+	if !formatSet.AutoConvert || !convertImageTypes[ext] {
+		return file, ext, nil
+	}
+	img, _, err := image.Decode(bytes.NewReader(file))
+	if err != nil {
+		return file, ext, err
+	}
+	var buf bytes.Buffer
+	if err = png.Encode(&buf, img); err != nil {
+		return file, ext, err
+	}
+	return buf.Bytes(), ".png", nil
+}
+
+// rotationToAngle provides a function to normalize the rotation degrees into
+// the range of 0 to 360, and convert it to the angle in 60000ths of a degree.
+func rotationToAngle(rotation int) int {
+	return (rotation%360 + 360) % 360 * 60000
+}
+
+// checkPictureCrop provides a function to check the crop settings of the
+// picture format set.
+func checkPictureCrop(formatSet *formatPicture) error {
+	for _, crop := range []float64{formatSet.CropLeft, formatSet.CropRight, formatSet.CropTop, formatSet.CropBottom} {
+		if crop < 0 || crop > 100 {
+			return ErrPictureCrop
+		}
+	}
+	if formatSet.CropLeft+formatSet.CropRight >= 100 || formatSet.CropTop+formatSet.CropBottom >= 100 {
+		return ErrPictureCrop
+	}
+	return nil
+}
+
 // deleteSheetRelationships provides a function to delete relationships in
 // xl/worksheets/_rels/sheet%d.xml.rels by given worksheet name and
 // relationship index.
@@ -277,6 +875,8 @@ func (f *File) addDrawingPicture(sheet, drawingXML, cell, file string, width, he
 	if err != nil {
 		return err
 	}
+	width = int(float64(width) * (100 - formatSet.CropLeft - formatSet.CropRight) / 100)
+	height = int(float64(height) * (100 - formatSet.CropTop - formatSet.CropBottom) / 100)
 	if formatSet.Autofit {
 		width, height, col, row, err = f.drawingResize(sheet, cell, float64(width), float64(height), formatSet)
 		if err != nil {
@@ -318,7 +918,16 @@ func (f *File) addDrawingPicture(sheet, drawingXML, cell, file string, width, he
 	}
 	pic.BlipFill.Blip.R = SourceRelationship.Value
 	pic.BlipFill.Blip.Embed = "rId" + strconv.Itoa(rID)
+	if formatSet.CropLeft != 0 || formatSet.CropRight != 0 || formatSet.CropTop != 0 || formatSet.CropBottom != 0 {
+		pic.BlipFill.SrcRect = &xlsxSrcRect{
+			L: int(formatSet.CropLeft * 1000),
+			T: int(formatSet.CropTop * 1000),
+			R: int(formatSet.CropRight * 1000),
+			B: int(formatSet.CropBottom * 1000),
+		}
+	}
 	pic.SpPr.PrstGeom.Prst = "rect"
+	pic.SpPr.Xfrm.Rot = rotationToAngle(formatSet.Rotation)
 
 	twoCellAnchor.Pic = &pic
 	twoCellAnchor.ClientData = &xdrClientData{
@@ -365,6 +974,10 @@ func (f *File) addMedia(file []byte, ext string) string {
 		return name
 	}
 	media := "xl/media/image" + strconv.Itoa(count+1) + ext
+	for _, ok := f.Pkg.Load(media); ok; _, ok = f.Pkg.Load(media) {
+		count++
+		media = "xl/media/image" + strconv.Itoa(count+1) + ext
+	}
 	f.Pkg.Store(media, file)
 	return media
 }
@@ -372,7 +985,7 @@ func (f *File) addMedia(file []byte, ext string) string {
 // setContentTypePartImageExtensions provides a function to set the content
 // type for relationship parts and the Main Document part.
 func (f *File) setContentTypePartImageExtensions() {
-	var imageTypes = map[string]bool{"jpeg": false, "png": false, "gif": false, "tiff": false}
+	var imageTypes = map[string]bool{"jpeg": false, "png": false, "gif": false, "tiff": false, "webp": false}
 	content := f.contentTypesReader()
 	content.Lock()
 	defer content.Unlock()
@@ -420,24 +1033,42 @@ func (f *File) addContentTypePart(index int, contentType string) {
 		"drawings": f.setContentTypePartImageExtensions,
 	}
 	partNames := map[string]string{
-		"chart":         "/xl/charts/chart" + strconv.Itoa(index) + ".xml",
-		"chartsheet":    "/xl/chartsheets/sheet" + strconv.Itoa(index) + ".xml",
-		"comments":      "/xl/comments" + strconv.Itoa(index) + ".xml",
-		"drawings":      "/xl/drawings/drawing" + strconv.Itoa(index) + ".xml",
-		"table":         "/xl/tables/table" + strconv.Itoa(index) + ".xml",
-		"pivotTable":    "/xl/pivotTables/pivotTable" + strconv.Itoa(index) + ".xml",
-		"pivotCache":    "/xl/pivotCache/pivotCacheDefinition" + strconv.Itoa(index) + ".xml",
-		"sharedStrings": "/xl/sharedStrings.xml",
+		"chart":              "/xl/charts/chart" + strconv.Itoa(index) + ".xml",
+		"chartsheet":         "/xl/chartsheets/sheet" + strconv.Itoa(index) + ".xml",
+		"comments":           "/xl/comments" + strconv.Itoa(index) + ".xml",
+		"drawings":           "/xl/drawings/drawing" + strconv.Itoa(index) + ".xml",
+		"metadata":           "/xl/metadata.xml",
+		"richValue":          "/xl/richData/rdrichvalue.xml",
+		"richValueRel":       "/xl/richData/richValueRel.xml",
+		"richValueStructure": "/xl/richData/rdrichvaluestructure.xml",
+		"table":              "/xl/tables/table" + strconv.Itoa(index) + ".xml",
+		"pivotTable":         "/xl/pivotTables/pivotTable" + strconv.Itoa(index) + ".xml",
+		"pivotCache":         "/xl/pivotCache/pivotCacheDefinition" + strconv.Itoa(index) + ".xml",
+		"pivotCacheRecords":  "/xl/pivotCache/pivotCacheRecords" + strconv.Itoa(index) + ".xml",
+		"sharedStrings":      "/xl/sharedStrings.xml",
+		"slicer":             "/xl/slicers/slicer" + strconv.Itoa(index) + ".xml",
+		"slicerCache":        "/xl/slicerCaches/slicerCache" + strconv.Itoa(index) + ".xml",
+		"threadedComments":   "/xl/threadedComments/threadedComment" + strconv.Itoa(index) + ".xml",
+		"person":             "/xl/persons/person.xml",
 	}
 	contentTypes := map[string]string{
-		"chart":         ContentTypeDrawingML,
-		"chartsheet":    ContentTypeSpreadSheetMLChartsheet,
-		"comments":      ContentTypeSpreadSheetMLComments,
-		"drawings":      ContentTypeDrawing,
-		"table":         ContentTypeSpreadSheetMLTable,
-		"pivotTable":    ContentTypeSpreadSheetMLPivotTable,
-		"pivotCache":    ContentTypeSpreadSheetMLPivotCacheDefinition,
-		"sharedStrings": ContentTypeSpreadSheetMLSharedStrings,
+		"chart":              ContentTypeDrawingML,
+		"chartsheet":         ContentTypeSpreadSheetMLChartsheet,
+		"comments":           ContentTypeSpreadSheetMLComments,
+		"drawings":           ContentTypeDrawing,
+		"metadata":           ContentTypeSheetMetadata,
+		"richValue":          ContentTypeRichValue,
+		"richValueRel":       ContentTypeRichValueRel,
+		"richValueStructure": ContentTypeRichValueStructure,
+		"table":              ContentTypeSpreadSheetMLTable,
+		"pivotTable":         ContentTypeSpreadSheetMLPivotTable,
+		"pivotCache":         ContentTypeSpreadSheetMLPivotCacheDefinition,
+		"pivotCacheRecords":  ContentTypeSpreadSheetMLPivotCacheRecords,
+		"sharedStrings":      ContentTypeSpreadSheetMLSharedStrings,
+		"slicer":             ContentTypeSlicer,
+		"slicerCache":        ContentTypeSlicerCache,
+		"threadedComments":   ContentTypeThreadedComments,
+		"person":             ContentTypePerson,
 	}
 	s, ok := setContentType[contentType]
 	if ok {
@@ -529,9 +1160,143 @@ func (f *File) GetPicture(sheet, cell string) (string, []byte, error) {
 	return f.getPicture(row, col, drawingXML, drawingRelationships)
 }
 
-// DeletePicture provides a function to delete charts in spreadsheet by given
-// worksheet and cell name. Note that the image file won't be deleted from the
-// document currently.
+// GetPictureCells returns all cell references which have the anchored
+// pictures by given worksheet name. The returned cell references are sorted
+// by row then column, and shapes or charts in the worksheet will be skipped.
+// For example:
+//
+//    cells, err := f.GetPictureCells("Sheet1")
+//    if err != nil {
+//        fmt.Println(err)
+//        return
+//    }
+//    for _, cell := range cells {
+//        file, raw, err := f.GetPicture("Sheet1", cell)
+//        if err != nil {
+//            fmt.Println(err)
+//        }
+//        fmt.Println(cell, file, len(raw))
+//    }
+//
+func (f *File) GetPictureCells(sheet string) ([]string, error) {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return nil, err
+	}
+	if ws.Drawing == nil {
+		return nil, err
+	}
+	target := f.getSheetRelationshipsTargetByID(sheet, ws.Drawing.RID)
+	drawingXML := strings.Replace(target, "..", "xl", -1)
+	drawingRelationships := strings.Replace(
+		strings.Replace(target, "../drawings", "xl/drawings/_rels", -1), ".xml", ".xml.rels", -1)
+	return f.getPictureCells(drawingXML, drawingRelationships)
+}
+
+// getPictureCells provides a function to get all cell references which have
+// the anchored pictures by given drawing part and drawing relationships.
+func (f *File) getPictureCells(drawingXML, drawingRelationships string) ([]string, error) {
+	var (
+		coordinates [][]int
+		cells       []string
+		isPicture   = func(rID string) bool {
+			if drawRel := f.getDrawingRelationships(drawingRelationships, rID); drawRel != nil {
+				_, ok := supportImageTypes[strings.ToLower(filepath.Ext(drawRel.Target))]
+				return ok
+			}
+			return false
+		}
+	)
+	wsDr, _ := f.drawingParser(drawingXML)
+	wsDr.Lock()
+	anchors := append(append([]*xdrCellAnchor{}, wsDr.OneCellAnchor...), wsDr.TwoCellAnchor...)
+	wsDr.Unlock()
+	for _, anchor := range anchors {
+		if anchor.From != nil && anchor.Pic != nil {
+			if isPicture(anchor.Pic.BlipFill.Blip.Embed) && inCoordinates(coordinates, []int{anchor.From.Row, anchor.From.Col}) == -1 {
+				coordinates = append(coordinates, []int{anchor.From.Row, anchor.From.Col})
+			}
+			continue
+		}
+		deTwoCellAnchor := new(decodeTwoCellAnchor)
+		if err := f.xmlNewDecoder(strings.NewReader("<decodeTwoCellAnchor>" + anchor.GraphicFrame + "</decodeTwoCellAnchor>")).
+			Decode(deTwoCellAnchor); err != nil && err != io.EOF {
+			return cells, fmt.Errorf("xml decode error: %s", err)
+		}
+		if deTwoCellAnchor.From != nil && deTwoCellAnchor.Pic != nil {
+			if isPicture(deTwoCellAnchor.Pic.BlipFill.Blip.Embed) && inCoordinates(coordinates, []int{deTwoCellAnchor.From.Row, deTwoCellAnchor.From.Col}) == -1 {
+				coordinates = append(coordinates, []int{deTwoCellAnchor.From.Row, deTwoCellAnchor.From.Col})
+			}
+		}
+	}
+	sort.Slice(coordinates, func(i, j int) bool {
+		if coordinates[i][0] == coordinates[j][0] {
+			return coordinates[i][1] < coordinates[j][1]
+		}
+		return coordinates[i][0] < coordinates[j][0]
+	})
+	for _, coordinate := range coordinates {
+		cell, err := CoordinatesToCellName(coordinate[1]+1, coordinate[0]+1)
+		if err != nil {
+			return cells, err
+		}
+		cells = append(cells, cell)
+	}
+	return cells, nil
+}
+
+// GetPictureRotation provides a function to get the clockwise rotation angle
+// in degrees of the picture by given worksheet and cell name. This function
+// returns 0 if the cell doesn't contain a picture or the picture is not
+// rotated. For example:
+//
+//    rotation, err := f.GetPictureRotation("Sheet1", "A2")
+//
+func (f *File) GetPictureRotation(sheet, cell string) (int, error) {
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return 0, err
+	}
+	col--
+	row--
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return 0, err
+	}
+	if ws.Drawing == nil {
+		return 0, err
+	}
+	drawingXML := strings.Replace(f.getSheetRelationshipsTargetByID(sheet, ws.Drawing.RID), "..", "xl", -1)
+	wsDr, _ := f.drawingParser(drawingXML)
+	wsDr.Lock()
+	defer wsDr.Unlock()
+	for _, anchor := range append(append([]*xdrCellAnchor{}, wsDr.OneCellAnchor...), wsDr.TwoCellAnchor...) {
+		if anchor.From != nil && anchor.Pic != nil {
+			if anchor.From.Col == col && anchor.From.Row == row {
+				return anchor.Pic.SpPr.Xfrm.Rot / 60000, err
+			}
+			continue
+		}
+		deTwoCellAnchor := new(decodeTwoCellAnchor)
+		if err = f.xmlNewDecoder(strings.NewReader("<decodeTwoCellAnchor>" + anchor.GraphicFrame + "</decodeTwoCellAnchor>")).
+			Decode(deTwoCellAnchor); err != nil && err != io.EOF {
+			return 0, fmt.Errorf("xml decode error: %s", err)
+		}
+		if err = nil; deTwoCellAnchor.From != nil && deTwoCellAnchor.Pic != nil {
+			if deTwoCellAnchor.From.Col == col && deTwoCellAnchor.From.Row == row {
+				return deTwoCellAnchor.Pic.SpPr.Xfrm.Rot / 60000, err
+			}
+		}
+	}
+	return 0, err
+}
+
+// DeletePicture provides a function to delete the picture in spreadsheet by
+// given worksheet name and cell reference. Only the anchor of the picture at
+// the cell and its relationship will be removed, other pictures, charts and
+// shapes in the same drawing will be kept. The image file will be deleted
+// from the document when no other part references it. This function returns
+// an error if the cell has no picture.
 func (f *File) DeletePicture(sheet, cell string) (err error) {
 	col, row, err := CellNameToCoordinates(cell)
 	if err != nil {
@@ -544,10 +1309,134 @@ func (f *File) DeletePicture(sheet, cell string) (err error) {
 		return
 	}
 	if ws.Drawing == nil {
+		return newNoPictureError(cell)
+	}
+	target := f.getSheetRelationshipsTargetByID(sheet, ws.Drawing.RID)
+	drawingXML := strings.Replace(target, "..", "xl", -1)
+	drawingRelationships := strings.Replace(
+		strings.Replace(target, "../drawings", "xl/drawings/_rels", -1), ".xml", ".xml.rels", -1)
+	rIDs, used, err := f.deletePictureAnchors(col, row, drawingXML)
+	if err != nil {
 		return
 	}
-	drawingXML := strings.Replace(f.getSheetRelationshipsTargetByID(sheet, ws.Drawing.RID), "..", "xl", -1)
-	return f.deleteDrawing(col, row, drawingXML, "Pic")
+	if len(rIDs) == 0 {
+		return newNoPictureError(cell)
+	}
+	for _, rID := range rIDs {
+		if !used[rID] {
+			f.deleteDrawingImageRels(drawingXML, drawingRelationships, rID)
+		}
+	}
+	return
+}
+
+// deletePictureAnchors provides a function to remove the anchors of the
+// pictures in the drawing part by given coordinates. This function returns
+// the image relationship IDs of the removed pictures, and the set of image
+// relationship IDs which are still used by the remaining pictures.
+func (f *File) deletePictureAnchors(col, row int, drawingXML string) ([]string, map[string]bool, error) {
+	var (
+		rIDs []string
+		used = map[string]bool{}
+	)
+	wsDr, _ := f.drawingParser(drawingXML)
+	wsDr.Lock()
+	defer wsDr.Unlock()
+	filter := func(anchors []*xdrCellAnchor) ([]*xdrCellAnchor, error) {
+		var kept []*xdrCellAnchor
+		for _, anchor := range anchors {
+			if anchor.Pic != nil {
+				if anchor.From != nil && anchor.From.Col == col && anchor.From.Row == row {
+					rIDs = append(rIDs, anchor.Pic.BlipFill.Blip.Embed)
+					continue
+				}
+				used[anchor.Pic.BlipFill.Blip.Embed] = true
+				kept = append(kept, anchor)
+				continue
+			}
+			deTwoCellAnchor := new(decodeTwoCellAnchor)
+			if err := f.xmlNewDecoder(strings.NewReader("<decodeTwoCellAnchor>" + anchor.GraphicFrame + "</decodeTwoCellAnchor>")).
+				Decode(deTwoCellAnchor); err != nil && err != io.EOF {
+				return anchors, fmt.Errorf("xml decode error: %s", err)
+			}
+			if deTwoCellAnchor.Pic != nil {
+				if deTwoCellAnchor.From != nil && deTwoCellAnchor.From.Col == col && deTwoCellAnchor.From.Row == row {
+					rIDs = append(rIDs, deTwoCellAnchor.Pic.BlipFill.Blip.Embed)
+					continue
+				}
+				used[deTwoCellAnchor.Pic.BlipFill.Blip.Embed] = true
+			}
+			kept = append(kept, anchor)
+		}
+		return kept, nil
+	}
+	oneCellAnchor, err := filter(wsDr.OneCellAnchor)
+	if err != nil {
+		return nil, nil, err
+	}
+	twoCellAnchor, err := filter(wsDr.TwoCellAnchor)
+	if err != nil {
+		return nil, nil, err
+	}
+	wsDr.OneCellAnchor, wsDr.TwoCellAnchor = oneCellAnchor, twoCellAnchor
+	return rIDs, used, nil
+}
+
+// deleteRelationship provides a function to remove the relationship by given
+// relationships part path and relationship ID.
+func (f *File) deleteRelationship(relPath, rID string) {
+	rels := f.relsReader(relPath)
+	if rels == nil {
+		return
+	}
+	rels.Lock()
+	defer rels.Unlock()
+	for k, v := range rels.Relationships {
+		if v.ID == rID {
+			rels.Relationships = append(rels.Relationships[:k], rels.Relationships[k+1:]...)
+			break
+		}
+	}
+	f.Relationships.Store(relPath, rels)
+}
+
+// isMediaReferenced provides a function to check if the media file is
+// referenced by any relationships part in the document by given media file
+// path.
+func (f *File) isMediaReferenced(media string) bool {
+	relPaths := map[string]bool{}
+	f.Pkg.Range(func(k, v interface{}) bool {
+		if strings.HasSuffix(k.(string), ".rels") {
+			relPaths[k.(string)] = true
+		}
+		return true
+	})
+	f.Relationships.Range(func(k, v interface{}) bool {
+		relPaths[k.(string)] = true
+		return true
+	})
+	for relPath := range relPaths {
+		rels := f.relsReader(relPath)
+		if rels == nil {
+			continue
+		}
+		rels.Lock()
+		for _, v := range rels.Relationships {
+			if v.TargetMode == "External" {
+				continue
+			}
+			target := path.Join(path.Dir(path.Dir(relPath)), v.Target)
+			if strings.HasPrefix(v.Target, "/") {
+				target = strings.TrimPrefix(v.Target, "/")
+			}
+			if target == media {
+				rels.Unlock()
+				return true
+			}
+		}
+		rels.Unlock()
+	}
+	return false
 }
 
 // getPicture provides a function to get picture base name and raw content
@@ -572,16 +1461,21 @@ func (f *File) getPicture(row, col int, drawingXML, drawingRelationships string)
 		return
 	}
 	err = nil
-	for _, anchor := range deWsDr.TwoCellAnchor {
+	for _, anchor := range wsDr.TwoCellAnchor {
+		if anchor.Pic != nil {
+			continue
+		}
 		deTwoCellAnchor = new(decodeTwoCellAnchor)
-		if err = f.xmlNewDecoder(strings.NewReader("<decodeTwoCellAnchor>" + anchor.Content + "</decodeTwoCellAnchor>")).
+		if err = f.xmlNewDecoder(strings.NewReader("<decodeTwoCellAnchor>" + anchor.GraphicFrame + "</decodeTwoCellAnchor>")).
 			Decode(deTwoCellAnchor); err != nil && err != io.EOF {
 			err = fmt.Errorf("xml decode error: %s", err)
 			return
 		}
 		if err = nil; deTwoCellAnchor.From != nil && deTwoCellAnchor.Pic != nil {
 			if deTwoCellAnchor.From.Col == col && deTwoCellAnchor.From.Row == row {
-				drawRel = f.getDrawingRelationships(drawingRelationships, deTwoCellAnchor.Pic.BlipFill.Blip.Embed)
+				if drawRel = f.getDrawingRelationships(drawingRelationships, deTwoCellAnchor.Pic.BlipFill.Blip.Embed); drawRel == nil {
+					return
+				}
 				if _, ok = supportImageTypes[filepath.Ext(drawRel.Target)]; ok {
 					ret = filepath.Base(drawRel.Target)
 					if buffer, _ := f.Pkg.Load(strings.Replace(drawRel.Target, "..", "xl", -1)); buffer != nil {
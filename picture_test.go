@@ -6,13 +6,17 @@ import (
 	_ "image/png"
 
 	_ "golang.org/x/image/tiff"
+	_ "golang.org/x/image/webp"
 
+	"bytes"
 	"fmt"
+	"image"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"testing/iotest"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -94,6 +98,60 @@ func TestAddPictureErrors(t *testing.T) {
 	assert.NoError(t, f.Close())
 }
 
+func TestAddPictureInCell(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddPictureInCell("Sheet1", "A1", filepath.Join("test", "images", "excel.png"), ""))
+	assert.NoError(t, f.AddPictureInCell("Sheet1", "B2", filepath.Join("test", "images", "excel.jpg"), ""))
+	// Test add the same picture into another cell.
+	assert.NoError(t, f.AddPictureInCell("Sheet1", "C3", filepath.Join("test", "images", "excel.png"), ""))
+	// Test add picture into the cell which already holds a value.
+	assert.NoError(t, f.SetCellValue("Sheet1", "D4", "value"))
+	assert.EqualError(t, f.AddPictureInCell("Sheet1", "D4", filepath.Join("test", "images", "excel.png"), ""), ErrCellNotEmpty.Error())
+	assert.EqualError(t, f.AddPictureInCell("Sheet1", "B2", filepath.Join("test", "images", "excel.png"), ""), ErrCellNotEmpty.Error())
+	// Test add picture with invalid parameters.
+	assert.EqualError(t, f.AddPictureInCell("SheetN", "A1", filepath.Join("test", "images", "excel.png"), ""), "sheet SheetN is not exist")
+	assert.EqualError(t, f.AddPictureInCell("Sheet1", "A", filepath.Join("test", "images", "excel.png"), ""), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+	assert.EqualError(t, f.AddPictureInCell("Sheet1", "A1", filepath.Join("test", "Book1.xlsx"), ""), ErrImgExt.Error())
+	assert.EqualError(t, f.AddPictureInCell("Sheet1", "A1", filepath.Join("test", "images", "excel.png"), "{"), "unexpected end of JSON input")
+	assert.True(t, os.IsNotExist(f.AddPictureInCell("Sheet1", "A1", filepath.Join("test", "not_exists.png"), "")))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddPictureInCell.xlsx")))
+
+	f, err := OpenFile(filepath.Join("test", "TestAddPictureInCell.xlsx"))
+	assert.NoError(t, err)
+	expected, err := ioutil.ReadFile(filepath.Join("test", "images", "excel.png"))
+	assert.NoError(t, err)
+	for _, cell := range []string{"A1", "C3"} {
+		file, raw, err := f.GetPictureInCell("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, "image1.png", file)
+		assert.Equal(t, expected, raw)
+	}
+	file, raw, err := f.GetPictureInCell("Sheet1", "B2")
+	assert.NoError(t, err)
+	assert.Equal(t, "image2.jpeg", file)
+	assert.NotEmpty(t, raw)
+	// Test get picture from the cell without picture.
+	file, raw, err = f.GetPictureInCell("Sheet1", "D4")
+	assert.NoError(t, err)
+	assert.Empty(t, file)
+	assert.Empty(t, raw)
+	// Test add picture into the cell of the opened workbook.
+	assert.NoError(t, f.AddPictureInCell("Sheet1", "E5", filepath.Join("test", "images", "excel.gif"), ""))
+	file, _, err = f.GetPictureInCell("Sheet1", "E5")
+	assert.NoError(t, err)
+	assert.Equal(t, "image3.gif", file)
+	// Test get picture with invalid parameters.
+	_, _, err = f.GetPictureInCell("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+	_, _, err = f.GetPictureInCell("Sheet1", "A")
+	assert.EqualError(t, err, `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+	// Test get picture with unsupported charset metadata.
+	f.Pkg.Store("xl/metadata.xml", MacintoshCyrillicCharset)
+	_, _, err = f.GetPictureInCell("Sheet1", "A1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
 func TestGetPicture(t *testing.T) {
 	f, err := prepareTestBook1()
 	if !assert.NoError(t, err) {
@@ -160,6 +218,55 @@ func TestGetPicture(t *testing.T) {
 	f.Pkg.Store("xl/drawings/drawing1.xml", MacintoshCyrillicCharset)
 	_, _, err = f.getPicture(20, 5, "xl/drawings/drawing1.xml", "xl/drawings/_rels/drawing2.xml.rels")
 	assert.EqualError(t, err, "xml decode error: XML syntax error on line 1: invalid UTF-8")
+	// Test get picture with invalid anchor and missing image relationship.
+	f.Pkg.Delete("xl/drawings/drawing1.xml")
+	f.Drawings.Store("xl/drawings/drawing1.xml", &xlsxWsDr{
+		TwoCellAnchor: []*xdrCellAnchor{{GraphicFrame: "<xdr:from><xdr:col>A</xdr:col></xdr:from>"}},
+	})
+	_, _, err = f.getPicture(20, 5, "xl/drawings/drawing1.xml", "xl/drawings/_rels/drawing2.xml.rels")
+	assert.EqualError(t, err, `xml decode error: strconv.ParseInt: parsing "A": invalid syntax`)
+	f.Drawings.Store("xl/drawings/drawing1.xml", &xlsxWsDr{
+		TwoCellAnchor: []*xdrCellAnchor{{GraphicFrame: `<xdr:from><xdr:col>5</xdr:col><xdr:row>20</xdr:row></xdr:from><xdr:pic><xdr:blipFill><a:blip r:embed="rId100"></a:blip></xdr:blipFill></xdr:pic>`}},
+	})
+	file, raw, err = f.getPicture(20, 5, "xl/drawings/drawing1.xml", "xl/drawings/_rels/drawing2.xml.rels")
+	assert.NoError(t, err)
+	assert.Empty(t, file)
+	assert.Empty(t, raw)
+}
+
+func TestGetPictureCells(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddPicture("Sheet1", "B10", filepath.Join("test", "images", "excel.png"), ""))
+	assert.NoError(t, f.AddPicture("Sheet1", "A2", filepath.Join("test", "images", "excel.jpg"), `{"positioning": "oneCell"}`))
+	assert.NoError(t, f.AddPicture("Sheet1", "A1", filepath.Join("test", "images", "excel.gif"), ""))
+	assert.NoError(t, f.AddPicture("Sheet1", "A1", filepath.Join("test", "images", "excel.png"), ""))
+	assert.NoError(t, f.AddShape("Sheet1", "D4", `{"type": "rect", "paragraph": [{"text": "Rectangle"}]}`))
+	assert.NoError(t, f.AddChart("Sheet1", "F1", `{"type": "col", "series": [{"name": "Sheet1!$A$1", "categories": "Sheet1!$B$1:$D$1", "values": "Sheet1!$B$2:$D$2"}]}`))
+	cells, err := f.GetPictureCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"A1", "A2", "B10"}, cells)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGetPictureCells.xlsx")))
+
+	f, err = OpenFile(filepath.Join("test", "TestGetPictureCells.xlsx"))
+	assert.NoError(t, err)
+	cells, err = f.GetPictureCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"A1", "A2", "B10"}, cells)
+	// Test get picture cells from a worksheet without drawing.
+	f.NewSheet("Sheet2")
+	cells, err = f.GetPictureCells("Sheet2")
+	assert.NoError(t, err)
+	assert.Empty(t, cells)
+	// Test get picture cells from a worksheet which doesn't exist.
+	_, err = f.GetPictureCells("SheetN")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+	// Test get picture cells with invalid drawing anchor.
+	f.Drawings.Store("xl/drawings/drawing1.xml", &xlsxWsDr{
+		TwoCellAnchor: []*xdrCellAnchor{{GraphicFrame: "<xdr:from><xdr:col>A</xdr:col></xdr:from>"}},
+	})
+	_, err = f.GetPictureCells("Sheet1")
+	assert.EqualError(t, err, `xml decode error: strconv.ParseInt: parsing "A": invalid syntax`)
+	assert.NoError(t, f.Close())
 }
 
 func TestAddDrawingPicture(t *testing.T) {
@@ -185,10 +292,202 @@ func TestAddPictureFromBytes(t *testing.T) {
 	assert.EqualError(t, f.AddPictureFromBytes("SheetN", fmt.Sprint("A", 1), "", "logo", ".png", imgFile), "sheet SheetN is not exist")
 }
 
+func TestAddPictureFromReader(t *testing.T) {
+	f := NewFile()
+	file, err := os.Open(filepath.Join("test", "images", "excel.png"))
+	assert.NoError(t, err)
+	defer file.Close()
+	assert.NoError(t, f.AddPictureFromReader("Sheet1", "A1", "", "logo", ".png", file))
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	name, raw, err := f.GetPicture("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "image1.png", name)
+	assert.NotEmpty(t, raw)
+	// Test add picture with unsupported extension.
+	assert.EqualError(t, f.AddPictureFromReader("Sheet1", "A1", "", "logo", ".ico", bytes.NewReader(raw)), ErrImgExt.Error())
+	// Test add picture with empty content.
+	assert.EqualError(t, f.AddPictureFromReader("Sheet1", "A1", "", "logo", ".png", bytes.NewReader(nil)), ErrImgEmpty.Error())
+	// Test add picture with the reader returns error.
+	assert.EqualError(t, f.AddPictureFromReader("Sheet1", "A1", "", "logo", ".png", iotest.TimeoutReader(bytes.NewReader(raw))), iotest.ErrTimeout.Error())
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddPictureFromReader.xlsx")))
+}
+
+func TestAddPictureCrop(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddPicture("Sheet1", "A1", filepath.Join("test", "images", "excel.png"), `{"crop_left": 10, "crop_right": 20.5, "crop_top": 5, "crop_bottom": 15}`))
+	assert.NoError(t, f.AddPicture("Sheet1", "A10", filepath.Join("test", "images", "excel.png"), ""))
+	drawing, ok := f.Drawings.Load("xl/drawings/drawing1.xml")
+	assert.True(t, ok)
+	wsDr := drawing.(*xlsxWsDr)
+	assert.Equal(t, &xlsxSrcRect{L: 10000, T: 5000, R: 20500, B: 15000}, wsDr.TwoCellAnchor[0].Pic.BlipFill.SrcRect)
+	// Test add picture without crop settings.
+	assert.Nil(t, wsDr.TwoCellAnchor[1].Pic.BlipFill.SrcRect)
+	// Test add picture with invalid crop settings.
+	for _, format := range []string{
+		`{"crop_left": -1}`, `{"crop_right": 101}`, `{"crop_top": 100.5}`, `{"crop_bottom": -0.5}`,
+		`{"crop_left": 50, "crop_right": 50}`, `{"crop_top": 60, "crop_bottom": 40}`,
+	} {
+		assert.EqualError(t, f.AddPicture("Sheet1", "C1", filepath.Join("test", "images", "excel.png"), format), ErrPictureCrop.Error())
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddPictureCrop.xlsx")))
+}
+
+func TestPictureRotation(t *testing.T) {
+	f := NewFile()
+	for cell, rotation := range map[string]int{"A1": 15, "A10": -90, "A20": 450, "A30": 0} {
+		assert.NoError(t, f.AddPicture("Sheet1", cell, filepath.Join("test", "images", "excel.png"), fmt.Sprintf(`{"rotation": %d}`, rotation)))
+	}
+	expected := map[string]int{"A1": 15, "A10": 270, "A20": 90, "A30": 0, "B1": 0}
+	for cell, rotation := range expected {
+		result, err := f.GetPictureRotation("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, rotation, result, cell)
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestPictureRotation.xlsx")))
+
+	f, err := OpenFile(filepath.Join("test", "TestPictureRotation.xlsx"))
+	assert.NoError(t, err)
+	for cell, rotation := range expected {
+		result, err := f.GetPictureRotation("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, rotation, result, cell)
+	}
+	// Test get picture rotation with invalid parameters.
+	_, err = f.GetPictureRotation("Sheet1", "A")
+	assert.EqualError(t, err, `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+	_, err = f.GetPictureRotation("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+	// Test get picture rotation on the worksheet without drawing.
+	f.NewSheet("Sheet2")
+	rotation, err := f.GetPictureRotation("Sheet2", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, rotation)
+	// Test get picture rotation with invalid drawing anchor.
+	f.Drawings.Store("xl/drawings/drawing1.xml", &xlsxWsDr{
+		TwoCellAnchor: []*xdrCellAnchor{{GraphicFrame: "<xdr:from><xdr:col>A</xdr:col></xdr:from>"}},
+	})
+	_, err = f.GetPictureRotation("Sheet1", "A1")
+	assert.EqualError(t, err, `xml decode error: strconv.ParseInt: parsing "A": invalid syntax`)
+	assert.NoError(t, f.Close())
+}
+
+func TestReplacePicture(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddPicture("Sheet1", "A1", filepath.Join("test", "images", "excel.png"), `{"x_scale": 0.5}`))
+	assert.NoError(t, f.AddChart("Sheet1", "H1", `{"type": "col", "series": [{"name": "Sheet1!$A$1", "categories": "Sheet1!$B$1:$D$1", "values": "Sheet1!$B$2:$D$2"}]}`))
+	drawing, ok := f.Drawings.Load("xl/drawings/drawing1.xml")
+	assert.True(t, ok)
+	from, to := *drawing.(*xlsxWsDr).TwoCellAnchor[0].From, *drawing.(*xlsxWsDr).TwoCellAnchor[0].To
+	replaced, err := f.ReplacePicture("Sheet1", "A1", filepath.Join("test", "images", "excel.jpg"), "")
+	assert.NoError(t, err)
+	assert.True(t, replaced)
+	// Test the anchor of the replaced picture is not changed.
+	assert.Equal(t, from, *drawing.(*xlsxWsDr).TwoCellAnchor[0].From)
+	assert.Equal(t, to, *drawing.(*xlsxWsDr).TwoCellAnchor[0].To)
+	// Test the image and relationship of the replaced picture are removed.
+	_, ok = f.Pkg.Load("xl/media/image1.png")
+	assert.False(t, ok)
+	assert.Nil(t, f.getDrawingRelationships("xl/drawings/_rels/drawing1.xml.rels", "rId1"))
+	// Test replace picture at the cell without picture.
+	replaced, err = f.ReplacePicture("Sheet1", "D10", filepath.Join("test", "images", "excel.gif"), "")
+	assert.NoError(t, err)
+	assert.False(t, replaced)
+	assert.Len(t, drawing.(*xlsxWsDr).TwoCellAnchor, 3)
+	// Test replace picture on the worksheet without drawing.
+	f.NewSheet("Sheet2")
+	replaced, err = f.ReplacePicture("Sheet2", "A1", filepath.Join("test", "images", "excel.gif"), "")
+	assert.NoError(t, err)
+	assert.False(t, replaced)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestReplacePicture.xlsx")))
+
+	f, err = OpenFile(filepath.Join("test", "TestReplacePicture.xlsx"))
+	assert.NoError(t, err)
+	name, raw, err := f.GetPicture("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "image2.jpeg", name)
+	assert.NotEmpty(t, raw)
+	name, _, err = f.GetPicture("Sheet1", "D10")
+	assert.NoError(t, err)
+	assert.Equal(t, "image2.gif", name)
+	// Test replace picture in the opened workbook.
+	replaced, err = f.ReplacePicture("Sheet1", "A1", filepath.Join("test", "images", "excel.tif"), "")
+	assert.NoError(t, err)
+	assert.True(t, replaced)
+	name, _, err = f.GetPicture("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "image3.tiff", name)
+	_, ok = f.Pkg.Load("xl/media/image2.jpeg")
+	assert.False(t, ok)
+	// Test replace picture which image is used by other pictures.
+	assert.NoError(t, f.AddPicture("Sheet1", "D20", filepath.Join("test", "images", "excel.tif"), ""))
+	replaced, err = f.ReplacePicture("Sheet1", "A1", filepath.Join("test", "images", "excel.png"), "")
+	assert.NoError(t, err)
+	assert.True(t, replaced)
+	name, _, err = f.GetPicture("Sheet1", "D20")
+	assert.NoError(t, err)
+	assert.Equal(t, "image3.tiff", name)
+	// Test replace picture with invalid format set.
+	_, err = f.ReplacePicture("Sheet1", "A1", filepath.Join("test", "images", "excel.png"), "{")
+	assert.EqualError(t, err, "unexpected end of JSON input")
+	// Test replace picture with invalid parameters.
+	_, err = f.ReplacePicture("Sheet1", "A1", filepath.Join("test", "not_exists.png"), "")
+	assert.True(t, os.IsNotExist(err))
+	_, err = f.ReplacePicture("Sheet1", "A1", filepath.Join("test", "Book1.xlsx"), "")
+	assert.EqualError(t, err, ErrImgExt.Error())
+	_, err = f.ReplacePicture("Sheet1", "A", filepath.Join("test", "images", "excel.png"), "")
+	assert.EqualError(t, err, `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+	_, err = f.ReplacePicture("SheetN", "A1", filepath.Join("test", "images", "excel.png"), "")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+	// Test replace picture with invalid drawing anchor.
+	f.Drawings.Store("xl/drawings/drawing1.xml", &xlsxWsDr{
+		TwoCellAnchor: []*xdrCellAnchor{{GraphicFrame: "<xdr:from><xdr:col>A</xdr:col></xdr:from>"}},
+	})
+	_, err = f.ReplacePicture("Sheet1", "A1", filepath.Join("test", "images", "excel.png"), "")
+	assert.EqualError(t, err, `xml decode error: strconv.ParseInt: parsing "A": invalid syntax`)
+	assert.NoError(t, f.Close())
+}
+
+func TestAddPictureWebP(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddPicture("Sheet1", "A1", filepath.Join("test", "images", "excel.webp"), ""))
+	assert.NoError(t, f.AddPicture("Sheet1", "F1", filepath.Join("test", "images", "excel.webp"), `{"auto_convert": true}`))
+	assert.NoError(t, f.AddPictureInCell("Sheet1", "K1", filepath.Join("test", "images", "excel.webp"), `{"auto_convert": true}`))
+	_, ok := f.Pkg.Load("xl/media/image1.webp")
+	assert.True(t, ok)
+	_, ok = f.Pkg.Load("xl/media/image2.png")
+	assert.True(t, ok)
+	_, ok = f.Pkg.Load("xl/media/image3.png")
+	assert.False(t, ok)
+	var contentTypes []string
+	for _, v := range f.contentTypesReader().Defaults {
+		contentTypes = append(contentTypes, v.ContentType)
+	}
+	assert.Contains(t, contentTypes, "image/webp")
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddPictureWebP.xlsx")))
+
+	f, err := OpenFile(filepath.Join("test", "TestAddPictureWebP.xlsx"))
+	assert.NoError(t, err)
+	name, _, err := f.GetPicture("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "image1.webp", name)
+	name, raw, err := f.GetPicture("Sheet1", "F1")
+	assert.NoError(t, err)
+	assert.Equal(t, "image2.png", name)
+	_, format, err := image.DecodeConfig(bytes.NewReader(raw))
+	assert.NoError(t, err)
+	assert.Equal(t, "png", format)
+	// Test convert picture with invalid image content.
+	assert.EqualError(t, f.AddPictureFromBytes("Sheet1", "A10", `{"auto_convert": true}`, "Excel", ".webp", make([]byte, 1)), image.ErrFormat.Error())
+	assert.NoError(t, f.Close())
+}
+
 func TestDeletePicture(t *testing.T) {
 	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
 	assert.NoError(t, err)
-	assert.NoError(t, f.DeletePicture("Sheet1", "A1"))
+	assert.EqualError(t, f.DeletePicture("Sheet1", "A1"), "no picture at cell A1")
 	assert.NoError(t, f.AddPicture("Sheet1", "P1", filepath.Join("test", "images", "excel.jpg"), ""))
 	assert.NoError(t, f.DeletePicture("Sheet1", "P1"))
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDeletePicture.xlsx")))
@@ -198,7 +497,62 @@ func TestDeletePicture(t *testing.T) {
 	assert.EqualError(t, f.DeletePicture("Sheet1", ""), `cannot convert cell "" to coordinates: invalid cell name ""`)
 	assert.NoError(t, f.Close())
 	// Test delete picture on no chart worksheet.
-	assert.NoError(t, NewFile().DeletePicture("Sheet1", "A1"))
+	assert.EqualError(t, NewFile().DeletePicture("Sheet1", "A1"), "no picture at cell A1")
+
+	// Test delete picture and keep the charts and the shared images.
+	f = NewFile()
+	assert.NoError(t, f.AddPicture("Sheet1", "A1", filepath.Join("test", "images", "excel.png"), ""))
+	assert.NoError(t, f.AddPicture("Sheet1", "A10", filepath.Join("test", "images", "excel.png"), ""))
+	assert.NoError(t, f.AddPicture("Sheet1", "A20", filepath.Join("test", "images", "excel.jpg"), ""))
+	assert.NoError(t, f.AddChart("Sheet1", "A20", `{"type": "col", "series": [{"name": "Sheet1!$A$1", "categories": "Sheet1!$B$1:$D$1", "values": "Sheet1!$B$2:$D$2"}]}`))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDeletePicture2.xlsx")))
+	f, err = OpenFile(filepath.Join("test", "TestDeletePicture2.xlsx"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.DeletePicture("Sheet1", "A1"))
+	_, ok := f.Pkg.Load("xl/media/image1.png")
+	assert.True(t, ok)
+	assert.NoError(t, f.DeletePicture("Sheet1", "A20"))
+	_, ok = f.Pkg.Load("xl/media/image2.jpeg")
+	assert.False(t, ok)
+	assert.EqualError(t, f.DeletePicture("Sheet1", "A20"), "no picture at cell A20")
+	cells, err := f.GetPictureCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"A10"}, cells)
+	drawing, ok := f.Drawings.Load("xl/drawings/drawing1.xml")
+	assert.True(t, ok)
+	assert.Len(t, drawing.(*xlsxWsDr).TwoCellAnchor, 2)
+	assert.Len(t, f.relsReader("xl/drawings/_rels/drawing1.xml.rels").Relationships, 2)
+	assert.NoError(t, f.DeletePicture("Sheet1", "A10"))
+	_, ok = f.Pkg.Load("xl/media/image1.png")
+	assert.False(t, ok)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDeletePicture2.xlsx")))
+	assert.NoError(t, f.Close())
+
+	// Test delete picture with invalid drawing anchor.
+	f.Drawings.Store("xl/drawings/drawing1.xml", &xlsxWsDr{
+		TwoCellAnchor: []*xdrCellAnchor{{GraphicFrame: "<xdr:from><xdr:col>A</xdr:col></xdr:from>"}},
+	})
+	assert.EqualError(t, f.DeletePicture("Sheet1", "A1"), `xml decode error: strconv.ParseInt: parsing "A": invalid syntax`)
+	f.Drawings.Store("xl/drawings/drawing1.xml", &xlsxWsDr{
+		OneCellAnchor: []*xdrCellAnchor{{GraphicFrame: "<xdr:from><xdr:col>A</xdr:col></xdr:from>"}},
+	})
+	assert.EqualError(t, f.DeletePicture("Sheet1", "A1"), `xml decode error: strconv.ParseInt: parsing "A": invalid syntax`)
+}
+
+func TestIsMediaReferenced(t *testing.T) {
+	f := NewFile()
+	f.Relationships.Store("xl/worksheets/_rels/sheet1.xml.rels", &xlsxRelationships{
+		Relationships: []xlsxRelationship{
+			{ID: "rId1", Target: "http://example.com/media/image1.png", TargetMode: "External"},
+			{ID: "rId2", Target: "/xl/media/image2.png"},
+		},
+	})
+	assert.False(t, f.isMediaReferenced("xl/media/image1.png"))
+	assert.True(t, f.isMediaReferenced("xl/media/image2.png"))
+	f.Relationships.Store("xl/worksheets/_rels/sheet2.xml.rels", nil)
+	assert.False(t, f.isMediaReferenced("xl/media/image3.png"))
+	// Test delete relationship on not exists relationships part.
+	f.deleteRelationship("xl/worksheets/_rels/sheet2.xml.rels", "rId1")
 }
 
 func TestDrawingResize(t *testing.T) {
@@ -12,8 +12,12 @@
 package excelize
 
 import (
+	"bytes"
 	"encoding/xml"
 	"fmt"
+	"io"
+	"math"
+	"path/filepath"
 	"strconv"
 	"strings"
 )
@@ -34,6 +38,7 @@ type PivotTableOption struct {
 	Columns             []PivotTableField
 	Data                []PivotTableField
 	Filter              []PivotTableField
+	CalculatedFields    []PivotTableCalculatedField
 	RowGrandTotals      bool
 	ColGrandTotals      bool
 	ShowDrill           bool
@@ -69,6 +74,26 @@ type PivotTableOption struct {
 //
 // Name specifies the name of the data field. Maximum 255 characters
 // are allowed in data field name, excess characters will be truncated.
+//
+// ShowDataAs specifies the display format of the data field, the data field
+// shows the aggregated values if the ShowDataAs is empty. The possible values
+// for this attribute are:
+//
+//     Normal
+//     Difference
+//     Percent
+//     PercentDiff
+//     RunTotal
+//     PercentOfRow
+//     PercentOfCol
+//     PercentOfTotal
+//     Index
+//
+// BaseField specifies the name of the base field of the Difference, Percent,
+// PercentDiff and RunTotal display formats. BaseItem specifies the base item
+// in the base field of the Difference, Percent and PercentDiff display
+// formats, which is "previous", "next" or the index of the item in the base
+// field, the default value is "previous".
 type PivotTableField struct {
 	Compact         bool
 	Data            string
@@ -76,6 +101,23 @@ type PivotTableField struct {
 	Outline         bool
 	Subtotal        string
 	DefaultSubtotal bool
+	ShowDataAs      string
+	BaseField       string
+	BaseItem        string
+}
+
+// PivotTableCalculatedField directly maps the calculated field settings of
+// the pivot table. Name specifies the name of the calculated field, and
+// Formula specifies the formula of the calculated field, which refers to the
+// fields of the data region by the field names, for example:
+//
+//    excelize.PivotTableCalculatedField{Name: "Profit", Formula: "Revenue - Cost"}
+//
+// The calculated field could be used as a data field by its name, but can not
+// be used in the Rows, Columns and Filter fields.
+type PivotTableCalculatedField struct {
+	Name    string
+	Formula string
 }
 
 // AddPivotTable provides the method to add pivot table by given pivot table
@@ -167,6 +209,431 @@ func (f *File) AddPivotTable(opt *PivotTableOption) error {
 	return nil
 }
 
+// GetPivotTables provides the method to get the pivot tables in a worksheet
+// by given worksheet name, the options of each pivot table are reconstructed
+// from the pivot table definition and its pivot cache. For example, get the
+// pivot tables in Sheet1:
+//
+//    pivotTables, err := f.GetPivotTables("Sheet1")
+//
+func (f *File) GetPivotTables(sheet string) ([]PivotTableOption, error) {
+	pivotTables := []PivotTableOption{}
+	sheetXML, ok := f.sheetMap[trimSheetName(sheet)]
+	if !ok {
+		return pivotTables, ErrSheetNotExist{sheet}
+	}
+	for _, pivotTableXML := range f.getSheetPivotTables(sheetXML) {
+		opt, err := f.getPivotTable(sheet, pivotTableXML)
+		if err != nil {
+			return pivotTables, err
+		}
+		pivotTables = append(pivotTables, opt)
+	}
+	return pivotTables, nil
+}
+
+// getSheetPivotTables provides a function to get the paths of the pivot
+// table parts by given worksheet file path.
+func (f *File) getSheetPivotTables(sheetXML string) []string {
+	var pivotTables []string
+	if sheetRels := f.relsReader("xl/worksheets/_rels/" + filepath.Base(sheetXML) + ".rels"); sheetRels != nil {
+		sheetRels.Lock()
+		for _, v := range sheetRels.Relationships {
+			if v.Type == SourceRelationshipPivotTable {
+				pivotTables = append(pivotTables, getPivotPartPath(v.Target))
+			}
+		}
+		sheetRels.Unlock()
+	}
+	return pivotTables
+}
+
+// getPivotCachePath provides a function to get the path of the pivot cache
+// definition part by given pivot table definition part path.
+func (f *File) getPivotCachePath(pivotTableXML string) string {
+	var pivotCacheXML string
+	if rels := f.relsReader("xl/pivotTables/_rels/" + filepath.Base(pivotTableXML) + ".rels"); rels != nil {
+		rels.Lock()
+		for _, v := range rels.Relationships {
+			if v.Type == SourceRelationshipPivotCache {
+				pivotCacheXML = getPivotPartPath(v.Target)
+			}
+		}
+		rels.Unlock()
+	}
+	return pivotCacheXML
+}
+
+// getPivotTable provides a function to get the options of the pivot table by
+// given worksheet name and the path of the pivot table definition part.
+func (f *File) getPivotTable(sheet, pivotTableXML string) (PivotTableOption, error) {
+	opt := PivotTableOption{pivotTableSheetName: sheet}
+	pt, err := f.pivotTableReader(pivotTableXML)
+	if err != nil {
+		return opt, err
+	}
+	pc, err := f.pivotCacheReader(f.getPivotCachePath(pivotTableXML))
+	if err != nil {
+		return opt, err
+	}
+	if pc.CacheSource != nil && pc.CacheSource.WorksheetSource != nil {
+		opt.DataRange = pc.CacheSource.WorksheetSource.Name
+		if ref, err := getPrintAreaRef(pc.CacheSource.WorksheetSource.Ref); err == nil && opt.DataRange == "" {
+			opt.DataRange = quoteSheetName(pc.CacheSource.WorksheetSource.Sheet) + "!" + ref
+		}
+	}
+	if pt.Location != nil {
+		if ref, err := getPrintAreaRef(pt.Location.Ref); err == nil {
+			opt.PivotTableRange = quoteSheetName(sheet) + "!" + ref
+		}
+	}
+	var names []string
+	if pc.CacheFields != nil {
+		for _, cacheField := range pc.CacheFields.CacheField {
+			names = append(names, cacheField.Name)
+			if cacheField.Formula != "" {
+				opt.CalculatedFields = append(opt.CalculatedFields, PivotTableCalculatedField{
+					Name:    cacheField.Name,
+					Formula: cacheField.Formula,
+				})
+			}
+		}
+	}
+	fieldName := func(idx int) string {
+		if idx >= 0 && idx < len(names) {
+			return names[idx]
+		}
+		return ""
+	}
+	axisField := func(idx int) PivotTableField {
+		pivotField := &xlsxPivotField{}
+		if pt.PivotFields != nil && idx >= 0 && idx < len(pt.PivotFields.PivotField) {
+			pivotField = pt.PivotFields.PivotField[idx]
+		}
+		return PivotTableField{
+			Data:            fieldName(idx),
+			Name:            pivotField.Name,
+			Compact:         defaultTrue(pivotField.Compact),
+			Outline:         defaultTrue(pivotField.Outline),
+			DefaultSubtotal: defaultTrue(pivotField.DefaultSubtotal),
+		}
+	}
+	if pt.RowFields != nil {
+		for _, field := range pt.RowFields.Field {
+			if field.X != -2 {
+				opt.Rows = append(opt.Rows, axisField(field.X))
+			}
+		}
+	}
+	if pt.ColFields != nil {
+		for _, field := range pt.ColFields.Field {
+			if field.X != -2 {
+				opt.Columns = append(opt.Columns, axisField(field.X))
+			}
+		}
+	}
+	if pt.PageFields != nil {
+		for _, pageField := range pt.PageFields.PageField {
+			opt.Filter = append(opt.Filter, PivotTableField{Data: fieldName(pageField.Fld), Name: pageField.Name})
+		}
+	}
+	if pt.DataFields != nil {
+		for _, dataField := range pt.DataFields.DataField {
+			field := PivotTableField{
+				Data:       fieldName(dataField.Fld),
+				Name:       dataField.Name,
+				Subtotal:   dataField.Subtotal,
+				ShowDataAs: dataField.ShowDataAs,
+			}
+			if field.Subtotal == "" {
+				field.Subtotal = "sum"
+			}
+			if dataField.BaseField != nil {
+				field.BaseField = fieldName(*dataField.BaseField)
+			}
+			if dataField.BaseItem != nil {
+				switch *dataField.BaseItem {
+				case 1048828:
+					field.BaseItem = "previous"
+				case 1048829:
+					field.BaseItem = "next"
+				default:
+					field.BaseItem = strconv.FormatInt(*dataField.BaseItem, 10)
+				}
+			}
+			opt.Data = append(opt.Data, field)
+		}
+	}
+	opt.RowGrandTotals = defaultTrue(pt.RowGrandTotals)
+	opt.ColGrandTotals = defaultTrue(pt.ColGrandTotals)
+	opt.ShowDrill = defaultTrue(pt.ShowDrill)
+	opt.UseAutoFormatting = pt.UseAutoFormatting != nil && *pt.UseAutoFormatting
+	opt.PageOverThenDown = pt.PageOverThenDown != nil && *pt.PageOverThenDown
+	opt.MergeItem = pt.MergeItem != nil && *pt.MergeItem
+	opt.CompactData = defaultTrue(pt.CompactData)
+	opt.ShowError = pt.ShowError != nil && *pt.ShowError
+	if pt.PivotTableStyleInfo != nil {
+		opt.PivotTableStyleName = pt.PivotTableStyleInfo.Name
+		opt.ShowRowHeaders = pt.PivotTableStyleInfo.ShowRowHeaders
+		opt.ShowColHeaders = pt.PivotTableStyleInfo.ShowColHeaders
+		opt.ShowRowStripes = pt.PivotTableStyleInfo.ShowRowStripes
+		opt.ShowColStripes = pt.PivotTableStyleInfo.ShowColStripes
+		opt.ShowLastColumn = pt.PivotTableStyleInfo.ShowLastColumn
+	}
+	return opt, err
+}
+
+// RefreshPivotTable provides the method to refresh the pivot cache of the
+// pivot table by given pivot table name, the pivot cache records will be
+// rebuilt from the current values of the source data range, and Excel will
+// refresh the pivot table when the workbook is opened. If the source data
+// range is not a defined name, the rows of the source data range will be
+// extended to include the rows with values added right below the range, or
+// shrunk to exclude the empty rows at the end of the range. For example,
+// refresh the pivot table named "Pivot Table1" after changing the source
+// data:
+//
+//    err := f.RefreshPivotTable("Pivot Table1")
+//
+func (f *File) RefreshPivotTable(name string) error {
+	for _, sheet := range f.GetSheetList() {
+		for _, pivotTableXML := range f.getSheetPivotTables(f.sheetMap[sheet]) {
+			pt, err := f.pivotTableReader(pivotTableXML)
+			if err != nil {
+				return err
+			}
+			if strings.EqualFold(pt.Name, name) {
+				return f.refreshPivotTable(sheet, pivotTableXML, pt)
+			}
+		}
+	}
+	return newNoPivotTableError(name)
+}
+
+// refreshPivotTable provides a function to rebuild the pivot cache records
+// and the items of the pivot fields by given worksheet name, the path of the
+// pivot table definition part and the pivot table definition.
+func (f *File) refreshPivotTable(sheet, pivotTableXML string, pt *xlsxPivotTableDefinition) error {
+	pivotCacheXML := f.getPivotCachePath(pivotTableXML)
+	pc, err := f.pivotCacheReader(pivotCacheXML)
+	if err != nil {
+		return err
+	}
+	if pc.CacheSource == nil || pc.CacheSource.WorksheetSource == nil {
+		return ErrParameterInvalid
+	}
+	source := pc.CacheSource.WorksheetSource
+	dataRange := source.Sheet + "!" + source.Ref
+	if source.Name != "" {
+		dataRange = f.getDefinedNameRefTo(source.Name, sheet)
+	}
+	dataSheet, coordinates, err := f.adjustRange(dataRange)
+	if err != nil {
+		return err
+	}
+	rows, err := f.GetRows(dataSheet, Options{RawCellValue: true})
+	if err != nil {
+		return err
+	}
+	cellValue := func(col, row int) string {
+		if row <= len(rows) && col <= len(rows[row-1]) {
+			return rows[row-1][col-1]
+		}
+		return ""
+	}
+	emptyRow := func(row int) bool {
+		for col := coordinates[0]; col <= coordinates[2]; col++ {
+			if cellValue(col, row) != "" {
+				return false
+			}
+		}
+		return true
+	}
+	if source.Name == "" {
+		for coordinates[3] < TotalRows && !emptyRow(coordinates[3]+1) {
+			coordinates[3]++
+		}
+		for coordinates[3] > coordinates[1]+1 && emptyRow(coordinates[3]) {
+			coordinates[3]--
+		}
+		hcell, _ := CoordinatesToCellName(coordinates[0], coordinates[1])
+		vcell, _ := CoordinatesToCellName(coordinates[2], coordinates[3])
+		source.Ref = hcell + ":" + vcell
+	}
+	var calculatedFields []*xlsxCacheField
+	if pc.CacheFields != nil {
+		for _, cacheField := range pc.CacheFields.CacheField {
+			if cacheField.Formula != "" {
+				calculatedFields = append(calculatedFields, cacheField)
+			}
+		}
+	}
+	cacheFields, records := f.getPivotCacheRecords(dataSheet, coordinates, cellValue)
+	pc.CacheFields = &xlsxCacheFields{CacheField: append(cacheFields, calculatedFields...)}
+	pc.CacheFields.Count = len(pc.CacheFields.CacheField)
+	pc.RecordCount, pc.SaveData, pc.RefreshOnLoad = records.Count, true, true
+	if pc.RID, err = f.savePivotCacheRecords(pivotCacheXML, pc.RID, records); err != nil {
+		return err
+	}
+	if pt.PivotFields != nil {
+		for idx, pivotField := range pt.PivotFields.PivotField {
+			if idx >= len(cacheFields) || pivotField.Axis == "" {
+				continue
+			}
+			var items []*xlsxItem
+			for x := 0; x < cacheFields[idx].SharedItems.Count; x++ {
+				items = append(items, &xlsxItem{X: intPtr(x)})
+			}
+			if defaultTrue(pivotField.DefaultSubtotal) {
+				items = append(items, &xlsxItem{T: "default"})
+			}
+			pivotField.Items = &xlsxItems{Count: len(items), Item: items}
+		}
+	}
+	pivotCache, _ := xml.Marshal(pc)
+	f.saveFileList(pivotCacheXML, pivotCache)
+	pivotTable, err := xml.Marshal(pt)
+	f.saveFileList(pivotTableXML, pivotTable)
+	return err
+}
+
+// getPivotCacheRecords provides a function to build the cache fields with
+// the shared items and the pivot cache records by given worksheet name, the
+// coordinates of the source data range and the function to get cell value.
+func (f *File) getPivotCacheRecords(sheet string, coordinates []int, cellValue func(col, row int) string) ([]*xlsxCacheField, *xlsxPivotCacheRecords) {
+	var cacheFields []*xlsxCacheField
+	records := &xlsxPivotCacheRecords{}
+	for col := coordinates[0]; col <= coordinates[2]; col++ {
+		cacheFields = append(cacheFields, &xlsxCacheField{Name: cellValue(col, coordinates[1]), SharedItems: &xlsxSharedItems{}})
+	}
+	for row := coordinates[1] + 1; row <= coordinates[3]; row++ {
+		records.R = append(records.R, &xlsxPivotCacheRecord{})
+	}
+	for idx, cacheField := range cacheFields {
+		col, index := coordinates[0]+idx, map[string]int{}
+		var hasString, hasNumber, hasBool bool
+		integer, min, max := true, math.MaxFloat64, -math.MaxFloat64
+		for row := coordinates[1] + 1; row <= coordinates[3]; row++ {
+			val := cellValue(col, row)
+			cell, _ := CoordinatesToCellName(col, row)
+			cellType, _ := f.GetCellType(sheet, cell)
+			item := xlsxPivotCacheItem{XMLName: xml.Name{Local: "s"}, V: stringPtr(val)}
+			num, err := strconv.ParseFloat(val, 64)
+			switch {
+			case val == "":
+				item = xlsxPivotCacheItem{XMLName: xml.Name{Local: "m"}}
+				cacheField.SharedItems.ContainsBlank = true
+			case cellType == CellTypeBool:
+				item.XMLName.Local, hasBool = "b", true
+			case (cellType == CellTypeUnset || cellType == CellTypeNumber) && err == nil:
+				item.XMLName.Local, hasNumber = "n", true
+				integer = integer && num == math.Trunc(num)
+				min, max = math.Min(min, num), math.Max(max, num)
+			default:
+				hasString = true
+			}
+			key := item.XMLName.Local + ":" + val
+			x, ok := index[key]
+			if !ok {
+				x = len(cacheField.SharedItems.Items)
+				index[key] = x
+				cacheField.SharedItems.Items = append(cacheField.SharedItems.Items, item)
+			}
+			records.R[row-coordinates[1]-1].Items = append(records.R[row-coordinates[1]-1].Items,
+				xlsxPivotCacheItem{XMLName: xml.Name{Local: "x"}, V: stringPtr(strconv.Itoa(x))})
+		}
+		sharedItems := cacheField.SharedItems
+		sharedItems.Count = len(sharedItems.Items)
+		if !hasString {
+			sharedItems.ContainsString = boolPtr(false)
+			if !sharedItems.ContainsBlank {
+				sharedItems.ContainsSemiMixedTypes = boolPtr(false)
+			}
+		}
+		sharedItems.ContainsMixedTypes = (hasString && hasNumber) || (hasString && hasBool) || (hasNumber && hasBool)
+		if hasNumber {
+			sharedItems.ContainsNumber, sharedItems.ContainsInteger = true, integer
+			sharedItems.MinValue, sharedItems.MaxValue = &min, &max
+		}
+	}
+	records.Count = len(records.R)
+	return cacheFields, records
+}
+
+// savePivotCacheRecords provides a function to save the pivot cache records
+// part by given pivot cache definition part path, the relationship ID of the
+// pivot cache records and the pivot cache records, returns the relationship
+// ID of the pivot cache records.
+func (f *File) savePivotCacheRecords(pivotCacheXML, rID string, records *xlsxPivotCacheRecords) (string, error) {
+	pivotCacheRels := "xl/pivotCache/_rels/" + filepath.Base(pivotCacheXML) + ".rels"
+	var recordsXML string
+	if rels := f.relsReader(pivotCacheRels); rels != nil && rID != "" {
+		rels.Lock()
+		for _, v := range rels.Relationships {
+			if v.ID == rID && v.Type == SourceRelationshipPivotCacheRecords {
+				recordsXML = strings.TrimPrefix(v.Target, "/")
+				if !strings.HasPrefix(v.Target, "/") {
+					recordsXML = filepath.ToSlash(filepath.Join(filepath.Dir(pivotCacheXML), v.Target))
+				}
+			}
+		}
+		rels.Unlock()
+	}
+	if recordsXML == "" {
+		recordsID := f.countPivotCacheRecords() + 1
+		recordsXML = "xl/pivotCache/pivotCacheRecords" + strconv.Itoa(recordsID) + ".xml"
+		rID = "rId" + strconv.Itoa(f.addRels(pivotCacheRels, SourceRelationshipPivotCacheRecords, filepath.Base(recordsXML), ""))
+		f.addContentTypePart(recordsID, "pivotCacheRecords")
+	}
+	output, err := xml.Marshal(records)
+	f.saveFileList(recordsXML, output)
+	return rID, err
+}
+
+// countPivotCacheRecords provides a function to get pivot cache records files
+// count storage in the folder xl/pivotCache.
+func (f *File) countPivotCacheRecords() int {
+	count := 0
+	f.Pkg.Range(func(k, v interface{}) bool {
+		if strings.Contains(k.(string), "xl/pivotCache/pivotCacheRecords") {
+			count++
+		}
+		return true
+	})
+	return count
+}
+
+// getPivotPartPath provides a function to get the path of the pivot table or
+// pivot cache part by given relationship target.
+func getPivotPartPath(target string) string {
+	if strings.HasPrefix(target, "/") {
+		return strings.TrimPrefix(target, "/")
+	}
+	return strings.Replace(target, "..", "xl", 1)
+}
+
+// pivotTableReader provides a function to get the pointer to the structure
+// after deserialization of xl/pivotTables/pivotTable%d.xml.
+func (f *File) pivotTableReader(path string) (*xlsxPivotTableDefinition, error) {
+	var pt xlsxPivotTableDefinition
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(path)))).
+		Decode(&pt); err != nil && err != io.EOF {
+		return &pt, err
+	}
+	return &pt, nil
+}
+
+// pivotCacheReader provides a function to get the pointer to the structure
+// after deserialization of xl/pivotCache/pivotCacheDefinition%d.xml.
+func (f *File) pivotCacheReader(path string) (*xlsxPivotCacheDefinition, error) {
+	var pc xlsxPivotCacheDefinition
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(path)))).
+		Decode(&pc); err != nil && err != io.EOF {
+		return &pc, err
+	}
+	return &pc, nil
+}
+
 // parseFormatPivotTableSet provides a function to validate pivot table
 // properties.
 func (f *File) parseFormatPivotTableSet(opt *PivotTableOption) (*xlsxWorksheet, string, error) {
@@ -194,7 +661,48 @@ func (f *File) parseFormatPivotTableSet(opt *PivotTableOption) (*xlsxWorksheet,
 	if !ok {
 		return dataSheet, pivotTableSheetPath, fmt.Errorf("sheet %s is not exist", pivotTableSheetName)
 	}
-	return dataSheet, pivotTableSheetPath, err
+	if err = f.checkPivotCalculatedFields(opt); err != nil {
+		return dataSheet, pivotTableSheetPath, err
+	}
+	return dataSheet, pivotTableSheetPath, f.checkPivotDataFields(opt)
+}
+
+// checkPivotDataFields provides a function to validate the display format
+// settings of the data fields of the pivot table.
+func (f *File) checkPivotDataFields(opt *PivotTableOption) error {
+	order, err := f.getPivotFieldsOrder(opt)
+	if err != nil {
+		return err
+	}
+	for _, field := range opt.Data {
+		if _, _, _, err = getPivotTableShowDataAs(field, order); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// checkPivotCalculatedFields provides a function to validate the calculated
+// fields of the pivot table.
+func (f *File) checkPivotCalculatedFields(opt *PivotTableOption) error {
+	order, err := f.getPivotFieldsOrder(opt)
+	if err != nil {
+		return err
+	}
+	offset := len(order) - len(opt.CalculatedFields)
+	for idx, field := range opt.CalculatedFields {
+		if field.Name == "" || strings.TrimPrefix(field.Formula, "=") == "" {
+			return ErrParameterRequired
+		}
+		if inStrSlice(order, field.Name) != offset+idx {
+			return fmt.Errorf("duplicate pivot table field name %s", field.Name)
+		}
+		if inPivotTableField(opt.Rows, field.Name) != -1 || inPivotTableField(opt.Columns, field.Name) != -1 ||
+			inPivotTableField(opt.Filter, field.Name) != -1 {
+			return fmt.Errorf("calculated field %s can only be used in data fields", field.Name)
+		}
+	}
+	return err
 }
 
 // adjustRange adjust range, for example: adjust Sheet1!$E$31:$A$1 to Sheet1!$A$1:$E$31
@@ -206,6 +714,9 @@ func (f *File) adjustRange(rangeStr string) (string, []int, error) {
 	if len(rng) != 2 {
 		return "", []int{}, ErrParameterInvalid
 	}
+	if len(rng[0]) > 1 && strings.HasPrefix(rng[0], "'") && strings.HasSuffix(rng[0], "'") {
+		rng[0] = strings.ReplaceAll(rng[0][1:len(rng[0])-1], "''", "'")
+	}
 	trimRng := strings.Replace(rng[1], "$", "", -1)
 	coordinates, err := areaRefToCoordinates(trimRng)
 	if err != nil {
@@ -247,6 +758,9 @@ func (f *File) getPivotFieldsOrder(opt *PivotTableOption) ([]string, error) {
 		}
 		order = append(order, name)
 	}
+	for _, field := range opt.CalculatedFields {
+		order = append(order, field.Name)
+	}
 	return order, nil
 }
 
@@ -285,19 +799,24 @@ func (f *File) addPivotCache(pivotCacheID int, pivotCacheXML string, opt *PivotT
 	if definedNameRef {
 		pc.CacheSource.WorksheetSource = &xlsxWorksheetSource{Name: opt.DataRange}
 	}
-	for _, name := range order {
+	offset := len(order) - len(opt.CalculatedFields)
+	for idx, name := range order {
+		if idx >= offset {
+			pc.CacheFields.CacheField = append(pc.CacheFields.CacheField, &xlsxCacheField{
+				Name:          name,
+				Formula:       strings.TrimPrefix(opt.CalculatedFields[idx-offset].Formula, "="),
+				DatabaseField: boolPtr(false),
+			})
+			continue
+		}
 		rowOptions, rowOk := f.getPivotTableFieldOptions(name, opt.Rows)
 		columnOptions, colOk := f.getPivotTableFieldOptions(name, opt.Columns)
 		sharedItems := xlsxSharedItems{
 			Count: 0,
 		}
-		s := xlsxString{}
 		if (rowOk && !rowOptions.DefaultSubtotal) || (colOk && !columnOptions.DefaultSubtotal) {
-			s = xlsxString{
-				V: "",
-			}
 			sharedItems.Count++
-			sharedItems.S = &s
+			sharedItems.Items = append(sharedItems.Items, xlsxPivotCacheItem{XMLName: xml.Name{Local: "s"}, V: stringPtr("")})
 		}
 
 		pc.CacheFields.CacheField = append(pc.CacheFields.CacheField, &xlsxCacheField{
@@ -448,16 +967,24 @@ func (f *File) addPivotDataFields(pt *xlsxPivotTableDefinition, opt *PivotTableO
 	if err != nil {
 		return err
 	}
+	order, _ := f.getPivotFieldsOrder(opt)
 	dataFieldsSubtotals := f.getPivotTableFieldsSubtotal(opt.Data)
 	dataFieldsName := f.getPivotTableFieldsName(opt.Data)
 	for idx, dataField := range dataFieldsIndex {
 		if pt.DataFields == nil {
 			pt.DataFields = &xlsxDataFields{}
 		}
+		showDataAs, baseField, baseItem, err := getPivotTableShowDataAs(opt.Data[idx], order)
+		if err != nil {
+			return err
+		}
 		pt.DataFields.DataField = append(pt.DataFields.DataField, &xlsxDataField{
-			Name:     dataFieldsName[idx],
-			Fld:      dataField,
-			Subtotal: dataFieldsSubtotals[idx],
+			Name:       dataFieldsName[idx],
+			Fld:        dataField,
+			Subtotal:   dataFieldsSubtotals[idx],
+			ShowDataAs: showDataAs,
+			BaseField:  baseField,
+			BaseItem:   baseItem,
 		})
 	}
 
@@ -657,6 +1184,51 @@ func (f *File) getPivotTableFieldsSubtotal(fields []PivotTableField) []string {
 	return field
 }
 
+// getPivotTableShowDataAs provides a function to get the display format,
+// the index of the base field and the base item of the data field by given
+// pivot table data field and the order list of the pivot table fields.
+func getPivotTableShowDataAs(field PivotTableField, order []string) (string, *int, *int64, error) {
+	if field.ShowDataAs == "" {
+		return "", nil, nil, nil
+	}
+	enums := []string{"normal", "difference", "percent", "percentDiff", "runTotal", "percentOfRow", "percentOfCol", "percentOfTotal", "index"}
+	var showDataAs string
+	for _, enum := range enums {
+		if strings.EqualFold(enum, field.ShowDataAs) {
+			showDataAs = enum
+		}
+	}
+	switch showDataAs {
+	case "":
+		return showDataAs, nil, nil, fmt.Errorf("invalid show data as type %s", field.ShowDataAs)
+	case "difference", "percent", "percentDiff", "runTotal":
+		if field.BaseField == "" {
+			return showDataAs, nil, nil, ErrParameterRequired
+		}
+		baseField := inStrSlice(order, field.BaseField)
+		if baseField == -1 {
+			return showDataAs, nil, nil, fmt.Errorf("pivot table field %s does not exist", field.BaseField)
+		}
+		if showDataAs == "runTotal" {
+			return showDataAs, &baseField, nil, nil
+		}
+		baseItem := int64(1048828)
+		switch strings.ToLower(field.BaseItem) {
+		case "", "previous":
+		case "next":
+			baseItem = 1048829
+		default:
+			idx, err := strconv.Atoi(field.BaseItem)
+			if err != nil || idx < 0 {
+				return showDataAs, nil, nil, ErrParameterInvalid
+			}
+			baseItem = int64(idx)
+		}
+		return showDataAs, &baseField, &baseItem, nil
+	}
+	return showDataAs, nil, nil, nil
+}
+
 // getPivotTableFieldsName prepare fields name list by given pivot table
 // fields.
 func (f *File) getPivotTableFieldsName(fields []PivotTableField) []string {
@@ -1,6 +1,7 @@
 package excelize
 
 import (
+	"encoding/xml"
 	"fmt"
 	"math/rand"
 	"path/filepath"
@@ -156,6 +157,107 @@ func TestAddPivotTable(t *testing.T) {
 		ShowColHeaders:  true,
 		ShowLastColumn:  true,
 	}))
+	// Create pivot table with calculated fields
+	assert.NoError(t, f.AddPivotTable(&PivotTableOption{
+		DataRange:        "Sheet1!$A$1:$E$31",
+		PivotTableRange:  "Sheet2!$A$94:$D$130",
+		Rows:             []PivotTableField{{Data: "Month", DefaultSubtotal: true}},
+		Data:             []PivotTableField{{Data: "Sales", Name: "Sum of Sales"}, {Data: "Tax", Name: "Sum of Tax"}},
+		CalculatedFields: []PivotTableCalculatedField{{Name: "Tax", Formula: "=Sales*0.1"}, {Name: "Net", Formula: "Sales-Tax"}},
+		RowGrandTotals:   true,
+		ColGrandTotals:   true,
+		ShowDrill:        true,
+		ShowRowHeaders:   true,
+		ShowColHeaders:   true,
+		ShowLastColumn:   true,
+	}))
+	pc := xlsxPivotCacheDefinition{}
+	assert.NoError(t, xml.Unmarshal(f.readXML(fmt.Sprintf("xl/pivotCache/pivotCacheDefinition%d.xml", f.countPivotCache())), &pc))
+	assert.Len(t, pc.CacheFields.CacheField, 7)
+	assert.Equal(t, "Tax", pc.CacheFields.CacheField[5].Name)
+	assert.Equal(t, "Sales*0.1", pc.CacheFields.CacheField[5].Formula)
+	assert.Equal(t, boolPtr(false), pc.CacheFields.CacheField[5].DatabaseField)
+	assert.Equal(t, "Sales-Tax", pc.CacheFields.CacheField[6].Formula)
+	pt := xlsxPivotTableDefinition{}
+	assert.NoError(t, xml.Unmarshal(f.readXML(fmt.Sprintf("xl/pivotTables/pivotTable%d.xml", f.countPivotTables())), &pt))
+	assert.Equal(t, 7, pt.PivotFields.Count)
+	assert.True(t, pt.PivotFields.PivotField[5].DataField)
+	assert.False(t, pt.PivotFields.PivotField[6].DataField)
+	assert.Equal(t, 5, pt.DataFields.DataField[1].Fld)
+	// Create pivot table with the display formats of the data fields
+	assert.NoError(t, f.AddPivotTable(&PivotTableOption{
+		DataRange:       "Sheet1!$A$1:$E$31",
+		PivotTableRange: "Sheet2!$F$94:$K$130",
+		Rows:            []PivotTableField{{Data: "Month", DefaultSubtotal: true}, {Data: "Year"}},
+		Data: []PivotTableField{
+			{Data: "Sales", Name: "Percent of Total", ShowDataAs: "PercentOfTotal"},
+			{Data: "Sales", Name: "Running Total", ShowDataAs: "runTotal", BaseField: "Month"},
+			{Data: "Sales", Name: "Difference", ShowDataAs: "difference", BaseField: "Year"},
+			{Data: "Sales", Name: "Percent Difference", ShowDataAs: "percentDiff", BaseField: "Year", BaseItem: "next"},
+			{Data: "Sales", Name: "Percent", ShowDataAs: "percent", BaseField: "Year", BaseItem: "1"},
+		},
+		RowGrandTotals: true,
+		ColGrandTotals: true,
+		ShowDrill:      true,
+		ShowRowHeaders: true,
+		ShowColHeaders: true,
+		ShowLastColumn: true,
+	}))
+	pt = xlsxPivotTableDefinition{}
+	assert.NoError(t, xml.Unmarshal(f.readXML(fmt.Sprintf("xl/pivotTables/pivotTable%d.xml", f.countPivotTables())), &pt))
+	int64Ptr := func(i int64) *int64 { return &i }
+	for idx, expected := range []xlsxDataField{
+		{ShowDataAs: "percentOfTotal"},
+		{ShowDataAs: "runTotal", BaseField: intPtr(0)},
+		{ShowDataAs: "difference", BaseField: intPtr(1), BaseItem: int64Ptr(1048828)},
+		{ShowDataAs: "percentDiff", BaseField: intPtr(1), BaseItem: int64Ptr(1048829)},
+		{ShowDataAs: "percent", BaseField: intPtr(1), BaseItem: int64Ptr(1)},
+	} {
+		dataField := pt.DataFields.DataField[idx]
+		assert.Equal(t, expected.ShowDataAs, dataField.ShowDataAs)
+		assert.Equal(t, expected.BaseField, dataField.BaseField)
+		assert.Equal(t, expected.BaseItem, dataField.BaseItem)
+	}
+	// Test create pivot table with invalid display formats of the data fields
+	for _, c := range []struct {
+		field PivotTableField
+		err   string
+	}{
+		{PivotTableField{Data: "Sales", ShowDataAs: "unknown"}, "invalid show data as type unknown"},
+		{PivotTableField{Data: "Sales", ShowDataAs: "runTotal"}, "parameter is required"},
+		{PivotTableField{Data: "Sales", ShowDataAs: "difference", BaseField: "Period"}, "pivot table field Period does not exist"},
+		{PivotTableField{Data: "Sales", ShowDataAs: "percent", BaseField: "Year", BaseItem: "-1"}, "parameter is invalid"},
+	} {
+		assert.EqualError(t, f.AddPivotTable(&PivotTableOption{
+			DataRange:       "Sheet1!$A$1:$E$31",
+			PivotTableRange: "Sheet2!$F$94:$K$130",
+			Rows:            []PivotTableField{{Data: "Year"}},
+			Data:            []PivotTableField{c.field},
+		}), c.err)
+		assert.EqualError(t, f.addPivotDataFields(&xlsxPivotTableDefinition{}, &PivotTableOption{
+			DataRange:       "Sheet1!$A$1:$E$31",
+			PivotTableRange: "Sheet2!$F$94:$K$130",
+			Data:            []PivotTableField{c.field},
+		}), c.err)
+	}
+	assert.EqualError(t, f.checkPivotDataFields(&PivotTableOption{}), "parameter 'DataRange' parsing error: parameter is required")
+	// Test create pivot table with invalid calculated fields
+	for _, c := range []struct {
+		opt *PivotTableOption
+		err string
+	}{
+		{&PivotTableOption{CalculatedFields: []PivotTableCalculatedField{{Formula: "Sales*0.1"}}}, "parameter is required"},
+		{&PivotTableOption{CalculatedFields: []PivotTableCalculatedField{{Name: "Tax", Formula: "="}}}, "parameter is required"},
+		{&PivotTableOption{CalculatedFields: []PivotTableCalculatedField{{Name: "Sales", Formula: "Sales*0.1"}}}, "duplicate pivot table field name Sales"},
+		{&PivotTableOption{CalculatedFields: []PivotTableCalculatedField{{Name: "Tax", Formula: "Sales*0.1"}, {Name: "Tax", Formula: "Sales*0.2"}}}, "duplicate pivot table field name Tax"},
+		{&PivotTableOption{Rows: []PivotTableField{{Data: "Tax"}}, CalculatedFields: []PivotTableCalculatedField{{Name: "Tax", Formula: "Sales*0.1"}}}, "calculated field Tax can only be used in data fields"},
+		{&PivotTableOption{Filter: []PivotTableField{{Data: "Tax"}}, CalculatedFields: []PivotTableCalculatedField{{Name: "Tax", Formula: "Sales*0.1"}}}, "calculated field Tax can only be used in data fields"},
+	} {
+		c.opt.DataRange, c.opt.PivotTableRange = "Sheet1!$A$1:$E$31", "Sheet2!$A$94:$D$130"
+		c.opt.Data = []PivotTableField{{Data: "Sales"}}
+		assert.EqualError(t, f.AddPivotTable(c.opt), c.err)
+	}
+	assert.EqualError(t, f.checkPivotCalculatedFields(&PivotTableOption{}), "parameter 'DataRange' parsing error: parameter is required")
 
 	// Test empty pivot table options
 	assert.EqualError(t, f.AddPivotTable(nil), "parameter is required")
@@ -305,3 +407,249 @@ func TestGetPivotTableFieldName(t *testing.T) {
 	f := NewFile()
 	f.getPivotTableFieldName("-", []PivotTableField{})
 }
+
+func TestGetPivotTables(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]string{"Month", "Year", "Type", "Sales", "Region"}))
+	for i := 0; i < 30; i++ {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", i+2), &[]interface{}{"Jan", 2017 + i%3, "Meat", i * 10, "East"}))
+	}
+	pivotTables, err := f.GetPivotTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, pivotTables, 0)
+
+	f.NewSheet("Sheet2")
+	expected := []PivotTableOption{
+		{
+			DataRange:       "Sheet1!$A$1:$E$31",
+			PivotTableRange: "Sheet2!$A$1:$M$34",
+			Rows:            []PivotTableField{{Data: "Month", DefaultSubtotal: true, Compact: true, Outline: true}, {Data: "Year", Name: "Year Label"}},
+			Columns:         []PivotTableField{{Data: "Type", DefaultSubtotal: true}},
+			Filter:          []PivotTableField{{Data: "Region", Name: "Region Filter"}},
+			Data: []PivotTableField{
+				{Data: "Sales", Subtotal: "sum", Name: "Summarize by Sum"},
+				{Data: "Sales", Subtotal: "average", Name: "Running Total", ShowDataAs: "runTotal", BaseField: "Month"},
+				{Data: "Sales", Subtotal: "sum", Name: "Difference", ShowDataAs: "difference", BaseField: "Year", BaseItem: "next"},
+				{Data: "Tax", Subtotal: "sum", Name: "Sum of Tax"},
+			},
+			CalculatedFields:    []PivotTableCalculatedField{{Name: "Tax", Formula: "Sales*0.1"}},
+			RowGrandTotals:      true,
+			ShowDrill:           true,
+			ShowRowHeaders:      true,
+			ShowLastColumn:      true,
+			ShowError:           true,
+			PivotTableStyleName: "PivotStyleLight19",
+		},
+		{
+			DataRange:           "Sheet1!$A$1:$E$31",
+			PivotTableRange:     "Sheet2!$O$1:$Q$40",
+			Rows:                []PivotTableField{{Data: "Region"}},
+			Data:                []PivotTableField{{Data: "Sales", Subtotal: "max", Name: "Max of Sales"}},
+			ColGrandTotals:      true,
+			CompactData:         true,
+			MergeItem:           true,
+			PageOverThenDown:    true,
+			UseAutoFormatting:   true,
+			ShowColHeaders:      true,
+			ShowRowStripes:      true,
+			ShowColStripes:      true,
+			PivotTableStyleName: "PivotStyleLight16",
+		},
+	}
+	for _, opt := range expected {
+		opt := opt
+		assert.NoError(t, f.AddPivotTable(&opt))
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGetPivotTables.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestGetPivotTables.xlsx"))
+	assert.NoError(t, err)
+	pivotTables, err = f.GetPivotTables("Sheet2")
+	assert.NoError(t, err)
+	for idx := range expected {
+		expected[idx].pivotTableSheetName = "Sheet2"
+	}
+	assert.Equal(t, expected, pivotTables)
+
+	// Test get pivot tables with defined name data range
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "dataRange", RefersTo: "Sheet1!$A$1:$E$31"}))
+	assert.NoError(t, f.AddPivotTable(&PivotTableOption{
+		DataRange:       "dataRange",
+		PivotTableRange: "Sheet1!$G$1:$J$20",
+		Rows:            []PivotTableField{{Data: "Month"}},
+		Data:            []PivotTableField{{Data: "Sales"}},
+	}))
+	pivotTables, err = f.GetPivotTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, pivotTables, 1)
+	assert.Equal(t, "dataRange", pivotTables[0].DataRange)
+	assert.Equal(t, "Sheet1!$G$1:$J$20", pivotTables[0].PivotTableRange)
+	// Test get pivot tables on not exists worksheet
+	_, err = f.GetPivotTables("SheetN")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+	// Test get pivot tables with unsupported charset pivot cache
+	f.Pkg.Store("xl/pivotCache/pivotCacheDefinition3.xml", MacintoshCyrillicCharset)
+	_, err = f.GetPivotTables("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test get pivot tables with unsupported charset pivot table
+	f.Pkg.Store("xl/pivotTables/pivotTable3.xml", MacintoshCyrillicCharset)
+	_, err = f.GetPivotTables("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+	// Test get pivot part path with absolute relationship target
+	assert.Equal(t, "xl/pivotTables/pivotTable1.xml", getPivotPartPath("/xl/pivotTables/pivotTable1.xml"))
+
+	// Test get pivot tables on the worksheets which name should be quoted
+	f = NewFile()
+	f.SetSheetName("Sheet1", "Data Sheet")
+	assert.NoError(t, f.SetSheetRow("Data Sheet", "A1", &[]string{"Month", "Sales"}))
+	assert.NoError(t, f.SetSheetRow("Data Sheet", "A2", &[]interface{}{"Jan", 10}))
+	assert.NoError(t, f.AddPivotTable(&PivotTableOption{
+		DataRange:       "Data Sheet!$A$1:$B$2",
+		PivotTableRange: "Data Sheet!$D$1:$E$5",
+		Rows:            []PivotTableField{{Data: "Month"}},
+		Data:            []PivotTableField{{Data: "Sales"}},
+	}))
+	pivotTables, err = f.GetPivotTables("Data Sheet")
+	assert.NoError(t, err)
+	if assert.Len(t, pivotTables, 1) {
+		assert.Equal(t, "'Data Sheet'!$A$1:$B$2", pivotTables[0].DataRange)
+		assert.Equal(t, "'Data Sheet'!$D$1:$E$5", pivotTables[0].PivotTableRange)
+		// Test add pivot table with the quoted sheet name in the ranges
+		pivotTables[0].PivotTableRange = "'Data Sheet'!$H$1:$I$5"
+		assert.NoError(t, f.AddPivotTable(&pivotTables[0]))
+	}
+}
+
+func TestRefreshPivotTable(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]string{"Month", "Year", "Sales", "Active"}))
+	for i := 0; i < 4; i++ {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", i+2), &[]interface{}{[]string{"Jan", "Feb"}[i%2], 2017 + i%2, 10.5 * float64(i), i%2 == 0}))
+	}
+	assert.NoError(t, f.AddPivotTable(&PivotTableOption{
+		DataRange:       "Sheet1!$A$1:$D$5",
+		PivotTableRange: "Sheet1!$G$1:$J$20",
+		Rows:            []PivotTableField{{Data: "Month", DefaultSubtotal: true}, {Data: "Year"}},
+		Data:            []PivotTableField{{Data: "Sales"}},
+	}))
+	// Add rows right below the source data range
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A6", &[]interface{}{"Mar", "2019", nil, true}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A7", &[]interface{}{"Mar", 2019, 7}))
+	assert.NoError(t, f.RefreshPivotTable("pivot table1"))
+
+	pc, err := f.pivotCacheReader("xl/pivotCache/pivotCacheDefinition1.xml")
+	assert.NoError(t, err)
+	assert.Equal(t, "A1:D7", pc.CacheSource.WorksheetSource.Ref)
+	assert.True(t, pc.SaveData)
+	assert.True(t, pc.RefreshOnLoad)
+	assert.Equal(t, 6, pc.RecordCount)
+	assert.Equal(t, "rId1", pc.RID)
+	itemsOf := func(items []xlsxPivotCacheItem) []string {
+		var values []string
+		for _, item := range items {
+			value := item.XMLName.Local
+			if item.V != nil {
+				value += ":" + *item.V
+			}
+			values = append(values, value)
+		}
+		return values
+	}
+	fields := pc.CacheFields.CacheField
+	assert.Equal(t, []string{"Month", "Year", "Sales", "Active"}, []string{fields[0].Name, fields[1].Name, fields[2].Name, fields[3].Name})
+	assert.Equal(t, []string{"s:Jan", "s:Feb", "s:Mar"}, itemsOf(fields[0].SharedItems.Items))
+	assert.Equal(t, []string{"n:2017", "n:2018", "s:2019", "n:2019"}, itemsOf(fields[1].SharedItems.Items))
+	assert.True(t, fields[1].SharedItems.ContainsMixedTypes)
+	assert.Nil(t, fields[1].SharedItems.ContainsString)
+	assert.Equal(t, []string{"n:0", "n:10.5", "n:21", "n:31.5", "m", "n:7"}, itemsOf(fields[2].SharedItems.Items))
+	assert.Equal(t, boolPtr(false), fields[2].SharedItems.ContainsString)
+	assert.Nil(t, fields[2].SharedItems.ContainsSemiMixedTypes)
+	assert.True(t, fields[2].SharedItems.ContainsBlank)
+	assert.False(t, fields[2].SharedItems.ContainsInteger)
+	assert.Equal(t, 0.0, *fields[2].SharedItems.MinValue)
+	assert.Equal(t, 31.5, *fields[2].SharedItems.MaxValue)
+	assert.Equal(t, []string{"b:1", "b:0", "m"}, itemsOf(fields[3].SharedItems.Items))
+	assert.Equal(t, boolPtr(false), fields[3].SharedItems.ContainsString)
+	assert.Equal(t, 3, fields[3].SharedItems.Count)
+
+	records := xlsxPivotCacheRecords{}
+	assert.NoError(t, xml.Unmarshal(f.readXML("xl/pivotCache/pivotCacheRecords1.xml"), &records))
+	assert.Equal(t, 6, records.Count)
+	assert.Equal(t, []string{"x:2", "x:3", "x:5", "x:2"}, itemsOf(records.R[5].Items))
+
+	pt, err := f.pivotTableReader("xl/pivotTables/pivotTable1.xml")
+	assert.NoError(t, err)
+	assert.Equal(t, 4, pt.PivotFields.PivotField[0].Items.Count)
+	assert.Equal(t, "default", pt.PivotFields.PivotField[0].Items.Item[3].T)
+	assert.Equal(t, 4, pt.PivotFields.PivotField[1].Items.Count)
+	assert.Nil(t, pt.PivotFields.PivotField[2].Items)
+
+	// Test refresh pivot table after removing rows at the end of the range
+	assert.NoError(t, f.RemoveRow("Sheet1", 7))
+	assert.NoError(t, f.RemoveRow("Sheet1", 6))
+	assert.NoError(t, f.RemoveRow("Sheet1", 5))
+	assert.NoError(t, f.RefreshPivotTable("Pivot Table1"))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestRefreshPivotTable.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestRefreshPivotTable.xlsx"))
+	assert.NoError(t, err)
+	pc, err = f.pivotCacheReader("xl/pivotCache/pivotCacheDefinition1.xml")
+	assert.NoError(t, err)
+	assert.Equal(t, "A1:D4", pc.CacheSource.WorksheetSource.Ref)
+	assert.Equal(t, 3, pc.RecordCount)
+	assert.Equal(t, "rId1", pc.RID)
+	assert.Equal(t, boolPtr(false), pc.CacheFields.CacheField[2].SharedItems.ContainsSemiMixedTypes)
+	assert.True(t, pc.CacheFields.CacheField[1].SharedItems.ContainsInteger)
+	assert.Equal(t, 1, f.countPivotCacheRecords())
+
+	// Test refresh pivot table with defined name data range
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "dataRange", RefersTo: "Sheet1!$A$1:$C$3"}))
+	assert.NoError(t, f.AddPivotTable(&PivotTableOption{
+		DataRange:       "dataRange",
+		PivotTableRange: "Sheet1!$L$1:$N$20",
+		Rows:            []PivotTableField{{Data: "Month"}},
+		Data:            []PivotTableField{{Data: "Sales"}},
+		CalculatedFields: []PivotTableCalculatedField{
+			{Name: "Tax", Formula: "Sales*0.1"},
+		},
+	}))
+	assert.NoError(t, f.RefreshPivotTable("Pivot Table2"))
+	pc, err = f.pivotCacheReader("xl/pivotCache/pivotCacheDefinition2.xml")
+	assert.NoError(t, err)
+	assert.Equal(t, "dataRange", pc.CacheSource.WorksheetSource.Name)
+	assert.Equal(t, 2, pc.RecordCount)
+	assert.Equal(t, 4, pc.CacheFields.Count)
+	assert.Equal(t, "Sales*0.1", pc.CacheFields.CacheField[3].Formula)
+	assert.Equal(t, 2, f.countPivotCacheRecords())
+
+	// Test refresh not exists pivot table
+	assert.EqualError(t, f.RefreshPivotTable("Pivot Table3"), "pivot table Pivot Table3 does not exist")
+	// Test refresh pivot table with unsupported pivot cache source
+	pc.CacheSource.WorksheetSource = nil
+	output, err := xml.Marshal(pc)
+	assert.NoError(t, err)
+	f.saveFileList("xl/pivotCache/pivotCacheDefinition2.xml", output)
+	assert.EqualError(t, f.RefreshPivotTable("Pivot Table2"), "parameter is invalid")
+	// Test refresh pivot table with invalid data range
+	pc.CacheSource.WorksheetSource = &xlsxWorksheetSource{Name: "undefined"}
+	output, err = xml.Marshal(pc)
+	assert.NoError(t, err)
+	f.saveFileList("xl/pivotCache/pivotCacheDefinition2.xml", output)
+	assert.EqualError(t, f.RefreshPivotTable("Pivot Table2"), "parameter is required")
+	// Test refresh pivot table with not exists data source worksheet
+	pc.CacheSource.WorksheetSource = &xlsxWorksheetSource{Sheet: "SheetN", Ref: "A1:C3"}
+	output, err = xml.Marshal(pc)
+	assert.NoError(t, err)
+	f.saveFileList("xl/pivotCache/pivotCacheDefinition2.xml", output)
+	assert.EqualError(t, f.RefreshPivotTable("Pivot Table2"), "sheet SheetN is not exist")
+	// Test refresh pivot table with unsupported charset pivot cache
+	f.Pkg.Store("xl/pivotCache/pivotCacheDefinition2.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.RefreshPivotTable("Pivot Table2"), "XML syntax error on line 1: invalid UTF-8")
+	// Test refresh pivot table with unsupported charset pivot table
+	f.Pkg.Store("xl/pivotTables/pivotTable2.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.RefreshPivotTable("Pivot Table2"), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
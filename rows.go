@@ -21,6 +21,7 @@ import (
 	"math/big"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/mohae/deepcopy"
 )
@@ -31,7 +32,9 @@ import (
 // applied to the value of the cell, the applied value will be used,
 // otherwise the original value will be used. GetRows fetched the rows with
 // value or formula cells, the tail continuously empty cell will be skipped.
-// For example:
+// The number format will not be applied and the raw value of the cells will
+// be returned if the RawCellValue option is specified, which is the same as
+// the value returned by GetCellValue with this option. For example:
 //
 //    rows, err := f.GetRows("Sheet1")
 //    if err != nil {
@@ -45,6 +48,10 @@ import (
 //        fmt.Println()
 //    }
 //
+// Get the raw values of all the rows in Sheet1:
+//
+//    rows, err := f.GetRows("Sheet1", excelize.Options{RawCellValue: true})
+//
 func (f *File) GetRows(sheet string, opts ...Options) ([][]string, error) {
 	rows, err := f.Rows(sheet)
 	if err != nil {
@@ -115,10 +122,19 @@ func (rows *Rows) Columns(opts ...Options) ([]string, error) {
 	rows.rawCellValue = parseOptions(opts...).RawCellValue
 	rowIterator.rows = rows
 	rowIterator.d = rows.f.sharedStringsReader()
+	rows.parseRow(&rowIterator, func(rowIterator *rowXMLIterator, xmlElement *xml.StartElement) {
+		rowXMLHandler(rowIterator, xmlElement, rows.rawCellValue)
+	})
+	return rowIterator.columns, rowIterator.err
+}
+
+// parseRow provides a function to parse the XML elements of the current row
+// by given row iterator and the handler of the start elements.
+func (rows *Rows) parseRow(rowIterator *rowXMLIterator, handler func(*rowXMLIterator, *xml.StartElement)) {
 	for {
 		token, _ := rows.decoder.Token()
 		if token == nil {
-			break
+			return
 		}
 		switch xmlElement := token.(type) {
 		case xml.StartElement:
@@ -130,12 +146,12 @@ func (rows *Rows) Columns(opts ...Options) ([]string, error) {
 				}
 				if rowIterator.row > rowIterator.rows.curRow {
 					rowIterator.rows.stashRow = rowIterator.row - 1
-					return rowIterator.columns, rowIterator.err
+					return
 				}
 			}
-			rowXMLHandler(&rowIterator, &xmlElement, rows.rawCellValue)
+			handler(rowIterator, &xmlElement)
 			if rowIterator.err != nil {
-				return rowIterator.columns, rowIterator.err
+				return
 			}
 		case xml.EndElement:
 			rowIterator.inElement = xmlElement.Name.Local
@@ -143,14 +159,13 @@ func (rows *Rows) Columns(opts ...Options) ([]string, error) {
 				rowIterator.row = rowIterator.rows.curRow
 			}
 			if rowIterator.inElement == "row" && rowIterator.row+1 < rowIterator.rows.curRow {
-				return rowIterator.columns, rowIterator.err
+				return
 			}
 			if rowIterator.inElement == "sheetData" {
-				return rowIterator.columns, rowIterator.err
+				return
 			}
 		}
 	}
-	return rowIterator.columns, rowIterator.err
 }
 
 // appendSpace append blank characters to slice by given length and source slice.
@@ -279,6 +294,168 @@ func (f *File) Rows(sheet string) (*Rows, error) {
 	return &rows, nil
 }
 
+// TypedCell defines the cell reference, raw value, value type and style index
+// of a cell read by the typed rows iterator.
+type TypedCell struct {
+	Cell    string
+	Value   string
+	Type    CellType
+	StyleID int
+}
+
+// TypedRows defines a typed rows iterator to a sheet.
+type TypedRows struct {
+	rows       *Rows
+	sst        *xlsxSST
+	dateStyles map[int]bool
+}
+
+// RowsTyped returns a typed rows iterator, used for streaming reading the raw
+// values of the cells with the value type and style index for a worksheet
+// with a large data. The shared strings table will be read only when a cell
+// of the shared string type is found. The numeric cells with the date and
+// time number format will be detected as the CellTypeDate type. For example:
+//
+//    rows, err := f.RowsTyped("Sheet1")
+//    if err != nil {
+//        fmt.Println(err)
+//        return
+//    }
+//    for rows.Next() {
+//        row, err := rows.Columns()
+//        if err != nil {
+//            fmt.Println(err)
+//        }
+//        for _, cell := range row {
+//            fmt.Println(cell.Cell, cell.Value, cell.Type, cell.StyleID)
+//        }
+//    }
+//    if err = rows.Close(); err != nil {
+//        fmt.Println(err)
+//    }
+//
+func (f *File) RowsTyped(sheet string) (*TypedRows, error) {
+	rows, err := f.Rows(sheet)
+	if err != nil {
+		return nil, err
+	}
+	return &TypedRows{rows: rows, dateStyles: make(map[int]bool)}, err
+}
+
+// CurrentRow returns the row number that represents the current row.
+func (rows *TypedRows) CurrentRow() int {
+	return rows.rows.CurrentRow()
+}
+
+// TotalRows returns the total rows count in the worksheet.
+func (rows *TypedRows) TotalRows() int {
+	return rows.rows.TotalRows()
+}
+
+// Next will return true if find the next row element.
+func (rows *TypedRows) Next() bool {
+	return rows.rows.Next()
+}
+
+// Error will return the error when the error occurs.
+func (rows *TypedRows) Error() error {
+	return rows.rows.Error()
+}
+
+// Close closes the open worksheet XML file in the system temporary
+// directory.
+func (rows *TypedRows) Close() error {
+	return rows.rows.Close()
+}
+
+// Columns return the current row's cells with value or formula, the empty
+// cells will be skipped.
+func (rows *TypedRows) Columns() ([]TypedCell, error) {
+	var (
+		rowIterator rowXMLIterator
+		cells       []TypedCell
+	)
+	if rows.rows.stashRow >= rows.rows.curRow {
+		return cells, rowIterator.err
+	}
+	rowIterator.rows = rows.rows
+	rows.rows.parseRow(&rowIterator, func(rowIterator *rowXMLIterator, xmlElement *xml.StartElement) {
+		if rowIterator.inElement != "c" {
+			return
+		}
+		rowIterator.cellCol++
+		var c xlsxC
+		_ = rowIterator.rows.decoder.DecodeElement(&c, xmlElement)
+		if c.R == "" {
+			c.R, rowIterator.err = CoordinatesToCellName(rowIterator.cellCol, rowIterator.row)
+		} else {
+			rowIterator.cellCol, _, rowIterator.err = CellNameToCoordinates(c.R)
+		}
+		if rowIterator.err != nil {
+			return
+		}
+		if cell := rows.typedCell(&c); cell.Value != "" || c.F != nil {
+			cells = append(cells, cell)
+		}
+	})
+	return cells, rowIterator.err
+}
+
+// typedCell provides a function to get the raw value, value type and style
+// index of the given cell.
+func (rows *TypedRows) typedCell(c *xlsxC) TypedCell {
+	cell := TypedCell{Cell: c.R, Value: c.V, Type: cellTypes[c.T], StyleID: c.S}
+	switch c.T {
+	case "s":
+		if rows.sst == nil {
+			rows.sst = rows.rows.f.sharedStringsReader()
+		}
+		if idx, err := strconv.Atoi(c.V); err == nil && idx >= 0 && idx < len(rows.sst.SI) {
+			cell.Value = rows.sst.SI[idx].String()
+		}
+	case "inlineStr":
+		if c.IS != nil {
+			cell.Value = c.IS.String()
+		}
+	case "", "n":
+		if cell.Value != "" {
+			cell.Type = CellTypeNumber
+			if rows.isDateStyle(c.S) {
+				cell.Type = CellTypeDate
+			}
+		}
+	}
+	return cell
+}
+
+// isDateStyle provides a function to check if the number format of the given
+// style index is a date or time number format, the result will be cached.
+func (rows *TypedRows) isDateStyle(styleID int) bool {
+	if styleID == 0 {
+		return false
+	}
+	if isDate, ok := rows.dateStyles[styleID]; ok {
+		return isDate
+	}
+	var isDate bool
+	styleSheet := rows.rows.f.stylesReader()
+	if styleSheet.CellXfs != nil && styleID < len(styleSheet.CellXfs.Xf) && styleSheet.CellXfs.Xf[styleID].NumFmtID != nil {
+		numFmtID := *styleSheet.CellXfs.Xf[styleID].NumFmtID
+		if format, ok := builtInNumFmt[numFmtID]; ok {
+			isDate = isTimeNumFmt(format)
+		} else if styleSheet.NumFmts != nil {
+			for _, numFmt := range styleSheet.NumFmts.NumFmt {
+				if numFmt.NumFmtID == numFmtID {
+					isDate = isTimeNumFmt(strings.ToLower(numFmt.FormatCode))
+					break
+				}
+			}
+		}
+	}
+	rows.dateStyles[styleID] = isDate
+	return isDate
+}
+
 // sheetDecoder creates XML decoder by given path in the zip from memory data
 // or system temporary file.
 func (f *File) sheetDecoder(name string) (bool, *xml.Decoder, *os.File, error) {
@@ -319,6 +496,127 @@ func (f *File) SetRowHeight(sheet string, row int, height float64) error {
 	return nil
 }
 
+// RowHeightAutoOptions directly maps the settings of the SetRowHeightAuto.
+//
+// CustomHeight specifies if keep the custom height flag of the row, the flag
+// will be cleared by default so the spreadsheet application can re-fit the
+// row height when the content changes.
+type RowHeightAutoOptions struct {
+	CustomHeight bool
+}
+
+// SetRowHeightAuto provides a function to set the height of a single row to
+// fit the tallest cell by given worksheet name and Excel row number. The
+// height of the cells with wrap text enabled is measured by the approximate
+// number of the wrapped lines with the column width and the font of the cell,
+// the cells within the merged cells spanning multiple rows will be excluded
+// from the measurement. The height won't exceed the MaxRowHeight. For
+// example, set the best fit height of the row 2 in Sheet1:
+//
+//    err := f.SetRowHeightAuto("Sheet1", 2)
+//
+// Set the best fit height of the row 2 in Sheet1 and keep the custom height
+// flag of the row:
+//
+//    err := f.SetRowHeightAuto("Sheet1", 2, excelize.RowHeightAutoOptions{CustomHeight: true})
+//
+func (f *File) SetRowHeightAuto(sheet string, row int, opts ...RowHeightAutoOptions) error {
+	if row < 1 {
+		return newInvalidRowNumberError(row)
+	}
+	if row > TotalRows {
+		return ErrMaxRows
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	var options RowHeightAutoOptions
+	for _, opt := range opts {
+		options = opt
+	}
+	mergeRects := map[int][]int{}
+	if ws.MergeCells != nil {
+		for _, mergeCell := range ws.MergeCells.Cells {
+			if mergeCell == nil {
+				continue
+			}
+			rect, err := mergeCell.Rect()
+			if err != nil {
+				return err
+			}
+			if rect[1] <= row && row <= rect[3] {
+				for col := rect[0]; col <= rect[2]; col++ {
+					mergeRects[col] = rect
+				}
+			}
+		}
+	}
+	prepareSheetXML(ws, 0, row)
+	height := defaultRowHeight
+	if ws.SheetFormatPr != nil && ws.SheetFormatPr.DefaultRowHeight > 0 {
+		height = ws.SheetFormatPr.DefaultRowHeight
+	}
+	sst := f.sharedStringsReader()
+	for cellIdx := range ws.SheetData.Row[row-1].C {
+		c := &ws.SheetData.Row[row-1].C[cellIdx]
+		col, _, err := CellNameToCoordinates(c.R)
+		if err != nil {
+			return err
+		}
+		colWidth := f.getColWidthByNum(ws, col)
+		if rect, ok := mergeRects[col]; ok {
+			if rect[1] != rect[3] || rect[0] != col {
+				continue
+			}
+			for mergeCol := rect[0] + 1; mergeCol <= rect[2]; mergeCol++ {
+				colWidth += f.getColWidthByNum(ws, mergeCol)
+			}
+		}
+		val, _ := c.getValueFrom(f, sst, false)
+		if val == "" {
+			continue
+		}
+		height = math.Max(height, f.measureCellTextHeight(c.S, val, colWidth))
+	}
+	ws.SheetData.Row[row-1].Ht = math.Min(height, MaxRowHeight)
+	ws.SheetData.Row[row-1].CustomHeight = options.CustomHeight
+	return err
+}
+
+// measureCellTextHeight provides a function to measure the height of the text
+// in points by given style ID, text and column width. The text will be
+// wrapped by the column width if the wrap text of the cell style is enabled.
+func (f *File) measureCellTextHeight(styleID int, text string, colWidth float64) float64 {
+	size, bold := f.getCellFontMetrics(styleID)
+	scale := size / defaultFontSize
+	if bold {
+		scale *= 1.1
+	}
+	lines := 1.0
+	if f.isCellWrapText(styleID) {
+		// Remove 5 pixels padding with the maximum digit width of the default font.
+		digitWidth := defaultColWidthPixels / defaultColWidth
+		capacity := math.Max((colWidth*digitWidth-5)/(scale*digitWidth), 1)
+		lines = 0
+		for _, line := range strings.Split(text, "\n") {
+			lines += math.Max(math.Ceil(measureTextChars(line)/capacity), 1)
+		}
+	}
+	return math.Round(lines*size*defaultRowHeight/defaultFontSize*100) / 100
+}
+
+// isCellWrapText provides a function to check if the wrap text of the cell
+// style is enabled by given style ID.
+func (f *File) isCellWrapText(styleID int) bool {
+	s := f.stylesReader()
+	if s.CellXfs == nil || styleID < 0 || styleID >= len(s.CellXfs.Xf) {
+		return false
+	}
+	alignment := s.CellXfs.Xf[styleID].Alignment
+	return alignment != nil && alignment.WrapText
+}
+
 // getRowHeight provides a function to get row height in pixels by given sheet
 // name and row number.
 func (f *File) getRowHeight(sheet string, row int) int {
@@ -653,6 +951,77 @@ func (f *File) DuplicateRowTo(sheet string, row, row2 int) error {
 	return f.duplicateMergeCells(sheet, ws, row, row2)
 }
 
+// DuplicateRows inserts the given number of copies of specified row by its
+// Excel row number below the row, the existing rows after the row will be
+// moved down once by the number of copies. For example, insert 50 copies of
+// row 2 in Sheet1:
+//
+//    err := f.DuplicateRows("Sheet1", 2, 50)
+//
+// The references to the moved rows in the formulas of the worksheet will be
+// updated, and the relative references in the formulas of the copies will be
+// shifted by the distance to the row. Use this method with caution, which
+// will affect changes in references such as the formulas of the other
+// worksheets, charts, and so on. If there is any referenced value of the
+// worksheet, it will cause a file error when you open it. The excelize only
+// partially updates these references currently.
+func (f *File) DuplicateRows(sheet string, row, count int) error {
+	if row < 1 {
+		return newInvalidRowNumberError(row)
+	}
+	if count < 1 {
+		return ErrParameterInvalid
+	}
+	if row+count > TotalRows {
+		return ErrMaxRows
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	if row > len(ws.SheetData.Row) {
+		return nil
+	}
+
+	var ok bool
+	for _, r := range ws.SheetData.Row {
+		if ok = r.R == row; ok {
+			break
+		}
+	}
+	if !ok {
+		return nil
+	}
+
+	if err := f.adjustHelper(sheet, rows, row+1, count); err != nil {
+		return err
+	}
+	f.adjustFormulas(ws, sheet, row+1, count)
+
+	var rowCopy xlsxRow
+	rowIdx := make(map[int]int, len(ws.SheetData.Row))
+	for i, r := range ws.SheetData.Row {
+		rowIdx[r.R] = i
+		if r.R == row {
+			rowCopy = deepcopy.Copy(r).(xlsxRow)
+		}
+	}
+	for row2 := row + 1; row2 <= row+count; row2++ {
+		rowNew := deepcopy.Copy(rowCopy).(xlsxRow)
+		f.ajustSingleRowDimensions(&rowNew, row2)
+		copyRowFormulas(ws, rowCopy, &rowNew, row2-row)
+		if idx, ok := rowIdx[row2]; ok {
+			ws.SheetData.Row[idx] = rowNew
+		} else {
+			ws.SheetData.Row = append(ws.SheetData.Row, rowNew)
+		}
+		if err := f.duplicateMergeCells(sheet, ws, row, row2); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // duplicateMergeCells merge cells in the destination row if there are single
 // row merged cells in the copied row.
 func (f *File) duplicateMergeCells(sheet string, ws *xlsxWorksheet, row, row2 int) error {
@@ -686,6 +1055,118 @@ func (f *File) duplicateMergeCells(sheet string, ws *xlsxWorksheet, row, row2 in
 	return nil
 }
 
+// CopyRow provides a function to copy the values, formulas and styles of the
+// cells in the source row to the target row by given worksheet name, source
+// and target Excel row number. The existing content of the target row will be
+// overwritten, and the merged cells confined to the source row will be
+// duplicated to the target row. The relative references in the formulas will
+// be adjusted by the distance between the rows. For example, copy row 2 to
+// row 10 in Sheet1:
+//
+//    err := f.CopyRow("Sheet1", 2, 10)
+//
+func (f *File) CopyRow(sheet string, source, target int) error {
+	if source < 1 {
+		return newInvalidRowNumberError(source)
+	}
+	if target < 1 {
+		return newInvalidRowNumberError(target)
+	}
+	if source > TotalRows || target > TotalRows {
+		return ErrMaxRows
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	if source == target {
+		return err
+	}
+	mergeCells, err := getCopyRowMergeCells(ws, source, target)
+	if err != nil {
+		return err
+	}
+	if source > target {
+		prepareSheetXML(ws, 0, source)
+	} else {
+		prepareSheetXML(ws, 0, target)
+	}
+	rowCopy := deepcopy.Copy(ws.SheetData.Row[source-1]).(xlsxRow)
+	rowCopy.C = append(make([]xlsxC, 0, len(rowCopy.C)), rowCopy.C...)
+	f.ajustSingleRowDimensions(&rowCopy, target)
+	copyRowFormulas(ws, ws.SheetData.Row[source-1], &rowCopy, target-source)
+	ws.SheetData.Row[target-1] = rowCopy
+	if ws.MergeCells != nil {
+		if ws.MergeCells.Cells, ws.MergeCells.Count = mergeCells, len(mergeCells); ws.MergeCells.Count == 0 {
+			ws.MergeCells = nil
+		}
+	}
+	return err
+}
+
+// copyRowFormulas provides a function to set the formulas of the cells in the
+// copied row by the formulas of the source row, the relative references in the
+// formulas will be shifted by the given rows distance. The shared formulas
+// will be converted to the normal formulas.
+func copyRowFormulas(ws *xlsxWorksheet, source xlsxRow, target *xlsxRow, dRow int) {
+	for i, c := range source.C {
+		if c.F == nil || i >= len(target.C) {
+			continue
+		}
+		formula := c.F.Content
+		if c.F.T == STCellFormulaTypeShared && c.F.Si != nil {
+			formula = getSharedForumula(ws, *c.F.Si, c.R)
+		}
+		target.C[i].F = &xlsxF{Content: parseSharedFormula(0, dRow, []byte(formula))}
+		if c.F.T == STCellFormulaTypeArray {
+			target.C[i].F.T, target.C[i].F.Ref = c.F.T, parseSharedFormula(0, dRow, []byte(c.F.Ref))
+		}
+	}
+}
+
+// getCopyRowMergeCells provides a function to get the merged cells of the
+// worksheet after copying the source row to the target row, the merged cells
+// confined to the target row will be removed, and the merged cells confined
+// to the source row will be duplicated to the target row. An error will be
+// returned if the duplicated merged cells overlap with the other merged
+// cells, so the worksheet will be kept unchanged.
+func getCopyRowMergeCells(ws *xlsxWorksheet, source, target int) ([]*xlsxMergeCell, error) {
+	if ws.MergeCells == nil {
+		return nil, nil
+	}
+	var sourceCells [][]int
+	mergeCells := make([]*xlsxMergeCell, 0, len(ws.MergeCells.Cells))
+	for _, mergeCell := range ws.MergeCells.Cells {
+		if mergeCell == nil {
+			continue
+		}
+		rect, err := mergeCell.Rect()
+		if err != nil {
+			return nil, err
+		}
+		if rect[1] == rect[3] && rect[1] == target {
+			continue
+		}
+		if rect[1] == rect[3] && rect[1] == source {
+			sourceCells = append(sourceCells, rect)
+		}
+		mergeCells = append(mergeCells, mergeCell)
+	}
+	for _, rect := range sourceCells {
+		targetRect := []int{rect[0], target, rect[2], target}
+		hcell, _ := CoordinatesToCellName(rect[0], target)
+		vcell, _ := CoordinatesToCellName(rect[2], target)
+		ref := hcell + ":" + vcell
+		for _, mergeCell := range mergeCells {
+			if rect2, _ := mergeCell.Rect(); isOverlap(targetRect, rect2) {
+				return nil, newMergeCellOverlapError(ref, mergeCell.Ref)
+			}
+		}
+		mergeCells = append(mergeCells, &xlsxMergeCell{Ref: ref, rect: targetRect})
+	}
+	return mergeCells, nil
+}
+
 // checkRow provides a function to check and fill each column element for all
 // rows and make that is continuous in a worksheet of XML. For example:
 //
@@ -805,6 +1286,33 @@ func (f *File) SetRowStyle(sheet string, start, end, styleID int) error {
 	return nil
 }
 
+// GetRowStyle provides a function to get row style ID by given worksheet name
+// and Excel row number. This function returns 0 if the row has no explicit
+// style. For example, get style ID of row 1 in Sheet1:
+//
+//    styleID, err := f.GetRowStyle("Sheet1", 1)
+//
+func (f *File) GetRowStyle(sheet string, row int) (int, error) {
+	var styleID int
+	if row < 1 {
+		return styleID, newInvalidRowNumberError(row)
+	}
+	if row > TotalRows {
+		return styleID, ErrMaxRows
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return styleID, err
+	}
+	for i := range ws.SheetData.Row {
+		if ws.SheetData.Row[i].R == row {
+			styleID = ws.SheetData.Row[i].S
+			break
+		}
+	}
+	return styleID, err
+}
+
 // convertRowHeightToPixels provides a function to convert the height of a
 // cell from user's units to pixels. If the height hasn't been set by the user
 // we use the default value. If the row is hidden it has a value of zero.
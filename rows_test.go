@@ -4,7 +4,10 @@ import (
 	"bytes"
 	"fmt"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -64,6 +67,34 @@ func TestRows(t *testing.T) {
 	assert.NoError(t, f.Close())
 }
 
+func TestGetRowsRawCellValue(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), 0.5, 1.123456789012345, "Text", true}))
+	style, err := f.NewStyle(`{"number_format":10}`)
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B1", "B1", style))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A2", "B1*2"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "C3", 42))
+
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1/1/21 00:00", "50.00%", "1.12345678901234", "Text", "1"}, rows[0])
+	rawRows, err := f.GetRows("Sheet1", Options{RawCellValue: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"44197", "0.5", "1.123456789012345", "Text", "1"}, rawRows[0])
+	assert.Len(t, rawRows, 3)
+	// Test the raw values are consistent with the GetCellValue cell-by-cell.
+	for rowIdx, row := range rawRows {
+		for colIdx, val := range row {
+			cell, err := CoordinatesToCellName(colIdx+1, rowIdx+1)
+			assert.NoError(t, err)
+			rawValue, err := f.GetCellValue("Sheet1", cell, Options{RawCellValue: true})
+			assert.NoError(t, err)
+			assert.Equal(t, rawValue, val, cell)
+		}
+	}
+}
+
 func TestRowsIterator(t *testing.T) {
 	sheetName, rowCount, expectedNumRow := "Sheet2", 0, 11
 	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
@@ -107,6 +138,139 @@ func TestRowsError(t *testing.T) {
 	assert.NoError(t, f.Close())
 }
 
+func TestRowsTyped(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "Text"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B1", 100.5))
+	assert.NoError(t, f.SetCellValue("Sheet1", "C1", true))
+	assert.NoError(t, f.SetCellValue("Sheet1", "D1", time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "E1", "B1*2"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B3", 1))
+	style, err := f.NewStyle(`{"custom_number_format":"yyyy/m/d"}`)
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B3", "C3", style))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestRowsTyped.xlsx")))
+
+	f, err = OpenFile(filepath.Join("test", "TestRowsTyped.xlsx"))
+	assert.NoError(t, err)
+	rows, err := f.RowsTyped("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, rows.TotalRows())
+	var results [][]TypedCell
+	for rows.Next() {
+		row, err := rows.Columns()
+		assert.NoError(t, err)
+		results = append(results, row)
+	}
+	assert.NoError(t, rows.Error())
+	assert.Equal(t, 3, rows.CurrentRow()-1)
+	assert.NoError(t, rows.Close())
+	assert.Equal(t, [][]TypedCell{
+		{
+			{Cell: "A1", Value: "Text", Type: CellTypeString},
+			{Cell: "B1", Value: "100.5", Type: CellTypeNumber},
+			{Cell: "C1", Value: "1", Type: CellTypeBool},
+			{Cell: "D1", Value: "44197", Type: CellTypeDate, StyleID: results[0][3].StyleID},
+			{Cell: "E1", Type: CellTypeUnset},
+		},
+		nil,
+		{{Cell: "B3", Value: "1", Type: CellTypeDate, StyleID: style}},
+	}, results)
+	assert.NotZero(t, results[0][3].StyleID)
+	assert.NoError(t, f.Close())
+
+	// Test the shared strings table will be read only when needed.
+	f = NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", 1))
+	f.SharedStrings = nil
+	rows, err = f.RowsTyped("Sheet1")
+	assert.NoError(t, err)
+	for rows.Next() {
+		_, err = rows.Columns()
+		assert.NoError(t, err)
+	}
+	assert.Nil(t, f.SharedStrings)
+
+	// Test typed rows iterator with invalid cell reference.
+	f = NewFile()
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData><row r="1"><c r="A"><v>1</v></c></row></sheetData></worksheet>`))
+	f.checked = nil
+	rows, err = f.RowsTyped("Sheet1")
+	assert.NoError(t, err)
+	assert.True(t, rows.Next())
+	_, err = rows.Columns()
+	assert.EqualError(t, err, `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+
+	_, err = f.RowsTyped("SheetN")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+}
+
+func TestSetRowHeightAuto(t *testing.T) {
+	f := NewFile()
+	wrapStyle, err := f.NewStyle(`{"alignment":{"wrap_text":true}}`)
+	assert.NoError(t, err)
+	largeStyle, err := f.NewStyle(`{"font":{"size":22}}`)
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetColWidth("Sheet1", "A", "A", 10))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", strings.Repeat("a", 40)))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", wrapStyle))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B1", "Text"))
+	assert.NoError(t, f.SetRowHeight("Sheet1", 1, 100))
+	assert.NoError(t, f.SetRowHeightAuto("Sheet1", 1))
+	height, err := f.GetRowHeight("Sheet1", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 75.0, height)
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.False(t, ws.(*xlsxWorksheet).SheetData.Row[0].CustomHeight)
+
+	// Test set row height auto with the font size and keep the custom height.
+	assert.NoError(t, f.SetCellValue("Sheet1", "A2", strings.Repeat("a", 40)))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A2", "A2", largeStyle))
+	assert.NoError(t, f.SetRowHeightAuto("Sheet1", 2, RowHeightAutoOptions{CustomHeight: true}))
+	height, err = f.GetRowHeight("Sheet1", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 30.0, height)
+	assert.True(t, ws.(*xlsxWorksheet).SheetData.Row[1].CustomHeight)
+
+	// Test set row height auto with the merged cells.
+	assert.NoError(t, f.SetCellValue("Sheet1", "A3", strings.Repeat("a", 40)))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A3", "A4", wrapStyle))
+	assert.NoError(t, f.MergeCell("Sheet1", "A3", "A4"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B5", strings.Repeat("a", 40)))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B5", "B5", wrapStyle))
+	assert.NoError(t, f.MergeCell("Sheet1", "B5", "D5"))
+	for row, expected := range map[int]float64{3: defaultRowHeight, 4: defaultRowHeight, 5: 30, 6: defaultRowHeight} {
+		assert.NoError(t, f.SetRowHeightAuto("Sheet1", row))
+		height, err = f.GetRowHeight("Sheet1", row)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, height, row)
+	}
+
+	// Test set row height auto won't exceed the max row height.
+	assert.NoError(t, f.SetCellValue("Sheet1", "A7", strings.Repeat("a", 1000)))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A7", "A7", wrapStyle))
+	assert.NoError(t, f.SetRowHeightAuto("Sheet1", 7))
+	height, err = f.GetRowHeight("Sheet1", 7)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(MaxRowHeight), height)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetRowHeightAuto.xlsx")))
+
+	// Test set row height auto with invalid arguments.
+	assert.EqualError(t, f.SetRowHeightAuto("Sheet1", 0), newInvalidRowNumberError(0).Error())
+	assert.EqualError(t, f.SetRowHeightAuto("Sheet1", TotalRows+1), ErrMaxRows.Error())
+	assert.EqualError(t, f.SetRowHeightAuto("SheetN", 1), "sheet SheetN is not exist")
+	worksheet, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	worksheet.MergeCells.Cells[0] = &xlsxMergeCell{Ref: "A1:-"}
+	assert.EqualError(t, f.SetRowHeightAuto("Sheet1", 1), `cannot convert cell "-" to coordinates: invalid cell name "-"`)
+	worksheet.MergeCells = nil
+	worksheet.SheetData.Row[0].C[0].R = "-"
+	assert.EqualError(t, f.SetRowHeightAuto("Sheet1", 1), `cannot convert cell "-" to coordinates: invalid cell name "-"`)
+	assert.False(t, f.isCellWrapText(-1))
+}
+
 func TestRowHeight(t *testing.T) {
 	f := NewFile()
 	sheet1 := f.GetSheetName(0)
@@ -842,6 +1006,55 @@ func TestDuplicateRowTo(t *testing.T) {
 	assert.EqualError(t, f.DuplicateRowTo("SheetN", 1, 2), "sheet SheetN is not exist")
 }
 
+func TestDuplicateRows(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Header"}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{"Item", 100}))
+	assert.NoError(t, f.MergeCell("Sheet1", "C2", "D2"))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A3", &[]interface{}{"Total"}))
+	assert.NoError(t, f.MergeCell("Sheet1", "A3", "B4"))
+
+	assert.NoError(t, f.DuplicateRows("Sheet1", 2, 3))
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"Header"}, {"Item", "100"}, {"Item", "100"}, {"Item", "100"}, {"Item", "100"}, {"Total"}}, rows)
+	mergeCells, err := f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	var refs []string
+	for _, mergeCell := range mergeCells {
+		refs = append(refs, mergeCell[0])
+	}
+	assert.Equal(t, []string{"C2:D2", "A6:B7", "C3:D3", "C4:D4", "C5:D5"}, refs)
+	// Test duplicate row with formulas.
+	assert.NoError(t, f.SetCellFormula("Sheet1", "E2", "B2*2+A1"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "E6", "B2+B5+SUM(B2:B5)"))
+	assert.NoError(t, f.DuplicateRows("Sheet1", 2, 2))
+	for cell, expected := range map[string]string{
+		"E2": "B2*2+A1", "E3": "B3*2+A2", "E4": "B4*2+A3", "E8": "B2+B7+SUM(B2:B7)",
+	} {
+		formula, err := f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, formula, cell)
+	}
+	// Test duplicate the last row.
+	assert.NoError(t, f.DuplicateRows("Sheet1", 8, 2))
+	rows, err = f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Total", rows[9][0])
+	formula, err := f.GetCellFormula("Sheet1", "E10")
+	assert.NoError(t, err)
+	assert.Equal(t, "B4+B9+SUM(B4:B9)", formula)
+	// Test duplicate the row which doesn't exist.
+	assert.NoError(t, f.DuplicateRows("Sheet1", 100, 2))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDuplicateRows.xlsx")))
+
+	// Test duplicate rows with invalid arguments.
+	assert.EqualError(t, f.DuplicateRows("Sheet1", 0, 1), newInvalidRowNumberError(0).Error())
+	assert.EqualError(t, f.DuplicateRows("Sheet1", 1, 0), ErrParameterInvalid.Error())
+	assert.EqualError(t, f.DuplicateRows("Sheet1", 1, TotalRows), ErrMaxRows.Error())
+	assert.EqualError(t, f.DuplicateRows("SheetN", 1, 1), "sheet SheetN is not exist")
+}
+
 func TestDuplicateMergeCells(t *testing.T) {
 	f := File{}
 	ws := &xlsxWorksheet{MergeCells: &xlsxMergeCells{
@@ -852,6 +1065,84 @@ func TestDuplicateMergeCells(t *testing.T) {
 	assert.EqualError(t, f.duplicateMergeCells("SheetN", ws, 1, 2), "sheet SheetN is not exist")
 }
 
+func TestCopyRow(t *testing.T) {
+	f := NewFile()
+	style, err := f.NewStyle(`{"font":{"bold":true}}`)
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Item", 2, 3}))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D1", "B1*C1"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "E1", "SUM($B$1:C1)"))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", style))
+	assert.NoError(t, f.MergeCell("Sheet1", "F1", "G1"))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A3", &[]interface{}{"Old", "Old", "Old", "Old", "Old", "Old", "Old", "Old"}))
+	assert.NoError(t, f.MergeCell("Sheet1", "G3", "H3"))
+
+	assert.NoError(t, f.CopyRow("Sheet1", 1, 3))
+	assert.NoError(t, f.CopyRow("Sheet1", 1, 5))
+	for _, row := range []int{3, 5} {
+		for cell, expected := range map[string]string{"A": "Item", "B": "2", "C": "3", "H": ""} {
+			val, err := f.GetCellValue("Sheet1", cell+strconv.Itoa(row))
+			assert.NoError(t, err)
+			assert.Equal(t, expected, val)
+		}
+		formula, err := f.GetCellFormula("Sheet1", "D"+strconv.Itoa(row))
+		assert.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf("B%d*C%d", row, row), formula)
+		formula, err = f.GetCellFormula("Sheet1", "E"+strconv.Itoa(row))
+		assert.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf("SUM($B$1:C%d)", row), formula)
+		styleID, err := f.GetCellStyle("Sheet1", "A"+strconv.Itoa(row))
+		assert.NoError(t, err)
+		assert.Equal(t, style, styleID)
+	}
+	mergeCells, err := f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	var refs []string
+	for _, mergeCell := range mergeCells {
+		refs = append(refs, mergeCell[0])
+	}
+	assert.Equal(t, []string{"F1:G1", "F3:G3", "F5:G5"}, refs)
+	// Test copy row to itself.
+	assert.NoError(t, f.CopyRow("Sheet1", 1, 1))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestCopyRow.xlsx")))
+
+	// Test copy row with shared formula.
+	f = NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{1, 2}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{3, 4}))
+	ref, formulaType := "C1:C2", STCellFormulaTypeShared
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C1", "A1+B1", FormulaOpts{Ref: &ref, Type: &formulaType}))
+	assert.NoError(t, f.CopyRow("Sheet1", 2, 4))
+	formula, err := f.GetCellFormula("Sheet1", "C4")
+	assert.NoError(t, err)
+	assert.Equal(t, "A4+B4", formula)
+
+	// Test copy row with the merged cells overlaps with the existing merged cells.
+	assert.NoError(t, f.MergeCell("Sheet1", "A1", "B1"))
+	assert.NoError(t, f.MergeCell("Sheet1", "B5", "B6"))
+	assert.EqualError(t, f.CopyRow("Sheet1", 1, 6), newMergeCellOverlapError("A6:B6", "B5:B6").Error())
+	// Test the target row is kept unchanged if the merged cells overlap.
+	assert.NoError(t, f.MergeCell("Sheet1", "A9", "A11"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A10", "Target"))
+	assert.EqualError(t, f.CopyRow("Sheet1", 1, 10), newMergeCellOverlapError("A10:B10", "A9:A11").Error())
+	val, err := f.GetCellValue("Sheet1", "A10")
+	assert.NoError(t, err)
+	assert.Equal(t, "Target", val)
+	mergeCells, err = f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 3)
+
+	// Test copy row with invalid arguments.
+	assert.EqualError(t, f.CopyRow("Sheet1", 0, 1), newInvalidRowNumberError(0).Error())
+	assert.EqualError(t, f.CopyRow("Sheet1", 1, 0), newInvalidRowNumberError(0).Error())
+	assert.EqualError(t, f.CopyRow("Sheet1", 1, TotalRows+1), ErrMaxRows.Error())
+	assert.EqualError(t, f.CopyRow("SheetN", 1, 2), "sheet SheetN is not exist")
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).MergeCells = &xlsxMergeCells{Cells: []*xlsxMergeCell{{Ref: "A1:-"}}}
+	assert.EqualError(t, f.CopyRow("Sheet1", 1, 2), `cannot convert cell "-" to coordinates: invalid cell name "-"`)
+}
+
 func TestGetValueFromInlineStr(t *testing.T) {
 	c := &xlsxC{T: "inlineStr"}
 	f := NewFile()
@@ -912,6 +1203,36 @@ func TestSetRowStyle(t *testing.T) {
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetRowStyle.xlsx")))
 }
 
+func TestGetRowStyle(t *testing.T) {
+	f := NewFile()
+	styleID, err := f.GetRowStyle("Sheet1", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, styleID)
+	style, err := f.NewStyle(`{"font":{"bold":true}}`)
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetRowStyle("Sheet1", 2, 3, style))
+	for row, expected := range map[int]int{1: 0, 2: style, 3: style, 4: 0} {
+		styleID, err = f.GetRowStyle("Sheet1", row)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, styleID)
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGetRowStyle.xlsx")))
+
+	f, err = OpenFile(filepath.Join("test", "TestGetRowStyle.xlsx"))
+	assert.NoError(t, err)
+	styleID, err = f.GetRowStyle("Sheet1", 3)
+	assert.NoError(t, err)
+	assert.Equal(t, style, styleID)
+	// Test get row style with invalid arguments.
+	_, err = f.GetRowStyle("Sheet1", 0)
+	assert.EqualError(t, err, newInvalidRowNumberError(0).Error())
+	_, err = f.GetRowStyle("Sheet1", TotalRows+1)
+	assert.EqualError(t, err, ErrMaxRows.Error())
+	_, err = f.GetRowStyle("SheetN", 1)
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+	assert.NoError(t, f.Close())
+}
+
 func TestNumberFormats(t *testing.T) {
 	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
 	if !assert.NoError(t, err) {
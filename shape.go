@@ -13,6 +13,10 @@ package excelize
 
 import (
 	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
 	"strconv"
 	"strings"
 )
@@ -71,6 +75,87 @@ func parseFormatShapeSet(formatSet string) (*formatShape, error) {
 //        }
 //    }`)
 //
+// A paragraph can contain multiple text runs with different font settings by
+// the "runs" field, the paragraph font will be used for the run without font
+// settings, and the newline character in the text will be written as a line
+// break of the paragraph. For example, add a text box with mixed formatting
+// runs and a line break in one paragraph:
+//
+//    err := f.AddShape("Sheet1", "G6", `{
+//        "type": "rect",
+//        "paragraph": [
+//        {
+//            "font":
+//            {
+//                "family": "Arial",
+//                "size": 12
+//            },
+//            "runs": [
+//            {
+//                "text": "Bold",
+//                "font":
+//                {
+//                    "bold": true,
+//                    "family": "Arial",
+//                    "size": 12
+//                }
+//            },
+//            {
+//                "text": " and normal text\nin the second line"
+//            }]
+//        }]
+//    }`)
+//
+// The shape can be filled with a linear gradient by the "gradient" field of
+// the color settings, which specifies at least 2 stops with the color and the
+// position (percentage in the color band from 0 to 100), and the angle of the
+// direction in degrees. The "fill" color will be ignored if the gradient is
+// specified. For example, add a rectangle shape with two-stop gradient fill:
+//
+//    err := f.AddShape("Sheet1", "G6", `{
+//        "type": "rect",
+//        "color":
+//        {
+//            "line": "#4286F4",
+//            "gradient":
+//            {
+//                "stops": [
+//                {
+//                    "color": "#8EB9FF",
+//                    "position": 0
+//                },
+//                {
+//                    "color": "#4286F4",
+//                    "position": 100
+//                }],
+//                "angle": 90
+//            }
+//        }
+//    }`)
+//
+// The text in the shape can be rotated by the "text_rotation" field in
+// degrees, and the "text_vertical" field specifies the vertical text type.
+// For example, add a text box with the text rotated 270 degrees:
+//
+//    err := f.AddShape("Sheet1", "G6", `{
+//        "type": "rect",
+//        "paragraph": [
+//        {
+//            "text": "Sidebar"
+//        }],
+//        "text_vertical": "vert270"
+//    }`)
+//
+// The following shows the vertical text type supported by excelize:
+//
+//    horz (Horizontal text)
+//    vert (Rotate all the text 90 degrees clockwise)
+//    vert270 (Rotate all the text 270 degrees clockwise)
+//    wordArtVert (Stacked text, one letter on top of another)
+//    eaVert (East Asian vertical text)
+//    mongolianVert (Mongolian vertical text)
+//    wordArtVertRtl (Stacked text, read from right to left)
+//
 // The following shows the type of shape supported by excelize:
 //
 //    accentBorderCallout1 (Callout 1 with Border and Accent Shape)
@@ -287,6 +372,15 @@ func (f *File) AddShape(sheet, cell, format string) error {
 	if err != nil {
 		return err
 	}
+	if err = checkShapeGradient(formatSet.Color.Gradient); err != nil {
+		return err
+	}
+	if err = checkShapeLineEnds(formatSet.Line); err != nil {
+		return err
+	}
+	if err = checkShapeTextVertical(formatSet.TextVertical); err != nil {
+		return err
+	}
 	// Read sheet data.
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
@@ -327,27 +421,6 @@ func (f *File) addDrawingShape(sheet, drawingXML, cell string, formatSet *format
 	colIdx := fromCol - 1
 	rowIdx := fromRow - 1
 
-	textUnderlineType := map[string]bool{
-		"none":            true,
-		"words":           true,
-		"sng":             true,
-		"dbl":             true,
-		"heavy":           true,
-		"dotted":          true,
-		"dottedHeavy":     true,
-		"dash":            true,
-		"dashHeavy":       true,
-		"dashLong":        true,
-		"dashLongHeavy":   true,
-		"dotDash":         true,
-		"dotDashHeavy":    true,
-		"dotDotDash":      true,
-		"dotDotDashHeavy": true,
-		"wavy":            true,
-		"wavyHeavy":       true,
-		"wavyDbl":         true,
-	}
-
 	width := int(float64(formatSet.Width) * formatSet.Format.XScale)
 	height := int(float64(formatSet.Height) * formatSet.Format.YScale)
 
@@ -380,6 +453,9 @@ func (f *File) addDrawingShape(sheet, drawingXML, cell string, formatSet *format
 			},
 		},
 		SpPr: &xlsxSpPr{
+			Xfrm: xlsxXfrm{
+				Rot: rotationToAngle(formatSet.Format.Rotation),
+			},
 			PrstGeom: xlsxPrstGeom{
 				Prst: formatSet.Type,
 			},
@@ -402,14 +478,16 @@ func (f *File) addDrawingShape(sheet, drawingXML, cell string, formatSet *format
 				Wrap:         "none",
 				RtlCol:       false,
 				Anchor:       "t",
+				Rot:          rotationToAngle(formatSet.TextRotation),
+				Vert:         formatSet.TextVertical,
 			},
 		},
 	}
-	if formatSet.Line.Width != 1 {
-		shape.SpPr.Ln = xlsxLineProperties{
-			W: f.ptToEMUs(formatSet.Line.Width),
-		}
+	if formatSet.Color.Gradient != nil {
+		shape.Style.FillRef = setShapeRef("", 1)
+		shape.SpPr.GradFill = newShapeGradFill(formatSet.Color.Gradient)
 	}
+	shape.SpPr.Ln = f.newShapeLine(formatSet.Line)
 	if len(formatSet.Paragraph) < 1 {
 		formatSet.Paragraph = []formatShapeParagraph{
 			{
@@ -426,40 +504,33 @@ func (f *File) addDrawingShape(sheet, drawingXML, cell string, formatSet *format
 		}
 	}
 	for _, p := range formatSet.Paragraph {
-		u := p.Font.Underline
-		_, ok := textUnderlineType[u]
-		if !ok {
-			u = "none"
-		}
-		text := p.Text
-		if text == "" {
-			text = " "
+		runs := p.Runs
+		if len(runs) == 0 {
+			runs = []formatShapeRun{{Text: p.Text}}
 		}
 		paragraph := &aP{
-			R: &aR{
-				RPr: aRPr{
-					I:       p.Font.Italic,
-					B:       p.Font.Bold,
-					Lang:    "en-US",
-					AltLang: "en-US",
-					U:       u,
-					Sz:      p.Font.Size * 100,
-					Latin:   &aLatin{Typeface: p.Font.Family},
-				},
-				T: text,
-			},
 			EndParaRPr: &aEndParaRPr{
 				Lang: "en-US",
 			},
 		}
-		srgbClr := strings.Replace(strings.ToUpper(p.Font.Color), "#", "", -1)
-		if len(srgbClr) == 6 {
-			paragraph.R.RPr.SolidFill = &aSolidFill{
-				SrgbClr: &attrValString{
-					Val: stringPtr(srgbClr),
-				},
+		for _, run := range runs {
+			fnt := p.Font
+			if run.Font != nil {
+				fnt = *run.Font
+			}
+			rPr := newShapeRunProps(fnt)
+			for idx, text := range strings.Split(strings.Replace(run.Text, "\r\n", "\n", -1), "\n") {
+				if idx > 0 {
+					paragraph.Runs = append(paragraph.Runs, aPEl{XMLName: xml.Name{Local: "a:br"}, RPr: rPr})
+				}
+				if text != "" {
+					paragraph.Runs = append(paragraph.Runs, aPEl{XMLName: xml.Name{Local: "a:r"}, RPr: rPr, T: stringPtr(text)})
+				}
 			}
 		}
+		if len(paragraph.Runs) == 0 {
+			paragraph.Runs = append(paragraph.Runs, aPEl{XMLName: xml.Name{Local: "a:r"}, RPr: newShapeRunProps(p.Font), T: stringPtr(" ")})
+		}
 		shape.TxBody.P = append(shape.TxBody.P, paragraph)
 	}
 	twoCellAnchor.Sp = &shape
@@ -472,6 +543,296 @@ func (f *File) addDrawingShape(sheet, drawingXML, cell string, formatSet *format
 	return err
 }
 
+// newShapeRunProps provides a function to create the run properties of the
+// text in the shape by given font settings.
+func newShapeRunProps(fnt Font) *aRPr {
+	textUnderlineType := map[string]bool{
+		"none":            true,
+		"words":           true,
+		"sng":             true,
+		"dbl":             true,
+		"heavy":           true,
+		"dotted":          true,
+		"dottedHeavy":     true,
+		"dash":            true,
+		"dashHeavy":       true,
+		"dashLong":        true,
+		"dashLongHeavy":   true,
+		"dotDash":         true,
+		"dotDashHeavy":    true,
+		"dotDotDash":      true,
+		"dotDotDashHeavy": true,
+		"wavy":            true,
+		"wavyHeavy":       true,
+		"wavyDbl":         true,
+	}
+	u := fnt.Underline
+	if _, ok := textUnderlineType[u]; !ok {
+		u = "none"
+	}
+	rPr := &aRPr{
+		I:       fnt.Italic,
+		B:       fnt.Bold,
+		Lang:    "en-US",
+		AltLang: "en-US",
+		U:       u,
+		Sz:      fnt.Size * 100,
+		Latin:   &aLatin{Typeface: fnt.Family},
+	}
+	srgbClr := strings.Replace(strings.ToUpper(fnt.Color), "#", "", -1)
+	if len(srgbClr) == 6 {
+		rPr.SolidFill = &aSolidFill{
+			SrgbClr: &attrValString{
+				Val: stringPtr(srgbClr),
+			},
+		}
+	}
+	return rPr
+}
+
+// parseFormatConnectorSet provides a function to parse the format settings
+// of the connector with default value.
+func parseFormatConnectorSet(formatSet string) (*formatConnector, error) {
+	format := formatConnector{
+		Type: "straightConnector1",
+		Format: formatPicture{
+			FPrintsWithSheet: true,
+		},
+		Line: formatLine{Width: 1},
+	}
+	err := json.Unmarshal([]byte(formatSet), &format)
+	return &format, err
+}
+
+// AddConnector provides the method to add a connector line shape in a sheet
+// by given worksheet name, the cells to connect and the format set (such as
+// connector type, line color, line width, arrowheads and print settings). The
+// connector will be drawn from the center of the fromCell to the center of the
+// toCell. For example, add an arrow connector from the cell A1 to D10 in
+// Sheet1:
+//
+//    err := f.AddConnector("Sheet1", "A1", "D10", `{
+//        "type": "straightConnector1",
+//        "color":
+//        {
+//            "line": "#4286F4"
+//        },
+//        "line":
+//        {
+//            "width": 1.5,
+//            "head_end": "oval",
+//            "tail_end": "triangle"
+//        }
+//    }`)
+//
+// The following shows the type of connector supported by excelize:
+//
+//    bentConnector2 (Elbow Connector 2 Shape)
+//    bentConnector3 (Elbow Connector 3 Shape)
+//    bentConnector4 (Elbow Connector 4 Shape)
+//    bentConnector5 (Elbow Connector 5 Shape)
+//    curvedConnector2 (Curved Connector 2 Shape)
+//    curvedConnector3 (Curved Connector 3 Shape)
+//    curvedConnector4 (Curved Connector 4 Shape)
+//    curvedConnector5 (Curved Connector 5 Shape)
+//    straightConnector1 (Straight Connector 1 Shape)
+//
+// The following shows the type of line ends (head_end and tail_end)
+// supported by excelize:
+//
+//    none
+//    triangle
+//    stealth
+//    diamond
+//    oval
+//    arrow
+//
+func (f *File) AddConnector(sheet, fromCell, toCell, format string) error {
+	formatSet, err := parseFormatConnectorSet(format)
+	if err != nil {
+		return err
+	}
+	if err = checkShapeLineEnds(formatSet.Line); err != nil {
+		return err
+	}
+	fromCol, fromRow, err := CellNameToCoordinates(fromCell)
+	if err != nil {
+		return err
+	}
+	toCol, toRow, err := CellNameToCoordinates(toCell)
+	if err != nil {
+		return err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	drawingID := f.countDrawings() + 1
+	drawingXML := "xl/drawings/drawing" + strconv.Itoa(drawingID) + ".xml"
+	drawingID, drawingXML = f.prepareDrawing(ws, drawingID, sheet, drawingXML)
+	f.addDrawingConnector(sheet, drawingXML, []int{fromCol, fromRow, toCol, toRow}, formatSet)
+	f.addContentTypePart(drawingID, "drawings")
+	f.addSheetNameSpace(sheet, SourceRelationship)
+	return err
+}
+
+// addDrawingConnector provides a function to add connector shape by given
+// sheet, drawingXML, the coordinates of the cells to connect and format sets.
+func (f *File) addDrawingConnector(sheet, drawingXML string, coordinates []int, formatSet *formatConnector) {
+	// The anchor points are the centers of the cells in EMUs
+	from := xlsxFrom{
+		Col:    coordinates[0] - 1,
+		ColOff: f.getColWidth(sheet, coordinates[0]) / 2 * EMU,
+		Row:    coordinates[1] - 1,
+		RowOff: f.getRowHeight(sheet, coordinates[1]) / 2 * EMU,
+	}
+	to := xlsxTo{
+		Col:    coordinates[2] - 1,
+		ColOff: f.getColWidth(sheet, coordinates[2]) / 2 * EMU,
+		Row:    coordinates[3] - 1,
+		RowOff: f.getRowHeight(sheet, coordinates[3]) / 2 * EMU,
+	}
+	// The from anchor should be the top left point of the connector, flip the
+	// connector if the end point is on the left or top of the start point
+	var flipH, flipV bool
+	if to.Col < from.Col {
+		flipH = true
+		from.Col, from.ColOff, to.Col, to.ColOff = to.Col, to.ColOff, from.Col, from.ColOff
+	}
+	if to.Row < from.Row {
+		flipV = true
+		from.Row, from.RowOff, to.Row, to.RowOff = to.Row, to.RowOff, from.Row, from.RowOff
+	}
+	content, cNvPrID := f.drawingParser(drawingXML)
+	lnRef := setShapeRef(formatSet.Color.Line, 1)
+	if formatSet.Color.Line == "" {
+		lnRef = &aRef{Idx: 1, SchemeClr: &attrValString{Val: stringPtr("accent1")}}
+	}
+	twoCellAnchor := xdrCellAnchor{
+		EditAs: formatSet.Format.Positioning,
+		From:   &from,
+		To:     &to,
+		CxnSp: &xdrCxnSp{
+			NvCxnSpPr: &xdrNvCxnSpPr{
+				CNvPr: &xlsxCNvPr{
+					ID:   cNvPrID,
+					Name: "Connector " + strconv.Itoa(cNvPrID),
+				},
+			},
+			SpPr: &xlsxSpPr{
+				Xfrm: xlsxXfrm{
+					FlipH: flipH,
+					FlipV: flipV,
+				},
+				PrstGeom: xlsxPrstGeom{
+					Prst: formatSet.Type,
+				},
+				Ln: f.newShapeLine(formatSet.Line),
+			},
+			Style: &xdrStyle{
+				LnRef:     lnRef,
+				FillRef:   setShapeRef("", 0),
+				EffectRef: setShapeRef("", 0),
+				FontRef: &aFontRef{
+					Idx: "minor",
+					SchemeClr: &attrValString{
+						Val: stringPtr("tx1"),
+					},
+				},
+			},
+		},
+		ClientData: &xdrClientData{
+			FLocksWithSheet:  formatSet.Format.FLocksWithSheet,
+			FPrintsWithSheet: formatSet.Format.FPrintsWithSheet,
+		},
+	}
+	content.TwoCellAnchor = append(content.TwoCellAnchor, &twoCellAnchor)
+	f.Drawings.Store(drawingXML, content)
+}
+
+// checkShapeLineEnds provides a function to check the type of line ends of
+// the shape line.
+func checkShapeLineEnds(line formatLine) error {
+	lineEndType := map[string]bool{
+		"":         true,
+		"none":     true,
+		"triangle": true,
+		"stealth":  true,
+		"diamond":  true,
+		"oval":     true,
+		"arrow":    true,
+	}
+	if !lineEndType[line.HeadEnd] || !lineEndType[line.TailEnd] {
+		return ErrParameterInvalid
+	}
+	return nil
+}
+
+// checkShapeTextVertical provides a function to check the vertical text type
+// of the shape text.
+func checkShapeTextVertical(vert string) error {
+	switch vert {
+	case "", "horz", "vert", "vert270", "wordArtVert", "eaVert", "mongolianVert", "wordArtVertRtl":
+		return nil
+	}
+	return ErrParameterInvalid
+}
+
+// newShapeLine provides a function to create the line properties of the
+// shape by given line settings.
+func (f *File) newShapeLine(line formatLine) xlsxLineProperties {
+	var ln xlsxLineProperties
+	if line.Width != 1 {
+		ln.W = f.ptToEMUs(line.Width)
+	}
+	if line.HeadEnd != "" {
+		ln.HeadEnd = &aLineEnd{Type: line.HeadEnd}
+	}
+	if line.TailEnd != "" {
+		ln.TailEnd = &aLineEnd{Type: line.TailEnd}
+	}
+	return ln
+}
+
+// checkShapeGradient provides a function to check the gradient fill settings
+// of the shape, the gradient should have at least 2 stops with the colors,
+// and the positions of the stops should be in the range of 0 to 100.
+func checkShapeGradient(gradient *formatShapeGradient) error {
+	if gradient == nil {
+		return nil
+	}
+	if len(gradient.Stops) < 2 {
+		return ErrParameterInvalid
+	}
+	for _, stop := range gradient.Stops {
+		if stop.Color == "" || stop.Position < 0 || stop.Position > 100 {
+			return ErrParameterInvalid
+		}
+	}
+	return nil
+}
+
+// newShapeGradFill provides a function to create the linear gradient fill of
+// the shape by given gradient settings.
+func newShapeGradFill(gradient *formatShapeGradient) *aGradFill {
+	gradFill := &aGradFill{
+		RotWithShape: true,
+		GsLst:        &aGsLst{},
+		Lin: &aLin{
+			Ang: int(math.Mod(math.Mod(gradient.Angle, 360)+360, 360) * 60000),
+		},
+	}
+	for _, stop := range gradient.Stops {
+		gradFill.GsLst.Gs = append(gradFill.GsLst.Gs, &aGs{
+			Pos: int(stop.Position * 1000),
+			SrgbClr: &attrValString{
+				Val: stringPtr(strings.Replace(strings.ToUpper(stop.Color), "#", "", -1)),
+			},
+		})
+	}
+	return gradFill
+}
+
 // setShapeRef provides a function to set color with hex model by given actual
 // color value.
 func setShapeRef(color string, i int) *aRef {
@@ -492,3 +853,102 @@ func setShapeRef(color string, i int) *aRef {
 		},
 	}
 }
+
+// GetShapes provides a function to get the type, anchor cell, size in pixels
+// and text of all shapes in a worksheet by given worksheet name. Pictures and
+// charts in the worksheet will be skipped. For example, get all shapes in
+// Sheet1:
+//
+//    shapes, err := f.GetShapes("Sheet1")
+//    if err != nil {
+//        fmt.Println(err)
+//        return
+//    }
+//    for _, shape := range shapes {
+//        fmt.Println(shape.Cell, shape.Type, shape.Text)
+//    }
+//
+func (f *File) GetShapes(sheet string) ([]Shape, error) {
+	shapes := []Shape{}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return shapes, err
+	}
+	if ws.Drawing == nil {
+		return shapes, err
+	}
+	target := f.getSheetRelationshipsTargetByID(sheet, ws.Drawing.RID)
+	drawingXML := strings.Replace(target, "..", "xl", -1)
+	if _, ok := f.Drawings.Load(drawingXML); !ok {
+		if _, ok = f.Pkg.Load(drawingXML); !ok {
+			return shapes, err
+		}
+	}
+	wsDr, _ := f.drawingParser(drawingXML)
+	wsDr.Lock()
+	anchors := append(append([]*xdrCellAnchor{}, wsDr.OneCellAnchor...), wsDr.TwoCellAnchor...)
+	wsDr.Unlock()
+	for _, anchor := range anchors {
+		output, _ := xml.Marshal(anchor)
+		deCellAnchor := new(decodeCellAnchor)
+		if err = f.xmlNewDecoder(strings.NewReader(string(output))).
+			Decode(deCellAnchor); err != nil && err != io.EOF {
+			return shapes, fmt.Errorf("xml decode error: %s", err)
+		}
+		if deCellAnchor.From == nil || deCellAnchor.Sp == nil {
+			continue
+		}
+		shape, err := f.getShape(sheet, deCellAnchor)
+		if err != nil {
+			return shapes, err
+		}
+		shapes = append(shapes, shape)
+	}
+	return shapes, nil
+}
+
+// getShape provides a function to get the type, anchor cell, size and text of
+// the shape by given worksheet name and decoded cell anchor.
+func (f *File) getShape(sheet string, anchor *decodeCellAnchor) (Shape, error) {
+	var (
+		shape  Shape
+		err    error
+		from   = anchor.From
+		paras  []string
+		sp     = anchor.Sp
+		offset = func(off int) int { return int(math.Round(float64(off) / float64(EMU))) }
+	)
+	if shape.Cell, err = CoordinatesToCellName(from.Col+1, from.Row+1); err != nil {
+		return shape, err
+	}
+	if sp.SpPr != nil {
+		shape.Type = sp.SpPr.PrstGeom.Prst
+		shape.Width = offset(sp.SpPr.Xfrm.Ext.Cx)
+		shape.Height = offset(sp.SpPr.Xfrm.Ext.Cy)
+	}
+	if to := anchor.To; to != nil && (shape.Width == 0 || shape.Height == 0) {
+		shape.Width, shape.Height = offset(to.ColOff)-offset(from.ColOff), offset(to.RowOff)-offset(from.RowOff)
+		for col := from.Col + 1; col <= to.Col; col++ {
+			shape.Width += f.getColWidth(sheet, col)
+		}
+		for row := from.Row + 1; row <= to.Row; row++ {
+			shape.Height += f.getRowHeight(sheet, row)
+		}
+	}
+	if sp.TxBody != nil {
+		for _, p := range sp.TxBody.P {
+			var text string
+			for _, r := range p.Runs {
+				switch r.XMLName.Local {
+				case "r":
+					text += r.T
+				case "br":
+					text += "\n"
+				}
+			}
+			paras = append(paras, text)
+		}
+	}
+	shape.Text = strings.Join(paras, "\n")
+	return shape, err
+}
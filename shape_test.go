@@ -87,4 +87,161 @@ func TestAddShape(t *testing.T) {
 		}
 	}`))
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddShape2.xlsx")))
+
+	// Test add shape with multiple runs and line breaks in one paragraph
+	f = NewFile()
+	assert.NoError(t, f.AddShape("Sheet1", "A1", `{
+		"type": "rect",
+		"paragraph": [
+		{
+			"font":
+			{
+				"family": "Arial",
+				"size": 12
+			},
+			"runs": [
+			{
+				"text": "Bold",
+				"font":
+				{
+					"bold": true,
+					"color": "#2980B9",
+					"size": 12
+				}
+			},
+			{
+				"text": " and normal\nsecond line"
+			}]
+		},
+		{
+			"text": "first line\r\n"
+		}]
+	}`))
+	drawing, ok := f.Drawings.Load("xl/drawings/drawing1.xml")
+	assert.True(t, ok)
+	paragraphs := drawing.(*xlsxWsDr).TwoCellAnchor[0].Sp.TxBody.P
+	var elements []string
+	for _, el := range paragraphs[0].Runs {
+		elements = append(elements, el.XMLName.Local)
+	}
+	assert.Equal(t, []string{"a:r", "a:r", "a:br", "a:r"}, elements)
+	assert.True(t, paragraphs[0].Runs[0].RPr.B)
+	assert.Equal(t, "Arial", paragraphs[0].Runs[1].RPr.Latin.Typeface)
+	assert.Equal(t, "second line", *paragraphs[0].Runs[3].T)
+	assert.Len(t, paragraphs[1].Runs, 2)
+	assert.Equal(t, "a:br", paragraphs[1].Runs[1].XMLName.Local)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddShape3.xlsx")))
+
+	// Test add shape with gradient fill
+	f = NewFile()
+	assert.NoError(t, f.AddShape("Sheet1", "A1", `{
+		"type": "rect",
+		"color":
+		{
+			"fill": "#FFFFFF",
+			"gradient":
+			{
+				"stops": [
+				{
+					"color": "#8eb9ff",
+					"position": 0
+				},
+				{
+					"color": "4286F4",
+					"position": 100
+				}],
+				"angle": -90
+			}
+		}
+	}`))
+	drawing, ok = f.Drawings.Load("xl/drawings/drawing1.xml")
+	assert.True(t, ok)
+	sp := drawing.(*xlsxWsDr).TwoCellAnchor[0].Sp
+	assert.Equal(t, 0, sp.Style.FillRef.Idx)
+	assert.Equal(t, 16200000, sp.SpPr.GradFill.Lin.Ang)
+	assert.Len(t, sp.SpPr.GradFill.GsLst.Gs, 2)
+	assert.Equal(t, "8EB9FF", *sp.SpPr.GradFill.GsLst.Gs[0].SrgbClr.Val)
+	assert.Equal(t, 100000, sp.SpPr.GradFill.GsLst.Gs[1].Pos)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddShape4.xlsx")))
+	// Test add shape with text rotation and vertical text
+	assert.NoError(t, f.AddShape("Sheet1", "D1", `{"type":"rect","paragraph":[{"text":"Sidebar"}],"text_rotation":-90,"text_vertical":"vert270"}`))
+	sp = drawing.(*xlsxWsDr).TwoCellAnchor[1].Sp
+	assert.Equal(t, 16200000, sp.TxBody.BodyPr.Rot)
+	assert.Equal(t, "vert270", sp.TxBody.BodyPr.Vert)
+	assert.EqualError(t, f.AddShape("Sheet1", "D1", `{"type":"rect","text_vertical":"unknown"}`), ErrParameterInvalid.Error())
+	// Test add shape with invalid gradient fill
+	for _, gradient := range []string{
+		`{"stops":[{"color":"#8EB9FF","position":0}]}`,
+		`{"stops":[{"color":"#8EB9FF","position":0},{"color":"","position":100}]}`,
+		`{"stops":[{"color":"#8EB9FF","position":-1},{"color":"#4286F4","position":100}]}`,
+		`{"stops":[{"color":"#8EB9FF","position":0},{"color":"#4286F4","position":101}]}`,
+	} {
+		assert.EqualError(t, f.AddShape("Sheet1", "A1", `{"type":"rect","color":{"gradient":`+gradient+`}}`), ErrParameterInvalid.Error())
+	}
+}
+
+func TestAddConnector(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetColWidth("Sheet1", "D", "D", 20))
+	assert.NoError(t, f.AddConnector("Sheet1", "A1", "D10", `{"color":{"line":"#4286F4"},"line":{"width":1.5,"head_end":"oval","tail_end":"triangle"}}`))
+	assert.NoError(t, f.AddConnector("Sheet1", "D10", "A1", `{"type":"bentConnector3"}`))
+	assert.NoError(t, f.AddShape("Sheet1", "F1", `{"type":"line","line":{"tail_end":"arrow"}}`))
+	drawing, ok := f.Drawings.Load("xl/drawings/drawing1.xml")
+	assert.True(t, ok)
+	anchors := drawing.(*xlsxWsDr).TwoCellAnchor
+	assert.Len(t, anchors, 3)
+	cxnSp := anchors[0].CxnSp
+	assert.Equal(t, "straightConnector1", cxnSp.SpPr.PrstGeom.Prst)
+	assert.Equal(t, f.ptToEMUs(1.5), cxnSp.SpPr.Ln.W)
+	assert.Equal(t, "oval", cxnSp.SpPr.Ln.HeadEnd.Type)
+	assert.Equal(t, "triangle", cxnSp.SpPr.Ln.TailEnd.Type)
+	assert.Equal(t, "4286F4", *cxnSp.Style.LnRef.SrgbClr.Val)
+	assert.False(t, cxnSp.SpPr.Xfrm.FlipH || cxnSp.SpPr.Xfrm.FlipV)
+	assert.Equal(t, []int{0, 0, 3, 9}, []int{anchors[0].From.Col, anchors[0].From.Row, anchors[0].To.Col, anchors[0].To.Row})
+	assert.Equal(t, f.getColWidth("Sheet1", 4)/2*EMU, anchors[0].To.ColOff)
+	// Test add connector in the reverse direction
+	cxnSp = anchors[1].CxnSp
+	assert.True(t, cxnSp.SpPr.Xfrm.FlipH && cxnSp.SpPr.Xfrm.FlipV)
+	assert.Equal(t, "accent1", *cxnSp.Style.LnRef.SchemeClr.Val)
+	assert.Equal(t, []int{0, 0, 3, 9}, []int{anchors[1].From.Col, anchors[1].From.Row, anchors[1].To.Col, anchors[1].To.Row})
+	assert.Equal(t, anchors[0].To.ColOff, anchors[1].To.ColOff)
+	assert.Nil(t, cxnSp.SpPr.Ln.HeadEnd)
+	assert.Equal(t, "arrow", anchors[2].Sp.SpPr.Ln.TailEnd.Type)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddConnector.xlsx")))
+
+	// Test add connector with invalid parameters
+	f = NewFile()
+	assert.EqualError(t, f.AddConnector("Sheet1", "A1", "D10", ""), "unexpected end of JSON input")
+	assert.EqualError(t, f.AddConnector("Sheet1", "A1", "D10", `{"line":{"head_end":"unknown"}}`), ErrParameterInvalid.Error())
+	assert.EqualError(t, f.AddShape("Sheet1", "A1", `{"type":"line","line":{"tail_end":"unknown"}}`), ErrParameterInvalid.Error())
+	assert.EqualError(t, f.AddConnector("Sheet1", "A", "D10", "{}"), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+	assert.EqualError(t, f.AddConnector("Sheet1", "A1", "D", "{}"), `cannot convert cell "D" to coordinates: invalid cell name "D"`)
+	assert.EqualError(t, f.AddConnector("SheetN", "A1", "D10", "{}"), "sheet SheetN is not exist")
+}
+
+func TestGetShapes(t *testing.T) {
+	f := NewFile()
+	shapes, err := f.GetShapes("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []Shape{}, shapes)
+	assert.NoError(t, f.AddPicture("Sheet1", "A1", filepath.Join("test", "images", "excel.png"), ""))
+	assert.NoError(t, f.AddShape("Sheet1", "B2", `{"type":"rect","paragraph":[{"text":"Rectangle\nShape"},{"runs":[{"text":"Second "},{"text":"line"}]}]}`))
+	assert.NoError(t, f.AddShape("Sheet1", "D5", `{"type":"ellipse","width":200,"height":100}`))
+	expected := []Shape{
+		{Type: "rect", Cell: "B2", Width: 160, Height: 160, Text: "Rectangle\nShape\nSecond line"},
+		{Type: "ellipse", Cell: "D5", Width: 200, Height: 100, Text: " "},
+	}
+	shapes, err = f.GetShapes("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, shapes)
+	// Test get shapes after save and reopen the workbook
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGetShapes.xlsx")))
+	f, err = OpenFile(filepath.Join("test", "TestGetShapes.xlsx"))
+	assert.NoError(t, err)
+	shapes, err = f.GetShapes("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, shapes)
+	// Test get shapes on not exists worksheet
+	_, err = f.GetShapes("SheetN")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
 }
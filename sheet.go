@@ -16,6 +16,7 @@ import (
 	"encoding/json"
 	"encoding/xml"
 	"fmt"
+	"image"
 	"io"
 	"io/ioutil"
 	"log"
@@ -821,11 +822,30 @@ func parseFormatPanesSet(formatSet string) (*formatPanes, error) {
 //    f.SetPanes("Sheet1", `{"freeze":false,"split":false}`)
 //
 func (f *File) SetPanes(sheet, panes string) error {
-	fs, _ := parseFormatPanesSet(panes)
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return err
 	}
+	ws.setPanes(panes)
+	return err
+}
+
+// prepareSheetView provides a function to create the sheet view of the
+// worksheet if it doesn't exist, the worksheet created by other applications
+// may have no sheet views.
+func (ws *xlsxWorksheet) prepareSheetView() {
+	if ws.SheetViews == nil {
+		ws.SheetViews = &xlsxSheetViews{}
+	}
+	if len(ws.SheetViews.SheetView) == 0 {
+		ws.SheetViews.SheetView = append(ws.SheetViews.SheetView, xlsxSheetView{WorkbookViewID: 0})
+	}
+}
+
+// setPanes provides a function to set the pane and selection of the last
+// sheet view in the worksheet by given panes format set.
+func (ws *xlsxWorksheet) setPanes(panes string) {
+	fs, _ := parseFormatPanesSet(panes)
 	p := &xlsxPane{
 		ActivePane:  fs.ActivePane,
 		TopLeftCell: fs.TopLeftCell,
@@ -835,6 +855,7 @@ func (f *File) SetPanes(sheet, panes string) error {
 	if fs.Freeze {
 		p.State = "frozen"
 	}
+	ws.prepareSheetView()
 	ws.SheetViews.SheetView[len(ws.SheetViews.SheetView)-1].Pane = p
 	if !(fs.Freeze) && !(fs.Split) {
 		if len(ws.SheetViews.SheetView) > 0 {
@@ -850,6 +871,61 @@ func (f *File) SetPanes(sheet, panes string) error {
 		})
 	}
 	ws.SheetViews.SheetView[len(ws.SheetViews.SheetView)-1].Selection = s
+}
+
+// FreezePanes provides a function to freeze panes by given worksheet name and
+// the number of frozen columns and rows, it coexists with SetPanes and
+// generates the pane and selection of the last sheet view. The top left
+// visible cell in the bottom right pane defaults to the first cell next to
+// the frozen panes, and it must not be inside the frozen panes. For example,
+// freeze the first two rows in Sheet1 and show row 3 at the top of the
+// scrolling pane:
+//
+//    err := f.FreezePanes("Sheet1", excelize.FreezePanesOptions{Rows: 2, TopLeftCell: "A3"})
+//
+// An example of how to unfreeze panes on Sheet1:
+//
+//    err := f.FreezePanes("Sheet1", excelize.FreezePanesOptions{})
+//
+func (f *File) FreezePanes(sheet string, opts FreezePanesOptions) error {
+	if opts.Cols < 0 || opts.Rows < 0 || opts.Cols >= TotalColumns || opts.Rows >= TotalRows {
+		return ErrParameterInvalid
+	}
+	topLeftCell, activePane := opts.TopLeftCell, "bottomRight"
+	if topLeftCell == "" {
+		topLeftCell, _ = CoordinatesToCellName(opts.Cols+1, opts.Rows+1)
+	}
+	col, row, err := CellNameToCoordinates(topLeftCell)
+	if err != nil {
+		return err
+	}
+	if col <= opts.Cols || row <= opts.Rows {
+		return ErrParameterInvalid
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	ws.prepareSheetView()
+	sheetView := &ws.SheetViews.SheetView[len(ws.SheetViews.SheetView)-1]
+	if opts.Cols == 0 && opts.Rows == 0 {
+		sheetView.Pane, sheetView.Selection = nil, nil
+		return err
+	}
+	if opts.Cols == 0 {
+		activePane = "bottomLeft"
+	}
+	if opts.Rows == 0 {
+		activePane = "topRight"
+	}
+	sheetView.Pane = &xlsxPane{
+		ActivePane:  activePane,
+		State:       "frozen",
+		TopLeftCell: topLeftCell,
+		XSplit:      float64(opts.Cols),
+		YSplit:      float64(opts.Rows),
+	}
+	sheetView.Selection = []*xlsxSelection{{ActiveCell: topLeftCell, Pane: activePane, SQRef: topLeftCell}}
 	return err
 }
 
@@ -1128,15 +1204,213 @@ func (f *File) SetHeaderFooter(sheet string, settings *FormatHeaderFooter) error
 	return err
 }
 
+// AddHeaderFooterImage provides a function to add an image to the left,
+// center or right section of the header or footer on the odd pages of the
+// worksheet. The image will be stored in the legacy VML drawing of the header
+// and footer, and the "&G" code will be added to the section of the odd
+// header or footer. Adding an image to the section which already has an
+// image will replace it. For example, add an image to the left section of
+// the header on Sheet1:
+//
+//    file, err := ioutil.ReadFile("logo.png")
+//    if err != nil {
+//        fmt.Println(err)
+//    }
+//    err = f.AddHeaderFooterImage("Sheet1", &excelize.HeaderFooterImageOptions{
+//        Position:  "left",
+//        Extension: ".png",
+//        File:      file,
+//    })
+//
+func (f *File) AddHeaderFooterImage(sheet string, opts *HeaderFooterImageOptions) error {
+	if opts == nil {
+		return ErrParameterRequired
+	}
+	section, ok := map[string]string{"left": "L", "center": "C", "right": "R"}[opts.Position]
+	if !ok {
+		return ErrParameterInvalid
+	}
+	ext, ok := supportImageTypes[opts.Extension]
+	if !ok {
+		return ErrImgExt
+	}
+	img, _, err := image.DecodeConfig(bytes.NewReader(opts.File))
+	if err != nil {
+		return err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	if ws.HeaderFooter == nil {
+		ws.HeaderFooter = &xlsxHeaderFooter{}
+	}
+	shapeID, field, text := section+"H", "OddHeader", &ws.HeaderFooter.OddHeader
+	if opts.IsFooter {
+		shapeID, field, text = section+"F", "OddFooter", &ws.HeaderFooter.OddFooter
+	}
+	value := addHeaderFooterImageCode(*text, section)
+	if len(utf16.Encode([]rune(value))) > MaxFieldLength {
+		return newFieldLengthError(field)
+	}
+	var drawingVML string
+	if ws.LegacyDrawingHF != nil {
+		drawingVML = strings.Replace(f.getSheetRelationshipsTargetByID(sheet, ws.LegacyDrawingHF.RID), "..", "xl", 1)
+	} else {
+		drawingVML = "xl/drawings/vmlDrawingHF" + strconv.Itoa(f.countHeaderFooterVMLDrawings()+1) + ".vml"
+		sheetRels := "xl/worksheets/_rels/" + strings.TrimPrefix(f.sheetMap[trimSheetName(sheet)], "xl/worksheets/") + ".rels"
+		rID := f.addRels(sheetRels, SourceRelationshipDrawingVML, strings.Replace(drawingVML, "xl", "..", 1), "")
+		f.addSheetNameSpace(sheet, SourceRelationship)
+		ws.LegacyDrawingHF = &xlsxLegacyDrawingHF{RID: "rId" + strconv.Itoa(rID)}
+	}
+	drawingRels := strings.Replace(drawingVML, "xl/drawings/", "xl/drawings/_rels/", 1) + ".rels"
+	media := f.addMedia(opts.File, ext)
+	rID := f.addRels(drawingRels, SourceRelationshipImage, ".."+strings.TrimPrefix(media, "xl"), "")
+	f.addHeaderFooterVMLShape(drawingVML, drawingRels, shapeID, strings.TrimSuffix(path.Base(media), ext), rID, img.Width, img.Height)
+	*text = value
+	f.setContentTypePartVMLExtensions()
+	f.setContentTypePartImageExtensions()
+	return err
+}
+
+// addHeaderFooterImageCode provides a function to add the image code "&G" to
+// the given section of the header or footer text, the text without section
+// code belongs to the center section.
+func addHeaderFooterImageCode(text, section string) string {
+	sections, current := map[string]string{}, "C"
+	for i := 0; i < len(text); i++ {
+		if text[i] == '&' && i+1 < len(text) {
+			if next := text[i+1]; next == 'L' || next == 'C' || next == 'R' {
+				current = string(next)
+				i++
+				continue
+			}
+			sections[current] += text[i : i+2]
+			i++
+			continue
+		}
+		sections[current] += text[i : i+1]
+	}
+	if !strings.Contains(sections[section], "&G") {
+		sections[section] += "&G"
+	}
+	var result string
+	for _, s := range []string{"L", "C", "R"} {
+		if sections[s] != "" {
+			result += "&" + s + sections[s]
+		}
+	}
+	return result
+}
+
+// countHeaderFooterVMLDrawings provides a function to get the header and
+// footer VML drawing files count storage in the folder xl/drawings.
+func (f *File) countHeaderFooterVMLDrawings() int {
+	count := 0
+	f.Pkg.Range(func(k, v interface{}) bool {
+		if strings.HasPrefix(k.(string), "xl/drawings/vmlDrawingHF") {
+			count++
+		}
+		return true
+	})
+	for path := range f.VMLDrawing {
+		if _, ok := f.Pkg.Load(path); !ok && strings.HasPrefix(path, "xl/drawings/vmlDrawingHF") {
+			count++
+		}
+	}
+	return count
+}
+
+// addHeaderFooterVMLShape provides a function to add the image shape to the
+// header and footer VML drawing by given shape ID, image title, relationship
+// index and the image size in pixels. The existing shape with the same ID
+// will be replaced and its image relationship will be removed.
+func (f *File) addHeaderFooterVMLShape(drawingVML, drawingRels, shapeID, title string, rID, width, height int) {
+	vml := f.VMLDrawing[drawingVML]
+	if vml == nil {
+		// Use the number in the drawing file name as the shape ID block.
+		idmap, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(path.Base(drawingVML), "vmlDrawingHF"), ".vml"))
+		vml = &vmlDrawing{
+			XMLNSv:  "urn:schemas-microsoft-com:vml",
+			XMLNSo:  "urn:schemas-microsoft-com:office:office",
+			XMLNSx:  "urn:schemas-microsoft-com:office:excel",
+			XMLNSmv: "http://macVmlSchemaUri",
+			Shapelayout: &xlsxShapelayout{
+				Ext:   "edit",
+				IDmap: &xlsxIDmap{Ext: "edit", Data: idmap},
+			},
+			Shapetype: &xlsxShapetype{
+				ID:             "_x0000_t75",
+				Coordsize:      "21600,21600",
+				Spt:            75,
+				Preferrelative: "t",
+				Path:           "m@4@5l@4@11@9@11@9@5xe",
+				Filled:         "f",
+				Stroked:        "f",
+				Stroke:         &xlsxStroke{Joinstyle: "miter"},
+				Formulas:       &vFormulas{},
+				VPath:          &vPath{Extrusionok: "f", Gradientshapeok: "t", Connecttype: "rect"},
+				Lock:           &oLock{Ext: "edit", Aspectratio: "t"},
+			},
+		}
+		for _, eqn := range []string{
+			"if lineDrawn pixelLineWidth 0", "sum @0 1 0", "sum 0 0 @1",
+			"prod @2 1 2", "prod @3 21600 pixelWidth", "prod @3 21600 pixelHeight",
+			"sum @0 0 1", "prod @6 1 2", "prod @7 21600 pixelWidth",
+			"sum @8 21600 0", "prod @7 21600 pixelHeight", "sum @10 21600 0",
+		} {
+			vml.Shapetype.Formulas.Formula = append(vml.Shapetype.Formulas.Formula, vFormula{Eqn: eqn})
+		}
+		if d := f.decodeVMLDrawingReader(drawingVML); d != nil {
+			for _, v := range d.Shape {
+				vml.Shape = append(vml.Shape, xlsxShape{ID: v.ID, Spid: v.Spid, Type: v.Type, Style: v.Style, Val: v.Val})
+			}
+		}
+	}
+	shapes, spid, zIndex := vml.Shape[:0], vml.Shapelayout.IDmap.Data*1024, 0
+	for _, shape := range vml.Shape {
+		if id, _ := strconv.Atoi(strings.TrimPrefix(shape.Spid, "_x0000_s")); id > spid {
+			spid = id
+		}
+		if z := regexp.MustCompile(`z-index:(\d+)`).FindStringSubmatch(shape.Style); z != nil {
+			if idx, _ := strconv.Atoi(z[1]); idx > zIndex {
+				zIndex = idx
+			}
+		}
+		if shape.ID != shapeID {
+			shapes = append(shapes, shape)
+			continue
+		}
+		if relID := regexp.MustCompile(`relid="([^"]*)"`).FindStringSubmatch(shape.Val); relID != nil {
+			f.deleteRelationship(drawingRels, relID[1])
+		}
+	}
+	imageData, _ := xml.Marshal(vImageData{RelID: "rId" + strconv.Itoa(rID), Title: title})
+	lock, _ := xml.Marshal(oLock{Ext: "edit", Rotation: "t"})
+	vml.Shape = append(shapes, xlsxShape{
+		ID:    shapeID,
+		Spid:  "_x0000_s" + strconv.Itoa(spid+1),
+		Type:  "#_x0000_t75",
+		Style: fmt.Sprintf("position:absolute;margin-left:0;margin-top:0;width:%gpt;height:%gpt;z-index:%d", float64(width)*0.75, float64(height)*0.75, zIndex+1),
+		Val:   string(imageData) + string(lock),
+	})
+	f.VMLDrawing[drawingVML] = vml
+}
+
 // ProtectSheet provides a function to prevent other users from accidentally
 // or deliberately changing, moving, or deleting data in a worksheet. For
 // example, protect Sheet1 with protection settings:
 //
 //    err := f.ProtectSheet("Sheet1", &excelize.FormatSheetProtection{
-//        Password:      "password",
-//        EditScenarios: false,
+//        ProtectionAlgorithm: "SHA-512",
+//        Password:            "password",
+//        EditScenarios:       false,
 //    })
 //
+// ProtectionAlgorithm specifies the hash algorithm of the password, the
+// legacy 16-bit hash will be used if it's empty, and the SHA-512 hash with a
+// random salt and 100000 spin count will be used if it's "SHA-512". The
+// worksheet will be protected without password if the Password is empty.
 func (f *File) ProtectSheet(sheet string, settings *FormatSheetProtection) error {
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
@@ -1149,30 +1423,85 @@ func (f *File) ProtectSheet(sheet string, settings *FormatSheetProtection) error
 			SelectLockedCells: true,
 		}
 	}
-	ws.SheetProtection = &xlsxSheetProtection{
-		AutoFilter:          settings.AutoFilter,
-		DeleteColumns:       settings.DeleteColumns,
-		DeleteRows:          settings.DeleteRows,
-		FormatCells:         settings.FormatCells,
-		FormatColumns:       settings.FormatColumns,
-		FormatRows:          settings.FormatRows,
-		InsertColumns:       settings.InsertColumns,
-		InsertHyperlinks:    settings.InsertHyperlinks,
-		InsertRows:          settings.InsertRows,
+	sheetProtection := &xlsxSheetProtection{
+		AutoFilter:          boolPtr(settings.AutoFilter),
+		DeleteColumns:       boolPtr(settings.DeleteColumns),
+		DeleteRows:          boolPtr(settings.DeleteRows),
+		FormatCells:         boolPtr(settings.FormatCells),
+		FormatColumns:       boolPtr(settings.FormatColumns),
+		FormatRows:          boolPtr(settings.FormatRows),
+		InsertColumns:       boolPtr(settings.InsertColumns),
+		InsertHyperlinks:    boolPtr(settings.InsertHyperlinks),
+		InsertRows:          boolPtr(settings.InsertRows),
 		Objects:             settings.EditObjects,
-		PivotTables:         settings.PivotTables,
+		PivotTables:         boolPtr(settings.PivotTables),
 		Scenarios:           settings.EditScenarios,
 		SelectLockedCells:   settings.SelectLockedCells,
 		SelectUnlockedCells: settings.SelectUnlockedCells,
 		Sheet:               true,
-		Sort:                settings.Sort,
+		Sort:                boolPtr(settings.Sort),
 	}
 	if settings.Password != "" {
-		ws.SheetProtection.Password = genSheetPasswd(settings.Password)
+		if settings.ProtectionAlgorithm == "" {
+			sheetProtection.Password = genSheetPasswd(settings.Password)
+		} else {
+			hashValue, saltValue, err := genISOPasswdHash(settings.Password, settings.ProtectionAlgorithm, "", protectionSpinCount)
+			if err != nil {
+				return err
+			}
+			sheetProtection.AlgorithmName = settings.ProtectionAlgorithm
+			sheetProtection.HashValue = hashValue
+			sheetProtection.SaltValue = saltValue
+			sheetProtection.SpinCount = protectionSpinCount
+		}
 	}
+	ws.SheetProtection = sheetProtection
 	return err
 }
 
+// GetSheetProtection provides a function to get the protection settings of
+// the worksheet by given worksheet name, returns nil if the worksheet is not
+// protected. The Password of the settings will always be empty, since the
+// password can't be restored from the hash value. For example, check if
+// Sheet1 is protected:
+//
+//    settings, err := f.GetSheetProtection("Sheet1")
+//    if err != nil {
+//        fmt.Println(err)
+//        return
+//    }
+//    if settings != nil {
+//        fmt.Println("Sheet1 is protected")
+//    }
+//
+func (f *File) GetSheetProtection(sheet string) (*FormatSheetProtection, error) {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return nil, err
+	}
+	if ws.SheetProtection == nil || !ws.SheetProtection.Sheet {
+		return nil, err
+	}
+	return &FormatSheetProtection{
+		AlgorithmName:       ws.SheetProtection.AlgorithmName,
+		AutoFilter:          defaultTrue(ws.SheetProtection.AutoFilter),
+		DeleteColumns:       defaultTrue(ws.SheetProtection.DeleteColumns),
+		DeleteRows:          defaultTrue(ws.SheetProtection.DeleteRows),
+		EditObjects:         ws.SheetProtection.Objects,
+		EditScenarios:       ws.SheetProtection.Scenarios,
+		FormatCells:         defaultTrue(ws.SheetProtection.FormatCells),
+		FormatColumns:       defaultTrue(ws.SheetProtection.FormatColumns),
+		FormatRows:          defaultTrue(ws.SheetProtection.FormatRows),
+		InsertColumns:       defaultTrue(ws.SheetProtection.InsertColumns),
+		InsertHyperlinks:    defaultTrue(ws.SheetProtection.InsertHyperlinks),
+		InsertRows:          defaultTrue(ws.SheetProtection.InsertRows),
+		PivotTables:         defaultTrue(ws.SheetProtection.PivotTables),
+		SelectLockedCells:   ws.SheetProtection.SelectLockedCells,
+		SelectUnlockedCells: ws.SheetProtection.SelectUnlockedCells,
+		Sort:                defaultTrue(ws.SheetProtection.Sort),
+	}, err
+}
+
 // UnprotectSheet provides a function to unprotect an Excel worksheet.
 func (f *File) UnprotectSheet(sheet string) error {
 	ws, err := f.workSheetReader(sheet)
@@ -1183,6 +1512,189 @@ func (f *File) UnprotectSheet(sheet string) error {
 	return err
 }
 
+// AddProtectedRange provides a function to add a protected range for the
+// worksheet by given worksheet name and protected range settings. The range
+// can be edited by the users after entering the password of the range when
+// the worksheet is protected, and the range without password can be edited
+// by anyone. For example, allow editing the range Sheet1!A1:B5 by the
+// password "passwd" after protect Sheet1:
+//
+//    err := f.AddProtectedRange("Sheet1", excelize.ProtectedRangeOptions{
+//        Name:     "Range1",
+//        Range:    "A1:B5",
+//        Password: "passwd",
+//    })
+//    err = f.ProtectSheet("Sheet1", nil)
+//
+// The Range can be multiple cell references separated by a space, such as
+// "A1:B5 D1:D5". The name of the protected ranges in a worksheet should be
+// unique, and the length of the name should be less than or equal to 255
+// characters.
+func (f *File) AddProtectedRange(sheet string, opts ProtectedRangeOptions) error {
+	if opts.Name == "" || opts.Range == "" {
+		return ErrParameterRequired
+	}
+	if len(opts.Name) > MaxFieldLength {
+		return ErrParameterInvalid
+	}
+	var refs []string
+	for _, ref := range strings.Fields(strings.ReplaceAll(opts.Range, "$", "")) {
+		for _, cell := range strings.Split(ref, ":") {
+			if _, _, err := CellNameToCoordinates(cell); err != nil {
+				return err
+			}
+		}
+		refs = append(refs, ref)
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	if err = f.appendProtectedRangeExt(ws, opts, strings.Join(refs, " ")); err != nil {
+		return err
+	}
+	f.addSheetNameSpace(sheet, NameSpaceSpreadSheetX14)
+	return err
+}
+
+// appendProtectedRangeExt provides a function to append the protected range
+// into the x14:protectedRanges of the worksheet extension list by given
+// worksheet, protected range settings and the reference sequence.
+func (f *File) appendProtectedRangeExt(ws *xlsxWorksheet, opts ProtectedRangeOptions, sqref string) error {
+	var (
+		err                                                    error
+		idx                                                    = -1
+		decodeExtLst                                           = new(decodeWorksheetExt)
+		decodeProtectedRanges                                  = new(decodeX14ProtectedRanges)
+		protectedRangeBytes, protectedRangesBytes, extLstBytes []byte
+	)
+	if ws.ExtLst != nil {
+		if err = f.xmlNewDecoder(strings.NewReader("<extLst>" + ws.ExtLst.Ext + "</extLst>")).
+			Decode(decodeExtLst); err != nil && err != io.EOF {
+			return err
+		}
+	}
+	for i, ext := range decodeExtLst.Ext {
+		if ext.URI == ExtURIProtectedRanges {
+			if err = f.xmlNewDecoder(strings.NewReader(ext.Content)).
+				Decode(decodeProtectedRanges); err != nil && err != io.EOF {
+				return err
+			}
+			idx = i
+		}
+	}
+	for _, protectedRange := range decodeProtectedRanges.ProtectedRange {
+		if strings.EqualFold(protectedRange.Name, opts.Name) {
+			return newProtectedRangeExistsError(opts.Name)
+		}
+	}
+	protectedRange := xlsxX14ProtectedRange{Name: opts.Name, Sqref: sqref}
+	if opts.Password != "" {
+		protectedRange.Password = genSheetPasswd(opts.Password)
+	}
+	if protectedRangeBytes, err = xml.Marshal(&protectedRange); err != nil {
+		return err
+	}
+	if protectedRangesBytes, err = xml.Marshal(&xlsxX14ProtectedRanges{
+		XMLNSXM: NameSpaceSpreadSheetExcel2006Main.Value,
+		Content: decodeProtectedRanges.Content + string(protectedRangeBytes),
+	}); err != nil {
+		return err
+	}
+	if idx == -1 {
+		decodeExtLst.Ext = append(decodeExtLst.Ext, &xlsxWorksheetExt{URI: ExtURIProtectedRanges})
+		idx = len(decodeExtLst.Ext) - 1
+	}
+	decodeExtLst.Ext[idx].Content = string(protectedRangesBytes)
+	if extLstBytes, err = xml.Marshal(decodeExtLst); err != nil {
+		return err
+	}
+	ws.ExtLst = &xlsxExtLst{
+		Ext: strings.TrimSuffix(strings.TrimPrefix(string(extLstBytes), "<extLst>"), "</extLst>"),
+	}
+	return err
+}
+
+// ProtectWorkbook provides a function to prevent other users from viewing
+// hidden worksheets, adding, moving, deleting, or hiding worksheets, and
+// renaming worksheets in a workbook. For example, protect the structure of
+// the workbook with the SHA-512 hash of the password:
+//
+//    err := f.ProtectWorkbook(&excelize.FormatWorkbookProtection{
+//        ProtectionAlgorithm: "SHA-512",
+//        Password:            "password",
+//        LockStructure:       true,
+//    })
+//
+// ProtectionAlgorithm specifies the hash algorithm of the password, the
+// legacy 16-bit hash will be used if it's empty, and the SHA-512 hash with a
+// random salt and 100000 spin count will be used if it's "SHA-512". If the
+// Password is empty, the AlgorithmName, HashValue, SaltValue, SpinCount and
+// the legacy WorkbookPassword hash will be written as is, this allows to
+// preserve the protection settings returned by GetWorkbookProtection.
+func (f *File) ProtectWorkbook(settings *FormatWorkbookProtection) error {
+	wb := f.workbookReader()
+	if settings == nil {
+		settings = &FormatWorkbookProtection{LockStructure: true}
+	}
+	protection := &xlsxWorkbookProtection{
+		LockStructure: settings.LockStructure,
+		LockWindows:   settings.LockWindows,
+	}
+	if settings.Password != "" {
+		if settings.ProtectionAlgorithm == "" {
+			protection.WorkbookPassword = genSheetPasswd(settings.Password)
+		} else {
+			hashValue, saltValue, err := genISOPasswdHash(settings.Password, settings.ProtectionAlgorithm, "", protectionSpinCount)
+			if err != nil {
+				return err
+			}
+			protection.WorkbookAlgorithmName = settings.ProtectionAlgorithm
+			protection.WorkbookHashValue = hashValue
+			protection.WorkbookSaltValue = saltValue
+			protection.WorkbookSpinCount = protectionSpinCount
+		}
+	} else {
+		protection.WorkbookAlgorithmName = settings.AlgorithmName
+		protection.WorkbookHashValue = settings.HashValue
+		protection.WorkbookSaltValue = settings.SaltValue
+		protection.WorkbookSpinCount = settings.SpinCount
+		protection.WorkbookPassword = settings.WorkbookPassword
+	}
+	wb.WorkbookProtection = protection
+	return nil
+}
+
+// GetWorkbookProtection provides a function to get the protection settings
+// of the workbook, returns nil if the workbook is not protected. The Password
+// of the settings will always be empty, since the password can't be restored
+// from the hash value. The AlgorithmName, HashValue, SaltValue and SpinCount
+// will be set if the password is hashed by the SHA-512 or other algorithms,
+// and the WorkbookPassword will be set if the password is hashed by the
+// legacy 16-bit hash.
+func (f *File) GetWorkbookProtection() (*FormatWorkbookProtection, error) {
+	wb := f.workbookReader()
+	if wb.WorkbookProtection == nil {
+		return nil, nil
+	}
+	return &FormatWorkbookProtection{
+		AlgorithmName:    wb.WorkbookProtection.WorkbookAlgorithmName,
+		HashValue:        wb.WorkbookProtection.WorkbookHashValue,
+		SaltValue:        wb.WorkbookProtection.WorkbookSaltValue,
+		SpinCount:        wb.WorkbookProtection.WorkbookSpinCount,
+		WorkbookPassword: wb.WorkbookProtection.WorkbookPassword,
+		LockStructure:    wb.WorkbookProtection.LockStructure,
+		LockWindows:      wb.WorkbookProtection.LockWindows,
+	}, nil
+}
+
+// UnprotectWorkbook provides a function to remove protection for workbook.
+func (f *File) UnprotectWorkbook() error {
+	wb := f.workbookReader()
+	wb.WorkbookProtection = nil
+	return nil
+}
+
 // trimSheetName provides a function to trim invaild characters by given worksheet
 // name.
 func trimSheetName(name string) string {
@@ -1281,9 +1793,12 @@ func (p *FirstPageNumber) getPageLayout(ps *xlsxPageSetUp) {
 	*p = 1
 }
 
-// setPageLayout provides a method to set the orientation for the worksheet.
+// setPageLayout provides a method to set the orientation for the worksheet,
+// the value other than portrait and landscape will be ignored.
 func (o PageLayoutOrientation) setPageLayout(ps *xlsxPageSetUp) {
-	ps.Orientation = string(o)
+	if o == OrientationPortrait || o == OrientationLandscape {
+		ps.Orientation = string(o)
+	}
 }
 
 // getPageLayout provides a method to get the orientation for the worksheet.
@@ -1359,7 +1874,17 @@ func (p *PageLayoutScale) getPageLayout(ps *xlsxPageSetUp) {
 	*p = PageLayoutScale(ps.Scale)
 }
 
-// SetPageLayout provides a function to sets worksheet page layout.
+// SetPageLayout provides a function to sets worksheet page layout. The
+// fitToPage flag in the worksheet properties will be set automatically when
+// FitToHeight or FitToWidth is specified, so that the fit to pages scaling
+// takes effect instead of the PageLayoutScale. For example, print Sheet1 in
+// landscape and fit all columns on one page:
+//
+//    err := f.SetPageLayout("Sheet1",
+//        excelize.PageLayoutOrientation(excelize.OrientationLandscape),
+//        excelize.PageLayoutPaperSize(9),
+//        excelize.FitToWidth(1),
+//    )
 //
 // Available options:
 //
@@ -1503,8 +2028,18 @@ func (f *File) SetPageLayout(sheet string, opts ...PageLayoutOption) error {
 		s.PageSetUp = ps
 	}
 
+	var fitToPage bool
 	for _, opt := range opts {
 		opt.setPageLayout(ps)
+		switch o := opt.(type) {
+		case FitToHeight:
+			fitToPage = fitToPage || o > 0
+		case FitToWidth:
+			fitToPage = fitToPage || o > 0
+		}
+	}
+	if fitToPage {
+		return f.SetSheetPrOptions(sheet, FitToPage(true))
 	}
 	return err
 }
@@ -1512,10 +2047,15 @@ func (f *File) SetPageLayout(sheet string, opts ...PageLayoutOption) error {
 // GetPageLayout provides a function to gets worksheet page layout.
 //
 // Available options:
-//   PageLayoutOrientation(string)
-//   PageLayoutPaperSize(int)
-//   FitToHeight(int)
-//   FitToWidth(int)
+//
+//    BlackAndWhite(bool)
+//    FirstPageNumber(uint)
+//    PageLayoutOrientation(string)
+//    PageLayoutPaperSize(int)
+//    FitToHeight(int)
+//    FitToWidth(int)
+//    PageLayoutScale(uint)
+//
 func (f *File) GetPageLayout(sheet string, opts ...PageLayoutOptionPtr) error {
 	s, err := f.workSheetReader(sheet)
 	if err != nil {
@@ -1530,8 +2070,11 @@ func (f *File) GetPageLayout(sheet string, opts ...PageLayoutOptionPtr) error {
 }
 
 // SetDefinedName provides a function to set the defined names of the workbook
-// or worksheet. If not specified scope, the default scope is workbook.
-// For example:
+// or worksheet. If not specified scope, the default scope is workbook. The
+// scope "Workbook" is also treated as the workbook scope, and an error will be
+// returned if the worksheet of the given scope doesn't exist. The built-in
+// names such as "Print_Area" and "Print_Titles" will be stored with the
+// "_xlnm." prefix. For example:
 //
 //    f.SetDefinedName(&excelize.DefinedName{
 //        Name:     "Amount",
@@ -1540,25 +2083,29 @@ func (f *File) GetPageLayout(sheet string, opts ...PageLayoutOptionPtr) error {
 //        Scope:    "Sheet2",
 //    })
 //
+// An example of how to define the print area locally on Sheet2:
+//
+//    f.SetDefinedName(&excelize.DefinedName{
+//        Name:     "Print_Area",
+//        RefersTo: "Sheet2!$A$1:$D$20",
+//        Scope:    "Sheet2",
+//    })
+//
 func (f *File) SetDefinedName(definedName *DefinedName) error {
+	localSheetID, err := f.getDefinedNameLocalSheetID(definedName.Scope)
+	if err != nil {
+		return err
+	}
 	wb := f.workbookReader()
 	d := xlsxDefinedName{
-		Name:    definedName.Name,
-		Comment: definedName.Comment,
-		Data:    definedName.RefersTo,
-	}
-	if definedName.Scope != "" {
-		if sheetIndex := f.GetSheetIndex(definedName.Scope); sheetIndex >= 0 {
-			d.LocalSheetID = &sheetIndex
-		}
+		Name:         getDefinedNameName(definedName.Name),
+		Comment:      definedName.Comment,
+		Data:         definedName.RefersTo,
+		LocalSheetID: localSheetID,
 	}
 	if wb.DefinedNames != nil {
 		for _, dn := range wb.DefinedNames.DefinedName {
-			var scope string
-			if dn.LocalSheetID != nil {
-				scope = f.GetSheetName(*dn.LocalSheetID)
-			}
-			if scope == definedName.Scope && dn.Name == definedName.Name {
+			if strings.EqualFold(dn.Name, d.Name) && isSameDefinedNameScope(dn.LocalSheetID, d.LocalSheetID) {
 				return ErrDefinedNameduplicate
 			}
 		}
@@ -1571,9 +2118,45 @@ func (f *File) SetDefinedName(definedName *DefinedName) error {
 	return nil
 }
 
+// getDefinedNameLocalSheetID provides a function to get the local sheet ID of
+// the defined name by given scope, nil for the workbook scope.
+func (f *File) getDefinedNameLocalSheetID(scope string) (*int, error) {
+	if scope == "" || scope == "Workbook" {
+		return nil, nil
+	}
+	sheetIndex := f.GetSheetIndex(scope)
+	if sheetIndex == -1 {
+		return nil, ErrSheetNotExist{scope}
+	}
+	return &sheetIndex, nil
+}
+
+// isSameDefinedNameScope provides a function to check if the given two local
+// sheet IDs of the defined names are in the same scope.
+func isSameDefinedNameScope(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// getDefinedNameName provides a function to add the "_xlnm." prefix for the
+// built-in defined name.
+func getDefinedNameName(name string) string {
+	for _, builtIn := range []string{"Consolidate_Area", "Criteria", "Extract", "Print_Area", "Print_Titles", "Sheet_Title", "_FilterDatabase"} {
+		if strings.EqualFold(name, builtIn) {
+			return "_xlnm." + builtIn
+		}
+	}
+	return name
+}
+
 // DeleteDefinedName provides a function to delete the defined names of the
 // workbook or worksheet. If not specified scope, the default scope is
-// workbook. For example:
+// workbook. The defined name will be matched on both name and scope, so the
+// workbook defined name and the worksheet defined name with the same name can
+// be deleted separately. An error will be returned if no matching defined
+// name exists. For example:
 //
 //    f.DeleteDefinedName(&excelize.DefinedName{
 //        Name:     "Amount",
@@ -1581,18 +2164,15 @@ func (f *File) SetDefinedName(definedName *DefinedName) error {
 //    })
 //
 func (f *File) DeleteDefinedName(definedName *DefinedName) error {
+	localSheetID, err := f.getDefinedNameLocalSheetID(definedName.Scope)
+	if err != nil {
+		return err
+	}
 	wb := f.workbookReader()
 	if wb.DefinedNames != nil {
+		name := getDefinedNameName(definedName.Name)
 		for idx, dn := range wb.DefinedNames.DefinedName {
-			scope := "Workbook"
-			deleteScope := definedName.Scope
-			if deleteScope == "" {
-				deleteScope = "Workbook"
-			}
-			if dn.LocalSheetID != nil {
-				scope = f.GetSheetName(*dn.LocalSheetID)
-			}
-			if scope == deleteScope && dn.Name == definedName.Name {
+			if strings.EqualFold(dn.Name, name) && isSameDefinedNameScope(dn.LocalSheetID, localSheetID) {
 				wb.DefinedNames.DefinedName = append(wb.DefinedNames.DefinedName[:idx], wb.DefinedNames.DefinedName[idx+1:]...)
 				return nil
 			}
@@ -1602,8 +2182,13 @@ func (f *File) DeleteDefinedName(definedName *DefinedName) error {
 }
 
 // GetDefinedName provides a function to get the defined names of the workbook
-// or worksheet.
-func (f *File) GetDefinedName() []DefinedName {
+// or worksheet. The scope of the workbook defined names is "Workbook", and the
+// defined names can be filtered by given scopes. For example, get the defined
+// names on the workbook and Sheet2:
+//
+//    definedNames := f.GetDefinedName("Workbook", "Sheet2")
+//
+func (f *File) GetDefinedName(scope ...string) []DefinedName {
 	var definedNames []DefinedName
 	wb := f.workbookReader()
 	if wb.DefinedNames != nil {
@@ -1617,12 +2202,234 @@ func (f *File) GetDefinedName() []DefinedName {
 			if dn.LocalSheetID != nil && *dn.LocalSheetID >= 0 {
 				definedName.Scope = f.GetSheetName(*dn.LocalSheetID)
 			}
+			if len(scope) > 0 && inStrSlice(scope, definedName.Scope) == -1 {
+				continue
+			}
 			definedNames = append(definedNames, definedName)
 		}
 	}
 	return definedNames
 }
 
+// SetPrintArea provides a function to set the print area of the worksheet by
+// given worksheet name and comma-separated cell ranges, the print area will be
+// stored in the "_xlnm.Print_Area" defined name on the worksheet scope. An
+// empty ranges will remove the print area. For example, set the print area of
+// Sheet1 to A1:D20 and F1:H10:
+//
+//    err := f.SetPrintArea("Sheet1", "A1:D20,F1:H10")
+//
+func (f *File) SetPrintArea(sheet, ranges string) error {
+	sheetIndex := f.GetSheetIndex(sheet)
+	if sheetIndex == -1 {
+		return ErrSheetNotExist{sheet}
+	}
+	var refs []string
+	if ranges != "" {
+		for _, rng := range strings.Split(ranges, ",") {
+			ref, err := getPrintAreaRef(strings.TrimSpace(rng))
+			if err != nil {
+				return err
+			}
+			refs = append(refs, quoteSheetName(f.GetSheetName(sheetIndex))+"!"+ref)
+		}
+	}
+	return f.setSheetDefinedName(sheetIndex, "_xlnm.Print_Area", strings.Join(refs, ","))
+}
+
+// setSheetDefinedName provides a function to create, update or remove the
+// worksheet scoped defined name by given worksheet index, defined name and
+// the reference, an empty reference will remove the defined name.
+func (f *File) setSheetDefinedName(sheetIndex int, name, refersTo string) error {
+	wb := f.workbookReader()
+	if wb.DefinedNames != nil {
+		for idx, dn := range wb.DefinedNames.DefinedName {
+			if dn.Name == name && isSameDefinedNameScope(dn.LocalSheetID, &sheetIndex) {
+				if refersTo == "" {
+					wb.DefinedNames.DefinedName = append(wb.DefinedNames.DefinedName[:idx], wb.DefinedNames.DefinedName[idx+1:]...)
+					return nil
+				}
+				wb.DefinedNames.DefinedName[idx].Data = refersTo
+				return nil
+			}
+		}
+	}
+	if refersTo == "" {
+		return nil
+	}
+	return f.SetDefinedName(&DefinedName{
+		Name:     name,
+		RefersTo: refersTo,
+		Scope:    f.GetSheetName(sheetIndex),
+	})
+}
+
+// getSheetDefinedName provides a function to get the references of the
+// worksheet scoped defined name by given worksheet index and defined name.
+func (f *File) getSheetDefinedName(sheetIndex int, name string) []string {
+	wb := f.workbookReader()
+	if wb.DefinedNames == nil {
+		return nil
+	}
+	for _, dn := range wb.DefinedNames.DefinedName {
+		if dn.Name == name && isSameDefinedNameScope(dn.LocalSheetID, &sheetIndex) {
+			return splitDefinedNameRefs(dn.Data)
+		}
+	}
+	return nil
+}
+
+// getPrintAreaRef provides a function to convert the given cell range to the
+// absolute reference, such as convert A1:D20 to $A$1:$D$20.
+func getPrintAreaRef(rng string) (string, error) {
+	cells := strings.Split(strings.Replace(rng, "$", "", -1), ":")
+	if rng == "" || len(cells) > 2 {
+		return "", ErrParameterInvalid
+	}
+	coordinates := []int{}
+	for _, cell := range cells {
+		col, row, err := CellNameToCoordinates(cell)
+		if err != nil {
+			return "", err
+		}
+		coordinates = append(coordinates, col, row)
+	}
+	if len(coordinates) == 2 {
+		return CoordinatesToCellName(coordinates[0], coordinates[1], true)
+	}
+	_ = sortCoordinates(coordinates)
+	hcell, _ := CoordinatesToCellName(coordinates[0], coordinates[1], true)
+	vcell, _ := CoordinatesToCellName(coordinates[2], coordinates[3], true)
+	return hcell + ":" + vcell, nil
+}
+
+// GetPrintArea provides a function to get the print area of the worksheet by
+// given worksheet name, the comma-separated cell ranges without worksheet name
+// will be returned, such as "$A$1:$D$20,$F$1:$H$10". An empty string will be
+// returned if the print area hasn't been set.
+func (f *File) GetPrintArea(sheet string) (string, error) {
+	sheetIndex := f.GetSheetIndex(sheet)
+	if sheetIndex == -1 {
+		return "", ErrSheetNotExist{sheet}
+	}
+	var refs []string
+	for _, ref := range f.getSheetDefinedName(sheetIndex, "_xlnm.Print_Area") {
+		refs = append(refs, ref[strings.LastIndex(ref, "!")+1:])
+	}
+	return strings.Join(refs, ","), nil
+}
+
+// SetPrintTitles provides a function to set the rows to repeat at top and the
+// columns to repeat at left on every printed page of the worksheet by given
+// worksheet name, row range and column range, the print titles will be stored
+// in the "_xlnm.Print_Titles" defined name on the worksheet scope. Either of
+// the rows and columns can be empty, and the print titles will be removed if
+// both of them are empty. For example, repeat the rows 1 to 2 and the columns
+// A to B on every printed page of Sheet1:
+//
+//    err := f.SetPrintTitles("Sheet1", "$1:$2", "$A:$B")
+//
+func (f *File) SetPrintTitles(sheet, rows, cols string) error {
+	sheetIndex := f.GetSheetIndex(sheet)
+	if sheetIndex == -1 {
+		return ErrSheetNotExist{sheet}
+	}
+	var refs []string
+	prefix := quoteSheetName(f.GetSheetName(sheetIndex)) + "!"
+	if cols != "" {
+		var nums []int
+		for _, col := range strings.Split(strings.Replace(cols, "$", "", -1), ":") {
+			num, err := ColumnNameToNumber(col)
+			if err != nil {
+				return err
+			}
+			nums = append(nums, num)
+		}
+		ref, err := getPrintTitlesRef(nums, func(num int) string {
+			name, _ := ColumnNumberToName(num)
+			return name
+		})
+		if err != nil {
+			return err
+		}
+		refs = append(refs, prefix+ref)
+	}
+	if rows != "" {
+		var nums []int
+		for _, row := range strings.Split(strings.Replace(rows, "$", "", -1), ":") {
+			num, err := strconv.Atoi(row)
+			if err != nil {
+				return ErrParameterInvalid
+			}
+			if num < 1 || num > TotalRows {
+				return newInvalidRowNumberError(num)
+			}
+			nums = append(nums, num)
+		}
+		ref, err := getPrintTitlesRef(nums, strconv.Itoa)
+		if err != nil {
+			return err
+		}
+		refs = append(refs, prefix+ref)
+	}
+	return f.setSheetDefinedName(sheetIndex, "_xlnm.Print_Titles", strings.Join(refs, ","))
+}
+
+// getPrintTitlesRef provides a function to convert the given row or column
+// numbers to the absolute reference of the print titles, such as $1:$2.
+func getPrintTitlesRef(nums []int, name func(int) string) (string, error) {
+	if len(nums) > 2 {
+		return "", ErrParameterInvalid
+	}
+	start, end := nums[0], nums[len(nums)-1]
+	if start > end {
+		start, end = end, start
+	}
+	return "$" + name(start) + ":$" + name(end), nil
+}
+
+// GetPrintTitles provides a function to get the rows to repeat at top and the
+// columns to repeat at left on every printed page of the worksheet by given
+// worksheet name, such as "$1:$2" and "$A:$B". Empty strings will be returned
+// if the print titles haven't been set.
+func (f *File) GetPrintTitles(sheet string) (rows, cols string, err error) {
+	sheetIndex := f.GetSheetIndex(sheet)
+	if sheetIndex == -1 {
+		err = ErrSheetNotExist{sheet}
+		return
+	}
+	for _, ref := range f.getSheetDefinedName(sheetIndex, "_xlnm.Print_Titles") {
+		ref = ref[strings.LastIndex(ref, "!")+1:]
+		if _, err := strconv.Atoi(strings.Trim(strings.Split(ref, ":")[0], "$")); err == nil {
+			rows = ref
+			continue
+		}
+		cols = ref
+	}
+	return
+}
+
+// splitDefinedNameRefs provides a function to split the comma-separated
+// references of the defined name, the commas in the quoted worksheet name
+// will be kept.
+func splitDefinedNameRefs(data string) []string {
+	var (
+		refs    []string
+		inQuote bool
+		start   int
+	)
+	for i, r := range data {
+		switch {
+		case r == '\'':
+			inQuote = !inQuote
+		case r == ',' && !inQuote:
+			refs = append(refs, data[start:i])
+			start = i + 1
+		}
+	}
+	return append(refs, data[start:])
+}
+
 // GroupSheets provides a function to group worksheets by given worksheets
 // name. Group worksheets must contain an active worksheet.
 func (f *File) GroupSheets(sheets []string) error {
@@ -1682,62 +2489,36 @@ func (f *File) UngroupSheets() error {
 // InsertPageBreak create a page break to determine where the printed page
 // ends and where begins the next one by given worksheet name and axis, so the
 // content before the page break will be printed on one page and after the
-// page break on another.
+// page break on another. The horizontal page break is inserted above the row
+// and the vertical page break is inserted to the left of the column of the
+// given cell, for example, insert page breaks above row 11 and to the left of
+// column D on Sheet1:
+//
+//    err := f.InsertPageBreak("Sheet1", "D11")
+//
+// The cell in the first row only inserts a vertical page break, and the cell
+// in the first column only inserts a horizontal page break.
 func (f *File) InsertPageBreak(sheet, cell string) (err error) {
 	var ws *xlsxWorksheet
 	var row, col int
-	var rowBrk, colBrk = -1, -1
 	if ws, err = f.workSheetReader(sheet); err != nil {
 		return
 	}
 	if col, row, err = CellNameToCoordinates(cell); err != nil {
 		return
 	}
-	col--
-	row--
-	if col == row && col == 0 {
-		return
-	}
-	if ws.RowBreaks == nil {
-		ws.RowBreaks = &xlsxBreaks{}
-	}
-	if ws.ColBreaks == nil {
-		ws.ColBreaks = &xlsxBreaks{}
-	}
-
-	for idx, brk := range ws.RowBreaks.Brk {
-		if brk.ID == row {
-			rowBrk = idx
-		}
-	}
-	for idx, brk := range ws.ColBreaks.Brk {
-		if brk.ID == col {
-			colBrk = idx
-		}
+	if row--; row > 0 {
+		ws.RowBreaks = insertPageBreak(ws.RowBreaks, row, TotalColumns-1)
 	}
-
-	if row != 0 && rowBrk == -1 {
-		ws.RowBreaks.Brk = append(ws.RowBreaks.Brk, &xlsxBrk{
-			ID:  row,
-			Max: 16383,
-			Man: true,
-		})
-		ws.RowBreaks.ManualBreakCount++
-	}
-	if col != 0 && colBrk == -1 {
-		ws.ColBreaks.Brk = append(ws.ColBreaks.Brk, &xlsxBrk{
-			ID:  col,
-			Max: 1048575,
-			Man: true,
-		})
-		ws.ColBreaks.ManualBreakCount++
+	if col--; col > 0 {
+		ws.ColBreaks = insertPageBreak(ws.ColBreaks, col, TotalRows-1)
 	}
-	ws.RowBreaks.Count = len(ws.RowBreaks.Brk)
-	ws.ColBreaks.Count = len(ws.ColBreaks.Brk)
 	return
 }
 
-// RemovePageBreak remove a page break by given worksheet name and axis.
+// RemovePageBreak remove the horizontal page break above the row and the
+// vertical page break to the left of the column of the given cell by given
+// worksheet name and axis.
 func (f *File) RemovePageBreak(sheet, cell string) (err error) {
 	var ws *xlsxWorksheet
 	var row, col int
@@ -1747,45 +2528,72 @@ func (f *File) RemovePageBreak(sheet, cell string) (err error) {
 	if col, row, err = CellNameToCoordinates(cell); err != nil {
 		return
 	}
-	col--
-	row--
-	if col == row && col == 0 {
-		return
+	if row--; row > 0 {
+		ws.RowBreaks = removePageBreak(ws.RowBreaks, row)
 	}
-	removeBrk := func(ID int, brks []*xlsxBrk) []*xlsxBrk {
-		for i, brk := range brks {
-			if brk.ID == ID {
-				brks = append(brks[:i], brks[i+1:]...)
-			}
+	if col--; col > 0 {
+		ws.ColBreaks = removePageBreak(ws.ColBreaks, col)
+	}
+	return
+}
+
+// insertPageBreak provides a function to insert a manual page break with the
+// given ID into the breaks in ascending order, and returns the breaks with
+// updated count attributes.
+func insertPageBreak(brks *xlsxBreaks, ID, max int) *xlsxBreaks {
+	if brks == nil {
+		brks = &xlsxBreaks{}
+	}
+	idx := len(brks.Brk)
+	for i, brk := range brks.Brk {
+		if brk.ID == ID {
+			brk.Man = true
+			idx = -1
+			break
+		}
+		if brk.ID > ID {
+			idx = i
+			break
 		}
-		return brks
 	}
-	if ws.RowBreaks == nil || ws.ColBreaks == nil {
-		return
+	if idx != -1 {
+		brks.Brk = append(brks.Brk, nil)
+		copy(brks.Brk[idx+1:], brks.Brk[idx:])
+		brks.Brk[idx] = &xlsxBrk{ID: ID, Max: max, Man: true}
 	}
-	rowBrks := len(ws.RowBreaks.Brk)
-	colBrks := len(ws.ColBreaks.Brk)
-	if rowBrks > 0 && rowBrks == colBrks {
-		ws.RowBreaks.Brk = removeBrk(row, ws.RowBreaks.Brk)
-		ws.ColBreaks.Brk = removeBrk(col, ws.ColBreaks.Brk)
-		ws.RowBreaks.Count = len(ws.RowBreaks.Brk)
-		ws.ColBreaks.Count = len(ws.ColBreaks.Brk)
-		ws.RowBreaks.ManualBreakCount--
-		ws.ColBreaks.ManualBreakCount--
-		return
+	brks.updateCount()
+	return brks
+}
+
+// removePageBreak provides a function to remove the page break with the
+// given ID from the breaks, and returns the breaks with updated count
+// attributes, or nil if no page break remains.
+func removePageBreak(brks *xlsxBreaks, ID int) *xlsxBreaks {
+	if brks == nil {
+		return nil
 	}
-	if rowBrks > 0 && rowBrks > colBrks {
-		ws.RowBreaks.Brk = removeBrk(row, ws.RowBreaks.Brk)
-		ws.RowBreaks.Count = len(ws.RowBreaks.Brk)
-		ws.RowBreaks.ManualBreakCount--
-		return
+	for i, brk := range brks.Brk {
+		if brk.ID == ID {
+			brks.Brk = append(brks.Brk[:i], brks.Brk[i+1:]...)
+			break
+		}
 	}
-	if colBrks > 0 && colBrks > rowBrks {
-		ws.ColBreaks.Brk = removeBrk(col, ws.ColBreaks.Brk)
-		ws.ColBreaks.Count = len(ws.ColBreaks.Brk)
-		ws.ColBreaks.ManualBreakCount--
+	if len(brks.Brk) == 0 {
+		return nil
+	}
+	brks.updateCount()
+	return brks
+}
+
+// updateCount provides a function to update the number of the breaks and the
+// number of the manual breaks.
+func (brks *xlsxBreaks) updateCount() {
+	brks.Count, brks.ManualBreakCount = len(brks.Brk), 0
+	for _, brk := range brks.Brk {
+		if brk.Man {
+			brks.ManualBreakCount++
+		}
 	}
-	return
 }
 
 // relsReader provides a function to get the pointer to the structure
@@ -2,6 +2,8 @@ package excelize
 
 import (
 	"fmt"
+	"image"
+	"io/ioutil"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -105,6 +107,65 @@ func TestSetPane(t *testing.T) {
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetPane.xlsx")))
 }
 
+func TestFreezePanes(t *testing.T) {
+	f := NewFile()
+	for _, c := range []struct {
+		opts        FreezePanesOptions
+		activePane  string
+		topLeftCell string
+	}{
+		{FreezePanesOptions{Rows: 2}, "bottomLeft", "A3"},
+		{FreezePanesOptions{Rows: 2, TopLeftCell: "A10"}, "bottomLeft", "A10"},
+		{FreezePanesOptions{Cols: 1}, "topRight", "B1"},
+		{FreezePanesOptions{Cols: 2, Rows: 3, TopLeftCell: "D5"}, "bottomRight", "D5"},
+	} {
+		assert.NoError(t, f.FreezePanes("Sheet1", c.opts))
+		ws, err := f.workSheetReader("Sheet1")
+		assert.NoError(t, err)
+		sheetView := ws.SheetViews.SheetView[0]
+		assert.Equal(t, &xlsxPane{
+			ActivePane:  c.activePane,
+			State:       "frozen",
+			TopLeftCell: c.topLeftCell,
+			XSplit:      float64(c.opts.Cols),
+			YSplit:      float64(c.opts.Rows),
+		}, sheetView.Pane)
+		assert.Equal(t, []*xlsxSelection{{ActiveCell: c.topLeftCell, Pane: c.activePane, SQRef: c.topLeftCell}}, sheetView.Selection)
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestFreezePanes.xlsx")))
+
+	// Test unfreeze panes.
+	assert.NoError(t, f.FreezePanes("Sheet1", FreezePanesOptions{}))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Nil(t, ws.SheetViews.SheetView[0].Pane)
+	assert.Nil(t, ws.SheetViews.SheetView[0].Selection)
+
+	// Test freeze panes with invalid options.
+	assert.EqualError(t, f.FreezePanes("Sheet1", FreezePanesOptions{Rows: -1}), ErrParameterInvalid.Error())
+	assert.EqualError(t, f.FreezePanes("Sheet1", FreezePanesOptions{Cols: TotalColumns}), ErrParameterInvalid.Error())
+	assert.EqualError(t, f.FreezePanes("Sheet1", FreezePanesOptions{Rows: 2, TopLeftCell: "A2"}), ErrParameterInvalid.Error())
+	assert.EqualError(t, f.FreezePanes("Sheet1", FreezePanesOptions{Rows: 2, TopLeftCell: "A"}), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+	// Test freeze panes on not exists worksheet.
+	assert.EqualError(t, f.FreezePanes("SheetN", FreezePanesOptions{Rows: 1}), "sheet SheetN is not exist")
+	// Test freeze panes on the worksheet without sheet views.
+	for _, empty := range []bool{false, true} {
+		newSheetViews := func() *xlsxSheetViews {
+			if empty {
+				return &xlsxSheetViews{}
+			}
+			return nil
+		}
+		ws.SheetViews = newSheetViews()
+		assert.NoError(t, f.FreezePanes("Sheet1", FreezePanesOptions{Rows: 1}))
+		assert.Len(t, ws.SheetViews.SheetView, 1)
+		assert.Equal(t, "A2", ws.SheetViews.SheetView[0].Pane.TopLeftCell)
+		ws.SheetViews = newSheetViews()
+		assert.NoError(t, f.SetPanes("Sheet1", `{"freeze":true,"split":false,"x_split":1,"y_split":0,"top_left_cell":"B1","active_pane":"topRight"}`))
+		assert.Len(t, ws.SheetViews.SheetView, 1)
+	}
+}
+
 func TestPageLayoutOption(t *testing.T) {
 	const sheet = "Sheet1"
 
@@ -220,6 +281,35 @@ func TestSetPageLayout(t *testing.T) {
 	f := NewFile()
 	// Test set page layout on not exists worksheet.
 	assert.EqualError(t, f.SetPageLayout("SheetN"), "sheet SheetN is not exist")
+
+	var fitToPage FitToPage
+	assert.NoError(t, f.SetPageLayout("Sheet1", PageLayoutScale(50), PageLayoutOrientation("unknown")))
+	assert.NoError(t, f.GetSheetPrOptions("Sheet1", &fitToPage))
+	assert.False(t, bool(fitToPage))
+	var orientation PageLayoutOrientation
+	assert.NoError(t, f.GetPageLayout("Sheet1", &orientation))
+	assert.Equal(t, PageLayoutOrientation(OrientationPortrait), orientation)
+
+	// Test set fit to pages with the fitToPage flag.
+	assert.NoError(t, f.SetPageLayout("Sheet1",
+		PageLayoutOrientation(OrientationLandscape),
+		PageLayoutPaperSize(9),
+		FitToWidth(1),
+		FitToHeight(0),
+	))
+	assert.NoError(t, f.GetSheetPrOptions("Sheet1", &fitToPage))
+	assert.True(t, bool(fitToPage))
+	var (
+		paperSize   PageLayoutPaperSize
+		fitToWidth  FitToWidth
+		fitToHeight FitToHeight
+	)
+	assert.NoError(t, f.GetPageLayout("Sheet1", &orientation, &paperSize, &fitToWidth, &fitToHeight))
+	assert.Equal(t, PageLayoutOrientation(OrientationLandscape), orientation)
+	assert.Equal(t, PageLayoutPaperSize(9), paperSize)
+	assert.Equal(t, FitToWidth(1), fitToWidth)
+	assert.Equal(t, FitToHeight(1), fitToHeight)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetPageLayout.xlsx")))
 }
 
 func TestGetPageLayout(t *testing.T) {
@@ -259,6 +349,68 @@ func TestSetHeaderFooter(t *testing.T) {
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetHeaderFooter.xlsx")))
 }
 
+func TestAddHeaderFooterImage(t *testing.T) {
+	f := NewFile()
+	file, err := ioutil.ReadFile(filepath.Join("test", "images", "excel.png"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetHeaderFooter("Sheet1", &FormatHeaderFooter{OddHeader: "&CCenter&RPage &P", OddFooter: "Footer"}))
+	assert.NoError(t, f.AddHeaderFooterImage("Sheet1", &HeaderFooterImageOptions{Position: "left", Extension: ".png", File: file}))
+	assert.NoError(t, f.AddHeaderFooterImage("Sheet1", &HeaderFooterImageOptions{Position: "right", IsFooter: true, Extension: ".png", File: file}))
+	// Test replace the image in the same section.
+	assert.NoError(t, f.AddHeaderFooterImage("Sheet1", &HeaderFooterImageOptions{Position: "left", Extension: ".png", File: file}))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "&L&G&CCenter&RPage &P", ws.HeaderFooter.OddHeader)
+	assert.Equal(t, "&CFooter&R&G", ws.HeaderFooter.OddFooter)
+	assert.NotNil(t, ws.LegacyDrawingHF)
+	vml := f.VMLDrawing["xl/drawings/vmlDrawingHF1.vml"]
+	assert.Len(t, vml.Shape, 2)
+	assert.Equal(t, "RF", vml.Shape[0].ID)
+	assert.Equal(t, "LH", vml.Shape[1].ID)
+	assert.Contains(t, vml.Shape[0].Style, "z-index:2")
+	assert.Contains(t, vml.Shape[1].Style, "z-index:3")
+	assert.Equal(t, 1, vml.Shapelayout.IDmap.Data)
+	assert.Len(t, f.relsReader("xl/drawings/_rels/vmlDrawingHF1.vml.rels").Relationships, 2)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddHeaderFooterImage.xlsx")))
+
+	// Test add image to the header and footer of the opened workbook.
+	f, err = OpenFile(filepath.Join("test", "TestAddHeaderFooterImage.xlsx"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddHeaderFooterImage("Sheet1", &HeaderFooterImageOptions{Position: "center", IsFooter: true, Extension: ".png", File: file}))
+	ws, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "&CFooter&G&R&G", ws.HeaderFooter.OddFooter)
+	vml = f.VMLDrawing["xl/drawings/vmlDrawingHF1.vml"]
+	assert.Len(t, vml.Shape, 3)
+	assert.Equal(t, "LH", vml.Shape[1].ID)
+	assert.Equal(t, "CF", vml.Shape[2].ID)
+	assert.Equal(t, "_x0000_s1028", vml.Shape[2].Spid)
+	assert.Contains(t, vml.Shape[2].Style, "z-index:4")
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddHeaderFooterImage2.xlsx")))
+	assert.NoError(t, f.Close())
+
+	// Test add image to the header and footer with invalid options.
+	f = NewFile()
+	assert.EqualError(t, f.AddHeaderFooterImage("Sheet1", nil), ErrParameterRequired.Error())
+	assert.EqualError(t, f.AddHeaderFooterImage("Sheet1", &HeaderFooterImageOptions{Position: "top", Extension: ".png", File: file}), ErrParameterInvalid.Error())
+	assert.EqualError(t, f.AddHeaderFooterImage("Sheet1", &HeaderFooterImageOptions{Position: "left", Extension: ".txt", File: file}), ErrImgExt.Error())
+	assert.EqualError(t, f.AddHeaderFooterImage("Sheet1", &HeaderFooterImageOptions{Position: "left", Extension: ".png", File: []byte("text")}), image.ErrFormat.Error())
+	assert.EqualError(t, f.AddHeaderFooterImage("SheetN", &HeaderFooterImageOptions{Position: "left", Extension: ".png", File: file}), "sheet SheetN is not exist")
+	assert.NoError(t, f.SetHeaderFooter("Sheet1", &FormatHeaderFooter{OddHeader: strings.Repeat("c", MaxFieldLength)}))
+	assert.EqualError(t, f.AddHeaderFooterImage("Sheet1", &HeaderFooterImageOptions{Position: "left", Extension: ".png", File: file}), "field OddHeader must be less or equal than 255 characters")
+}
+
+func TestAddHeaderFooterImageCode(t *testing.T) {
+	for text, expected := range map[string]string{
+		"":                  "&L&G",
+		"Title":             "&L&G&CTitle",
+		"&L&&Left&G&RPage":  "&L&&Left&G&RPage",
+		"&L&\"Arial,Bold\"": "&L&\"Arial,Bold\"&G",
+	} {
+		assert.Equal(t, expected, addHeaderFooterImageCode(text, "L"), text)
+	}
+}
+
 func TestDefinedName(t *testing.T) {
 	f := NewFile()
 	assert.NoError(t, f.SetDefinedName(&DefinedName{
@@ -287,6 +439,133 @@ func TestDefinedName(t *testing.T) {
 	assert.Exactly(t, "Sheet1!$A$2:$D$5", f.GetDefinedName()[0].RefersTo)
 	assert.Exactly(t, 1, len(f.GetDefinedName()))
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDefinedName.xlsx")))
+
+	// Test set sheet-scoped defined names and get defined names by scopes.
+	f = NewFile()
+	f.NewSheet("Sheet2")
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Print_Area", RefersTo: "Sheet2!$A$1:$D$20", Scope: "Sheet2"}))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Amount", RefersTo: "Sheet1!$A$2:$D$5", Scope: "Workbook"}))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Amount", RefersTo: "Sheet2!$A$2:$D$5", Scope: "sheet2"}))
+	assert.EqualError(t, f.SetDefinedName(&DefinedName{Name: "Amount", RefersTo: "Sheet1!$A$2:$D$5"}), ErrDefinedNameduplicate.Error())
+	assert.EqualError(t, f.SetDefinedName(&DefinedName{Name: "AMOUNT", RefersTo: "Sheet1!$A$2:$D$5"}), ErrDefinedNameduplicate.Error())
+	assert.EqualError(t, f.SetDefinedName(&DefinedName{Name: "print_area", RefersTo: "Sheet2!$A$1:$D$20", Scope: "Sheet2"}), ErrDefinedNameduplicate.Error())
+	assert.EqualError(t, f.SetDefinedName(&DefinedName{Name: "Amount", RefersTo: "Sheet1!$A$2:$D$5", Scope: "SheetN"}), "sheet SheetN is not exist")
+	wb := f.workbookReader()
+	assert.Equal(t, "_xlnm.Print_Area", wb.DefinedNames.DefinedName[0].Name)
+	assert.Equal(t, 1, *wb.DefinedNames.DefinedName[0].LocalSheetID)
+	assert.Nil(t, wb.DefinedNames.DefinedName[1].LocalSheetID)
+	assert.Equal(t, 1, *wb.DefinedNames.DefinedName[2].LocalSheetID)
+	assert.Equal(t, []DefinedName{
+		{Name: "_xlnm.Print_Area", RefersTo: "Sheet2!$A$1:$D$20", Scope: "Sheet2"},
+		{Name: "Amount", RefersTo: "Sheet2!$A$2:$D$5", Scope: "Sheet2"},
+	}, f.GetDefinedName("Sheet2"))
+	assert.Equal(t, []DefinedName{{Name: "Amount", RefersTo: "Sheet1!$A$2:$D$5", Scope: "Workbook"}}, f.GetDefinedName("Workbook"))
+	assert.Len(t, f.GetDefinedName("Workbook", "Sheet2"), 3)
+	assert.Len(t, f.GetDefinedName("Sheet1"), 0)
+
+	// Test delete defined names with the same name on different scopes.
+	assert.EqualError(t, f.DeleteDefinedName(&DefinedName{Name: "Amount", Scope: "Sheet1"}), ErrDefinedNameScope.Error())
+	assert.EqualError(t, f.DeleteDefinedName(&DefinedName{Name: "Amount", Scope: "SheetN"}), "sheet SheetN is not exist")
+	assert.NoError(t, f.DeleteDefinedName(&DefinedName{Name: "amount", Scope: "Sheet2"}))
+	assert.Equal(t, []DefinedName{{Name: "Amount", RefersTo: "Sheet1!$A$2:$D$5", Scope: "Workbook"}}, f.GetDefinedName("Workbook", "Sheet1"))
+	assert.EqualError(t, f.DeleteDefinedName(&DefinedName{Name: "Amount", Scope: "Sheet2"}), ErrDefinedNameScope.Error())
+	assert.NoError(t, f.DeleteDefinedName(&DefinedName{Name: "Amount", Scope: "Workbook"}))
+	assert.NoError(t, f.DeleteDefinedName(&DefinedName{Name: "Print_Area", Scope: "Sheet2"}))
+	assert.Len(t, f.GetDefinedName(), 0)
+	assert.EqualError(t, f.DeleteDefinedName(&DefinedName{Name: "Amount"}), ErrDefinedNameScope.Error())
+}
+
+func TestPrintArea(t *testing.T) {
+	f := NewFile()
+	f.NewSheet("Sheet 2")
+	printArea, err := f.GetPrintArea("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "", printArea)
+
+	assert.NoError(t, f.SetPrintArea("Sheet1", "A1:D20"))
+	assert.NoError(t, f.SetPrintArea("Sheet 2", "D20:A1, $F$1:H10,J5"))
+	printArea, err = f.GetPrintArea("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "$A$1:$D$20", printArea)
+	printArea, err = f.GetPrintArea("Sheet 2")
+	assert.NoError(t, err)
+	assert.Equal(t, "$A$1:$D$20,$F$1:$H$10,$J$5", printArea)
+	assert.Equal(t, []DefinedName{{Name: "_xlnm.Print_Area", RefersTo: "'Sheet 2'!$A$1:$D$20,'Sheet 2'!$F$1:$H$10,'Sheet 2'!$J$5", Scope: "Sheet 2"}}, f.GetDefinedName("Sheet 2"))
+
+	// Test update the print area.
+	assert.NoError(t, f.SetPrintArea("Sheet1", "B2:C3"))
+	printArea, err = f.GetPrintArea("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "$B$2:$C$3", printArea)
+	assert.Len(t, f.GetDefinedName(), 2)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestPrintArea.xlsx")))
+
+	// Test remove the print area.
+	assert.NoError(t, f.SetPrintArea("Sheet1", ""))
+	printArea, err = f.GetPrintArea("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "", printArea)
+	assert.Len(t, f.GetDefinedName(), 1)
+	assert.NoError(t, f.SetPrintArea("Sheet1", ""))
+
+	// Test set the print area with invalid ranges.
+	assert.EqualError(t, f.SetPrintArea("Sheet1", "A1:B2,"), ErrParameterInvalid.Error())
+	assert.EqualError(t, f.SetPrintArea("Sheet1", "A1:B2:C3"), ErrParameterInvalid.Error())
+	assert.EqualError(t, f.SetPrintArea("Sheet1", "A:B"), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+	assert.Len(t, f.GetDefinedName(), 1)
+
+	// Test set and get the print area on not exists worksheet.
+	assert.EqualError(t, f.SetPrintArea("SheetN", "A1:B2"), "sheet SheetN is not exist")
+	_, err = f.GetPrintArea("SheetN")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+
+	// Test get the print area without defined names.
+	f = NewFile()
+	printArea, err = f.GetPrintArea("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "", printArea)
+	assert.Equal(t, []string{"'Sheet, 1'!$A$1", "Sheet2!$B$2"}, splitDefinedNameRefs("'Sheet, 1'!$A$1,Sheet2!$B$2"))
+}
+
+func TestPrintTitles(t *testing.T) {
+	f := NewFile()
+	rows, cols, err := f.GetPrintTitles("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "", rows)
+	assert.Equal(t, "", cols)
+
+	for _, c := range []struct {
+		rows, cols, expectedRows, expectedCols, refersTo string
+	}{
+		{"$1:$2", "$A:$B", "$1:$2", "$A:$B", "Sheet1!$A:$B,Sheet1!$1:$2"},
+		{"3:1", "", "$1:$3", "", "Sheet1!$1:$3"},
+		{"", "C", "", "$C:$C", "Sheet1!$C:$C"},
+		{"$5", "$D:$B", "$5:$5", "$B:$D", "Sheet1!$B:$D,Sheet1!$5:$5"},
+	} {
+		assert.NoError(t, f.SetPrintTitles("Sheet1", c.rows, c.cols))
+		rows, cols, err = f.GetPrintTitles("Sheet1")
+		assert.NoError(t, err)
+		assert.Equal(t, c.expectedRows, rows)
+		assert.Equal(t, c.expectedCols, cols)
+		assert.Equal(t, []DefinedName{{Name: "_xlnm.Print_Titles", RefersTo: c.refersTo, Scope: "Sheet1"}}, f.GetDefinedName())
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestPrintTitles.xlsx")))
+
+	// Test remove the print titles.
+	assert.NoError(t, f.SetPrintTitles("Sheet1", "", ""))
+	assert.Len(t, f.GetDefinedName(), 0)
+
+	// Test set the print titles with invalid rows and columns.
+	assert.EqualError(t, f.SetPrintTitles("Sheet1", "$1:$2:$3", ""), ErrParameterInvalid.Error())
+	assert.EqualError(t, f.SetPrintTitles("Sheet1", "$A:$B", ""), ErrParameterInvalid.Error())
+	assert.EqualError(t, f.SetPrintTitles("Sheet1", "$0", ""), "invalid row number 0")
+	assert.EqualError(t, f.SetPrintTitles("Sheet1", "", "$A:$B:$C"), ErrParameterInvalid.Error())
+	assert.EqualError(t, f.SetPrintTitles("Sheet1", "", "$1:$2"), `invalid column name "1"`)
+
+	// Test set and get the print titles on not exists worksheet.
+	assert.EqualError(t, f.SetPrintTitles("SheetN", "$1:$2", ""), "sheet SheetN is not exist")
+	_, _, err = f.GetPrintTitles("SheetN")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
 }
 
 func TestGroupSheets(t *testing.T) {
@@ -319,6 +598,31 @@ func TestInsertPageBreak(t *testing.T) {
 	assert.EqualError(t, f.InsertPageBreak("Sheet1", "A"), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
 	assert.EqualError(t, f.InsertPageBreak("SheetN", "C3"), "sheet SheetN is not exist")
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestInsertPageBreak.xlsx")))
+
+	// Test insert page breaks in ascending order with count attributes.
+	f = NewFile()
+	assert.NoError(t, f.InsertPageBreak("Sheet1", "A11"))
+	assert.NoError(t, f.InsertPageBreak("Sheet1", "D5"))
+	assert.NoError(t, f.InsertPageBreak("Sheet1", "A5"))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, &xlsxBreaks{
+		Brk:              []*xlsxBrk{{ID: 4, Max: 16383, Man: true}, {ID: 10, Max: 16383, Man: true}},
+		Count:            2,
+		ManualBreakCount: 2,
+	}, ws.RowBreaks)
+	assert.Equal(t, &xlsxBreaks{
+		Brk:              []*xlsxBrk{{ID: 3, Max: 1048575, Man: true}},
+		Count:            1,
+		ManualBreakCount: 1,
+	}, ws.ColBreaks)
+	// Test insert page break in the first row without horizontal page break.
+	f = NewFile()
+	assert.NoError(t, f.InsertPageBreak("Sheet1", "C1"))
+	ws, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Nil(t, ws.RowBreaks)
+	assert.Equal(t, 1, ws.ColBreaks.Count)
 }
 
 func TestRemovePageBreak(t *testing.T) {
@@ -345,6 +649,22 @@ func TestRemovePageBreak(t *testing.T) {
 	assert.EqualError(t, f.RemovePageBreak("Sheet1", "A"), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
 	assert.EqualError(t, f.RemovePageBreak("SheetN", "C3"), "sheet SheetN is not exist")
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestRemovePageBreak.xlsx")))
+
+	// Test remove page breaks and update count attributes.
+	f = NewFile()
+	assert.NoError(t, f.InsertPageBreak("Sheet1", "C3"))
+	assert.NoError(t, f.InsertPageBreak("Sheet1", "A5"))
+	assert.NoError(t, f.RemovePageBreak("Sheet1", "C3"))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Nil(t, ws.ColBreaks)
+	assert.Equal(t, &xlsxBreaks{
+		Brk:              []*xlsxBrk{{ID: 4, Max: 16383, Man: true}},
+		Count:            1,
+		ManualBreakCount: 1,
+	}, ws.RowBreaks)
+	assert.NoError(t, f.RemovePageBreak("Sheet1", "A5"))
+	assert.Nil(t, ws.RowBreaks)
 }
 
 func TestGetSheetName(t *testing.T) {
@@ -233,15 +233,21 @@ type (
 	PageMarginRight float64
 	// PageMarginTop specifies the top margin for the page.
 	PageMarginTop float64
+	// CenterHorizontally specifies whether to center the data horizontally
+	// on the printed page.
+	CenterHorizontally bool
+	// CenterVertically specifies whether to center the data vertically on
+	// the printed page.
+	CenterVertically bool
 )
 
 // setPageMargins provides a method to set the bottom margin for the worksheet.
-func (p PageMarginBottom) setPageMargins(pm *xlsxPageMargins) {
+func (p PageMarginBottom) setPageMargins(pm *xlsxPageMargins, _ *xlsxPrintOptions) {
 	pm.Bottom = float64(p)
 }
 
 // setPageMargins provides a method to get the bottom margin for the worksheet.
-func (p *PageMarginBottom) getPageMargins(pm *xlsxPageMargins) {
+func (p *PageMarginBottom) getPageMargins(pm *xlsxPageMargins, _ *xlsxPrintOptions) {
 	// Excel default: 0.75
 	if pm == nil || pm.Bottom == 0 {
 		*p = 0.75
@@ -251,12 +257,12 @@ func (p *PageMarginBottom) getPageMargins(pm *xlsxPageMargins) {
 }
 
 // setPageMargins provides a method to set the footer margin for the worksheet.
-func (p PageMarginFooter) setPageMargins(pm *xlsxPageMargins) {
+func (p PageMarginFooter) setPageMargins(pm *xlsxPageMargins, _ *xlsxPrintOptions) {
 	pm.Footer = float64(p)
 }
 
 // setPageMargins provides a method to get the footer margin for the worksheet.
-func (p *PageMarginFooter) getPageMargins(pm *xlsxPageMargins) {
+func (p *PageMarginFooter) getPageMargins(pm *xlsxPageMargins, _ *xlsxPrintOptions) {
 	// Excel default: 0.3
 	if pm == nil || pm.Footer == 0 {
 		*p = 0.3
@@ -266,12 +272,12 @@ func (p *PageMarginFooter) getPageMargins(pm *xlsxPageMargins) {
 }
 
 // setPageMargins provides a method to set the header margin for the worksheet.
-func (p PageMarginHeader) setPageMargins(pm *xlsxPageMargins) {
+func (p PageMarginHeader) setPageMargins(pm *xlsxPageMargins, _ *xlsxPrintOptions) {
 	pm.Header = float64(p)
 }
 
 // setPageMargins provides a method to get the header margin for the worksheet.
-func (p *PageMarginHeader) getPageMargins(pm *xlsxPageMargins) {
+func (p *PageMarginHeader) getPageMargins(pm *xlsxPageMargins, _ *xlsxPrintOptions) {
 	// Excel default: 0.3
 	if pm == nil || pm.Header == 0 {
 		*p = 0.3
@@ -281,12 +287,12 @@ func (p *PageMarginHeader) getPageMargins(pm *xlsxPageMargins) {
 }
 
 // setPageMargins provides a method to set the left margin for the worksheet.
-func (p PageMarginLeft) setPageMargins(pm *xlsxPageMargins) {
+func (p PageMarginLeft) setPageMargins(pm *xlsxPageMargins, _ *xlsxPrintOptions) {
 	pm.Left = float64(p)
 }
 
 // setPageMargins provides a method to get the left margin for the worksheet.
-func (p *PageMarginLeft) getPageMargins(pm *xlsxPageMargins) {
+func (p *PageMarginLeft) getPageMargins(pm *xlsxPageMargins, _ *xlsxPrintOptions) {
 	// Excel default: 0.7
 	if pm == nil || pm.Left == 0 {
 		*p = 0.7
@@ -296,12 +302,12 @@ func (p *PageMarginLeft) getPageMargins(pm *xlsxPageMargins) {
 }
 
 // setPageMargins provides a method to set the right margin for the worksheet.
-func (p PageMarginRight) setPageMargins(pm *xlsxPageMargins) {
+func (p PageMarginRight) setPageMargins(pm *xlsxPageMargins, _ *xlsxPrintOptions) {
 	pm.Right = float64(p)
 }
 
 // setPageMargins provides a method to get the right margin for the worksheet.
-func (p *PageMarginRight) getPageMargins(pm *xlsxPageMargins) {
+func (p *PageMarginRight) getPageMargins(pm *xlsxPageMargins, _ *xlsxPrintOptions) {
 	// Excel default: 0.7
 	if pm == nil || pm.Right == 0 {
 		*p = 0.7
@@ -311,12 +317,12 @@ func (p *PageMarginRight) getPageMargins(pm *xlsxPageMargins) {
 }
 
 // setPageMargins provides a method to set the top margin for the worksheet.
-func (p PageMarginTop) setPageMargins(pm *xlsxPageMargins) {
+func (p PageMarginTop) setPageMargins(pm *xlsxPageMargins, _ *xlsxPrintOptions) {
 	pm.Top = float64(p)
 }
 
 // setPageMargins provides a method to get the top margin for the worksheet.
-func (p *PageMarginTop) getPageMargins(pm *xlsxPageMargins) {
+func (p *PageMarginTop) getPageMargins(pm *xlsxPageMargins, _ *xlsxPrintOptions) {
 	// Excel default: 0.75
 	if pm == nil || pm.Top == 0 {
 		*p = 0.75
@@ -325,20 +331,63 @@ func (p *PageMarginTop) getPageMargins(pm *xlsxPageMargins) {
 	*p = PageMarginTop(pm.Top)
 }
 
+// setPageMargins provides a method to set whether to center the data
+// horizontally on the printed page for the worksheet.
+func (p CenterHorizontally) setPageMargins(_ *xlsxPageMargins, po *xlsxPrintOptions) {
+	po.HorizontalCentered = bool(p)
+}
+
+// getPageMargins provides a method to get whether to center the data
+// horizontally on the printed page for the worksheet.
+func (p *CenterHorizontally) getPageMargins(_ *xlsxPageMargins, po *xlsxPrintOptions) {
+	// Excel default: false
+	if po == nil {
+		*p = false
+		return
+	}
+	*p = CenterHorizontally(po.HorizontalCentered)
+}
+
+// setPageMargins provides a method to set whether to center the data
+// vertically on the printed page for the worksheet.
+func (p CenterVertically) setPageMargins(_ *xlsxPageMargins, po *xlsxPrintOptions) {
+	po.VerticalCentered = bool(p)
+}
+
+// getPageMargins provides a method to get whether to center the data
+// vertically on the printed page for the worksheet.
+func (p *CenterVertically) getPageMargins(_ *xlsxPageMargins, po *xlsxPrintOptions) {
+	// Excel default: false
+	if po == nil {
+		*p = false
+		return
+	}
+	*p = CenterVertically(po.VerticalCentered)
+}
+
 // PageMarginsOptions is an option of a page margin of a worksheet. See
 // SetPageMargins().
 type PageMarginsOptions interface {
-	setPageMargins(layout *xlsxPageMargins)
+	setPageMargins(layout *xlsxPageMargins, printOpts *xlsxPrintOptions)
 }
 
 // PageMarginsOptionsPtr is a writable PageMarginsOptions. See
 // GetPageMargins().
 type PageMarginsOptionsPtr interface {
 	PageMarginsOptions
-	getPageMargins(layout *xlsxPageMargins)
+	getPageMargins(layout *xlsxPageMargins, printOpts *xlsxPrintOptions)
 }
 
-// SetPageMargins provides a function to set worksheet page margins.
+// SetPageMargins provides a function to set worksheet page margins and the
+// centering of the data on the printed page. The margins which are not
+// specified default to the Excel standard margins. For example, set the left
+// and right margins to 1 inch and center the data horizontally on Sheet1:
+//
+//    err := f.SetPageMargins("Sheet1",
+//        excelize.PageMarginLeft(1),
+//        excelize.PageMarginRight(1),
+//        excelize.CenterHorizontally(true),
+//    )
 //
 // Available options:
 //   PageMarginBottom(float64)
@@ -347,6 +396,8 @@ type PageMarginsOptionsPtr interface {
 //   PageMarginLeft(float64)
 //   PageMarginRight(float64)
 //   PageMarginTop(float64)
+//   CenterHorizontally(bool)
+//   CenterVertically(bool)
 func (f *File) SetPageMargins(sheet string, opts ...PageMarginsOptions) error {
 	s, err := f.workSheetReader(sheet)
 	if err != nil {
@@ -354,17 +405,25 @@ func (f *File) SetPageMargins(sheet string, opts ...PageMarginsOptions) error {
 	}
 	pm := s.PageMargins
 	if pm == nil {
-		pm = new(xlsxPageMargins)
+		pm = &xlsxPageMargins{Bottom: 0.75, Footer: 0.3, Header: 0.3, Left: 0.7, Right: 0.7, Top: 0.75}
 		s.PageMargins = pm
 	}
+	po := s.PrintOptions
+	if po == nil {
+		po = new(xlsxPrintOptions)
+	}
 
 	for _, opt := range opts {
-		opt.setPageMargins(pm)
+		opt.setPageMargins(pm, po)
+	}
+	if s.PrintOptions == nil && *po != (xlsxPrintOptions{}) {
+		s.PrintOptions = po
 	}
 	return err
 }
 
-// GetPageMargins provides a function to get worksheet page margins.
+// GetPageMargins provides a function to get worksheet page margins and the
+// centering of the data on the printed page.
 //
 // Available options:
 //   PageMarginBottom(float64)
@@ -373,6 +432,8 @@ func (f *File) SetPageMargins(sheet string, opts ...PageMarginsOptions) error {
 //   PageMarginLeft(float64)
 //   PageMarginRight(float64)
 //   PageMarginTop(float64)
+//   CenterHorizontally(bool)
+//   CenterVertically(bool)
 func (f *File) GetPageMargins(sheet string, opts ...PageMarginsOptionsPtr) error {
 	s, err := f.workSheetReader(sheet)
 	if err != nil {
@@ -381,7 +442,7 @@ func (f *File) GetPageMargins(sheet string, opts ...PageMarginsOptionsPtr) error
 	pm := s.PageMargins
 
 	for _, opt := range opts {
-		opt.getPageMargins(pm)
+		opt.getPageMargins(pm, s.PrintOptions)
 	}
 	return err
 }
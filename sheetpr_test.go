@@ -173,6 +173,8 @@ var _ = []PageMarginsOptions{
 	PageMarginLeft(1.0),
 	PageMarginRight(1.0),
 	PageMarginTop(1.0),
+	CenterHorizontally(true),
+	CenterVertically(true),
 }
 
 var _ = []PageMarginsOptionsPtr{
@@ -182,6 +184,8 @@ var _ = []PageMarginsOptionsPtr{
 	(*PageMarginLeft)(nil),
 	(*PageMarginRight)(nil),
 	(*PageMarginTop)(nil),
+	(*CenterHorizontally)(nil),
+	(*CenterVertically)(nil),
 }
 
 func ExampleFile_SetPageMargins() {
@@ -212,6 +216,8 @@ func ExampleFile_GetPageMargins() {
 		marginLeft   PageMarginLeft
 		marginRight  PageMarginRight
 		marginTop    PageMarginTop
+		centerHoriz  CenterHorizontally
+		centerVert   CenterVertically
 	)
 
 	if err := f.GetPageMargins(sheet,
@@ -221,6 +227,8 @@ func ExampleFile_GetPageMargins() {
 		&marginLeft,
 		&marginRight,
 		&marginTop,
+		&centerHoriz,
+		&centerVert,
 	); err != nil {
 		fmt.Println(err)
 	}
@@ -231,6 +239,8 @@ func ExampleFile_GetPageMargins() {
 	fmt.Println("- marginLeft:", marginLeft)
 	fmt.Println("- marginRight:", marginRight)
 	fmt.Println("- marginTop:", marginTop)
+	fmt.Println("- centerHoriz:", centerHoriz)
+	fmt.Println("- centerVert:", centerVert)
 	// Output:
 	// Defaults:
 	// - marginBottom: 0.75
@@ -239,6 +249,8 @@ func ExampleFile_GetPageMargins() {
 	// - marginLeft: 0.7
 	// - marginRight: 0.7
 	// - marginTop: 0.75
+	// - centerHoriz: false
+	// - centerVert: false
 }
 
 func TestPageMarginsOption(t *testing.T) {
@@ -254,6 +266,8 @@ func TestPageMarginsOption(t *testing.T) {
 		{new(PageMarginRight), PageMarginRight(1.0)},
 		{new(PageMarginHeader), PageMarginHeader(1.0)},
 		{new(PageMarginFooter), PageMarginFooter(1.0)},
+		{new(CenterHorizontally), CenterHorizontally(true)},
+		{new(CenterVertically), CenterVertically(true)},
 	}
 
 	for i, test := range testData {
@@ -307,6 +321,18 @@ func TestSetPageMargins(t *testing.T) {
 	f := NewFile()
 	// Test set page margins on not exists worksheet.
 	assert.EqualError(t, f.SetPageMargins("SheetN"), "sheet SheetN is not exist")
+	// Test unspecified margins default to the Excel standard margins.
+	assert.NoError(t, f.SetPageMargins("Sheet1", PageMarginLeft(1), CenterHorizontally(true)))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, &xlsxPageMargins{Bottom: 0.75, Footer: 0.3, Header: 0.3, Left: 1, Right: 0.7, Top: 0.75}, ws.PageMargins)
+	assert.Equal(t, &xlsxPrintOptions{HorizontalCentered: true}, ws.PrintOptions)
+	// Test page margins without centering options doesn't create print options.
+	f = NewFile()
+	assert.NoError(t, f.SetPageMargins("Sheet1", PageMarginTop(1)))
+	ws, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Nil(t, ws.PrintOptions)
 }
 
 func TestGetPageMargins(t *testing.T) {
@@ -11,7 +11,10 @@
 
 package excelize
 
-import "fmt"
+import (
+	"strconv"
+	"strings"
+)
 
 // SheetViewOption is an option of a view of a worksheet. See
 // SetSheetViewOptions().
@@ -58,6 +61,28 @@ type (
 	// When using a formula to reference another cell which is empty, the referenced value becomes 0
 	// when the flag is true. (Default setting is true.)
 	ShowZeros bool
+	// GridLineColor is a SheetViewOption. It specifies the color of the grid
+	// lines in RGB hex format, such as "FF0000". The color will be mapped to
+	// the nearest color of the indexed color palette, since the worksheet
+	// view stores the color by the index in colorId. An empty value or an
+	// invalid hex color uses the default grid lines color.
+	GridLineColor string
+	// View is a SheetViewOption. It specifies the view type of the worksheet
+	// view, the possible values are "normal", "pageLayout" and
+	// "pageBreakPreview". (Default setting is "normal".)
+	View string
+	// ZoomScaleNormal is a SheetViewOption. It specifies a window zoom
+	// magnification for the normal view representing percent values. This
+	// attribute is restricted to values ranging from 10 to 400.
+	ZoomScaleNormal float64
+	// ZoomScalePageLayoutView is a SheetViewOption. It specifies a window zoom
+	// magnification for the page layout view representing percent values.
+	// This attribute is restricted to values ranging from 10 to 400.
+	ZoomScalePageLayoutView float64
+	// ZoomScaleSheetLayoutView is a SheetViewOption. It specifies a window
+	// zoom magnification for the page break preview representing percent
+	// values. This attribute is restricted to values ranging from 10 to 400.
+	ZoomScaleSheetLayoutView float64
 
 	/* TODO
 	// ShowWhiteSpace is a SheetViewOption. It specifies a flag indicating
@@ -139,26 +164,125 @@ func (o *ZoomScale) getSheetViewOption(view *xlsxSheetView) {
 	*o = ZoomScale(view.ZoomScale)
 }
 
+func (o View) setSheetViewOption(view *xlsxSheetView) {
+	switch string(o) {
+	case "normal":
+		view.View = ""
+	case "pageLayout", "pageBreakPreview":
+		view.View = string(o)
+	}
+}
+
+func (o *View) getSheetViewOption(view *xlsxSheetView) {
+	*o = View(view.View)
+	if view.View == "" {
+		*o = "normal" // Excel default: normal
+	}
+}
+
+func (o ZoomScaleNormal) setSheetViewOption(view *xlsxSheetView) {
+	// This attribute is restricted to values ranging from 10 to 400.
+	if float64(o) >= 10 && float64(o) <= 400 {
+		view.ZoomScaleNormal = float64(o)
+	}
+}
+
+func (o *ZoomScaleNormal) getSheetViewOption(view *xlsxSheetView) {
+	*o = ZoomScaleNormal(view.ZoomScaleNormal)
+}
+
+func (o ZoomScalePageLayoutView) setSheetViewOption(view *xlsxSheetView) {
+	// This attribute is restricted to values ranging from 10 to 400.
+	if float64(o) >= 10 && float64(o) <= 400 {
+		view.ZoomScalePageLayoutView = float64(o)
+	}
+}
+
+func (o *ZoomScalePageLayoutView) getSheetViewOption(view *xlsxSheetView) {
+	*o = ZoomScalePageLayoutView(view.ZoomScalePageLayoutView)
+}
+
+func (o ZoomScaleSheetLayoutView) setSheetViewOption(view *xlsxSheetView) {
+	// This attribute is restricted to values ranging from 10 to 400.
+	if float64(o) >= 10 && float64(o) <= 400 {
+		view.ZoomScaleSheetLayoutView = float64(o)
+	}
+}
+
+func (o *ZoomScaleSheetLayoutView) getSheetViewOption(view *xlsxSheetView) {
+	*o = ZoomScaleSheetLayoutView(view.ZoomScaleSheetLayoutView)
+}
+
+func (o GridLineColor) setSheetViewOption(view *xlsxSheetView) {
+	view.DefaultGridColor, view.ColorID = nil, 0
+	color := strings.TrimPrefix(string(o), "#")
+	rgb, err := strconv.ParseUint(color, 16, 32)
+	if err != nil || len(color) != 6 {
+		return
+	}
+	view.DefaultGridColor, view.ColorID = boolPtr(false), nearestIndexedColor(rgb)
+}
+
+func (o *GridLineColor) getSheetViewOption(view *xlsxSheetView) {
+	*o = ""
+	if defaultTrue(view.DefaultGridColor) || view.ColorID <= 0 || view.ColorID >= len(indexedColors) {
+		return
+	}
+	*o = GridLineColor(indexedColors[view.ColorID])
+}
+
+// indexedColors defined the default indexed color palette, the grid lines
+// color of the worksheet view is specified by the index of this palette.
+var indexedColors = []string{
+	"000000", "FFFFFF", "FF0000", "00FF00", "0000FF", "FFFF00", "FF00FF", "00FFFF",
+	"000000", "FFFFFF", "FF0000", "00FF00", "0000FF", "FFFF00", "FF00FF", "00FFFF",
+	"800000", "008000", "000080", "808000", "800080", "008080", "C0C0C0", "808080",
+	"9999FF", "993366", "FFFFCC", "CCFFFF", "660066", "FF8080", "0066CC", "CCCCFF",
+	"000080", "FF00FF", "FFFF00", "00FFFF", "800080", "800000", "008080", "0000FF",
+	"00CCFF", "CCFFFF", "CCFFCC", "FFFF99", "99CCFF", "FF99CC", "CC99FF", "FFCC99",
+	"3366FF", "33CCCC", "99CC00", "FFCC00", "FF9900", "FF6600", "666699", "969696",
+	"003366", "339966", "003300", "333300", "993300", "993366", "333399", "333333",
+}
+
+// nearestIndexedColor returns the index of the nearest color in the indexed
+// color palette by given RGB color. The first 8 colors are skipped, because
+// they are duplicated with the following colors.
+func nearestIndexedColor(rgb uint64) int {
+	r, g, b := int(rgb>>16&0xFF), int(rgb>>8&0xFF), int(rgb&0xFF)
+	idx, minDist := 8, -1
+	for i := 8; i < len(indexedColors); i++ {
+		c, _ := strconv.ParseUint(indexedColors[i], 16, 32)
+		dr, dg, db := r-int(c>>16&0xFF), g-int(c>>8&0xFF), b-int(c&0xFF)
+		if dist := dr*dr + dg*dg + db*db; minDist == -1 || dist < minDist {
+			idx, minDist = i, dist
+		}
+	}
+	return idx
+}
+
 // getSheetView returns the SheetView object
 func (f *File) getSheetView(sheet string, viewIndex int) (*xlsxSheetView, error) {
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return nil, err
 	}
+	views := len(ws.SheetViews.SheetView)
 	if viewIndex < 0 {
-		if viewIndex < -len(ws.SheetViews.SheetView) {
-			return nil, fmt.Errorf("view index %d out of range", viewIndex)
+		if viewIndex < -views {
+			return nil, newViewIndexError(sheet, viewIndex, views)
 		}
-		viewIndex = len(ws.SheetViews.SheetView) + viewIndex
-	} else if viewIndex >= len(ws.SheetViews.SheetView) {
-		return nil, fmt.Errorf("view index %d out of range", viewIndex)
+		viewIndex = views + viewIndex
+	} else if viewIndex >= views {
+		return nil, newViewIndexError(sheet, viewIndex, views)
 	}
 
 	return &(ws.SheetViews.SheetView[viewIndex]), err
 }
 
 // SetSheetViewOptions sets sheet view options. The viewIndex may be negative
-// and if so is counted backward (-1 is the last view).
+// and if so is counted backward (-1 is the last view). A worksheet may have
+// multiple views, and an error will be returned if the view of the given
+// index doesn't exist.
 //
 // Available options:
 //
@@ -170,6 +294,11 @@ func (f *File) getSheetView(sheet string, viewIndex int) (*xlsxSheetView, error)
 //    ZoomScale(float64)
 //    TopLeftCell(string)
 //    ShowZeros(bool)
+//    GridLineColor(string)
+//    View(string)
+//    ZoomScaleNormal(float64)
+//    ZoomScalePageLayoutView(float64)
+//    ZoomScaleSheetLayoutView(float64)
 //
 // Example:
 //
@@ -200,6 +329,11 @@ func (f *File) SetSheetViewOptions(name string, viewIndex int, opts ...SheetView
 //    ZoomScale(float64)
 //    TopLeftCell(string)
 //    ShowZeros(bool)
+//    GridLineColor(string)
+//    View(string)
+//    ZoomScaleNormal(float64)
+//    ZoomScalePageLayoutView(float64)
+//    ZoomScaleSheetLayoutView(float64)
 //
 // Example:
 //
@@ -2,6 +2,7 @@ package excelize
 
 import (
 	"fmt"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -14,6 +15,7 @@ var _ = []SheetViewOption{
 	ShowGridLines(true),
 	ShowRowColHeaders(true),
 	TopLeftCell("B2"),
+	GridLineColor("FF0000"),
 	// SheetViewOptionPtr are also SheetViewOption
 	new(DefaultGridColor),
 	new(RightToLeft),
@@ -21,6 +23,7 @@ var _ = []SheetViewOption{
 	new(ShowGridLines),
 	new(ShowRowColHeaders),
 	new(TopLeftCell),
+	new(GridLineColor),
 }
 
 var _ = []SheetViewOptionPtr{
@@ -30,6 +33,7 @@ var _ = []SheetViewOptionPtr{
 	(*ShowGridLines)(nil),
 	(*ShowRowColHeaders)(nil),
 	(*TopLeftCell)(nil),
+	(*GridLineColor)(nil),
 }
 
 func ExampleFile_SetSheetViewOptions() {
@@ -179,4 +183,83 @@ func TestSheetViewOptionsErrors(t *testing.T) {
 	assert.NoError(t, f.SetSheetViewOptions(sheet, -1))
 	assert.Error(t, f.SetSheetViewOptions(sheet, 1))
 	assert.Error(t, f.SetSheetViewOptions(sheet, -2))
+	assert.EqualError(t, f.SetSheetViewOptions(sheet, 1), "view index 1 out of range, sheet Sheet1 has 1 views")
+}
+
+func TestSheetViewOptionsMultipleViews(t *testing.T) {
+	f := NewFile()
+	const sheet = "Sheet1"
+	ws, err := f.workSheetReader(sheet)
+	assert.NoError(t, err)
+	ws.SheetViews.SheetView = append(ws.SheetViews.SheetView, xlsxSheetView{WorkbookViewID: 1})
+
+	assert.NoError(t, f.SetSheetViewOptions(sheet, 0, ZoomScaleNormal(120), ZoomScale(120)))
+	assert.NoError(t, f.SetSheetViewOptions(sheet, 1, View("pageLayout"), ZoomScalePageLayoutView(80), ZoomScale(80)))
+	assert.NoError(t, f.SetSheetViewOptions(sheet, -1, ZoomScaleSheetLayoutView(60), ZoomScaleNormal(500)))
+
+	var (
+		view                     View
+		zoomScale                ZoomScale
+		zoomScaleNormal          ZoomScaleNormal
+		zoomScalePageLayoutView  ZoomScalePageLayoutView
+		zoomScaleSheetLayoutView ZoomScaleSheetLayoutView
+	)
+	assert.NoError(t, f.GetSheetViewOptions(sheet, 0, &view, &zoomScale, &zoomScaleNormal, &zoomScalePageLayoutView, &zoomScaleSheetLayoutView))
+	assert.Equal(t, View("normal"), view)
+	assert.Equal(t, ZoomScale(120), zoomScale)
+	assert.Equal(t, ZoomScaleNormal(120), zoomScaleNormal)
+	assert.Equal(t, ZoomScalePageLayoutView(0), zoomScalePageLayoutView)
+	assert.Equal(t, ZoomScaleSheetLayoutView(0), zoomScaleSheetLayoutView)
+
+	assert.NoError(t, f.GetSheetViewOptions(sheet, 1, &view, &zoomScale, &zoomScaleNormal, &zoomScalePageLayoutView, &zoomScaleSheetLayoutView))
+	assert.Equal(t, View("pageLayout"), view)
+	assert.Equal(t, ZoomScale(80), zoomScale)
+	assert.Equal(t, ZoomScaleNormal(0), zoomScaleNormal)
+	assert.Equal(t, ZoomScalePageLayoutView(80), zoomScalePageLayoutView)
+	assert.Equal(t, ZoomScaleSheetLayoutView(60), zoomScaleSheetLayoutView)
+
+	// Test set view with invalid and normal view type.
+	assert.NoError(t, f.SetSheetViewOptions(sheet, 1, View("unknown")))
+	assert.NoError(t, f.GetSheetViewOptions(sheet, 1, &view))
+	assert.Equal(t, View("pageLayout"), view)
+	assert.NoError(t, f.SetSheetViewOptions(sheet, 1, View("pageBreakPreview")))
+	assert.NoError(t, f.GetSheetViewOptions(sheet, 1, &view))
+	assert.Equal(t, View("pageBreakPreview"), view)
+	assert.NoError(t, f.SetSheetViewOptions(sheet, 1, View("normal")))
+	assert.NoError(t, f.GetSheetViewOptions(sheet, 1, &view))
+	assert.Equal(t, View("normal"), view)
+
+	// Test get sheet view with nonexistent view index.
+	assert.EqualError(t, f.GetSheetViewOptions(sheet, 2, &view), "view index 2 out of range, sheet Sheet1 has 2 views")
+	assert.EqualError(t, f.GetSheetViewOptions(sheet, -3, &view), "view index -3 out of range, sheet Sheet1 has 2 views")
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSheetViewOptionsMultipleViews.xlsx")))
+}
+
+func TestGridLineColor(t *testing.T) {
+	f := NewFile()
+	const sheet = "Sheet1"
+	var gridLineColor GridLineColor
+	assert.NoError(t, f.GetSheetViewOptions(sheet, 0, &gridLineColor))
+	assert.Equal(t, GridLineColor(""), gridLineColor)
+	for color, expected := range map[string]string{
+		"FF0000":  "FF0000",
+		"#3366ff": "3366FF",
+		"FE0102":  "FF0000",
+		"":        "",
+		"FF00":    "",
+		"GGGGGG":  "",
+	} {
+		assert.NoError(t, f.SetSheetViewOptions(sheet, 0, GridLineColor(color)))
+		assert.NoError(t, f.GetSheetViewOptions(sheet, 0, &gridLineColor))
+		assert.Equal(t, GridLineColor(expected), gridLineColor, color)
+	}
+	assert.NoError(t, f.SetSheetViewOptions(sheet, 0, GridLineColor("00FF00")))
+	view, err := f.getSheetView(sheet, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 11, view.ColorID)
+	assert.False(t, *view.DefaultGridColor)
+	// Test the default grid lines color overrides the grid lines color.
+	assert.NoError(t, f.SetSheetViewOptions(sheet, 0, DefaultGridColor(true)))
+	assert.NoError(t, f.GetSheetViewOptions(sheet, 0, &gridLineColor))
+	assert.Equal(t, GridLineColor(""), gridLineColor)
 }
@@ -0,0 +1,473 @@
+// Copyright 2016 - 2021 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to
+// and read from XLSX / XLSM / XLTM files. Supports reading and writing
+// spreadsheet documents generated by Microsoft Excel™ 2007 and later. Supports
+// complex components by high compatibility, and provided streaming API for
+// generating or reading data from a worksheet with huge amounts of data. This
+// library needs Go version 1.15 or later.
+
+package excelize
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Default width and height of the slicer in pixels, the default height of the
+// slicer items in EMUs and the maximum number of columns of the slicer items.
+const (
+	defaultSlicerWidth     = 200
+	defaultSlicerHeight    = 200
+	defaultSlicerRowHeight = 241300
+	maxSlicerColumnCount   = 20000
+)
+
+// parseSlicerOptions provides a function to parse the format settings of the
+// slicer with default value.
+func parseSlicerOptions(opts *SlicerOptions) (*SlicerOptions, error) {
+	if opts == nil {
+		return nil, ErrParameterRequired
+	}
+	if opts.Name == "" || opts.Cell == "" || opts.TableName == "" {
+		return opts, ErrParameterRequired
+	}
+	if _, _, err := CellNameToCoordinates(opts.Cell); err != nil {
+		return opts, err
+	}
+	if opts.ColumnCount < 0 || opts.ColumnCount > maxSlicerColumnCount {
+		return opts, ErrParameterInvalid
+	}
+	if opts.Width == 0 {
+		opts.Width = defaultSlicerWidth
+	}
+	if opts.Height == 0 {
+		opts.Height = defaultSlicerHeight
+	}
+	return opts, nil
+}
+
+// AddSlicer provides the method to add a slicer for the table by given
+// worksheet name and slicer settings. The Name of the slicer settings is the
+// column name of the table which to be filtered, and the table specified by
+// TableName can be placed in any worksheet of the workbook. The slicer will
+// be anchored at the top-left corner of the given Cell. For example, add a
+// slicer on the Sheet1!$G$2 for the column "Region" of the table "Table1":
+//
+//    err := f.AddSlicer("Sheet1", &excelize.SlicerOptions{
+//        Name:        "Region",
+//        Cell:        "G2",
+//        TableName:   "Table1",
+//        Caption:     "Region",
+//        Style:       "SlicerStyleLight6",
+//        ColumnCount: 3,
+//        Width:       300,
+//        Height:      100,
+//    })
+//
+// Caption specifies the header of the slicer, and default value is the
+// column name. Style specifies the name of the slicer style, the built-in
+// styles are SlicerStyleLight1 - SlicerStyleLight6, SlicerStyleOther1 -
+// SlicerStyleOther2 and SlicerStyleDark1 - SlicerStyleDark6, the default
+// style SlicerStyleLight1 will be used if it's empty. ColumnCount specifies
+// the number of columns of the buttons in the slicer, and the value should be
+// between 1 and 20000, default value is 1. Width and Height specifies the size
+// of the slicer in pixels, and default value is 200. Slicers for the table
+// are supported by Excel 2013 and later.
+func (f *File) AddSlicer(sheet string, opts *SlicerOptions) error {
+	opts, err := parseSlicerOptions(opts)
+	if err != nil {
+		return err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	table, column, err := f.getSlicerSource(opts)
+	if err != nil {
+		return err
+	}
+	slicerName, err := f.genSlicerName(column.Name)
+	if err != nil {
+		return err
+	}
+	cacheName, err := f.addSlicerCache(table, column)
+	if err != nil {
+		return err
+	}
+	slicer := &xlsxSlicer{
+		Name:      slicerName,
+		Cache:     cacheName,
+		Caption:   opts.Caption,
+		Style:     opts.Style,
+		RowHeight: defaultSlicerRowHeight,
+	}
+	if slicer.Caption == "" {
+		slicer.Caption = column.Name
+	}
+	if opts.ColumnCount > 1 {
+		slicer.ColumnCount = intPtr(opts.ColumnCount)
+	}
+	if err = f.addSheetSlicer(sheet, ws, slicer); err != nil {
+		return err
+	}
+	return f.addDrawingSlicer(sheet, ws, slicerName, opts)
+}
+
+// getSlicerSource provides a function to get the table and the table column
+// as the data source of the slicer by given slicer settings.
+func (f *File) getSlicerSource(opts *SlicerOptions) (*xlsxTable, *xlsxTableColumn, error) {
+	for _, sheet := range f.GetSheetList() {
+		if !strings.HasPrefix(f.sheetMap[sheet], "xl/worksheets/") {
+			continue
+		}
+		ws, err := f.workSheetReader(sheet)
+		if err != nil {
+			return nil, nil, err
+		}
+		if ws.TableParts == nil {
+			continue
+		}
+		for _, tablePart := range ws.TableParts.TableParts {
+			tableXML := f.getTablePartPath(sheet, tablePart.RID)
+			if tableXML == "" {
+				continue
+			}
+			t, err := f.tableReader(tableXML)
+			if err != nil {
+				return nil, nil, err
+			}
+			if !strings.EqualFold(t.Name, opts.TableName) {
+				continue
+			}
+			if t.TableColumns != nil {
+				for _, column := range t.TableColumns.TableColumn {
+					if strings.EqualFold(column.Name, opts.Name) {
+						return t, column, err
+					}
+				}
+			}
+			return t, nil, newNoTableColumnError(opts.Name, opts.TableName)
+		}
+	}
+	return nil, nil, newNoTableError(opts.TableName)
+}
+
+// genSlicerName provides a function to generate an unique slicer name in the
+// workbook by given name.
+func (f *File) genSlicerName(name string) (string, error) {
+	names := map[string]bool{}
+	var err error
+	f.Pkg.Range(func(k, v interface{}) bool {
+		if strings.HasPrefix(k.(string), "xl/slicers/slicer") {
+			var slicers *xlsxSlicers
+			if slicers, err = f.slicersReader(k.(string)); err != nil {
+				return false
+			}
+			for _, slicer := range slicers.Slicer {
+				names[strings.ToLower(slicer.Name)] = true
+			}
+		}
+		return true
+	})
+	slicerName := name
+	for i := 1; names[strings.ToLower(slicerName)]; i++ {
+		slicerName = name + " " + strconv.Itoa(i)
+	}
+	return slicerName, err
+}
+
+// genSlicerCacheName provides a function to generate an unique slicer cache
+// name by given column name of the table. The slicer cache name will be used
+// as a defined name of the workbook, so the characters which are invalid in
+// the defined name will be replaced with underscores.
+func (f *File) genSlicerCacheName(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.' {
+			b.WriteRune(r)
+			continue
+		}
+		b.WriteRune('_')
+	}
+	names := map[string]bool{}
+	if wb := f.workbookReader(); wb.DefinedNames != nil {
+		for _, dn := range wb.DefinedNames.DefinedName {
+			names[strings.ToLower(dn.Name)] = true
+		}
+	}
+	base := "Slicer_" + b.String()
+	cacheName := base
+	for i := 1; names[strings.ToLower(cacheName)]; i++ {
+		cacheName = base + strconv.Itoa(i)
+	}
+	return cacheName
+}
+
+// addSlicerCache provides a function to create the slicer cache part
+// xl/slicerCaches/slicerCache%d.xml for the table column, and add the slicer
+// cache into the workbook. It returns the name of the slicer cache.
+func (f *File) addSlicerCache(table *xlsxTable, column *xlsxTableColumn) (string, error) {
+	cacheName := f.genSlicerCacheName(column.Name)
+	tableSlicerCache, err := xml.Marshal(xlsxX15TableSlicerCache{TableID: table.ID, Column: column.ID})
+	if err != nil {
+		return cacheName, err
+	}
+	ext, err := xml.Marshal(xlsxSlicerCacheExt{
+		URI:      ExtURITableSlicerCache,
+		XMLNSX15: NameSpaceSpreadSheetX15.Value,
+		Content:  string(tableSlicerCache),
+	})
+	if err != nil {
+		return cacheName, err
+	}
+	slicerCache, err := xml.Marshal(xlsxSlicerCacheDefinition{
+		XMLNSXMC:    SourceRelationshipCompatibility.Value,
+		McIgnorable: "x",
+		XMLNSX:      NameSpaceSpreadSheet.Value,
+		Name:        cacheName,
+		SourceName:  column.Name,
+		ExtLst:      &xlsxExtLst{Ext: string(ext)},
+	})
+	if err != nil {
+		return cacheName, err
+	}
+	cacheID := f.countSlicerCaches() + 1
+	f.saveFileList("xl/slicerCaches/slicerCache"+strconv.Itoa(cacheID)+".xml", slicerCache)
+	f.addContentTypePart(cacheID, "slicerCache")
+	rID := f.addRels(f.getWorkbookRelsPath(), SourceRelationshipSlicerCache, "/xl/slicerCaches/slicerCache"+strconv.Itoa(cacheID)+".xml", "")
+	if err = f.addWorkbookSlicerCache(rID); err != nil {
+		return cacheName, err
+	}
+	wb := f.workbookReader()
+	if wb.DefinedNames == nil {
+		wb.DefinedNames = &xlsxDefinedNames{}
+	}
+	wb.DefinedNames.DefinedName = append(wb.DefinedNames.DefinedName, xlsxDefinedName{Name: cacheName, Data: "#N/A"})
+	return cacheName, err
+}
+
+// addWorkbookSlicerCache provides a function to add the relationship ID of
+// the table slicer cache into the extension list of the workbook.
+func (f *File) addWorkbookSlicerCache(rID int) error {
+	wb := f.workbookReader()
+	decodeExtLst := new(decodeWorksheetExt)
+	if wb.ExtLst != nil {
+		if err := f.xmlNewDecoder(strings.NewReader("<extLst>" + wb.ExtLst.Ext + "</extLst>")).
+			Decode(decodeExtLst); err != nil && err != io.EOF {
+			return err
+		}
+	}
+	slicerCache, _ := xml.Marshal(xlsxX14SlicerCache{RID: "rId" + strconv.Itoa(rID)})
+	var exist bool
+	for idx, ext := range decodeExtLst.Ext {
+		if ext.URI != ExtURISlicerCachesX15 {
+			continue
+		}
+		decodeCaches := new(decodeSlicerCaches)
+		if err := f.xmlNewDecoder(strings.NewReader(ext.Content)).
+			Decode(decodeCaches); err != nil && err != io.EOF {
+			return err
+		}
+		slicerCaches, _ := xml.Marshal(xlsxX15SlicerCaches{
+			XMLNSX14: NameSpaceSpreadSheetX14.Value,
+			Content:  decodeCaches.Content + string(slicerCache),
+		})
+		decodeExtLst.Ext[idx].Content, exist = string(slicerCaches), true
+	}
+	if !exist {
+		slicerCaches, _ := xml.Marshal(xlsxX15SlicerCaches{
+			XMLNSX14: NameSpaceSpreadSheetX14.Value,
+			Content:  string(slicerCache),
+		})
+		decodeExtLst.Ext = append(decodeExtLst.Ext, &xlsxWorksheetExt{
+			URI:     ExtURISlicerCachesX15,
+			Content: string(slicerCaches),
+		})
+	}
+	extLstBytes, err := xml.Marshal(decodeExtLst)
+	if err != nil {
+		return err
+	}
+	wb.ExtLst = &xlsxExtLst{
+		Ext: strings.TrimSuffix(strings.TrimPrefix(string(extLstBytes), "<extLst>"), "</extLst>"),
+	}
+	f.addNameSpaces(f.getWorkbookPath(), NameSpaceSpreadSheetX15)
+	return err
+}
+
+// addSheetSlicer provides a function to add the slicer into the slicer part
+// of the worksheet by given worksheet name. The slicer part
+// xl/slicers/slicer%d.xml will be created if the worksheet doesn't have one.
+func (f *File) addSheetSlicer(sheet string, ws *xlsxWorksheet, slicer *xlsxSlicer) error {
+	sheetXML := f.sheetMap[trimSheetName(sheet)]
+	slicerXML := f.getSheetSlicerPart(sheetXML)
+	slicers, err := f.slicersReader(slicerXML)
+	if err != nil {
+		return err
+	}
+	if slicerXML == "" {
+		slicerID := f.countSlicers() + 1
+		slicerXML = "xl/slicers/slicer" + strconv.Itoa(slicerID) + ".xml"
+		sheetRels := "xl/worksheets/_rels/" + strings.TrimPrefix(sheetXML, "xl/worksheets/") + ".rels"
+		rID := f.addRels(sheetRels, SourceRelationshipSlicer, "../slicers/slicer"+strconv.Itoa(slicerID)+".xml", "")
+		f.addSheetNameSpace(sheet, SourceRelationship)
+		f.addContentTypePart(slicerID, "slicer")
+		if err = f.addWorksheetSlicerList(ws, rID); err != nil {
+			return err
+		}
+	}
+	slicers.XMLNSXMC = SourceRelationshipCompatibility.Value
+	slicers.XMLNSX = NameSpaceSpreadSheet.Value
+	slicers.Slicer = append(slicers.Slicer, slicer)
+	output, err := xml.Marshal(slicers)
+	f.saveFileList(slicerXML, output)
+	return err
+}
+
+// addWorksheetSlicerList provides a function to add the relationship ID of
+// the slicer part into the extension list of the worksheet.
+func (f *File) addWorksheetSlicerList(ws *xlsxWorksheet, rID int) error {
+	decodeExtLst := new(decodeWorksheetExt)
+	if ws.ExtLst != nil {
+		if err := f.xmlNewDecoder(strings.NewReader("<extLst>" + ws.ExtLst.Ext + "</extLst>")).
+			Decode(decodeExtLst); err != nil && err != io.EOF {
+			return err
+		}
+	}
+	slicerList, err := xml.Marshal(xlsxX14SlicerList{
+		XMLNSX14: NameSpaceSpreadSheetX14.Value,
+		Slicer:   []*xlsxX14SlicerListItem{{RID: "rId" + strconv.Itoa(rID)}},
+	})
+	if err != nil {
+		return err
+	}
+	decodeExtLst.Ext = append(decodeExtLst.Ext, &xlsxWorksheetExt{
+		URI:     ExtURISlicerListX15,
+		Content: string(slicerList),
+	})
+	extLstBytes, err := xml.Marshal(decodeExtLst)
+	if err != nil {
+		return err
+	}
+	ws.ExtLst = &xlsxExtLst{
+		Ext: strings.TrimSuffix(strings.TrimPrefix(string(extLstBytes), "<extLst>"), "</extLst>"),
+	}
+	return err
+}
+
+// addDrawingSlicer provides a function to add the graphic frame of the slicer
+// into the drawing part of the worksheet by given worksheet name, slicer name
+// and slicer settings.
+func (f *File) addDrawingSlicer(sheet string, ws *xlsxWorksheet, slicerName string, opts *SlicerOptions) error {
+	col, row, err := CellNameToCoordinates(opts.Cell)
+	if err != nil {
+		return err
+	}
+	drawingID := f.countDrawings() + 1
+	drawingXML := "xl/drawings/drawing" + strconv.Itoa(drawingID) + ".xml"
+	drawingID, drawingXML = f.prepareDrawing(ws, drawingID, sheet, drawingXML)
+	colStart, rowStart, colEnd, rowEnd, x2, y2 :=
+		f.positionObjectPixels(sheet, col-1, row-1, 0, 0, opts.Width, opts.Height)
+	content, cNvPrID := f.drawingParser(drawingXML)
+	graphicFrame, err := xml.Marshal(xlsxSlicerAlternateContent{
+		XMLNSMC: SourceRelationshipCompatibility.Value,
+		Choice: &xlsxSlicerChoice{
+			XMLNSSle15: NameSpaceDrawingMLSlicerX15.Value,
+			Requires:   NameSpaceDrawingMLSlicerX15.Name.Local,
+			GraphicFrame: &xlsxGraphicFrame{
+				NvGraphicFramePr: xlsxNvGraphicFramePr{
+					CNvPr: &xlsxCNvPr{ID: cNvPrID, Name: slicerName},
+				},
+				Graphic: &xlsxGraphic{
+					GraphicData: &xlsxGraphicData{
+						URI: NameSpaceDrawingMLSlicer.Value,
+						Sle: &xlsxSle{XMLNSSle: NameSpaceDrawingMLSlicer.Value, Name: slicerName},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	content.TwoCellAnchor = append(content.TwoCellAnchor, &xdrCellAnchor{
+		EditAs:       "oneCell",
+		From:         &xlsxFrom{Col: colStart, Row: rowStart},
+		To:           &xlsxTo{Col: colEnd, ColOff: x2 * EMU, Row: rowEnd, RowOff: y2 * EMU},
+		GraphicFrame: string(graphicFrame),
+		ClientData:   &xdrClientData{FLocksWithSheet: true, FPrintsWithSheet: true},
+	})
+	f.Drawings.Store(drawingXML, content)
+	f.addContentTypePart(drawingID, "drawings")
+	return err
+}
+
+// getSheetSlicerPart provides a function to get the path of the slicer part
+// by given worksheet file path, returns empty string if the worksheet has no
+// slicers.
+func (f *File) getSheetSlicerPart(sheetXML string) string {
+	var target string
+	if sheetRels := f.relsReader("xl/worksheets/_rels/" + strings.TrimPrefix(sheetXML, "xl/worksheets/") + ".rels"); sheetRels != nil {
+		sheetRels.Lock()
+		for _, v := range sheetRels.Relationships {
+			if v.Type == SourceRelationshipSlicer {
+				target = v.Target
+			}
+		}
+		sheetRels.Unlock()
+	}
+	if target == "" {
+		return target
+	}
+	if !strings.HasPrefix(target, "/") {
+		target = "xl" + strings.TrimPrefix(target, "..")
+	}
+	return strings.TrimPrefix(target, "/")
+}
+
+// slicersReader provides a function to get the pointer to the structure
+// after deserialization of xl/slicers/slicer%d.xml.
+func (f *File) slicersReader(path string) (*xlsxSlicers, error) {
+	var slicers xlsxSlicers
+	if path == "" {
+		return &slicers, nil
+	}
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(path)))).
+		Decode(&slicers); err != nil && err != io.EOF {
+		return &slicers, err
+	}
+	return &slicers, nil
+}
+
+// countSlicers provides a function to get slicer files count storage in the
+// folder xl/slicers.
+func (f *File) countSlicers() int {
+	count := 0
+	f.Pkg.Range(func(k, v interface{}) bool {
+		if strings.HasPrefix(k.(string), "xl/slicers/slicer") {
+			count++
+		}
+		return true
+	})
+	return count
+}
+
+// countSlicerCaches provides a function to get slicer cache files count
+// storage in the folder xl/slicerCaches.
+func (f *File) countSlicerCaches() int {
+	count := 0
+	f.Pkg.Range(func(k, v interface{}) bool {
+		if strings.HasPrefix(k.(string), "xl/slicerCaches/slicerCache") {
+			count++
+		}
+		return true
+	})
+	return count
+}
@@ -0,0 +1,119 @@
+package excelize
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddSlicer(t *testing.T) {
+	f := NewFile()
+	f.NewSheet("Sheet2")
+	for idx, row := range [][]interface{}{
+		{"Region", "Product Name", "Sales"},
+		{"East", "Apple", 100},
+		{"West", "Banana", 200},
+		{"East", "Cherry", 300},
+	} {
+		cell, err := CoordinatesToCellName(1, idx+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet2", cell, &row))
+	}
+	assert.NoError(t, f.AddTable("Sheet2", "A1", "C4", `{"table_name":"Table1"}`))
+	assert.NoError(t, f.AddSlicer("Sheet1", &SlicerOptions{Name: "Region", Cell: "E2", TableName: "Table1"}))
+	assert.NoError(t, f.AddSlicer("Sheet1", &SlicerOptions{Name: "region", Cell: "H2", TableName: "table1", Width: 150, Height: 100}))
+	assert.NoError(t, f.AddSlicer("Sheet2", &SlicerOptions{Name: "Product Name", Cell: "E2", TableName: "Table1"}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddSlicer.xlsx")))
+
+	f, err := OpenFile(filepath.Join("test", "TestAddSlicer.xlsx"))
+	assert.NoError(t, err)
+	// Test the slicer caches reference the table columns.
+	for path, expected := range map[string]string{
+		"xl/slicerCaches/slicerCache1.xml": `name="Slicer_Region" sourceName="Region"`,
+		"xl/slicerCaches/slicerCache2.xml": `name="Slicer_Region1" sourceName="Region"`,
+		"xl/slicerCaches/slicerCache3.xml": `name="Slicer_Product_Name" sourceName="Product Name"`,
+	} {
+		content := string(f.readXML(path))
+		assert.Contains(t, content, expected)
+		assert.Contains(t, content, ExtURITableSlicerCache)
+	}
+	assert.Contains(t, string(f.readXML("xl/slicerCaches/slicerCache3.xml")), `<x15:tableSlicerCache tableId="1" column="2"></x15:tableSlicerCache>`)
+	// Test the slicers of the same worksheet are in the same slicer part.
+	slicers, err := f.slicersReader("xl/slicers/slicer1.xml")
+	assert.NoError(t, err)
+	if assert.Len(t, slicers.Slicer, 2) {
+		assert.Equal(t, "Region", slicers.Slicer[0].Name)
+		assert.Equal(t, "Slicer_Region", slicers.Slicer[0].Cache)
+		assert.Equal(t, "Region 1", slicers.Slicer[1].Name)
+		assert.Equal(t, "Slicer_Region1", slicers.Slicer[1].Cache)
+	}
+	slicers, err = f.slicersReader("xl/slicers/slicer2.xml")
+	assert.NoError(t, err)
+	if assert.Len(t, slicers.Slicer, 1) {
+		assert.Equal(t, "Product Name", slicers.Slicer[0].Name)
+	}
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(ws.ExtLst.Ext, ExtURISlicerListX15))
+	wb := f.workbookReader()
+	assert.Equal(t, 1, strings.Count(wb.ExtLst.Ext, ExtURISlicerCachesX15))
+	assert.Equal(t, 3, strings.Count(wb.ExtLst.Ext, "<x14:slicerCache "))
+	assert.Contains(t, string(f.readXML("xl/workbook.xml")), NameSpaceSpreadSheetX15.Value)
+	assert.Contains(t, string(f.readXML("xl/drawings/drawing1.xml")), `<sle:slicer xmlns:sle="http://schemas.microsoft.com/office/drawing/2010/slicer" name="Region 1"></sle:slicer>`)
+	definedNames := f.GetDefinedName()
+	assert.Len(t, definedNames, 3)
+	// Test add slicer after open the workbook.
+	assert.NoError(t, f.AddSlicer("Sheet2", &SlicerOptions{Name: "Sales", Cell: "E20", TableName: "Table1"}))
+	slicers, err = f.slicersReader("xl/slicers/slicer2.xml")
+	assert.NoError(t, err)
+	assert.Len(t, slicers.Slicer, 2)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddSlicer.xlsx")))
+
+	// Test add slicer with invalid options.
+	assert.EqualError(t, f.AddSlicer("Sheet1", nil), ErrParameterRequired.Error())
+	assert.EqualError(t, f.AddSlicer("Sheet1", &SlicerOptions{Cell: "A1", TableName: "Table1"}), ErrParameterRequired.Error())
+	assert.EqualError(t, f.AddSlicer("Sheet1", &SlicerOptions{Name: "Region", Cell: "A", TableName: "Table1"}), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+	assert.EqualError(t, f.AddSlicer("SheetN", &SlicerOptions{Name: "Region", Cell: "A1", TableName: "Table1"}), "sheet SheetN is not exist")
+	assert.EqualError(t, f.AddSlicer("Sheet1", &SlicerOptions{Name: "Region", Cell: "A1", TableName: "TableN"}), "table TableN does not exist")
+	assert.EqualError(t, f.AddSlicer("Sheet1", &SlicerOptions{Name: "Price", Cell: "A1", TableName: "Table1"}), "column Price does not exist in table Table1")
+	// Test add slicer with unsupported charset slicer part.
+	f.Pkg.Store("xl/slicers/slicer1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AddSlicer("Sheet1", &SlicerOptions{Name: "Region", Cell: "A1", TableName: "Table1"}), "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestAddSlicerFormat(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Region", "Sales"}))
+	assert.NoError(t, f.AddTable("Sheet1", "A1", "B5", `{"table_name":"Table1"}`))
+	assert.NoError(t, f.AddSlicer("Sheet1", &SlicerOptions{
+		Name:        "Region",
+		Cell:        "D2",
+		TableName:   "Table1",
+		Caption:     "Sales Region",
+		Style:       "SlicerStyleDark3",
+		ColumnCount: 3,
+		Width:       300,
+		Height:      100,
+	}))
+	assert.NoError(t, f.AddSlicer("Sheet1", &SlicerOptions{Name: "Sales", Cell: "D10", TableName: "Table1", ColumnCount: 1}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddSlicerFormat.xlsx")))
+
+	f, err := OpenFile(filepath.Join("test", "TestAddSlicerFormat.xlsx"))
+	assert.NoError(t, err)
+	slicers, err := f.slicersReader("xl/slicers/slicer1.xml")
+	assert.NoError(t, err)
+	if assert.Len(t, slicers.Slicer, 2) {
+		assert.Equal(t, "Sales Region", slicers.Slicer[0].Caption)
+		assert.Equal(t, "SlicerStyleDark3", slicers.Slicer[0].Style)
+		assert.Equal(t, intPtr(3), slicers.Slicer[0].ColumnCount)
+		assert.Equal(t, "Sales", slicers.Slicer[1].Caption)
+		assert.Empty(t, slicers.Slicer[1].Style)
+		assert.Nil(t, slicers.Slicer[1].ColumnCount)
+	}
+	// Test add slicer with invalid column count.
+	for _, columnCount := range []int{-1, 20001} {
+		assert.EqualError(t, f.AddSlicer("Sheet1", &SlicerOptions{Name: "Region", Cell: "D20", TableName: "Table1", ColumnCount: columnCount}), ErrParameterInvalid.Error())
+	}
+}
@@ -359,6 +359,15 @@ func (f *File) addSparklineGroupByStyle(ID int) *xlsxX14SparklineGroup {
 	return groups[ID]
 }
 
+// sparklineAxisTypes defined the list of valid vertical axis scaling types of
+// the sparkline, the individual type is the default value.
+var sparklineAxisTypes = map[string]string{
+	"":           "",
+	"individual": "",
+	"group":      "group",
+	"custom":     "custom",
+}
+
 // AddSparkline provides a function to add sparklines to the worksheet by
 // given formatting options. Sparklines are small charts that fit in a single
 // cell and are used to show trends in data. Sparklines are a feature of Excel
@@ -374,20 +383,44 @@ func (f *File) addSparklineGroupByStyle(ID int) *xlsxX14SparklineGroup {
 //
 // The following shows the formatting options of sparkline supported by excelize:
 //
-//     Parameter | Description
-//    -----------+--------------------------------------------
-//     Location  | Required, must have the same number with 'Range' parameter
-//     Range     | Required, must have the same number with 'Location' parameter
-//     Type      | Enumeration value: line, column, win_loss
-//     Style     | Value range: 0 - 35
-//     Hight     | Toggle sparkline high points
-//     Low       | Toggle sparkline low points
-//     First     | Toggle sparkline first points
-//     Last      | Toggle sparkline last points
-//     Negative  | Toggle sparkline negative points
-//     Markers   | Toggle sparkline markers
-//     ColorAxis | An RGB Color is specified as RRGGBB
-//     Axis      | Show sparkline axis
+//     Parameter     | Description
+//    ---------------+--------------------------------------------
+//     Location      | Required, must have the same number with 'Range' parameter
+//     Range         | Required, must have the same number with 'Location' parameter
+//     Type          | Enumeration value: line, column, win_loss (or winloss)
+//     Style         | Value range: 0 - 35
+//     Hight         | Toggle sparkline high points
+//     Low           | Toggle sparkline low points
+//     First         | Toggle sparkline first points
+//     Last          | Toggle sparkline last points
+//     Negative      | Toggle sparkline negative points
+//     Markers       | Toggle sparkline markers, only for line sparkline
+//     ColorAxis     | An RGB Color is specified as RRGGBB
+//     Axis          | Show sparkline axis
+//     SeriesColor   | An RGB Color is specified as RRGGBB
+//     NegativeColor | The color of the negative points, specified as RRGGBB
+//     MarkersColor  | The color of the markers, specified as RRGGBB
+//     FirstColor    | The color of the first points, specified as RRGGBB
+//     LastColor     | The color of the last points, specified as RRGGBB
+//     HightColor    | The color of the high points, specified as RRGGBB
+//     LowColor      | The color of the low points, specified as RRGGBB
+//     MinAxisType   | Enumeration value: individual (default), group, custom
+//     MaxAxisType   | Enumeration value: individual (default), group, custom
+//     Min           | The minimum value of the vertical axis for custom type
+//     Max           | The maximum value of the vertical axis for custom type
+//     DateAxis      | Toggle sparkline date axis, required 'DateRange'
+//     DateRange     | The range of the dates for the date axis, such as Sheet2!A1:J1
+//
+// For example, add a group of sparklines which share the same vertical axis
+// range, so they are visually comparable:
+//
+//    err := f.AddSparkline("Sheet1", &excelize.SparklineOption{
+//        Location:    []string{"A1", "A2"},
+//        Range:       []string{"Sheet2!A1:J1", "Sheet2!A2:J2"},
+//        MinAxisType: "custom",
+//        Min:         -10,
+//        MaxAxisType: "group",
+//    })
 //
 func (f *File) AddSparkline(sheet string, opt *SparklineOption) (err error) {
 	var (
@@ -407,10 +440,10 @@ func (f *File) AddSparkline(sheet string, opt *SparklineOption) (err error) {
 	}
 	// Handle the sparkline type
 	sparkType = "line"
-	sparkTypes = map[string]string{"line": "line", "column": "column", "win_loss": "stacked"}
+	sparkTypes = map[string]string{"line": "line", "column": "column", "win_loss": "stacked", "winloss": "stacked"}
 	if opt.Type != "" {
 		if specifiedSparkTypes, ok = sparkTypes[opt.Type]; !ok {
-			err = errors.New("parameter 'Type' must be 'line', 'column' or 'win_loss'")
+			err = errors.New("parameter 'Type' must be 'line', 'column', 'win_loss' or 'winloss'")
 			return
 		}
 		sparkType = specifiedSparkTypes
@@ -425,15 +458,37 @@ func (f *File) AddSparkline(sheet string, opt *SparklineOption) (err error) {
 	group.Last = opt.Last
 	group.Negative = opt.Negative
 	group.DisplayXAxis = opt.Axis
-	group.Markers = opt.Markers
-	if opt.SeriesColor != "" {
-		group.ColorSeries = &xlsxTabColor{
-			RGB: getPaletteColor(opt.SeriesColor),
+	// The markers are only available for the line sparkline
+	group.Markers = opt.Markers && sparkType == "line"
+	for _, color := range []struct {
+		value string
+		color **xlsxTabColor
+	}{
+		{opt.SeriesColor, &group.ColorSeries},
+		{opt.NegativeColor, &group.ColorNegative},
+		{opt.MarkersColor, &group.ColorMarkers},
+		{opt.FirstColor, &group.ColorFirst},
+		{opt.LastColor, &group.ColorLast},
+		{opt.HightColor, &group.ColorHigh},
+		{opt.LowColor, &group.ColorLow},
+	} {
+		if color.value != "" {
+			*color.color = &xlsxTabColor{RGB: getPaletteColor(color.value)}
 		}
 	}
 	if opt.Reverse {
 		group.RightToLeft = opt.Reverse
 	}
+	group.MinAxisType, group.MaxAxisType = sparklineAxisTypes[opt.MinAxisType], sparklineAxisTypes[opt.MaxAxisType]
+	if group.MinAxisType == "custom" {
+		group.ManualMin = float64Ptr(opt.Min)
+	}
+	if group.MaxAxisType == "custom" {
+		group.ManualMax = float64Ptr(opt.Max)
+	}
+	if opt.DateRange != "" {
+		group.DateAxis, group.F = true, opt.DateRange
+	}
 	f.addSparkline(opt, group)
 	if ws.ExtLst.Ext != "" { // append mode ext
 		if err = f.appendSparkline(ws, group, groups); err != nil {
@@ -482,6 +537,15 @@ func (f *File) parseFormatAddSparklineSet(sheet string, opt *SparklineOption) (*
 	if opt.Style < 0 || opt.Style > 35 {
 		return ws, errors.New("parameter 'Style' must betweent 0-35")
 	}
+	if _, ok := sparklineAxisTypes[opt.MinAxisType]; !ok {
+		return ws, errors.New("parameter 'MinAxisType' must be 'individual', 'group' or 'custom'")
+	}
+	if _, ok := sparklineAxisTypes[opt.MaxAxisType]; !ok {
+		return ws, errors.New("parameter 'MaxAxisType' must be 'individual', 'group' or 'custom'")
+	}
+	if opt.DateAxis && opt.DateRange == "" {
+		return ws, errors.New("parameter 'DateRange' is required when 'DateAxis' is enabled")
+	}
 	if ws.ExtLst == nil {
 		ws.ExtLst = &xlsxExtLst{}
 	}
@@ -535,6 +599,18 @@ func (f *File) appendSparkline(ws *xlsxWorksheet, group *xlsxX14SparklineGroup,
 			decodeExtLst.Ext[idx].Content = string(sparklineGroupsBytes)
 		}
 	}
+	if decodeSparklineGroups == nil { // the extension list without sparkline groups
+		if sparklineGroupsBytes, err = xml.Marshal(&xlsxX14SparklineGroups{
+			XMLNSXM:         NameSpaceSpreadSheetExcel2006Main.Value,
+			SparklineGroups: []*xlsxX14SparklineGroup{group},
+		}); err != nil {
+			return
+		}
+		decodeExtLst.Ext = append(decodeExtLst.Ext, &xlsxWorksheetExt{
+			URI:     ExtURISparklineGroups,
+			Content: string(sparklineGroupsBytes),
+		})
+	}
 	if extLstBytes, err = xml.Marshal(decodeExtLst); err != nil {
 		return
 	}
@@ -543,3 +619,111 @@ func (f *File) appendSparkline(ws *xlsxWorksheet, group *xlsxX14SparklineGroup,
 	}
 	return
 }
+
+// GetSparklines provides a function to get the sparklines settings of the
+// worksheet by given worksheet name. The Style will be detected if the
+// theme colors of the sparkline group match one of the preset styles, and
+// the RGB colors will be returned by the color options. For example:
+//
+//    sparklines, err := f.GetSparklines("Sheet1")
+//    if err != nil {
+//        fmt.Println(err)
+//        return
+//    }
+//    for _, sparkline := range sparklines {
+//        fmt.Println(sparkline.Location, sparkline.Range, sparkline.Type)
+//    }
+//
+func (f *File) GetSparklines(sheet string) ([]SparklineOption, error) {
+	sparklines := []SparklineOption{}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil || ws.ExtLst == nil {
+		return sparklines, err
+	}
+	decodeExtLst := new(decodeWorksheetExt)
+	if err = f.xmlNewDecoder(strings.NewReader("<extLst>" + ws.ExtLst.Ext + "</extLst>")).
+		Decode(decodeExtLst); err != nil && err != io.EOF {
+		return sparklines, err
+	}
+	for _, ext := range decodeExtLst.Ext {
+		if ext.URI != ExtURISparklineGroups {
+			continue
+		}
+		decodeSparklineGroups := new(decodeX14SparklineGroups)
+		if err = f.xmlNewDecoder(strings.NewReader(ext.Content)).
+			Decode(decodeSparklineGroups); err != nil && err != io.EOF {
+			return sparklines, err
+		}
+		for _, group := range decodeSparklineGroups.SparklineGroups {
+			sparklines = append(sparklines, f.getSparklineOption(group))
+		}
+	}
+	return sparklines, nil
+}
+
+// getSparklineOption provides a function to convert the sparkline group to
+// the sparkline settings.
+func (f *File) getSparklineOption(group *decodeX14SparklineGroup) SparklineOption {
+	opt := SparklineOption{
+		Type:        map[string]string{"": "line", "line": "line", "column": "column", "stacked": "win_loss"}[group.Type],
+		Weight:      group.LineWeight,
+		DateAxis:    group.DateAxis,
+		DateRange:   group.F,
+		Markers:     group.Markers,
+		High:        group.High,
+		Low:         group.Low,
+		First:       group.First,
+		Last:        group.Last,
+		Negative:    group.Negative,
+		Axis:        group.DisplayXAxis,
+		Hidden:      group.DisplayHidden,
+		Reverse:     group.RightToLeft,
+		EmptyCells:  group.DisplayEmptyCellsAs,
+		MinAxisType: group.MinAxisType,
+		MaxAxisType: group.MaxAxisType,
+	}
+	if opt.MinAxisType == "custom" {
+		opt.Min = group.ManualMin
+	}
+	if opt.MaxAxisType == "custom" {
+		opt.Max = group.ManualMax
+	}
+	for _, sparkline := range group.Sparklines.Sparkline {
+		opt.Location = append(opt.Location, sparkline.Sqref)
+		opt.Range = append(opt.Range, sparkline.F)
+	}
+	colors := []struct {
+		value *string
+		color *xlsxTabColor
+	}{
+		{&opt.SeriesColor, group.ColorSeries},
+		{&opt.NegativeColor, group.ColorNegative},
+		{&opt.MarkersColor, group.ColorMarkers},
+		{&opt.FirstColor, group.ColorFirst},
+		{&opt.LastColor, group.ColorLast},
+		{&opt.HightColor, group.ColorHigh},
+		{&opt.LowColor, group.ColorLow},
+	}
+	for _, color := range colors {
+		if color.color != nil && color.color.RGB != "" {
+			*color.value = "#" + strings.TrimPrefix(strings.ToUpper(color.color.RGB), "FF")
+		}
+	}
+	for style := 0; style <= 35; style++ {
+		preset := f.addSparklineGroupByStyle(style)
+		presetColors := []*xlsxTabColor{preset.ColorSeries, preset.ColorNegative, preset.ColorMarkers,
+			preset.ColorFirst, preset.ColorLast, preset.ColorHigh, preset.ColorLow}
+		matched := true
+		for i, color := range colors {
+			if *color.value == "" && (color.color == nil || *color.color != *presetColors[i]) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			opt.Style = style
+			break
+		}
+	}
+	return opt
+}
@@ -1,6 +1,7 @@
 package excelize
 
 import (
+	"encoding/xml"
 	"fmt"
 	"path/filepath"
 	"testing"
@@ -239,7 +240,7 @@ func TestAddSparkline(t *testing.T) {
 		Location: []string{"F3"},
 		Range:    []string{"Sheet2!A3:E3"},
 		Type:     "unknown_type",
-	}), `parameter 'Type' must be 'line', 'column' or 'win_loss'`)
+	}), `parameter 'Type' must be 'line', 'column', 'win_loss' or 'winloss'`)
 
 	assert.EqualError(t, f.AddSparkline("Sheet1", &SparklineOption{
 		Location: []string{"F3"},
@@ -271,6 +272,134 @@ func TestAddSparkline(t *testing.T) {
 	}), "XML syntax error on line 6: element <sparklineGroup> closed by </sparklines>")
 }
 
+func TestAddSparklineWinLoss(t *testing.T) {
+	f := prepareSparklineDataset()
+	assert.NoError(t, f.AddSparkline("Sheet1", &SparklineOption{
+		Location:      []string{"A1"},
+		Range:         []string{"Sheet3!A3:J3"},
+		Type:          "winloss",
+		Negative:      true,
+		Markers:       true,
+		High:          true,
+		NegativeColor: "#FF0000",
+		HightColor:    "#00FF00",
+	}))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	decodeExtLst := new(decodeWorksheetExt)
+	assert.NoError(t, xml.Unmarshal([]byte("<extLst>"+ws.ExtLst.Ext+"</extLst>"), decodeExtLst))
+	assert.Contains(t, decodeExtLst.Ext[0].Content, `<x14:sparklineGroup type="stacked" displayEmptyCellsAs="gap" high="true" negative="true">`)
+	assert.Contains(t, decodeExtLst.Ext[0].Content, `<x14:colorNegative rgb="FFFF0000"></x14:colorNegative>`)
+	assert.Contains(t, decodeExtLst.Ext[0].Content, `<x14:colorHigh rgb="FF00FF00"></x14:colorHigh>`)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddSparklineWinLoss.xlsx")))
+}
+
+func TestAddSparklineAxis(t *testing.T) {
+	f := prepareSparklineDataset()
+	assert.NoError(t, f.AddSparkline("Sheet1", &SparklineOption{
+		Location:    []string{"A1", "A2"},
+		Range:       []string{"Sheet3!A1:J1", "Sheet3!A2:J2"},
+		MinAxisType: "custom",
+		Min:         -10,
+		MaxAxisType: "group",
+		Max:         100,
+		DateAxis:    true,
+		DateRange:   "Sheet3!A7:J7",
+	}))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	decodeExtLst := new(decodeWorksheetExt)
+	assert.NoError(t, xml.Unmarshal([]byte("<extLst>"+ws.ExtLst.Ext+"</extLst>"), decodeExtLst))
+	assert.Contains(t, decodeExtLst.Ext[0].Content, `<x14:sparklineGroup manualMin="-10" type="line" dateAxis="true" displayEmptyCellsAs="gap" minAxisType="custom" maxAxisType="group">`)
+	assert.Contains(t, decodeExtLst.Ext[0].Content, `<xm:f>Sheet3!A7:J7</xm:f><x14:sparklines>`)
+	// Test add sparkline with zero and fractional custom axis values
+	assert.NoError(t, f.AddSparkline("Sheet1", &SparklineOption{
+		Location:    []string{"A3"},
+		Range:       []string{"Sheet3!A3:J3"},
+		MinAxisType: "custom",
+		MaxAxisType: "custom",
+		Max:         2.5,
+	}))
+	decodeExtLst = new(decodeWorksheetExt)
+	assert.NoError(t, xml.Unmarshal([]byte("<extLst>"+ws.ExtLst.Ext+"</extLst>"), decodeExtLst))
+	assert.Contains(t, decodeExtLst.Ext[0].Content, `<x14:sparklineGroup manualMax="2.5" manualMin="0" type="line"`)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddSparklineAxis.xlsx")))
+
+	// Test add sparkline with invalid axis options
+	assert.EqualError(t, f.AddSparkline("Sheet1", &SparklineOption{
+		Location:    []string{"A3"},
+		Range:       []string{"Sheet3!A3:J3"},
+		MinAxisType: "unknown",
+	}), `parameter 'MinAxisType' must be 'individual', 'group' or 'custom'`)
+	assert.EqualError(t, f.AddSparkline("Sheet1", &SparklineOption{
+		Location:    []string{"A3"},
+		Range:       []string{"Sheet3!A3:J3"},
+		MaxAxisType: "unknown",
+	}), `parameter 'MaxAxisType' must be 'individual', 'group' or 'custom'`)
+	assert.EqualError(t, f.AddSparkline("Sheet1", &SparklineOption{
+		Location: []string{"A3"},
+		Range:    []string{"Sheet3!A3:J3"},
+		DateAxis: true,
+	}), `parameter 'DateRange' is required when 'DateAxis' is enabled`)
+}
+
+func TestGetSparklines(t *testing.T) {
+	f := prepareSparklineDataset()
+	sparklines, err := f.GetSparklines("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []SparklineOption{}, sparklines)
+
+	expected := []SparklineOption{
+		{
+			Location:   []string{"A1", "A2"},
+			Range:      []string{"Sheet3!A1:J1", "Sheet3!A2:J2"},
+			Type:       "line",
+			Markers:    true,
+			High:       true,
+			Style:      18,
+			EmptyCells: "gap",
+		},
+		{
+			Location:      []string{"A3"},
+			Range:         []string{"Sheet3!A3:J3"},
+			Type:          "win_loss",
+			Negative:      true,
+			Axis:          true,
+			Reverse:       true,
+			NegativeColor: "#FF0000",
+			MinAxisType:   "custom",
+			Min:           -1,
+			MaxAxisType:   "group",
+			DateAxis:      true,
+			DateRange:     "Sheet3!A7:J7",
+			EmptyCells:    "gap",
+		},
+	}
+	for _, opt := range expected {
+		opt := opt
+		assert.NoError(t, f.AddSparkline("Sheet1", &opt))
+	}
+	sparklines, err = f.GetSparklines("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, sparklines)
+	// Test get sparklines after save and reopen the workbook
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGetSparklines.xlsx")))
+	f, err = OpenFile(filepath.Join("test", "TestGetSparklines.xlsx"))
+	assert.NoError(t, err)
+	sparklines, err = f.GetSparklines("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, sparklines)
+	// Test get sparklines on not exists worksheet
+	_, err = f.GetSparklines("SheetN")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+	// Test get sparklines with unsupported charset extension list
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.ExtLst = &xlsxExtLst{Ext: string(MacintoshCyrillicCharset)}
+	_, err = f.GetSparklines("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
 func TestAppendSparkline(t *testing.T) {
 	// Test unsupported charset.
 	f := NewFile()
@@ -26,16 +26,14 @@ import (
 
 // StreamWriter defined the type of stream writer.
 type StreamWriter struct {
-	File            *File
-	Sheet           string
-	SheetID         int
-	sheetWritten    bool
-	cols            string
-	worksheet       *xlsxWorksheet
-	rawData         bufferedWriter
-	mergeCellsCount int
-	mergeCells      string
-	tableParts      string
+	File         *File
+	Sheet        string
+	SheetID      int
+	sheetWritten bool
+	cols         string
+	worksheet    *xlsxWorksheet
+	rawData      bufferedWriter
+	tableParts   string
 }
 
 // NewStreamWriter return stream writer struct by given worksheet name for
@@ -84,12 +82,21 @@ type StreamWriter struct {
 //        excelize.Cell{Value: 2},
 //        excelize.Cell{Formula: "SUM(A1,B1)"}});
 //
+// Set cell formula with the cached result for a worksheet with stream writer:
+//
+//    err := streamWriter.SetRow("C1", []interface{}{
+//        excelize.Cell{Formula: "SUM(A1,B1)", Value: 3}});
+//
 // Set cell value and rows style for a worksheet with stream writer:
 //
 //    err := streamWriter.SetRow("A1", []interface{}{
 //        excelize.Cell{Value: 1}},
 //        excelize.RowOpts{StyleID: styleID, Height: 20, Hidden: false});
 //
+// Merge cells for a section header with stream writer:
+//
+//    err := streamWriter.MergeCell("A1", "D1")
+//
 func (f *File) NewStreamWriter(sheet string) (*StreamWriter, error) {
 	sheetID := f.getSheetID(sheet)
 	if sheetID == -1 {
@@ -113,7 +120,6 @@ func (f *File) NewStreamWriter(sheet string) (*StreamWriter, error) {
 	f.streams[sheetPath] = sw
 
 	_, _ = sw.rawData.WriteString(XMLHeader + `<worksheet` + templateNamespaceIDMap)
-	bulkAppendFields(&sw.rawData, sw.worksheet, 2, 5)
 	return sw, err
 }
 
@@ -288,7 +294,9 @@ func getRowElement(token xml.Token, hrow int) (startElement xml.StartElement, ok
 }
 
 // Cell can be used directly in StreamWriter.SetRow to specify a style and
-// a value.
+// a value. If the Formula is specified, the Value will be written as the
+// cached result of the formula, so the spreadsheet application can display
+// it without recalculating.
 type Cell struct {
 	StyleID int
 	Formula string
@@ -298,9 +306,10 @@ type Cell struct {
 // RowOpts define the options for the set row, it can be used directly in
 // StreamWriter.SetRow to specify the style and properties of the row.
 type RowOpts struct {
-	Height  float64
-	Hidden  bool
-	StyleID int
+	Height       float64
+	Hidden       bool
+	StyleID      int
+	OutlineLevel int
 }
 
 // SetRow writes an array to stream rows by giving a worksheet name, starting
@@ -308,23 +317,22 @@ type RowOpts struct {
 // 'Flush' method to end the streaming writing process.
 //
 // As a special case, if Cell is used as a value, then the Cell.StyleID will be
-// applied to that cell.
+// applied to that cell. The style of the row specified by RowOpts.StyleID
+// will be applied to the cells of the row which have no style.
 func (sw *StreamWriter) SetRow(axis string, values []interface{}, opts ...RowOpts) error {
 	col, row, err := CellNameToCoordinates(axis)
 	if err != nil {
 		return err
 	}
-	if !sw.sheetWritten {
-		if len(sw.cols) > 0 {
-			_, _ = sw.rawData.WriteString("<cols>" + sw.cols + "</cols>")
-		}
-		_, _ = sw.rawData.WriteString(`<sheetData>`)
-		sw.sheetWritten = true
-	}
+	sw.writeSheetData()
 	attrs, err := marshalRowAttrs(opts...)
 	if err != nil {
 		return err
 	}
+	var rowStyleID int
+	for _, opt := range opts {
+		rowStyleID = opt.StyleID
+	}
 	fmt.Fprintf(&sw.rawData, `<row r="%d"%s>`, row, attrs)
 	for i, val := range values {
 		axis, err := CoordinatesToCellName(col+i, row)
@@ -341,6 +349,13 @@ func (sw *StreamWriter) SetRow(axis string, values []interface{}, opts ...RowOpt
 			val = v.Value
 			setCellFormula(&c, v.Formula)
 		}
+		if c.S == 0 {
+			c.S = rowStyleID
+		}
+		if c.F != nil && val == nil {
+			writeCell(&sw.rawData, c)
+			continue
+		}
 		if err = setCellValFunc(&c, val); err != nil {
 			_, _ = sw.rawData.WriteString(`</row>`)
 			return err
@@ -364,6 +379,14 @@ func marshalRowAttrs(opts ...RowOpts) (attrs string, err error) {
 		err = ErrMaxRowHeight
 		return
 	}
+	if opt.StyleID < 0 {
+		err = newInvalidStyleID(opt.StyleID)
+		return
+	}
+	if opt.OutlineLevel < 0 || opt.OutlineLevel > 7 {
+		err = ErrOutlineLevel
+		return
+	}
 	if opt.StyleID > 0 {
 		attrs += fmt.Sprintf(` s="%d" customFormat="true"`, opt.StyleID)
 	}
@@ -373,6 +396,9 @@ func marshalRowAttrs(opts ...RowOpts) (attrs string, err error) {
 	if opt.Hidden {
 		attrs += ` hidden="true"`
 	}
+	if opt.OutlineLevel > 0 {
+		attrs += fmt.Sprintf(` outlineLevel="%d"`, opt.OutlineLevel)
+	}
 	return
 }
 
@@ -403,16 +429,46 @@ func (sw *StreamWriter) SetColWidth(min, max int, width float64) error {
 	return nil
 }
 
+// SetPanes provides a function to create and remove freeze panes and split
+// panes for the StreamWriter by given panes format set, the format set is
+// the same as the SetPanes function of the File. Note that you must call the
+// 'SetPanes' function before the 'SetRow' function. For example, freeze the
+// first row of the worksheet:
+//
+//    err := streamWriter.SetPanes(`{"freeze":true,"split":false,"x_split":0,"y_split":1,"top_left_cell":"A2","active_pane":"bottomLeft","panes":[{"sqref":"A2","active_cell":"A2","pane":"bottomLeft"}]}`)
+//
+func (sw *StreamWriter) SetPanes(panes string) error {
+	if sw.sheetWritten {
+		return ErrStreamSetPanes
+	}
+	sw.worksheet.setPanes(panes)
+	return nil
+}
+
 // MergeCell provides a function to merge cells by a given coordinate area for
-// the StreamWriter. Don't create a merged cell that overlaps with another
-// existing merged cell.
+// the StreamWriter. The merged cells will be buffered and written into the
+// worksheet on calling the 'Flush' method, so it can be called at any time
+// before 'Flush'. An error will be returned if the merged cell overlaps with
+// another existing merged cell.
 func (sw *StreamWriter) MergeCell(hcell, vcell string) error {
-	_, err := areaRangeToCoordinates(hcell, vcell)
+	rect, err := areaRangeToCoordinates(hcell, vcell)
 	if err != nil {
 		return err
 	}
-	sw.mergeCellsCount++
-	sw.mergeCells += fmt.Sprintf(`<mergeCell ref="%s:%s"/>`, hcell, vcell)
+	// Correct the coordinate area, such correct C1:B3 to B1:C3.
+	_ = sortCoordinates(rect)
+
+	hcell, _ = CoordinatesToCellName(rect[0], rect[1])
+	vcell, _ = CoordinatesToCellName(rect[2], rect[3])
+	ref := hcell + ":" + vcell
+	if err = checkMergeCellOverlap(sw.worksheet, ref, rect); err != nil {
+		return err
+	}
+	if sw.worksheet.MergeCells == nil {
+		sw.worksheet.MergeCells = &xlsxMergeCells{}
+	}
+	sw.worksheet.MergeCells.Cells = append(sw.worksheet.MergeCells.Cells, &xlsxMergeCell{Ref: ref, rect: rect})
+	sw.worksheet.MergeCells.Count = len(sw.worksheet.MergeCells.Cells)
 	return nil
 }
 
@@ -504,19 +560,25 @@ func writeCell(buf *bufferedWriter, c xlsxC) {
 	_, _ = buf.WriteString(`</c>`)
 }
 
+// writeSheetData provides a function to write the worksheet properties, sheet
+// views, columns and the beginning of the sheet data before the first row.
+func (sw *StreamWriter) writeSheetData() {
+	if sw.sheetWritten {
+		return
+	}
+	bulkAppendFields(&sw.rawData, sw.worksheet, 2, 5)
+	if len(sw.cols) > 0 {
+		_, _ = sw.rawData.WriteString("<cols>" + sw.cols + "</cols>")
+	}
+	_, _ = sw.rawData.WriteString(`<sheetData>`)
+	sw.sheetWritten = true
+}
+
 // Flush ending the streaming writing process.
 func (sw *StreamWriter) Flush() error {
-	if !sw.sheetWritten {
-		_, _ = sw.rawData.WriteString(`<sheetData>`)
-		sw.sheetWritten = true
-	}
+	sw.writeSheetData()
 	_, _ = sw.rawData.WriteString(`</sheetData>`)
-	bulkAppendFields(&sw.rawData, sw.worksheet, 8, 15)
-	if sw.mergeCellsCount > 0 {
-		sw.mergeCells = fmt.Sprintf(`<mergeCells count="%d">%s</mergeCells>`, sw.mergeCellsCount, sw.mergeCells)
-	}
-	_, _ = sw.rawData.WriteString(sw.mergeCells)
-	bulkAppendFields(&sw.rawData, sw.worksheet, 17, 38)
+	bulkAppendFields(&sw.rawData, sw.worksheet, 8, 38)
 	_, _ = sw.rawData.WriteString(sw.tableParts)
 	bulkAppendFields(&sw.rawData, sw.worksheet, 40, 40)
 	_, _ = sw.rawData.WriteString(`</worksheet>`)
@@ -533,13 +595,15 @@ func (sw *StreamWriter) Flush() error {
 }
 
 // bulkAppendFields bulk-appends fields in a worksheet by specified field
-// names order range.
+// names order range. The fields are encoded with the element name in the
+// struct tag of the worksheet.
 func bulkAppendFields(w io.Writer, ws *xlsxWorksheet, from, to int) {
 	s := reflect.ValueOf(ws).Elem()
 	enc := xml.NewEncoder(w)
 	for i := 0; i < s.NumField(); i++ {
 		if from <= i && i <= to {
-			_ = enc.Encode(s.Field(i).Interface())
+			name := strings.Split(s.Type().Field(i).Tag.Get("xml"), ",")[0]
+			_ = enc.EncodeElement(s.Field(i).Interface(), xml.StartElement{Name: xml.Name{Local: name}})
 		}
 	}
 }
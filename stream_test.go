@@ -1,6 +1,7 @@
 package excelize
 
 import (
+	"bytes"
 	"encoding/xml"
 	"fmt"
 	"io/ioutil"
@@ -144,6 +145,37 @@ func TestStreamSetColWidth(t *testing.T) {
 	assert.EqualError(t, streamWriter.SetColWidth(2, 3, 20), ErrStreamSetColWidth.Error())
 }
 
+func TestStreamSetPanes(t *testing.T) {
+	file := NewFile()
+	streamWriter, err := file.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, streamWriter.SetColWidth(1, 2, 30))
+	assert.NoError(t, streamWriter.SetPanes(`{"freeze":true,"split":false,"x_split":0,"y_split":1,"top_left_cell":"A2","active_pane":"bottomLeft","panes":[{"sqref":"A2","active_cell":"A2","pane":"bottomLeft"}]}`))
+	assert.NoError(t, streamWriter.SetRow("A1", []interface{}{"Header"}))
+	assert.NoError(t, streamWriter.SetRow("A2", []interface{}{"Data"}, RowOpts{OutlineLevel: 1}))
+	assert.EqualError(t, streamWriter.SetRow("A3", []interface{}{"Data"}, RowOpts{OutlineLevel: 8}), ErrOutlineLevel.Error())
+	assert.EqualError(t, streamWriter.SetPanes(`{"freeze":false,"split":false}`), ErrStreamSetPanes.Error())
+	assert.NoError(t, streamWriter.Flush())
+	assert.NoError(t, file.SaveAs(filepath.Join("test", "TestStreamSetPanes.xlsx")))
+
+	file, err = OpenFile(filepath.Join("test", "TestStreamSetPanes.xlsx"))
+	assert.NoError(t, err)
+	ws, err := file.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	pane := ws.SheetViews.SheetView[0].Pane
+	if assert.NotNil(t, pane) {
+		assert.Equal(t, "frozen", pane.State)
+		assert.Equal(t, "A2", pane.TopLeftCell)
+	}
+	width, err := file.GetColWidth("Sheet1", "B")
+	assert.NoError(t, err)
+	assert.Equal(t, 30.0, width)
+	level, err := file.GetRowOutlineLevel("Sheet1", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(1), level)
+	assert.NoError(t, file.Close())
+}
+
 func TestStreamTable(t *testing.T) {
 	file := NewFile()
 	streamWriter, err := file.NewStreamWriter("Sheet1")
@@ -183,11 +215,120 @@ func TestStreamMergeCells(t *testing.T) {
 	streamWriter, err := file.NewStreamWriter("Sheet1")
 	assert.NoError(t, err)
 	assert.NoError(t, streamWriter.MergeCell("A1", "D1"))
+	assert.NoError(t, streamWriter.MergeCell("C3", "A2"))
 	// Test merge cells with illegal cell coordinates.
 	assert.EqualError(t, streamWriter.MergeCell("A", "D1"), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+	// Test merge cells overlaps with the existing merged cells.
+	assert.EqualError(t, streamWriter.MergeCell("B1", "B3"), newMergeCellOverlapError("B1:B3", "A1:D1").Error())
+	assert.NoError(t, streamWriter.SetRow("A1", []interface{}{"Header"}))
 	assert.NoError(t, streamWriter.Flush())
 	// Save spreadsheet by the given path.
 	assert.NoError(t, file.SaveAs(filepath.Join("test", "TestStreamMergeCells.xlsx")))
+
+	file, err = OpenFile(filepath.Join("test", "TestStreamMergeCells.xlsx"))
+	assert.NoError(t, err)
+	mergeCells, err := file.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []MergeCell{{"A1:D1", "Header"}, {"A2:C3", ""}}, mergeCells)
+	assert.NoError(t, file.Close())
+
+	// Test stream writer keeps the merged cells of the worksheet.
+	file = NewFile()
+	assert.NoError(t, file.MergeCell("Sheet1", "A1", "B1"))
+	streamWriter, err = file.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.EqualError(t, streamWriter.MergeCell("A1", "A2"), newMergeCellOverlapError("A1:A2", "A1:B1").Error())
+	assert.NoError(t, streamWriter.MergeCell("A2", "B2"))
+	assert.NoError(t, streamWriter.Flush())
+	mergeCells, err = file.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 2)
+}
+
+func TestStreamFlushElementsOrder(t *testing.T) {
+	file := NewFile()
+	ws, err := file.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.ExtLst = &xlsxExtLst{Ext: `<ext uri="{05C60535-1F16-4fd2-B633-F4F36F0B64E0}"></ext>`}
+	streamWriter, err := file.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, streamWriter.SetRow("A1", []interface{}{"A", "B"}))
+	assert.NoError(t, streamWriter.AddTable("A1", "B2", ``))
+	assert.NoError(t, streamWriter.MergeCell("D1", "E1"))
+	assert.NoError(t, streamWriter.Flush())
+	reader, err := streamWriter.rawData.Reader()
+	assert.NoError(t, err)
+	data, err := ioutil.ReadAll(reader)
+	assert.NoError(t, err)
+	// Test the elements are written in the order of the worksheet schema.
+	var last int
+	for _, element := range []string{"<sheetData>", "</sheetData>", "<mergeCells", "<tableParts", "<extLst>", "</worksheet>"} {
+		idx := bytes.Index(data, []byte(element))
+		assert.Greater(t, idx, last, element)
+		last = idx
+	}
+	assert.NotContains(t, string(data), "xlsxExtLst")
+	assert.NoError(t, file.SaveAs(filepath.Join("test", "TestStreamFlushElementsOrder.xlsx")))
+}
+
+func TestStreamSetRowStyle(t *testing.T) {
+	file := NewFile()
+	styleID, err := file.NewStyle(`{"font":{"bold":true}}`)
+	assert.NoError(t, err)
+	cellStyleID, err := file.NewStyle(`{"font":{"italic":true}}`)
+	assert.NoError(t, err)
+	streamWriter, err := file.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, streamWriter.SetRow("A1", []interface{}{"Section", Cell{StyleID: cellStyleID, Value: 1}}, RowOpts{StyleID: styleID}))
+	assert.NoError(t, streamWriter.SetRow("A2", []interface{}{"Data"}))
+	assert.EqualError(t, streamWriter.SetRow("A3", []interface{}{"Data"}, RowOpts{StyleID: -1}), newInvalidStyleID(-1).Error())
+	assert.NoError(t, streamWriter.Flush())
+	for cell, expected := range map[string]int{"A1": styleID, "B1": cellStyleID, "A2": 0} {
+		style, err := file.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, style)
+	}
+}
+
+func TestStreamSetRowWithFormula(t *testing.T) {
+	file := NewFile()
+	streamWriter, err := file.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, streamWriter.SetRow("A1", []interface{}{
+		1, 2,
+		Cell{Formula: "SUM(A1,B1)", Value: 3},
+		&Cell{Formula: "A1&B1", Value: "12"},
+		Cell{Formula: "A1>B1", Value: false},
+		Cell{Formula: "A1*B1"},
+	}))
+	assert.NoError(t, streamWriter.Flush())
+	assert.NoError(t, file.SaveAs(filepath.Join("test", "TestStreamSetRowWithFormula.xlsx")))
+
+	file, err = OpenFile(filepath.Join("test", "TestStreamSetRowWithFormula.xlsx"))
+	assert.NoError(t, err)
+	ws, err := file.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	for idx, expected := range []xlsxC{
+		{R: "C1", F: &xlsxF{Content: "SUM(A1,B1)"}, V: "3"},
+		{R: "D1", T: "str", F: &xlsxF{Content: "A1&B1"}, V: "12"},
+		{R: "E1", T: "b", F: &xlsxF{Content: "A1>B1"}, V: "0"},
+		{R: "F1", F: &xlsxF{Content: "A1*B1"}},
+	} {
+		c := ws.SheetData.Row[0].C[idx+2]
+		assert.Equal(t, expected.R, c.R)
+		assert.Equal(t, expected.T, c.T)
+		assert.Equal(t, expected.F.Content, c.F.Content)
+		assert.Equal(t, expected.V, c.V)
+	}
+	for cell, expected := range map[string]string{"C1": "3", "D1": "12", "E1": "0", "F1": ""} {
+		val, err := file.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val)
+	}
+	formula, err := file.GetCellFormula("Sheet1", "C1")
+	assert.NoError(t, err)
+	assert.Equal(t, "SUM(A1,B1)", formula)
+	assert.NoError(t, file.Close())
 }
 
 func TestNewStreamWriter(t *testing.T) {
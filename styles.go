@@ -809,9 +809,71 @@ var validType = map[string]string{
 	"2_color_scale": "2_color_scale",
 	"3_color_scale": "3_color_scale",
 	"data_bar":      "dataBar",
+	"icon_set":      "iconSet",
 	"formula":       "expression",
 }
 
+// condFmtIconSets defined the list of valid icon set styles of the conditional
+// formatting, the value indicates whether the icon set style is only
+// available in the worksheet extension list.
+var condFmtIconSets = map[string]bool{
+	"3Arrows":         false,
+	"3ArrowsGray":     false,
+	"3Flags":          false,
+	"3Signs":          false,
+	"3Stars":          true,
+	"3Symbols":        false,
+	"3Symbols2":       false,
+	"3TrafficLights1": false,
+	"3TrafficLights2": false,
+	"3Triangles":      true,
+	"4Arrows":         false,
+	"4ArrowsGray":     false,
+	"4Rating":         false,
+	"4RedToBlack":     false,
+	"4TrafficLights":  false,
+	"5Arrows":         false,
+	"5ArrowsGray":     false,
+	"5Boxes":          true,
+	"5Quarters":       false,
+	"5Rating":         false,
+}
+
+// condFmtValueType defined the list of valid value types of the icon set
+// thresholds.
+var condFmtValueType = map[string]string{
+	"percent":    "percent",
+	"percentile": "percentile",
+	"number":     "num",
+	"num":        "num",
+	"formula":    "formula",
+}
+
+// operatorType defined the list of valid operator types.
+var operatorType = map[string]string{
+	"lastMonth":          "last month",
+	"between":            "between",
+	"notContains":        "not containing",
+	"lastWeek":           "last week",
+	"notBetween":         "not between",
+	"greaterThan":        "greater than",
+	"lessThanOrEqual":    "less than or equal to",
+	"beginsWith":         "begins with",
+	"thisMonth":          "this month",
+	"thisWeek":           "this week",
+	"equal":              "equal to",
+	"endsWith":           "ends with",
+	"greaterThanOrEqual": "greater than or equal to",
+	"lessThan":           "less than",
+	"notEqual":           "not equal to",
+	"containsText":       "containing",
+	"today":              "today",
+	"yesterday":          "yesterday",
+	"last7Days":          "last 7 days",
+	"continueWeek":       "continue week",
+	"continueMonth":      "continue month",
+}
+
 // criteriaType defined the list of valid criteria types.
 var criteriaType = map[string]string{
 	"between":                  "between",
@@ -1126,8 +1188,16 @@ func parseFormatStyleSet(style interface{}) (*Style, error) {
 			return &fs, ErrFontSize
 		}
 	}
-	if fs.CustomNumFmt != nil && len(*fs.CustomNumFmt) == 0 {
-		err = ErrCustomNumFmt
+	if fs.CustomNumFmt != nil {
+		if len(*fs.CustomNumFmt) == 0 {
+			return &fs, ErrCustomNumFmt
+		}
+		if err := validateNumFmtLocale(*fs.CustomNumFmt); err != nil {
+			return &fs, err
+		}
+		if fs.NumFmtLocale {
+			fs.CustomNumFmt = setNumFmtLangLocale(*fs.CustomNumFmt, fs.Lang)
+		}
 	}
 	return &fs, err
 }
@@ -1983,8 +2053,15 @@ func parseFormatStyleSet(style interface{}) (*Style, error) {
 //     633   | ZWN
 //     634   | ZWR
 //
-// Excelize support set custom number format for cell. For example, set number
-// as date type in Uruguay (Spanish) format for Sheet1!A6:
+// Excelize support set custom number format for cell. The locale code in the
+// custom number format, such as [$-407] or [$€-407], will be validated and an
+// error will be returned if the locale code is invalid. The LocaleNumFmt and
+// CurrencyNumFmt functions can be used to generate the custom number format
+// with locale code by given language tag. If the NumFmtLocale is enabled, the
+// custom number format is a date or time format without locale code and the
+// Lang is a supported language tag of the LocaleNumFmt, the locale code of the
+// language will be added to the number format. For example, set number as
+// date type in Uruguay (Spanish) format for Sheet1!A6:
 //
 //    f := excelize.NewFile()
 //    f.SetCellValue("Sheet1", "A6", 42920.5)
@@ -2051,6 +2128,168 @@ func (f *File) NewStyle(style interface{}) (int, error) {
 	return cellXfsID, nil
 }
 
+// NewStyleWith provides a function to create the style for cells by given
+// style settings. Unlike the NewStyle, the settings will be validated before
+// creating the style, and an error will be returned if any of the settings is
+// unknown or out of range, instead of ignored. For example, create a style
+// with bold font and solid fill:
+//
+//    style, err := f.NewStyleWith(&excelize.Style{
+//        Font: &excelize.Font{Bold: true, Color: "#FFFFFF"},
+//        Fill: excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"#1F4E78"}},
+//    })
+//
+func (f *File) NewStyleWith(style *Style) (int, error) {
+	if style == nil {
+		return 0, ErrParameterRequired
+	}
+	if err := validateStyle(style); err != nil {
+		return 0, err
+	}
+	return f.NewStyle(style)
+}
+
+// styleColorRe defined the regular expression of the RGB color code in the
+// style settings.
+var styleColorRe = regexp.MustCompile(`^#?[0-9A-Fa-f]{6}$`)
+
+// validateStyleColor provides a function to validate the RGB color code of
+// the style settings, the empty color is allowed.
+func validateStyleColor(field, color string) error {
+	if color != "" && !styleColorRe.MatchString(color) {
+		return newInvalidStyleFieldError(field, color)
+	}
+	return nil
+}
+
+// validateStyle provides a function to validate the style settings used by
+// the NewStyleWith.
+func validateStyle(style *Style) error {
+	validators := []func(*Style) error{
+		validateStyleBorder, validateStyleFill, validateStyleFont,
+		validateStyleAlignment, validateStyleNumFmt,
+	}
+	for _, validator := range validators {
+		if err := validator(style); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateStyleBorder provides a function to validate the border settings.
+func validateStyleBorder(style *Style) error {
+	for _, border := range style.Border {
+		if inStrSlice([]string{"left", "right", "top", "bottom", "diagonalUp", "diagonalDown"}, border.Type) == -1 {
+			return newInvalidStyleFieldError("border type", border.Type)
+		}
+		if border.Style < 0 || border.Style >= len(styleBorders) {
+			return newInvalidStyleFieldError("border style", border.Style)
+		}
+		if err := validateStyleColor("border color", border.Color); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateStyleFill provides a function to validate the fill settings.
+func validateStyleFill(style *Style) error {
+	switch style.Fill.Type {
+	case "":
+		return nil
+	case "gradient":
+		if len(style.Fill.Color) != 2 {
+			return newInvalidStyleFieldError("gradient fill colors count", len(style.Fill.Color))
+		}
+		if style.Fill.Shading < 0 || style.Fill.Shading > 5 {
+			return newInvalidStyleFieldError("fill shading", style.Fill.Shading)
+		}
+	case "pattern":
+		if style.Fill.Pattern < 0 || style.Fill.Pattern >= len(styleFillPatterns) {
+			return newInvalidStyleFieldError("fill pattern", style.Fill.Pattern)
+		}
+		if len(style.Fill.Color) < 1 {
+			return newInvalidStyleFieldError("pattern fill colors count", len(style.Fill.Color))
+		}
+	default:
+		return newInvalidStyleFieldError("fill type", style.Fill.Type)
+	}
+	for _, color := range style.Fill.Color {
+		if err := validateStyleColor("fill color", color); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateStyleFont provides a function to validate the font settings.
+func validateStyleFont(style *Style) error {
+	if style.Font == nil {
+		return nil
+	}
+	if len(style.Font.Family) > MaxFontFamilyLength {
+		return ErrFontLength
+	}
+	if style.Font.Size < 0 || style.Font.Size > MaxFontSize {
+		return ErrFontSize
+	}
+	if inStrSlice([]string{"", "single", "double"}, style.Font.Underline) == -1 {
+		return newInvalidStyleFieldError("font underline", style.Font.Underline)
+	}
+	if inStrSlice([]string{"", "baseline", "superscript", "subscript"}, style.Font.VertAlign) == -1 {
+		return newInvalidStyleFieldError("font vertical alignment", style.Font.VertAlign)
+	}
+	return validateStyleColor("font color", style.Font.Color)
+}
+
+// validateStyleAlignment provides a function to validate the alignment
+// settings.
+func validateStyleAlignment(style *Style) error {
+	alignment := style.Alignment
+	if alignment == nil {
+		return nil
+	}
+	if inStrSlice([]string{"", "general", "left", "center", "right", "fill", "justify", "centerContinuous", "distributed"}, alignment.Horizontal) == -1 {
+		return newInvalidStyleFieldError("horizontal alignment", alignment.Horizontal)
+	}
+	if inStrSlice([]string{"", "top", "center", "bottom", "justify", "distributed"}, alignment.Vertical) == -1 {
+		return newInvalidStyleFieldError("vertical alignment", alignment.Vertical)
+	}
+	if alignment.Indent < 0 || alignment.Indent > 250 {
+		return newInvalidStyleFieldError("indent", alignment.Indent)
+	}
+	if alignment.ReadingOrder > 2 {
+		return newInvalidStyleFieldError("reading order", alignment.ReadingOrder)
+	}
+	if (alignment.TextRotation < 0 || alignment.TextRotation > 180) && alignment.TextRotation != 255 {
+		return newInvalidStyleFieldError("text rotation", alignment.TextRotation)
+	}
+	return nil
+}
+
+// validateStyleNumFmt provides a function to validate the number format
+// settings, the number format ID will be ignored if the custom number format
+// is specified.
+func validateStyleNumFmt(style *Style) error {
+	if style.DecimalPlaces < 0 || style.DecimalPlaces > 30 {
+		return newInvalidStyleFieldError("decimal places", style.DecimalPlaces)
+	}
+	if style.CustomNumFmt != nil {
+		return nil
+	}
+	if _, ok := builtInNumFmt[style.NumFmt]; ok {
+		return nil
+	}
+	if _, ok := currencyNumFmt[style.NumFmt]; ok {
+		return nil
+	}
+	if _, ok := langNumFmt[style.Lang][style.NumFmt]; ok {
+		return nil
+	}
+	return newInvalidStyleFieldError("number format", style.NumFmt)
+}
+
 var getXfIDFuncs = map[string]func(int, xlsxXf, *Style) bool{
 	"numFmt": func(numFmtID int, xf xlsxXf, style *Style) bool {
 		if style.CustomNumFmt == nil && numFmtID == -1 {
@@ -2118,6 +2357,236 @@ func (f *File) getStyleID(ss *xlsxStyleSheet, style *Style) (styleID int) {
 	return
 }
 
+// GetStyle provides a function to get the style settings by given style ID,
+// the settings are reconstructed from the cell formats, fonts, fills, borders
+// and number formats of the workbook, an error will be returned if the style
+// ID or the index of any components referenced by the style is out of range.
+// For example, copy the style of the cell Sheet1!A1 with a bold font:
+//
+//    styleID, err := f.GetCellStyle("Sheet1", "A1")
+//    if err != nil {
+//        fmt.Println(err)
+//        return
+//    }
+//    style, err := f.GetStyle(styleID)
+//    if err != nil {
+//        fmt.Println(err)
+//        return
+//    }
+//    if style.Font == nil {
+//        style.Font = &excelize.Font{}
+//    }
+//    style.Font.Bold = true
+//    boldStyleID, err := f.NewStyle(style)
+//
+// Note that the theme and indexed colors will be converted to the RGB color
+// code.
+func (f *File) GetStyle(styleID int) (*Style, error) {
+	s := f.stylesReader()
+	s.Lock()
+	defer s.Unlock()
+	if s.CellXfs == nil || styleID < 0 || styleID >= len(s.CellXfs.Xf) {
+		return nil, newStyleIndexOutOfRangeError("style", styleID)
+	}
+	xf, style := s.CellXfs.Xf[styleID], &Style{}
+	if xf.NumFmtID != nil {
+		style.NumFmt = *xf.NumFmtID
+		if s.NumFmts != nil {
+			for _, numFmt := range s.NumFmts.NumFmt {
+				if numFmt.NumFmtID == *xf.NumFmtID {
+					style.NumFmt, style.CustomNumFmt = 0, stringPtr(numFmt.FormatCode)
+					break
+				}
+			}
+		}
+	}
+	if fontID, ok := getStyleComponentID(xf.FontID, xf.ApplyFont); ok {
+		if s.Fonts == nil || fontID < 0 || fontID >= len(s.Fonts.Font) {
+			return nil, newStyleIndexOutOfRangeError("font", fontID)
+		}
+		style.Font = f.getStyleFont(s.Fonts.Font[fontID])
+	}
+	if fillID, ok := getStyleComponentID(xf.FillID, xf.ApplyFill); ok {
+		if s.Fills == nil || fillID < 0 || fillID >= len(s.Fills.Fill) {
+			return nil, newStyleIndexOutOfRangeError("fill", fillID)
+		}
+		style.Fill = f.getStyleFill(s.Fills.Fill[fillID])
+	}
+	if borderID, ok := getStyleComponentID(xf.BorderID, xf.ApplyBorder); ok {
+		if s.Borders == nil || borderID < 0 || borderID >= len(s.Borders.Border) {
+			return nil, newStyleIndexOutOfRangeError("border", borderID)
+		}
+		style.Border = f.getStyleBorders(s.Borders.Border[borderID])
+	}
+	if xf.Alignment != nil && (xf.ApplyAlignment == nil || *xf.ApplyAlignment) {
+		style.Alignment = &Alignment{
+			Horizontal:      xf.Alignment.Horizontal,
+			Indent:          xf.Alignment.Indent,
+			JustifyLastLine: xf.Alignment.JustifyLastLine,
+			ReadingOrder:    xf.Alignment.ReadingOrder,
+			RelativeIndent:  xf.Alignment.RelativeIndent,
+			ShrinkToFit:     xf.Alignment.ShrinkToFit,
+			TextRotation:    xf.Alignment.TextRotation,
+			Vertical:        xf.Alignment.Vertical,
+			WrapText:        xf.Alignment.WrapText,
+		}
+	}
+	if xf.Protection != nil {
+		style.Protection = &Protection{Locked: true}
+		if xf.Protection.Hidden != nil {
+			style.Protection.Hidden = *xf.Protection.Hidden
+		}
+		if xf.Protection.Locked != nil {
+			style.Protection.Locked = *xf.Protection.Locked
+		}
+	}
+	return style, nil
+}
+
+// getStyleComponentID provides a function to get the index of the font, fill
+// or border referenced by the cell format. The default component with index
+// 0 will be ignored unless it is applied explicitly.
+func getStyleComponentID(ID *int, apply *bool) (int, bool) {
+	if ID == nil {
+		return 0, false
+	}
+	return *ID, *ID != 0 || (apply != nil && *apply)
+}
+
+// getStyleColor provides a function to convert the color of the styles part
+// to the RGB color code, returns empty string if the color is not specified
+// or is automatic.
+func (f *File) getStyleColor(color *xlsxColor) string {
+	if color == nil || color.Auto {
+		return ""
+	}
+	if color.RGB != "" {
+		rgb := strings.ToUpper(color.RGB)
+		if len(rgb) == 8 {
+			rgb = rgb[2:]
+		}
+		return "#" + rgb
+	}
+	if color.Theme != nil {
+		clrScheme := f.themeReader().ThemeElements.ClrScheme.Children
+		// The first two pairs of the theme colors are swapped, the index 0
+		// and 1 are refer to the lt1 and dk1 of the color scheme.
+		idx := *color.Theme
+		if idx < 4 {
+			idx ^= 1
+		}
+		if idx < 0 || idx >= len(clrScheme) {
+			return ""
+		}
+		var baseColor string
+		if clr := clrScheme[idx]; clr.SrgbClr != nil && clr.SrgbClr.Val != nil {
+			baseColor = *clr.SrgbClr.Val
+		} else if clr.SysClr != nil {
+			baseColor = clr.SysClr.LastClr
+		}
+		if len(baseColor) != 6 {
+			return ""
+		}
+		return "#" + strings.TrimPrefix(ThemeColor(baseColor, color.Tint), "FF")
+	}
+	if color.Indexed > 0 && color.Indexed < len(indexedColors) {
+		return "#" + indexedColors[color.Indexed]
+	}
+	return ""
+}
+
+// getStyleFont provides a function to convert the font of the styles part
+// to the font settings.
+func (f *File) getStyleFont(fnt *xlsxFont) *Font {
+	isTrue := func(val *attrValBool) bool {
+		return val != nil && (val.Val == nil || *val.Val)
+	}
+	font := &Font{
+		Bold:   isTrue(fnt.B),
+		Italic: isTrue(fnt.I),
+		Strike: isTrue(fnt.Strike),
+		Color:  f.getStyleColor(fnt.Color),
+	}
+	if fnt.U != nil {
+		font.Underline = "single"
+		if fnt.U.Val != nil {
+			font.Underline = *fnt.U.Val
+		}
+	}
+	if fnt.VertAlign != nil && fnt.VertAlign.Val != nil {
+		font.VertAlign = *fnt.VertAlign.Val
+	}
+	if fnt.Sz != nil && fnt.Sz.Val != nil {
+		font.Size = *fnt.Sz.Val
+	}
+	if fnt.Name != nil && fnt.Name.Val != nil {
+		font.Family = *fnt.Name.Val
+	}
+	return font
+}
+
+// getStyleFill provides a function to convert the fill of the styles part to
+// the fill settings, the foreground color of the pattern fill will be used as
+// the fill color.
+func (f *File) getStyleFill(fill *xlsxFill) Fill {
+	var style Fill
+	if fill.GradientFill != nil {
+		style.Type = "gradient"
+		for _, stop := range fill.GradientFill.Stop {
+			style.Color = append(style.Color, f.getStyleColor(&stop.Color))
+		}
+		switch fill.GradientFill.Type {
+		case "path":
+			style.Shading = 4
+			if fill.GradientFill.Top == 0.5 {
+				style.Shading = 5
+			}
+		default:
+			if idx := inFloat64Slice(styleFillVariants, fill.GradientFill.Degree); idx != -1 {
+				style.Shading = idx
+			}
+		}
+		return style
+	}
+	if fill.PatternFill != nil {
+		idx := inStrSlice(styleFillPatterns, fill.PatternFill.PatternType)
+		if idx <= 0 {
+			return style
+		}
+		style.Type, style.Pattern = "pattern", idx
+		if color := f.getStyleColor(fill.PatternFill.FgColor); color != "" {
+			style.Color = []string{color}
+		}
+	}
+	return style
+}
+
+// getStyleBorders provides a function to convert the border of the styles
+// part to the borders settings.
+func (f *File) getStyleBorders(border *xlsxBorder) []Border {
+	var borders []Border
+	for _, line := range []struct {
+		Type string
+		Line xlsxLine
+		Show bool
+	}{
+		{"left", border.Left, true},
+		{"right", border.Right, true},
+		{"top", border.Top, true},
+		{"bottom", border.Bottom, true},
+		{"diagonalUp", border.Diagonal, border.DiagonalUp},
+		{"diagonalDown", border.Diagonal, border.DiagonalDown},
+	} {
+		if !line.Show {
+			continue
+		}
+		if idx := inStrSlice(styleBorders, line.Line.Style); idx > 0 {
+			borders = append(borders, Border{Type: line.Type, Color: f.getStyleColor(line.Line.Color), Style: idx})
+		}
+	}
+	return borders
+}
+
 // NewConditionalStyle provides a function to create style for conditional
 // format by given style format. The parameters are the same as function
 // NewStyle(). Note that the color field uses RGB color code and only support
@@ -2222,6 +2691,9 @@ func (f *File) newFont(style *Style) *xlsxFont {
 	if ok {
 		fnt.U = &attrValString{Val: stringPtr(val)}
 	}
+	if style.Font.VertAlign != "" {
+		fnt.VertAlign = &attrValString{Val: stringPtr(style.Font.VertAlign)}
+	}
 	return &fnt
 }
 
@@ -2322,6 +2794,174 @@ func setCustomNumFmt(styleSheet *xlsxStyleSheet, style *Style) int {
 	return nf.NumFmtID
 }
 
+// languageLCID defined the locale identifier (LCID) of the language tags
+// supported by the locale number format.
+var languageLCID = map[string]int{
+	"ar-sa": 0x401,
+	"cs-cz": 0x405,
+	"da-dk": 0x406,
+	"de-at": 0xC07,
+	"de-ch": 0x807,
+	"de-de": 0x407,
+	"el-gr": 0x408,
+	"en-au": 0xC09,
+	"en-ca": 0x1009,
+	"en-gb": 0x809,
+	"en-us": 0x409,
+	"es-es": 0xC0A,
+	"es-mx": 0x80A,
+	"es-uy": 0x380A,
+	"fi-fi": 0x40B,
+	"fr-ca": 0xC0C,
+	"fr-ch": 0x100C,
+	"fr-fr": 0x40C,
+	"he-il": 0x40D,
+	"hi-in": 0x439,
+	"hu-hu": 0x40E,
+	"id-id": 0x421,
+	"it-it": 0x410,
+	"ja-jp": 0x411,
+	"ko-kr": 0x412,
+	"nb-no": 0x414,
+	"nl-be": 0x813,
+	"nl-nl": 0x413,
+	"pl-pl": 0x415,
+	"pt-br": 0x416,
+	"pt-pt": 0x816,
+	"ru-ru": 0x419,
+	"sv-se": 0x41D,
+	"th-th": 0x41E,
+	"tr-tr": 0x41F,
+	"uk-ua": 0x422,
+	"vi-vn": 0x42A,
+	"zh-cn": 0x804,
+	"zh-hk": 0xC04,
+	"zh-tw": 0x404,
+}
+
+// LocaleNumFmt provides a function to generate the custom number format with
+// the locale code by given language tag and number format code, the number
+// format will be displayed with the date and time symbols of the language.
+// For example, generate a long date format in German:
+//
+//    exp, err := excelize.LocaleNumFmt("de-DE", "dddd, d. mmmm yyyy")
+//
+// The generated number format is: [$-407]dddd, d. mmmm yyyy
+func LocaleNumFmt(lang, format string) (string, error) {
+	lcid, ok := languageLCID[strings.ToLower(lang)]
+	if !ok {
+		return format, newUnsupportedLocaleError(lang)
+	}
+	return fmt.Sprintf("[$-%X]%s", lcid, format), nil
+}
+
+// CurrencyNumFmt provides a function to generate the custom number format
+// with the currency symbol and locale code by given currency symbol, language
+// tag and number format code. The {} placeholder in the number format code
+// specifies the position of the currency symbol, and the currency symbol will
+// be added before the number format code if there is no placeholder. For
+// example, generate a currency format with euro symbol in German:
+//
+//    exp, err := excelize.CurrencyNumFmt("€", "de-DE", "#,##0.00 {}")
+//
+// The generated number format is: #,##0.00 [$€-407]
+func CurrencyNumFmt(symbol, lang, format string) (string, error) {
+	lcid, ok := languageLCID[strings.ToLower(lang)]
+	if !ok {
+		return format, newUnsupportedLocaleError(lang)
+	}
+	currency := fmt.Sprintf("[$%s-%X]", symbol, lcid)
+	if strings.Contains(format, "{}") {
+		return strings.Replace(format, "{}", currency, -1), nil
+	}
+	return currency + format, nil
+}
+
+// validateNumFmtLocale provides a function to validate the syntax of the
+// currency symbol and locale code sections like [$€-407] in the custom number
+// format, the quoted text and escaped characters will be skipped.
+func validateNumFmtLocale(format string) error {
+	for i := 0; i < len(format); i++ {
+		switch format[i] {
+		case '"':
+			end := strings.IndexByte(format[i+1:], '"')
+			if end == -1 {
+				return nil
+			}
+			i += end + 1
+		case '\\':
+			i++
+		case '[':
+			if !strings.HasPrefix(format[i:], "[$") {
+				continue
+			}
+			end := strings.IndexByte(format[i:], ']')
+			if end == -1 {
+				return newInvalidNumFmtLocaleError(format[i:])
+			}
+			section := format[i+2 : i+end]
+			i += end
+			if section == "-x-sysdate" || section == "-x-systime" {
+				continue
+			}
+			idx := strings.LastIndexByte(section, '-')
+			if idx == -1 {
+				continue
+			}
+			if lcid := section[idx+1:]; len(lcid) == 0 || len(lcid) > 8 {
+				return newInvalidNumFmtLocaleError(section)
+			} else if _, err := strconv.ParseUint(lcid, 16, 32); err != nil {
+				return newInvalidNumFmtLocaleError(section)
+			}
+		}
+	}
+	return nil
+}
+
+// setNumFmtLangLocale provides a function to add the locale code of the
+// language into the date and time custom number format without locale code.
+func setNumFmtLangLocale(format, lang string) *string {
+	if strings.Contains(format, "[$") || !isTimeNumFmt(strings.ToLower(trimNumFmtLiterals(format))) {
+		return &format
+	}
+	if exp, err := LocaleNumFmt(lang, format); err == nil {
+		return &exp
+	}
+	return &format
+}
+
+// trimNumFmtLiterals provides a function to remove the quoted text, escaped
+// characters and the sections in brackets, such as colors and conditions,
+// from the custom number format, the rest are the format tokens.
+func trimNumFmtLiterals(format string) string {
+	var tokens strings.Builder
+	for i := 0; i < len(format); i++ {
+		switch format[i] {
+		case '"':
+			end := strings.IndexByte(format[i+1:], '"')
+			if end == -1 {
+				return tokens.String()
+			}
+			i += end + 1
+		case '\\':
+			i++
+		case '[':
+			end := strings.IndexByte(format[i:], ']')
+			if end == -1 {
+				return tokens.String()
+			}
+			// Keep the elapsed time tokens, such as [h], [mm] and [ss].
+			if section := strings.ToLower(format[i+1 : i+end]); section != "" && strings.Trim(section, "hms") == "" {
+				tokens.WriteString(section)
+			}
+			i += end
+		default:
+			tokens.WriteByte(format[i])
+		}
+	}
+	return tokens.String()
+}
+
 // getCustomNumFmtID provides a function to get custom number format code ID.
 // If given custom number format code is not exist, will return -1.
 func getCustomNumFmtID(styleSheet *xlsxStyleSheet, style *Style) (customNumFmtID int) {
@@ -2385,38 +3025,42 @@ func getFillID(styleSheet *xlsxStyleSheet, style *Style) (fillID int) {
 	return
 }
 
+// styleFillPatterns defined the pattern types of the fill sorted by excelize
+// index number.
+var styleFillPatterns = []string{
+	"none",
+	"solid",
+	"mediumGray",
+	"darkGray",
+	"lightGray",
+	"darkHorizontal",
+	"darkVertical",
+	"darkDown",
+	"darkUp",
+	"darkGrid",
+	"darkTrellis",
+	"lightHorizontal",
+	"lightVertical",
+	"lightDown",
+	"lightUp",
+	"lightGrid",
+	"lightTrellis",
+	"gray125",
+	"gray0625",
+}
+
+// styleFillVariants defined the degrees of the gradient fill sorted by the
+// shading index number.
+var styleFillVariants = []float64{
+	90,
+	0,
+	45,
+	135,
+}
+
 // newFills provides a function to add fill elements in the styles.xml by
 // given cell format settings.
 func newFills(style *Style, fg bool) *xlsxFill {
-	var patterns = []string{
-		"none",
-		"solid",
-		"mediumGray",
-		"darkGray",
-		"lightGray",
-		"darkHorizontal",
-		"darkVertical",
-		"darkDown",
-		"darkUp",
-		"darkGrid",
-		"darkTrellis",
-		"lightHorizontal",
-		"lightVertical",
-		"lightDown",
-		"lightUp",
-		"lightGrid",
-		"lightTrellis",
-		"gray125",
-		"gray0625",
-	}
-
-	var variants = []float64{
-		90,
-		0,
-		45,
-		135,
-	}
-
 	var fill xlsxFill
 	switch style.Fill.Type {
 	case "gradient":
@@ -2426,7 +3070,7 @@ func newFills(style *Style, fg bool) *xlsxFill {
 		var gradient xlsxGradientFill
 		switch style.Fill.Shading {
 		case 0, 1, 2, 3:
-			gradient.Degree = variants[style.Fill.Shading]
+			gradient.Degree = styleFillVariants[style.Fill.Shading]
 		case 4:
 			gradient.Type = "path"
 		case 5:
@@ -2453,7 +3097,7 @@ func newFills(style *Style, fg bool) *xlsxFill {
 			break
 		}
 		var pattern xlsxPatternFill
-		pattern.PatternType = patterns[style.Fill.Pattern]
+		pattern.PatternType = styleFillPatterns[style.Fill.Pattern]
 		if fg {
 			if pattern.FgColor == nil {
 				pattern.FgColor = new(xlsxColor)
@@ -2519,50 +3163,51 @@ func getBorderID(styleSheet *xlsxStyleSheet, style *Style) (borderID int) {
 	return
 }
 
+// styleBorders defined the border styles sorted by excelize index number.
+var styleBorders = []string{
+	"none",
+	"thin",
+	"medium",
+	"dashed",
+	"dotted",
+	"thick",
+	"double",
+	"hair",
+	"mediumDashed",
+	"dashDot",
+	"mediumDashDot",
+	"dashDotDot",
+	"mediumDashDotDot",
+	"slantDashDot",
+}
+
 // newBorders provides a function to add border elements in the styles.xml by
 // given borders format settings.
 func newBorders(style *Style) *xlsxBorder {
-	var styles = []string{
-		"none",
-		"thin",
-		"medium",
-		"dashed",
-		"dotted",
-		"thick",
-		"double",
-		"hair",
-		"mediumDashed",
-		"dashDot",
-		"mediumDashDot",
-		"dashDotDot",
-		"mediumDashDotDot",
-		"slantDashDot",
-	}
-
 	var border xlsxBorder
 	for _, v := range style.Border {
-		if 0 <= v.Style && v.Style < 14 {
+		if 0 <= v.Style && v.Style < len(styleBorders) {
 			var color xlsxColor
 			color.RGB = getPaletteColor(v.Color)
 			switch v.Type {
 			case "left":
-				border.Left.Style = styles[v.Style]
+				border.Left.Style = styleBorders[v.Style]
 				border.Left.Color = &color
 			case "right":
-				border.Right.Style = styles[v.Style]
+				border.Right.Style = styleBorders[v.Style]
 				border.Right.Color = &color
 			case "top":
-				border.Top.Style = styles[v.Style]
+				border.Top.Style = styleBorders[v.Style]
 				border.Top.Color = &color
 			case "bottom":
-				border.Bottom.Style = styles[v.Style]
+				border.Bottom.Style = styleBorders[v.Style]
 				border.Bottom.Color = &color
 			case "diagonalUp":
-				border.Diagonal.Style = styles[v.Style]
+				border.Diagonal.Style = styleBorders[v.Style]
 				border.Diagonal.Color = &color
 				border.DiagonalUp = true
 			case "diagonalDown":
-				border.Diagonal.Style = styles[v.Style]
+				border.Diagonal.Style = styleBorders[v.Style]
 				border.Diagonal.Color = &color
 				border.DiagonalDown = true
 			}
@@ -2780,6 +3425,9 @@ func (f *File) SetCellStyle(sheet, hcell, vcell string, styleID int) error {
 //                   | min_value
 //                   | max_value
 //                   | bar_color
+//     icon_set      | icon_style
+//                   | thresholds
+//                   | reverse
 //     formula       | criteria
 //
 // The criteria parameter is used to set the criteria by which the cell data
@@ -2810,6 +3458,13 @@ func (f *File) SetCellStyle(sheet, hcell, vcell string, styleID int) error {
 //
 //    f.SetConditionalFormat("Sheet1", "D1:D10", fmt.Sprintf(`[{"type":"cell","criteria":">","format":%d,"value":"$C$1"}]`, format))
 //
+// stop_if_true - The stop_if_true parameter is available for all types, which
+// is used to stop evaluating the rules with lower priority on the same cells
+// when the rule is met. For example, highlight the blank cells and don't
+// apply the following color scale rule on them:
+//
+//    f.SetConditionalFormat("Sheet1", "A1:A10", fmt.Sprintf(`[{"type":"formula","criteria":"LEN(A1)=0","format":%d,"stop_if_true":true},{"type":"2_color_scale","criteria":"=","min_type":"min","max_type":"max","min_color":"#F8696B","max_color":"#63BE7B"}]`, format))
+//
 // type: format - The format parameter is used to specify the format that will
 // be applied to the cell when the conditional formatting criterion is met. The
 // format is created using the NewConditionalStyle() method in the same way as
@@ -2939,6 +3594,31 @@ func (f *File) SetCellStyle(sheet, hcell, vcell string, styleID int) error {
 //
 // bar_color - Used for data_bar. Same as min_color, see above.
 //
+// type: icon_set - The icon_set type is used to specify Excel's "Icon Sets"
+// style conditional format. The icon_style parameter is required, the
+// thresholds parameter specifies the lower bound of each icon except the
+// first one, and the reverse parameter is used to reverse the order of the
+// icons:
+//
+//    // Icon Sets: 3 Arrows (Colored).
+//    f.SetConditionalFormat("Sheet1", "L1:L10", `[{"type":"icon_set","icon_style":"3Arrows","thresholds":[{"type":"percent","value":"33"},{"type":"percent","value":"67"}],"reverse":true}]`)
+//
+// The available icon styles are:
+//
+//    3Arrows          4Arrows          5Arrows
+//    3ArrowsGray      4ArrowsGray      5ArrowsGray
+//    3Flags           4Rating          5Boxes
+//    3Signs           4RedToBlack      5Quarters
+//    3Stars           4TrafficLights   5Rating
+//    3Symbols
+//    3Symbols2
+//    3TrafficLights1
+//    3TrafficLights2
+//    3Triangles
+//
+// The available threshold types are percent, percentile, number and formula.
+// The thresholds will be evenly divided by percent if not specified.
+//
 func (f *File) SetConditionalFormat(sheet, area, formatSet string) error {
 	var format []*formatConditional
 	err := json.Unmarshal([]byte(formatSet), &format)
@@ -2954,6 +3634,7 @@ func (f *File) SetConditionalFormat(sheet, area, formatSet string) error {
 		"2_color_scale":   drawCondFmtColorScale,
 		"3_color_scale":   drawCondFmtColorScale,
 		"dataBar":         drawCondFmtDataBar,
+		"iconSet":         drawCondFmtIconSet,
 		"expression":      drawConfFmtExp,
 	}
 
@@ -2961,24 +3642,45 @@ func (f *File) SetConditionalFormat(sheet, area, formatSet string) error {
 	if err != nil {
 		return err
 	}
-	cfRule := []*xlsxCfRule{}
+	cfRule, x14CfRule := []*xlsxCfRule{}, []*xlsxX14CfRule{}
 	for p, v := range format {
 		var vt, ct string
 		var ok bool
 		// "type" is a required parameter, check for valid validation types.
 		vt, ok = validType[v.Type]
 		if ok {
+			if vt == "iconSet" {
+				if err = checkCondFmtIconSet(v); err != nil {
+					return err
+				}
+				if condFmtIconSets[v.IconStyle] {
+					rule := drawCondFmtX14IconSet(p, v)
+					rule.StopIfTrue = v.StopIfTrue
+					x14CfRule = append(x14CfRule, rule)
+					continue
+				}
+			}
 			// Check for valid criteria types.
 			ct, ok = criteriaType[v.Criteria]
-			if ok || vt == "expression" {
+			if ok || vt == "expression" || vt == "iconSet" {
 				drawfunc, ok := drawContFmtFunc[vt]
 				if ok {
-					cfRule = append(cfRule, drawfunc(p, ct, v))
+					rule := drawfunc(p, ct, v)
+					rule.StopIfTrue = v.StopIfTrue
+					cfRule = append(cfRule, rule)
 				}
 			}
 		}
 	}
-
+	if len(x14CfRule) > 0 {
+		if err = f.appendCondFmtExt(ws, area, f.getSheetID(sheet), x14CfRule); err != nil {
+			return err
+		}
+		f.addSheetNameSpace(sheet, NameSpaceSpreadSheetX14)
+		if len(cfRule) == 0 {
+			return err
+		}
+	}
 	ws.ConditionalFormatting = append(ws.ConditionalFormatting, &xlsxConditionalFormatting{
 		SQRef:  area,
 		CfRule: cfRule,
@@ -2986,8 +3688,110 @@ func (f *File) SetConditionalFormat(sheet, area, formatSet string) error {
 	return err
 }
 
+// checkCondFmtIconSet provides a function to check the icon style and
+// thresholds of the icon set conditional formatting rule.
+func checkCondFmtIconSet(format *formatConditional) error {
+	if _, ok := condFmtIconSets[format.IconStyle]; !ok {
+		return ErrParameterInvalid
+	}
+	if len(format.Thresholds) > 0 && len(format.Thresholds) != getCondFmtIconsCount(format.IconStyle)-1 {
+		return ErrParameterInvalid
+	}
+	for _, threshold := range format.Thresholds {
+		if _, ok := condFmtValueType[threshold.Type]; !ok || threshold.Value == "" {
+			return ErrParameterInvalid
+		}
+	}
+	return nil
+}
+
+// getCondFmtIconsCount provides a function to get the number of icons by
+// given icon set style.
+func getCondFmtIconsCount(iconStyle string) int {
+	count, _ := strconv.Atoi(iconStyle[:1])
+	return count
+}
+
+// getCondFmtIconSetCfvo provides a function to get the type and value of the
+// conditional format value objects of the icon set by given format settings.
+// The first value object is always the 0 percent, the others will be evenly
+// divided by percent if the thresholds not specified.
+func getCondFmtIconSetCfvo(format *formatConditional) [][]string {
+	cfvo := [][]string{{"percent", "0"}}
+	if len(format.Thresholds) == 0 {
+		count := getCondFmtIconsCount(format.IconStyle)
+		for i := 1; i < count; i++ {
+			cfvo = append(cfvo, []string{"percent", strconv.Itoa(int(math.Round(float64(i) * 100 / float64(count))))})
+		}
+		return cfvo
+	}
+	for _, threshold := range format.Thresholds {
+		cfvo = append(cfvo, []string{condFmtValueType[threshold.Type], threshold.Value})
+	}
+	return cfvo
+}
+
+// appendCondFmtExt provides a function to append the conditional formatting
+// rules into the x14:conditionalFormattings of the worksheet extension list
+// by given worksheet, range, sheet ID and rules.
+func (f *File) appendCondFmtExt(ws *xlsxWorksheet, area string, sheetID int, rules []*xlsxX14CfRule) error {
+	var (
+		err                                      error
+		idx                                      = -1
+		content                                  string
+		decodeExtLst                             = new(decodeWorksheetExt)
+		decodeCondFmts                           = new(decodeX14ConditionalFormattings)
+		condFmtBytes, condFmtsBytes, extLstBytes []byte
+	)
+	if ws.ExtLst != nil {
+		if err = f.xmlNewDecoder(strings.NewReader("<extLst>" + ws.ExtLst.Ext + "</extLst>")).
+			Decode(decodeExtLst); err != nil && err != io.EOF {
+			return err
+		}
+	}
+	for i, ext := range decodeExtLst.Ext {
+		if ext.URI == ExtURIConditionalFormattings {
+			if err = f.xmlNewDecoder(strings.NewReader(ext.Content)).
+				Decode(decodeCondFmts); err != nil && err != io.EOF {
+				return err
+			}
+			idx, content = i, decodeCondFmts.Content
+		}
+	}
+	count := strings.Count(content, "cfRule ")
+	for i, rule := range rules {
+		rule.ID = fmt.Sprintf("{00000000-0000-0000-%04X-%012X}", sheetID, count+i)
+	}
+	if condFmtBytes, err = xml.Marshal(&xlsxX14ConditionalFormatting{
+		XMLNSXM: NameSpaceSpreadSheetExcel2006Main.Value,
+		CfRule:  rules,
+		SQRef:   area,
+	}); err != nil {
+		return err
+	}
+	if condFmtsBytes, err = xml.Marshal(&xlsxX14ConditionalFormattings{
+		Content: content + string(condFmtBytes),
+	}); err != nil {
+		return err
+	}
+	if idx == -1 {
+		decodeExtLst.Ext = append(decodeExtLst.Ext, &xlsxWorksheetExt{URI: ExtURIConditionalFormattings})
+		idx = len(decodeExtLst.Ext) - 1
+	}
+	decodeExtLst.Ext[idx].Content = string(condFmtsBytes)
+	if extLstBytes, err = xml.Marshal(decodeExtLst); err != nil {
+		return err
+	}
+	ws.ExtLst = &xlsxExtLst{
+		Ext: strings.TrimSuffix(strings.TrimPrefix(string(extLstBytes), "<extLst>"), "</extLst>"),
+	}
+	return err
+}
+
 // UnsetConditionalFormat provides a function to unset the conditional format
-// by given worksheet name and range.
+// by given worksheet name and range, the conditional formatting rules of the
+// range in the worksheet extension list, such as icon sets and data bars, will
+// also be removed.
 func (f *File) UnsetConditionalFormat(sheet, area string) error {
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
@@ -2996,10 +3800,315 @@ func (f *File) UnsetConditionalFormat(sheet, area string) error {
 	for i, cf := range ws.ConditionalFormatting {
 		if cf.SQRef == area {
 			ws.ConditionalFormatting = append(ws.ConditionalFormatting[:i], ws.ConditionalFormatting[i+1:]...)
-			return nil
+			break
 		}
 	}
-	return nil
+	return f.deleteCondFmtExt(ws, area)
+}
+
+// deleteCondFmtExt provides a function to remove the conditional formatting
+// rules of the given range from the x14:conditionalFormattings of the
+// worksheet extension list.
+func (f *File) deleteCondFmtExt(ws *xlsxWorksheet, area string) error {
+	if ws.ExtLst == nil {
+		return nil
+	}
+	decodeExtLst := new(decodeWorksheetExt)
+	if err := f.xmlNewDecoder(strings.NewReader("<extLst>" + ws.ExtLst.Ext + "</extLst>")).
+		Decode(decodeExtLst); err != nil && err != io.EOF {
+		return err
+	}
+	exts := decodeExtLst.Ext[:0]
+	for _, ext := range decodeExtLst.Ext {
+		if ext.URI != ExtURIConditionalFormattings {
+			exts = append(exts, ext)
+			continue
+		}
+		decodeCondFmts := new(decodeX14ConditionalFormattings)
+		if err := f.xmlNewDecoder(strings.NewReader(ext.Content)).
+			Decode(decodeCondFmts); err != nil && err != io.EOF {
+			return err
+		}
+		var (
+			content strings.Builder
+			last    int64
+			d       = f.xmlNewDecoder(strings.NewReader(decodeCondFmts.Content))
+		)
+		for {
+			start := d.InputOffset()
+			token, err := d.Token()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return err
+			}
+			se, ok := token.(xml.StartElement)
+			if !ok || se.Name.Local != "conditionalFormatting" {
+				continue
+			}
+			condFmt := new(decodeX14ConditionalFormatting)
+			if err = d.DecodeElement(condFmt, &se); err != nil {
+				return err
+			}
+			if condFmt.SQRef == area {
+				content.WriteString(decodeCondFmts.Content[last:start])
+				last = d.InputOffset()
+			}
+		}
+		content.WriteString(decodeCondFmts.Content[last:])
+		if strings.TrimSpace(content.String()) == "" {
+			continue
+		}
+		condFmtsBytes, err := xml.Marshal(&xlsxX14ConditionalFormattings{Content: content.String()})
+		if err != nil {
+			return err
+		}
+		ext.Content = string(condFmtsBytes)
+		exts = append(exts, ext)
+	}
+	if decodeExtLst.Ext = exts; len(exts) == 0 {
+		ws.ExtLst = nil
+		return nil
+	}
+	extLstBytes, err := xml.Marshal(decodeExtLst)
+	if err != nil {
+		return err
+	}
+	ws.ExtLst = &xlsxExtLst{
+		Ext: strings.TrimSuffix(strings.TrimPrefix(string(extLstBytes), "<extLst>"), "</extLst>"),
+	}
+	return err
+}
+
+// GetConditionalFormats returns conditional format settings by given worksheet
+// name. The key of the returned map is the range of the conditional
+// formatting, and the value is the JSON rule definition that can be used by
+// SetConditionalFormat to reproduce it. For example:
+//
+//    formats, err := f.GetConditionalFormats("Sheet1")
+//    if err != nil {
+//        fmt.Println(err)
+//        return
+//    }
+//    for area, format := range formats {
+//        fmt.Println(area, format)
+//    }
+//
+func (f *File) GetConditionalFormats(sheet string) (map[string]string, error) {
+	extractContFmtFunc := map[string]func(c *xlsxCfRule) *formatConditional{
+		"cellIs":          extractCondFmtCellIs,
+		"top10":           extractCondFmtTop10,
+		"aboveAverage":    extractCondFmtAboveAverage,
+		"duplicateValues": extractCondFmtDuplicateUniqueValues,
+		"uniqueValues":    extractCondFmtDuplicateUniqueValues,
+		"colorScale":      extractCondFmtColorScale,
+		"dataBar":         extractCondFmtDataBar,
+		"iconSet":         extractCondFmtIconSet,
+		"expression":      extractCondFmtExp,
+	}
+	conditionalFormats := make(map[string]string)
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return conditionalFormats, err
+	}
+	var areas []string
+	formats := make(map[string][]*formatConditional)
+	appendFormat := func(area string, format *formatConditional) {
+		if _, ok := formats[area]; !ok {
+			areas = append(areas, area)
+		}
+		formats[area] = append(formats[area], format)
+	}
+	for _, cf := range ws.ConditionalFormatting {
+		for _, cr := range cf.CfRule {
+			if extractFunc, ok := extractContFmtFunc[cr.Type]; ok {
+				format := extractFunc(cr)
+				if cr.DxfID != nil {
+					format.Format = *cr.DxfID
+				}
+				format.StopIfTrue = cr.StopIfTrue
+				appendFormat(cf.SQRef, format)
+			}
+		}
+	}
+	if ws.ExtLst != nil {
+		decodeExtLst := new(decodeWorksheetExt)
+		if err = f.xmlNewDecoder(strings.NewReader("<extLst>" + ws.ExtLst.Ext + "</extLst>")).
+			Decode(decodeExtLst); err != nil && err != io.EOF {
+			return conditionalFormats, err
+		}
+		for _, ext := range decodeExtLst.Ext {
+			if ext.URI != ExtURIConditionalFormattings {
+				continue
+			}
+			decodeCondFmts := new(decodeX14ConditionalFormattings)
+			if err = f.xmlNewDecoder(strings.NewReader(ext.Content)).
+				Decode(decodeCondFmts); err != nil && err != io.EOF {
+				return conditionalFormats, err
+			}
+			for _, cf := range decodeCondFmts.ConditionalFormatting {
+				for _, cr := range cf.CfRule {
+					if cr.Type == "iconSet" && cr.IconSet != nil {
+						format := extractCondFmtX14IconSet(cr)
+						format.StopIfTrue = cr.StopIfTrue
+						appendFormat(cf.SQRef, format)
+					}
+				}
+			}
+		}
+	}
+	for _, area := range areas {
+		formatSet, _ := json.Marshal(formats[area])
+		conditionalFormats[area] = string(formatSet)
+	}
+	return conditionalFormats, nil
+}
+
+// extractCondFmtCellIs provides a function to extract conditional format
+// settings for cell value (include between, not between, equal, not equal,
+// greater than and less than) by given conditional formatting rule.
+func extractCondFmtCellIs(c *xlsxCfRule) *formatConditional {
+	format := formatConditional{Type: "cell", Criteria: operatorType[c.Operator]}
+	if len(c.Formula) == 2 {
+		format.Minimum, format.Maximum = c.Formula[0], c.Formula[1]
+		return &format
+	}
+	if len(c.Formula) > 0 {
+		format.Value = c.Formula[0]
+	}
+	return &format
+}
+
+// extractCondFmtTop10 provides a function to extract conditional format
+// settings for top N (default is top 10) by given conditional formatting
+// rule.
+func extractCondFmtTop10(c *xlsxCfRule) *formatConditional {
+	format := formatConditional{
+		Type:     "top",
+		Criteria: "=",
+		Percent:  c.Percent,
+		Value:    strconv.Itoa(c.Rank),
+	}
+	if c.Bottom {
+		format.Type = "bottom"
+	}
+	return &format
+}
+
+// extractCondFmtAboveAverage provides a function to extract conditional format
+// settings for above average and below average by given conditional
+// formatting rule.
+func extractCondFmtAboveAverage(c *xlsxCfRule) *formatConditional {
+	return &formatConditional{
+		Type:         "average",
+		Criteria:     "=",
+		AboveAverage: c.AboveAverage == nil || *c.AboveAverage,
+	}
+}
+
+// extractCondFmtDuplicateUniqueValues provides a function to extract
+// conditional format settings for duplicate and unique values by given
+// conditional formatting rule.
+func extractCondFmtDuplicateUniqueValues(c *xlsxCfRule) *formatConditional {
+	return &formatConditional{
+		Type: map[string]string{
+			"duplicateValues": "duplicate",
+			"uniqueValues":    "unique",
+		}[c.Type],
+		Criteria: "=",
+	}
+}
+
+// extractCondFmtColorScale provides a function to extract conditional format
+// settings for color scale (include 2 color scale and 3 color scale) by given
+// conditional formatting rule.
+func extractCondFmtColorScale(c *xlsxCfRule) *formatConditional {
+	var format formatConditional
+	values := len(c.ColorScale.Cfvo)
+	colors := len(c.ColorScale.Color)
+	if colors > 1 && values > 1 {
+		format.Type, format.Criteria = "2_color_scale", "="
+		format.MinType = c.ColorScale.Cfvo[0].Type
+		format.MinValue = c.ColorScale.Cfvo[0].Val
+		format.MinColor = "#" + strings.TrimPrefix(strings.ToUpper(c.ColorScale.Color[0].RGB), "FF")
+		format.MaxType = c.ColorScale.Cfvo[values-1].Type
+		format.MaxValue = c.ColorScale.Cfvo[values-1].Val
+		format.MaxColor = "#" + strings.TrimPrefix(strings.ToUpper(c.ColorScale.Color[colors-1].RGB), "FF")
+	}
+	if colors == 3 && values == 3 {
+		format.Type = "3_color_scale"
+		format.MidType = c.ColorScale.Cfvo[1].Type
+		format.MidValue = c.ColorScale.Cfvo[1].Val
+		format.MidColor = "#" + strings.TrimPrefix(strings.ToUpper(c.ColorScale.Color[1].RGB), "FF")
+	}
+	return &format
+}
+
+// extractCondFmtDataBar provides a function to extract conditional format
+// settings for data bar by given conditional formatting rule.
+func extractCondFmtDataBar(c *xlsxCfRule) *formatConditional {
+	format := formatConditional{Type: "data_bar", Criteria: "="}
+	if c.DataBar != nil {
+		if len(c.DataBar.Cfvo) == 2 {
+			format.MinType, format.MinValue = c.DataBar.Cfvo[0].Type, c.DataBar.Cfvo[0].Val
+			format.MaxType, format.MaxValue = c.DataBar.Cfvo[1].Type, c.DataBar.Cfvo[1].Val
+		}
+		if len(c.DataBar.Color) > 0 {
+			format.BarColor = "#" + strings.TrimPrefix(strings.ToUpper(c.DataBar.Color[0].RGB), "FF")
+		}
+	}
+	return &format
+}
+
+// extractCondFmtIconSet provides a function to extract conditional format
+// settings for icon set by given conditional formatting rule.
+func extractCondFmtIconSet(c *xlsxCfRule) *formatConditional {
+	format := formatConditional{Type: "icon_set", IconStyle: "3TrafficLights1"}
+	if c.IconSet != nil {
+		if c.IconSet.IconSet != "" {
+			format.IconStyle = c.IconSet.IconSet
+		}
+		format.Reverse = c.IconSet.Reverse
+		for i, cfvo := range c.IconSet.Cfvo {
+			if i > 0 {
+				format.Thresholds = append(format.Thresholds, formatConditionalThreshold{Type: getCondFmtThresholdType(cfvo.Type), Value: cfvo.Val})
+			}
+		}
+	}
+	return &format
+}
+
+// extractCondFmtX14IconSet provides a function to extract conditional format
+// settings for icon set by given conditional formatting rule in the worksheet
+// extension list.
+func extractCondFmtX14IconSet(c *decodeX14CfRule) *formatConditional {
+	format := formatConditional{Type: "icon_set", IconStyle: c.IconSet.IconSet, Reverse: c.IconSet.Reverse}
+	for i, cfvo := range c.IconSet.Cfvo {
+		if i > 0 {
+			format.Thresholds = append(format.Thresholds, formatConditionalThreshold{Type: getCondFmtThresholdType(cfvo.Type), Value: cfvo.F})
+		}
+	}
+	return &format
+}
+
+// getCondFmtThresholdType provides a function to get the threshold type of
+// the icon set by given conditional format value object type.
+func getCondFmtThresholdType(cfvoType string) string {
+	if cfvoType == "num" {
+		return "number"
+	}
+	return cfvoType
+}
+
+// extractCondFmtExp provides a function to extract conditional format settings
+// for expression by given conditional formatting rule.
+func extractCondFmtExp(c *xlsxCfRule) *formatConditional {
+	format := formatConditional{Type: "formula"}
+	if len(c.Formula) > 0 {
+		format.Criteria = c.Formula[0]
+	}
+	return &format
 }
 
 // drawCondFmtCellIs provides a function to create conditional formatting rule
@@ -3118,6 +4227,41 @@ func drawCondFmtDataBar(p int, ct string, format *formatConditional) *xlsxCfRule
 	}
 }
 
+// drawCondFmtIconSet provides a function to create conditional formatting
+// rule for icon set by given priority, criteria type and format settings.
+func drawCondFmtIconSet(p int, ct string, format *formatConditional) *xlsxCfRule {
+	c := &xlsxCfRule{
+		Priority: p + 1,
+		Type:     validType[format.Type],
+		IconSet: &xlsxIconSet{
+			IconSet: format.IconStyle,
+			Reverse: format.Reverse,
+		},
+	}
+	for _, cfvo := range getCondFmtIconSetCfvo(format) {
+		c.IconSet.Cfvo = append(c.IconSet.Cfvo, &xlsxCfvo{Type: cfvo[0], Val: cfvo[1]})
+	}
+	return c
+}
+
+// drawCondFmtX14IconSet provides a function to create conditional formatting
+// rule in the worksheet extension list for icon set by given priority and
+// format settings.
+func drawCondFmtX14IconSet(p int, format *formatConditional) *xlsxX14CfRule {
+	c := &xlsxX14CfRule{
+		Priority: p + 1,
+		Type:     validType[format.Type],
+		IconSet: &xlsxX14IconSet{
+			IconSet: format.IconStyle,
+			Reverse: format.Reverse,
+		},
+	}
+	for _, cfvo := range getCondFmtIconSetCfvo(format) {
+		c.IconSet.Cfvo = append(c.IconSet.Cfvo, &xlsxX14Cfvo{Type: cfvo[0], F: cfvo[1]})
+	}
+	return c
+}
+
 // drawConfFmtExp provides a function to create conditional formatting rule
 // for expression by given priority, criteria type and format settings.
 func drawConfFmtExp(p int, ct string, format *formatConditional) *xlsxCfRule {
@@ -1,6 +1,7 @@
 package excelize
 
 import (
+	"encoding/json"
 	"fmt"
 	"math"
 	"path/filepath"
@@ -175,6 +176,115 @@ func TestSetConditionalFormat(t *testing.T) {
 	}
 }
 
+func TestSetConditionalFormatIconSet(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A1:A10", `[{"type":"icon_set","icon_style":"3Arrows","thresholds":[{"type":"number","value":"10"},{"type":"formula","value":"$B$1"}],"reverse":true}]`))
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "B1:B10", `[{"type":"icon_set","icon_style":"4Rating"}]`))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, ws.ConditionalFormatting, 2)
+	assert.Equal(t, &xlsxCfRule{
+		Priority: 1,
+		Type:     "iconSet",
+		IconSet: &xlsxIconSet{
+			IconSet: "3Arrows",
+			Reverse: true,
+			Cfvo:    []*xlsxCfvo{{Type: "percent", Val: "0"}, {Type: "num", Val: "10"}, {Type: "formula", Val: "$B$1"}},
+		},
+	}, ws.ConditionalFormatting[0].CfRule[0])
+	assert.Equal(t, []*xlsxCfvo{{Type: "percent", Val: "0"}, {Type: "percent", Val: "25"}, {Type: "percent", Val: "50"}, {Type: "percent", Val: "75"}},
+		ws.ConditionalFormatting[1].CfRule[0].IconSet.Cfvo)
+	// Test set the icon set which only available in the extension list
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "C1:C10", `[{"type":"icon_set","icon_style":"3Stars","thresholds":[{"type":"percentile","value":"50"},{"type":"percentile","value":"90"}]}]`))
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "D1:D10", `[{"type":"icon_set","icon_style":"5Boxes","reverse":true}]`))
+	assert.Len(t, ws.ConditionalFormatting, 2)
+	assert.Equal(t, `<ext uri="{78C0D931-6437-407D-A8EE-F0AAD7539E65}"><x14:conditionalFormattings>`+
+		`<x14:conditionalFormatting xmlns:xm="http://schemas.microsoft.com/office/excel/2006/main"><x14:cfRule type="iconSet" priority="1" id="{00000000-0000-0000-0001-000000000000}"><x14:iconSet iconSet="3Stars"><x14:cfvo type="percent"><xm:f>0</xm:f></x14:cfvo><x14:cfvo type="percentile"><xm:f>50</xm:f></x14:cfvo><x14:cfvo type="percentile"><xm:f>90</xm:f></x14:cfvo></x14:iconSet></x14:cfRule><xm:sqref>C1:C10</xm:sqref></x14:conditionalFormatting>`+
+		`<x14:conditionalFormatting xmlns:xm="http://schemas.microsoft.com/office/excel/2006/main"><x14:cfRule type="iconSet" priority="1" id="{00000000-0000-0000-0001-000000000001}"><x14:iconSet iconSet="5Boxes" reverse="true"><x14:cfvo type="percent"><xm:f>0</xm:f></x14:cfvo><x14:cfvo type="percent"><xm:f>20</xm:f></x14:cfvo><x14:cfvo type="percent"><xm:f>40</xm:f></x14:cfvo><x14:cfvo type="percent"><xm:f>60</xm:f></x14:cfvo><x14:cfvo type="percent"><xm:f>80</xm:f></x14:cfvo></x14:iconSet></x14:cfRule><xm:sqref>D1:D10</xm:sqref></x14:conditionalFormatting>`+
+		`</x14:conditionalFormattings></ext>`, ws.ExtLst.Ext)
+	assert.NoError(t, f.AddSparkline("Sheet1", &SparklineOption{Location: []string{"E1"}, Range: []string{"Sheet1!A1:D1"}}))
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "F1:F10", `[{"type":"icon_set","icon_style":"3Triangles"}]`))
+	assert.Equal(t, 3, strings.Count(ws.ExtLst.Ext, "<x14:conditionalFormatting "))
+	assert.NotContains(t, ws.ExtLst.Ext, "stopIfTrue")
+	assert.Contains(t, ws.ExtLst.Ext, ExtURISparklineGroups)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetConditionalFormatIconSet.xlsx")))
+
+	// Test set icon set conditional format with invalid parameters
+	for _, format := range []string{
+		`[{"type":"icon_set"}]`,
+		`[{"type":"icon_set","icon_style":"6Arrows"}]`,
+		`[{"type":"icon_set","icon_style":"3Arrows","thresholds":[{"type":"percent","value":"50"}]}]`,
+		`[{"type":"icon_set","icon_style":"3Arrows","thresholds":[{"type":"min","value":"0"},{"type":"percent","value":"50"}]}]`,
+		`[{"type":"icon_set","icon_style":"3Arrows","thresholds":[{"type":"percent"},{"type":"percent","value":"50"}]}]`,
+	} {
+		assert.EqualError(t, f.SetConditionalFormat("Sheet1", "A1:A10", format), ErrParameterInvalid.Error())
+	}
+}
+
+func TestGetConditionalFormats(t *testing.T) {
+	for _, format := range []string{
+		`[{"type":"cell","criteria":"greater than","format":1,"value":"6"}]`,
+		`[{"type":"cell","criteria":"between","format":1,"minimum":"6","maximum":"8"}]`,
+		`[{"type":"top","criteria":"=","format":1,"value":"15","percent":true}]`,
+		`[{"type":"bottom","criteria":"=","format":1,"value":"10"}]`,
+		`[{"type":"average","criteria":"=","format":1,"above_average":true}]`,
+		`[{"type":"duplicate","criteria":"=","format":1},{"type":"unique","criteria":"=","format":2}]`,
+		`[{"type":"2_color_scale","criteria":"=","min_type":"min","max_type":"max","min_value":"0","max_value":"0","min_color":"#F8696B","max_color":"#63BE7B"}]`,
+		`[{"type":"3_color_scale","criteria":"=","min_type":"min","mid_type":"percentile","max_type":"max","min_value":"0","mid_value":"50","max_value":"0","min_color":"#F8696B","mid_color":"#FFEB84","max_color":"#63BE7B"}]`,
+		`[{"type":"data_bar","criteria":"=","min_type":"min","max_type":"max","bar_color":"#638EC6"}]`,
+		`[{"type":"formula","criteria":"MOD(A1,2)=0","format":1}]`,
+		`[{"type":"icon_set","icon_style":"3Arrows","reverse":true,"thresholds":[{"type":"number","value":"10"},{"type":"formula","value":"$B$1"}]}]`,
+		`[{"type":"icon_set","icon_style":"3Stars","thresholds":[{"type":"percent","value":"33"},{"type":"percentile","value":"67"}]}]`,
+		`[{"type":"formula","criteria":"LEN(A1)=0","format":1,"stop_if_true":true},{"type":"2_color_scale","criteria":"=","min_type":"min","max_type":"max","min_value":"0","max_value":"0","min_color":"#F8696B","max_color":"#63BE7B"}]`,
+		`[{"type":"icon_set","icon_style":"3Triangles","thresholds":[{"type":"percent","value":"33"},{"type":"percent","value":"67"}],"stop_if_true":true}]`,
+	} {
+		f := NewFile()
+		assert.NoError(t, f.SetConditionalFormat("Sheet1", "A1:A2", format))
+		expected := []*formatConditional{}
+		assert.NoError(t, json.Unmarshal([]byte(format), &expected))
+		formats, err := f.GetConditionalFormats("Sheet1")
+		assert.NoError(t, err)
+		assert.Len(t, formats, 1)
+		actual := []*formatConditional{}
+		assert.NoError(t, json.Unmarshal([]byte(formats["A1:A2"]), &actual))
+		assert.Equal(t, expected, actual, format)
+		// Test reproduce the conditional format by the returned rule definition
+		f2 := NewFile()
+		assert.NoError(t, f2.SetConditionalFormat("Sheet1", "A1:A2", formats["A1:A2"]))
+		reproduced, err := f2.GetConditionalFormats("Sheet1")
+		assert.NoError(t, err)
+		assert.Equal(t, formats, reproduced)
+	}
+	// Test get conditional formats after save and reopen the workbook
+	f := NewFile()
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A1:A10", `[{"type":"icon_set","icon_style":"5Boxes"}]`))
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A1:A10", `[{"type":"cell","criteria":"<=","format":0,"value":"0"}]`))
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "B1:B10", `[{"type":"data_bar","criteria":"=","min_type":"min","max_type":"max","bar_color":"#638EC6"}]`))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGetConditionalFormats.xlsx")))
+	f, err := OpenFile(filepath.Join("test", "TestGetConditionalFormats.xlsx"))
+	assert.NoError(t, err)
+	formats, err := f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"A1:A10": `[{"type":"cell","above_average":false,"percent":false,"format":0,"criteria":"less than or equal to","value":"0"},{"type":"icon_set","above_average":false,"percent":false,"format":0,"criteria":"","icon_style":"5Boxes","thresholds":[{"type":"percent","value":"20"},{"type":"percent","value":"40"},{"type":"percent","value":"60"},{"type":"percent","value":"80"}]}]`,
+		"B1:B10": `[{"type":"data_bar","above_average":false,"percent":false,"format":0,"criteria":"=","min_type":"min","max_type":"max","bar_color":"#638EC6"}]`,
+	}, formats)
+	// Test get conditional formats on not exists worksheet
+	_, err = f.GetConditionalFormats("SheetN")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+}
+
+func TestSetConditionalFormatStopIfTrue(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A1:A10", `[{"type":"formula","criteria":"LEN(A1)=0","format":0,"stop_if_true":true},{"type":"2_color_scale","criteria":"=","min_type":"min","max_type":"max","min_color":"#F8696B","max_color":"#63BE7B"}]`))
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "B1:B10", `[{"type":"icon_set","icon_style":"3Stars","stop_if_true":true}]`))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.True(t, ws.ConditionalFormatting[0].CfRule[0].StopIfTrue)
+	assert.False(t, ws.ConditionalFormatting[0].CfRule[1].StopIfTrue)
+	assert.Contains(t, ws.ExtLst.Ext, `<x14:cfRule type="iconSet" priority="1" stopIfTrue="true"`)
+}
+
 func TestUnsetConditionalFormat(t *testing.T) {
 	f := NewFile()
 	assert.NoError(t, f.SetCellValue("Sheet1", "A1", 7))
@@ -187,6 +297,36 @@ func TestUnsetConditionalFormat(t *testing.T) {
 	assert.EqualError(t, f.UnsetConditionalFormat("SheetN", "A1:A10"), "sheet SheetN is not exist")
 	// Save spreadsheet by the given path.
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestUnsetConditionalFormat.xlsx")))
+
+	// Test unset conditional format rules in the worksheet extension list.
+	f = NewFile()
+	for _, area := range []string{"A1:A10", "B1:B10"} {
+		assert.NoError(t, f.SetConditionalFormat("Sheet1", area, `[{"type":"icon_set","icon_style":"3Stars"}]`))
+	}
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.ExtLst.Ext = strings.Replace(ws.ExtLst.Ext, "</x14:conditionalFormattings>", `<x14:conditionalFormatting xmlns:xm="http://schemas.microsoft.com/office/excel/2006/main"><x14:cfRule type="dataBar" id="{00000000-0000-0000-0001-000000000002}"><x14:dataBar minLength="0" maxLength="100"><x14:cfvo type="autoMin"/><x14:cfvo type="autoMax"/></x14:dataBar></x14:cfRule><xm:sqref>A1:A10</xm:sqref></x14:conditionalFormatting></x14:conditionalFormattings>`, 1)
+	assert.NoError(t, f.AddSparkline("Sheet1", &SparklineOption{Location: []string{"C1"}, Range: []string{"Sheet1!A1:B1"}}))
+	assert.NoError(t, f.UnsetConditionalFormat("Sheet1", "A1:A10"))
+	assert.NotContains(t, ws.ExtLst.Ext, "A1:A10")
+	assert.NotContains(t, ws.ExtLst.Ext, "dataBar")
+	formats, err := f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, formats, 1)
+	assert.Contains(t, formats["B1:B10"], `"icon_style":"3Stars"`)
+	assert.NoError(t, f.UnsetConditionalFormat("Sheet1", "B1:B10"))
+	assert.NotContains(t, ws.ExtLst.Ext, ExtURIConditionalFormattings)
+	assert.Contains(t, ws.ExtLst.Ext, ExtURISparklineGroups)
+	// Test unset conditional format with the empty extension list.
+	ws.ExtLst = nil
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A1:A10", `[{"type":"icon_set","icon_style":"3Stars"}]`))
+	assert.NoError(t, f.UnsetConditionalFormat("Sheet1", "A1:A10"))
+	assert.Nil(t, ws.ExtLst)
+	// Test unset conditional format with invalid extension list.
+	ws.ExtLst = &xlsxExtLst{Ext: "<ext><x14:conditionalFormattings></ext>"}
+	assert.Error(t, f.UnsetConditionalFormat("Sheet1", "A1:A10"))
+	ws.ExtLst = &xlsxExtLst{Ext: fmt.Sprintf(`<ext uri="%s"><x14:conditionalFormattings><x14:conditionalFormatting><xm:sqref></x14:conditionalFormatting></x14:conditionalFormattings></ext>`, ExtURIConditionalFormattings)}
+	assert.Error(t, f.UnsetConditionalFormat("Sheet1", "A1:A10"))
 }
 
 func TestNewStyle(t *testing.T) {
@@ -279,6 +419,265 @@ func TestNewStyle(t *testing.T) {
 	style5, err := f.NewStyle(&Style{NumFmt: 160, Lang: "zh-cn"})
 	assert.NoError(t, err)
 	assert.Equal(t, 1, style5)
+
+	// Test create style with font vertical alignment
+	f = NewFile()
+	styleID, err = f.NewStyle(&Style{Font: &Font{VertAlign: "superscript"}})
+	assert.NoError(t, err)
+	styles = f.stylesReader()
+	assert.Equal(t, "superscript", *styles.Fonts.Font[*styles.CellXfs.Xf[styleID].FontID].VertAlign.Val)
+	styleID, err = f.NewStyle(`{"font":{"vert_align":"subscript"}}`)
+	assert.NoError(t, err)
+	assert.Equal(t, "subscript", *styles.Fonts.Font[*styles.CellXfs.Xf[styleID].FontID].VertAlign.Val)
+}
+
+func TestNewStyleNumFmtLocale(t *testing.T) {
+	f := NewFile()
+	getFormatCode := func(styleID int) string {
+		styles := f.stylesReader()
+		numFmtID := *styles.CellXfs.Xf[styleID].NumFmtID
+		for _, numFmt := range styles.NumFmts.NumFmt {
+			if numFmt.NumFmtID == numFmtID {
+				return numFmt.FormatCode
+			}
+		}
+		return ""
+	}
+	for _, format := range []string{
+		"[$€-407]#,##0.00",
+		"#,##0.00 [$USD-409]",
+		"[$-F800]dddd, mmmm dd, yyyy",
+		"[$-x-sysdate]dddd, mmmm dd, yyyy",
+		"[$€]#,##0",
+		"[$-1010409]yyyy/mm/dd",
+		`"[$-ZZZ]"0.00`,
+		`\[$-ZZZ]0.00`,
+		"[Red]0.00",
+	} {
+		exp := format
+		styleID, err := f.NewStyle(&Style{CustomNumFmt: &exp})
+		assert.NoError(t, err, format)
+		assert.Equal(t, format, getFormatCode(styleID))
+	}
+	// Test new style with invalid locale code in the number format.
+	for format, code := range map[string]string{
+		"[$-ZZZ]yyyy":         "-ZZZ",
+		"[$€-]#,##0.00":       "€-",
+		"[$€-123456789]0":     "€-123456789",
+		"0.00 [$-407":         "[$-407",
+		"0.00 [$USD-4O9]":     "USD-4O9",
+		`"quoted"[$-G07]yyyy`: "-G07",
+	} {
+		exp := format
+		_, err := f.NewStyle(&Style{CustomNumFmt: &exp})
+		assert.EqualError(t, err, newInvalidNumFmtLocaleError(code).Error(), format)
+	}
+	_, err := f.NewStyle(`{"custom_number_format":"[$-ZZZ]yyyy"}`)
+	assert.EqualError(t, err, newInvalidNumFmtLocaleError("-ZZZ").Error())
+
+	// Test new style with date format picks up the locale of the language.
+	exp := "dddd, d. mmmm yyyy"
+	styleID, err := f.NewStyle(&Style{CustomNumFmt: &exp, Lang: "de-DE", NumFmtLocale: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "[$-407]dddd, d. mmmm yyyy", getFormatCode(styleID))
+	assert.Equal(t, "dddd, d. mmmm yyyy", exp)
+	styleID, err = f.NewStyle(`{"custom_number_format":"[h]:mm","lang":"de-DE","number_format_locale":true}`)
+	assert.NoError(t, err)
+	assert.Equal(t, "[$-407][h]:mm", getFormatCode(styleID))
+	for _, style := range []*Style{
+		{CustomNumFmt: &exp, Lang: "unknown", NumFmtLocale: true},
+		{CustomNumFmt: &exp, Lang: "de-DE"},
+		{CustomNumFmt: &exp, NumFmtLocale: true},
+	} {
+		styleID, err = f.NewStyle(style)
+		assert.NoError(t, err)
+		assert.Equal(t, exp, getFormatCode(styleID))
+	}
+	// Test the quoted text, escaped characters and the sections in brackets
+	// are not detected as the date and time format.
+	for _, numExp := range []string{"#,##0.00", `0.00 "days"`, `0.0\m`, "[Red]0.00", "[>=100]0;0.00"} {
+		numExp := numExp
+		styleID, err = f.NewStyle(&Style{CustomNumFmt: &numExp, Lang: "de-de", NumFmtLocale: true})
+		assert.NoError(t, err)
+		assert.Equal(t, numExp, getFormatCode(styleID))
+	}
+	localeExp := "[$-409]yyyy"
+	styleID, err = f.NewStyle(&Style{CustomNumFmt: &localeExp, Lang: "de-de", NumFmtLocale: true})
+	assert.NoError(t, err)
+	assert.Equal(t, localeExp, getFormatCode(styleID))
+}
+
+func TestLocaleNumFmt(t *testing.T) {
+	exp, err := LocaleNumFmt("de-DE", "dddd, d. mmmm yyyy")
+	assert.NoError(t, err)
+	assert.Equal(t, "[$-407]dddd, d. mmmm yyyy", exp)
+	exp, err = LocaleNumFmt("es-UY", "dd/mm/yyyy")
+	assert.NoError(t, err)
+	assert.Equal(t, "[$-380A]dd/mm/yyyy", exp)
+	_, err = LocaleNumFmt("xx-XX", "yyyy")
+	assert.EqualError(t, err, newUnsupportedLocaleError("xx-XX").Error())
+}
+
+func TestCurrencyNumFmt(t *testing.T) {
+	exp, err := CurrencyNumFmt("€", "de-DE", "#,##0.00 {}")
+	assert.NoError(t, err)
+	assert.Equal(t, "#,##0.00 [$€-407]", exp)
+	exp, err = CurrencyNumFmt("$", "en-US", "#,##0.00")
+	assert.NoError(t, err)
+	assert.Equal(t, "[$$-409]#,##0.00", exp)
+	_, err = CurrencyNumFmt("€", "xx-XX", "#,##0.00")
+	assert.EqualError(t, err, newUnsupportedLocaleError("xx-XX").Error())
+	// Test the generated number format can be used to create the style.
+	f := NewFile()
+	exp, err = CurrencyNumFmt("€", "fr-FR", "#,##0.00 {};-#,##0.00 {}")
+	assert.NoError(t, err)
+	_, err = f.NewStyle(&Style{CustomNumFmt: &exp})
+	assert.NoError(t, err)
+}
+
+func TestNewStyleWith(t *testing.T) {
+	f := NewFile()
+	style, err := f.NewStyleWith(&Style{
+		Border:    []Border{{Type: "left", Color: "#000000", Style: 1}, {Type: "diagonalUp", Color: "FF0000", Style: 13}},
+		Fill:      Fill{Type: "gradient", Color: []string{"#FFFFFF", "#E0EBF5"}, Shading: 5},
+		Font:      &Font{Bold: true, Underline: "double", Size: 12, Color: "#777777", VertAlign: "superscript"},
+		Alignment: &Alignment{Horizontal: "centerContinuous", Vertical: "distributed", Indent: 1, ReadingOrder: 2, TextRotation: 255},
+		NumFmt:    188,
+	})
+	assert.NoError(t, err)
+	styles := f.stylesReader()
+	xf := styles.CellXfs.Xf[style]
+	assert.Equal(t, "centerContinuous", xf.Alignment.Horizontal)
+	assert.Equal(t, 255, xf.Alignment.TextRotation)
+	assert.Equal(t, "double", *styles.Fonts.Font[*xf.FontID].U.Val)
+	// Test create the same style again.
+	sameStyle, err := f.NewStyleWith(&Style{Fill: Fill{Type: "pattern", Pattern: 1, Color: []string{"#1F4E78"}}})
+	assert.NoError(t, err)
+	newStyle, err := f.NewStyleWith(&Style{Fill: Fill{Type: "pattern", Pattern: 1, Color: []string{"#1F4E78"}}})
+	assert.NoError(t, err)
+	assert.Equal(t, sameStyle, newStyle)
+	// Test create style with language number format.
+	_, err = f.NewStyleWith(&Style{NumFmt: 27, Lang: "zh-cn"})
+	assert.NoError(t, err)
+
+	// Test create style with unknown or out of range settings.
+	for _, c := range []struct {
+		style  *Style
+		errMsg string
+	}{
+		{nil, ErrParameterRequired.Error()},
+		{&Style{Border: []Border{{Type: "center", Style: 1}}}, "invalid style border type center"},
+		{&Style{Border: []Border{{Type: "top", Style: 14}}}, "invalid style border style 14"},
+		{&Style{Border: []Border{{Type: "top", Style: -1}}}, "invalid style border style -1"},
+		{&Style{Border: []Border{{Type: "top", Color: "red", Style: 1}}}, "invalid style border color red"},
+		{&Style{Fill: Fill{Type: "solid"}}, "invalid style fill type solid"},
+		{&Style{Fill: Fill{Type: "gradient", Color: []string{"#FFFFFF"}}}, "invalid style gradient fill colors count 1"},
+		{&Style{Fill: Fill{Type: "gradient", Color: []string{"#FFFFFF", "#000000"}, Shading: 6}}, "invalid style fill shading 6"},
+		{&Style{Fill: Fill{Type: "pattern", Pattern: 19, Color: []string{"#FFFFFF"}}}, "invalid style fill pattern 19"},
+		{&Style{Fill: Fill{Type: "pattern", Pattern: 1}}, "invalid style pattern fill colors count 0"},
+		{&Style{Fill: Fill{Type: "pattern", Pattern: 1, Color: []string{"#FFFFFFFF"}}}, "invalid style fill color #FFFFFFFF"},
+		{&Style{Font: &Font{Family: strings.Repeat("s", MaxFontFamilyLength+1)}}, ErrFontLength.Error()},
+		{&Style{Font: &Font{Size: -1}}, ErrFontSize.Error()},
+		{&Style{Font: &Font{Size: MaxFontSize + 1}}, ErrFontSize.Error()},
+		{&Style{Font: &Font{Underline: "triple"}}, "invalid style font underline triple"},
+		{&Style{Font: &Font{VertAlign: "top"}}, "invalid style font vertical alignment top"},
+		{&Style{Font: &Font{Color: "#GGGGGG"}}, "invalid style font color #GGGGGG"},
+		{&Style{Alignment: &Alignment{Horizontal: "middle"}}, "invalid style horizontal alignment middle"},
+		{&Style{Alignment: &Alignment{Vertical: "middle"}}, "invalid style vertical alignment middle"},
+		{&Style{Alignment: &Alignment{Indent: 251}}, "invalid style indent 251"},
+		{&Style{Alignment: &Alignment{ReadingOrder: 3}}, "invalid style reading order 3"},
+		{&Style{Alignment: &Alignment{TextRotation: 181}}, "invalid style text rotation 181"},
+		{&Style{Alignment: &Alignment{TextRotation: -90}}, "invalid style text rotation -90"},
+		{&Style{DecimalPlaces: 31}, "invalid style decimal places 31"},
+		{&Style{NumFmt: 1000}, "invalid style number format 1000"},
+		{&Style{NumFmt: 27}, "invalid style number format 27"},
+		{&Style{CustomNumFmt: stringPtr("")}, ErrCustomNumFmt.Error()},
+	} {
+		_, err = f.NewStyleWith(c.style)
+		assert.EqualError(t, err, c.errMsg)
+	}
+}
+
+func TestGetStyle(t *testing.T) {
+	f := NewFile()
+	expected := &Style{
+		Border: []Border{
+			{Type: "left", Color: "#000000", Style: 1},
+			{Type: "top", Color: "#FF0000", Style: 6},
+			{Type: "diagonalDown", Color: "#0000FF", Style: 13},
+		},
+		Fill:         Fill{Type: "gradient", Color: []string{"#FFFFFF", "#E0EBF5"}, Shading: 2},
+		Font:         &Font{Bold: true, Italic: true, Underline: "double", Family: "Times New Roman", Size: 12, Strike: true, Color: "#777777", VertAlign: "superscript"},
+		Alignment:    &Alignment{Horizontal: "center", Indent: 1, ReadingOrder: 1, TextRotation: 45, Vertical: "top", WrapText: true},
+		Protection:   &Protection{Hidden: true, Locked: false},
+		CustomNumFmt: stringPtr("0.00%"),
+	}
+	styleID, err := f.NewStyle(expected)
+	assert.NoError(t, err)
+	style, err := f.GetStyle(styleID)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, style)
+	// Test create style by the reconstructed style settings.
+	newStyleID, err := f.NewStyle(style)
+	assert.NoError(t, err)
+	assert.Equal(t, styleID, newStyleID)
+	// Test copy the style and change the font.
+	style.Font.Bold = false
+	newStyleID, err = f.NewStyle(style)
+	assert.NoError(t, err)
+	assert.NotEqual(t, styleID, newStyleID)
+
+	for _, c := range []struct {
+		style    *Style
+		expected *Style
+	}{
+		{&Style{NumFmt: 14}, &Style{NumFmt: 14}},
+		{&Style{Fill: Fill{Type: "pattern", Pattern: 1, Color: []string{"#1F4E78"}}}, &Style{Fill: Fill{Type: "pattern", Pattern: 1, Color: []string{"#1F4E78"}}}},
+		{&Style{Fill: Fill{Type: "gradient", Color: []string{"#FFFFFF", "#000000"}, Shading: 4}}, &Style{Fill: Fill{Type: "gradient", Color: []string{"#FFFFFF", "#000000"}, Shading: 4}}},
+		{&Style{Fill: Fill{Type: "gradient", Color: []string{"#FFFFFF", "#000000"}, Shading: 5}}, &Style{Fill: Fill{Type: "gradient", Color: []string{"#FFFFFF", "#000000"}, Shading: 5}}},
+		{&Style{Border: []Border{{Type: "diagonalUp", Color: "#000000", Style: 2}}}, &Style{Border: []Border{{Type: "diagonalUp", Color: "#000000", Style: 2}}}},
+	} {
+		styleID, err = f.NewStyle(c.style)
+		assert.NoError(t, err)
+		style, err = f.GetStyle(styleID)
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, style)
+	}
+
+	// Test get the default style.
+	style, err = f.GetStyle(0)
+	assert.NoError(t, err)
+	assert.Equal(t, &Style{}, style)
+
+	// Test get style with the theme and indexed colors.
+	styles := f.stylesReader()
+	styles.Fonts.Font[0].Color = &xlsxColor{Theme: intPtr(1)}
+	styles.Fonts.Font = append(styles.Fonts.Font, &xlsxFont{U: &attrValString{}, Color: &xlsxColor{Theme: intPtr(4), Tint: -0.25}})
+	styles.Fills.Fill = append(styles.Fills.Fill, &xlsxFill{PatternFill: &xlsxPatternFill{PatternType: "solid", FgColor: &xlsxColor{Indexed: 2}}})
+	styles.CellXfs.Xf = append(styles.CellXfs.Xf, xlsxXf{FontID: intPtr(0), ApplyFont: boolPtr(true)},
+		xlsxXf{FontID: intPtr(len(styles.Fonts.Font) - 1), FillID: intPtr(len(styles.Fills.Fill) - 1)})
+	style, err = f.GetStyle(len(styles.CellXfs.Xf) - 2)
+	assert.NoError(t, err)
+	assert.Equal(t, &Font{Family: "Calibri", Size: 11, Color: "#000000"}, style.Font)
+	style, err = f.GetStyle(len(styles.CellXfs.Xf) - 1)
+	assert.NoError(t, err)
+	assert.Equal(t, &Font{Underline: "single", Color: "#2E75B6"}, style.Font)
+	assert.Equal(t, Fill{Type: "pattern", Pattern: 1, Color: []string{"#FF0000"}}, style.Fill)
+
+	// Test get style with out of range style ID.
+	for _, styleID := range []int{-1, len(styles.CellXfs.Xf)} {
+		_, err = f.GetStyle(styleID)
+		assert.EqualError(t, err, fmt.Sprintf("style ID %d is out of range", styleID))
+	}
+	// Test get style with out of range font, fill and border ID.
+	styles.CellXfs.Xf = append(styles.CellXfs.Xf, xlsxXf{FontID: intPtr(100)}, xlsxXf{FillID: intPtr(100)}, xlsxXf{BorderID: intPtr(100)})
+	for idx, part := range []string{"font", "fill", "border"} {
+		_, err = f.GetStyle(len(styles.CellXfs.Xf) - 3 + idx)
+		assert.EqualError(t, err, fmt.Sprintf("%s ID 100 is out of range", part))
+	}
+	f.Styles.CellXfs = nil
+	_, err = f.GetStyle(0)
+	assert.EqualError(t, err, "style ID 0 is out of range")
 }
 
 func TestGetDefaultFont(t *testing.T) {
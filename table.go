@@ -12,11 +12,14 @@
 package excelize
 
 import (
+	"bytes"
 	"encoding/json"
 	"encoding/xml"
 	"errors"
 	"fmt"
+	"io"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -28,8 +31,33 @@ func parseFormatTableSet(formatSet string) (*formatTable, error) {
 		TableStyle:     "",
 		ShowRowStripes: true,
 	}
-	err := json.Unmarshal(parseFormatSet(formatSet), &format)
-	return &format, err
+	if err := json.Unmarshal(parseFormatSet(formatSet), &format); err != nil {
+		return &format, err
+	}
+	for _, col := range format.Columns {
+		if col.TotalsRowFunction == "custom" && col.TotalsRowFormula == "" {
+			return &format, ErrParameterRequired
+		}
+		if _, ok := tableTotalsRowFunctions[col.TotalsRowFunction]; !ok && col.TotalsRowFunction != "custom" {
+			return &format, ErrParameterInvalid
+		}
+	}
+	return &format, nil
+}
+
+// tableTotalsRowFunctions defined the function number of the SUBTOTAL
+// function for the built-in totals row functions of the table column.
+var tableTotalsRowFunctions = map[string]int{
+	"":          0,
+	"none":      0,
+	"average":   101,
+	"countNums": 102,
+	"count":     103,
+	"max":       104,
+	"min":       105,
+	"stdDev":    107,
+	"sum":       109,
+	"var":       110,
 }
 
 // AddTable provides the method to add table in a worksheet by given worksheet
@@ -62,6 +90,37 @@ func parseFormatTableSet(formatSet string) (*formatTable, error) {
 //    TableStyleMedium1 - TableStyleMedium28
 //    TableStyleDark1 - TableStyleDark11
 //
+// show_total_row: Show the totals row below the given coordinate area of the
+// table, the aggregate function of each column in the totals row could be
+// specified by the columns settings in the order of the table columns. For
+// example, create a table of A1:C5 on Sheet1 with the totals row in the row
+// 6, which shows a label in the first column, the sum of the second column
+// and a custom formula in the third column:
+//
+//    err := f.AddTable("Sheet1", "A1", "C5", `{
+//        "show_total_row": true,
+//        "columns": [
+//            {"totals_row_label": "Total"},
+//            {"totals_row_function": "sum"},
+//            {"totals_row_function": "custom", "totals_row_formula": "COUNTBLANK(Table1[Column3])"}
+//        ]
+//    }`)
+//
+// totals_row_function: The aggregate function of the column in the totals
+// row, the custom function uses the formula specified by the
+// totals_row_formula:
+//
+//    none
+//    average
+//    count
+//    countNums
+//    max
+//    min
+//    stdDev
+//    sum
+//    var
+//    custom
+//
 func (f *File) AddTable(sheet, hcell, vcell, format string) error {
 	formatSet, err := parseFormatTableSet(format)
 	if err != nil {
@@ -131,13 +190,137 @@ func (f *File) validTableID(id int) bool {
 	return id >= 1 && id <= tableCount
 }
 
-// countTables provides a function to get table files count storage in the
-// folder xl/tables.
+// GetTables provides the method to get all tables in a worksheet by given
+// worksheet name, returns the name, range, header row, style and column
+// settings of each table. For example, get all tables in Sheet1:
+//
+//    tables, err := f.GetTables("Sheet1")
+//
+// An empty slice will be returned if there are no tables in the worksheet.
+func (f *File) GetTables(sheet string) ([]Table, error) {
+	tables := []Table{}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return tables, err
+	}
+	if ws.TableParts == nil {
+		return tables, err
+	}
+	for _, tablePart := range ws.TableParts.TableParts {
+		tableXML := f.getTablePartPath(sheet, tablePart.RID)
+		if tableXML == "" {
+			continue
+		}
+		t, err := f.tableReader(tableXML)
+		if err != nil {
+			return tables, err
+		}
+		table := Table{
+			Name:          t.Name,
+			Range:         t.Ref,
+			ShowHeaderRow: t.HeaderRowCount == nil || *t.HeaderRowCount > 0,
+			ShowTotalRow:  t.TotalsRowCount > 0,
+		}
+		if t.TableStyleInfo != nil {
+			table.TableStyle = t.TableStyleInfo.Name
+			table.ShowFirstColumn = t.TableStyleInfo.ShowFirstColumn
+			table.ShowLastColumn = t.TableStyleInfo.ShowLastColumn
+			table.ShowRowStripes = t.TableStyleInfo.ShowRowStripes
+			table.ShowColumnStripes = t.TableStyleInfo.ShowColumnStripes
+		}
+		if t.TableColumns != nil {
+			for _, column := range t.TableColumns.TableColumn {
+				table.Columns = append(table.Columns, TableColumn{
+					ID:                column.ID,
+					Name:              column.Name,
+					TotalsRowFunction: column.TotalsRowFunction,
+					TotalsRowLabel:    column.TotalsRowLabel,
+					TotalsRowFormula:  column.TotalsRowFormula,
+				})
+			}
+		}
+		tables = append(tables, table)
+	}
+	return tables, nil
+}
+
+// DeleteTable provides the method to delete a table by given table name. The
+// table will be looked up in all worksheets by the name case-insensitively,
+// and the table part, the relationship and the table part reference of the
+// worksheet will be removed, the cells of the table will be kept. For example,
+// delete the table named Table1:
+//
+//    err := f.DeleteTable("Table1")
+//
+func (f *File) DeleteTable(name string) error {
+	for _, sheet := range f.GetSheetList() {
+		if !strings.HasPrefix(f.sheetMap[sheet], "xl/worksheets/") {
+			continue
+		}
+		ws, err := f.workSheetReader(sheet)
+		if err != nil {
+			return err
+		}
+		if ws.TableParts == nil {
+			continue
+		}
+		for idx, tablePart := range ws.TableParts.TableParts {
+			tableXML := f.getTablePartPath(sheet, tablePart.RID)
+			if tableXML == "" {
+				continue
+			}
+			t, err := f.tableReader(tableXML)
+			if err != nil {
+				return err
+			}
+			if !strings.EqualFold(t.Name, name) {
+				continue
+			}
+			ws.TableParts.TableParts = append(ws.TableParts.TableParts[:idx], ws.TableParts.TableParts[idx+1:]...)
+			ws.TableParts.Count = len(ws.TableParts.TableParts)
+			if ws.TableParts.Count == 0 {
+				ws.TableParts = nil
+			}
+			f.deleteSheetRelationships(sheet, tablePart.RID)
+			f.Pkg.Delete(tableXML)
+			f.deleteSheetFromContentTypes("/" + tableXML)
+			return err
+		}
+	}
+	return newNoTableError(name)
+}
+
+// getTablePartPath provides a function to get the path of the table part by
+// given worksheet name and relationship ID of the table part.
+func (f *File) getTablePartPath(sheet, rID string) string {
+	target := f.getSheetRelationshipsTargetByID(sheet, rID)
+	if strings.HasPrefix(target, "/") {
+		return strings.TrimPrefix(target, "/")
+	}
+	return strings.Replace(target, "..", "xl", 1)
+}
+
+// tableReader provides a function to get the pointer to the structure after
+// deserialization of xl/tables/table%d.xml.
+func (f *File) tableReader(path string) (*xlsxTable, error) {
+	var table xlsxTable
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(path)))).
+		Decode(&table); err != nil && err != io.EOF {
+		return &table, err
+	}
+	return &table, nil
+}
+
+// countTables provides a function to get the largest index of the table
+// files storage in the folder xl/tables, the index of the deleted tables
+// will not be reused.
 func (f *File) countTables() int {
 	count := 0
 	f.Pkg.Range(func(k, v interface{}) bool {
-		if strings.Contains(k.(string), "xl/tables/table") {
-			count++
+		if strings.HasPrefix(k.(string), "xl/tables/table") {
+			if idx, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(k.(string), "xl/tables/table"), ".xml")); err == nil && idx > count {
+				count = idx
+			}
 		}
 		return true
 	})
@@ -176,6 +359,14 @@ func (f *File) addTable(sheet, tableXML string, x1, y1, x2, y2, i int, formatSet
 		return err
 	}
 
+	if formatSet.ShowTotalRow {
+		if y2+1 > TotalRows {
+			return ErrMaxRows
+		}
+		ref, _ = f.coordinatesToAreaRef([]int{x1, y1, x2, y2 + 1})
+	}
+	filterRef, _ := f.coordinatesToAreaRef([]int{x1, y1, x2, y2})
+
 	var tableColumn []*xlsxTableColumn
 
 	idx := 0
@@ -202,6 +393,11 @@ func (f *File) addTable(sheet, tableXML string, x1, y1, x2, y2, i int, formatSet
 	if name == "" {
 		name = "Table" + strconv.Itoa(i)
 	}
+	if formatSet.ShowTotalRow {
+		if err = f.setTableTotalsRow(sheet, name, tableColumn, x1, y2+1, formatSet); err != nil {
+			return err
+		}
+	}
 	t := xlsxTable{
 		XMLNS:       NameSpaceSpreadSheet.Value,
 		ID:          i,
@@ -209,7 +405,7 @@ func (f *File) addTable(sheet, tableXML string, x1, y1, x2, y2, i int, formatSet
 		DisplayName: name,
 		Ref:         ref,
 		AutoFilter: &xlsxAutoFilter{
-			Ref: ref,
+			Ref: filterRef,
 		},
 		TableColumns: &xlsxTableColumns{
 			Count:       idx,
@@ -223,11 +419,58 @@ func (f *File) addTable(sheet, tableXML string, x1, y1, x2, y2, i int, formatSet
 			ShowColumnStripes: formatSet.ShowColumnStripes,
 		},
 	}
+	if formatSet.ShowTotalRow {
+		t.TotalsRowCount, t.TotalsRowShown = 1, true
+	}
 	table, _ := xml.Marshal(t)
 	f.saveFileList(tableXML, table)
 	return nil
 }
 
+// setTableTotalsRow provides a function to set the totals row functions of
+// the table columns, and write the labels and the formulas of the totals row
+// into the worksheet by given worksheet name, table name, table columns,
+// coordinates of the first cell in the totals row and format set.
+func (f *File) setTableTotalsRow(sheet, name string, columns []*xlsxTableColumn, col, row int, formatSet *formatTable) error {
+	for idx, column := range columns {
+		if idx >= len(formatSet.Columns) {
+			break
+		}
+		opts := formatSet.Columns[idx]
+		cell, err := CoordinatesToCellName(col+idx, row)
+		if err != nil {
+			return err
+		}
+		if opts.TotalsRowLabel != "" {
+			column.TotalsRowLabel = opts.TotalsRowLabel
+			if err = f.SetCellStr(sheet, cell, opts.TotalsRowLabel); err != nil {
+				return err
+			}
+		}
+		var formula string
+		switch opts.TotalsRowFunction {
+		case "", "none":
+			continue
+		case "custom":
+			column.TotalsRowFormula = opts.TotalsRowFormula
+			formula = opts.TotalsRowFormula
+		default:
+			formula = fmt.Sprintf("SUBTOTAL(%d,%s[%s])", tableTotalsRowFunctions[opts.TotalsRowFunction], name, escapeTableColumnName(column.Name))
+		}
+		column.TotalsRowFunction = opts.TotalsRowFunction
+		if err = f.SetCellFormula(sheet, cell, formula); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// escapeTableColumnName provides a function to escape the special characters
+// of the table column name in the structured reference.
+func escapeTableColumnName(name string) string {
+	return strings.NewReplacer("'", "''", "[", "'[", "]", "']", "#", "'#").Replace(name)
+}
+
 // parseAutoFilterSet provides a function to parse the settings of the auto
 // filter.
 func parseAutoFilterSet(formatSet string) (*formatAutoFilter, error) {
@@ -251,9 +494,15 @@ func parseAutoFilterSet(formatSet string) (*formatAutoFilter, error) {
 // criteria
 //
 // It isn't sufficient to just specify the filter condition. You must also
-// hide any rows that don't match the filter condition. Rows are hidden using
-// the SetRowVisible() method. Excelize can't filter rows automatically since
-// this isn't part of the file format.
+// hide any rows that don't match the filter condition. Set the apply option
+// to evaluate the filter criteria with the cell values and hide the rows that
+// don't match, or hide the rows using the SetRowVisible() method:
+//
+//    err := f.AutoFilter("Sheet1", "A1", "D4", `{"column":"B","expression":"x > 1000","apply":true}`)
+//
+// When applying the filter, the numeric criteria are compared with the
+// numeric cell values, and the text criteria are compared with the displayed
+// cell values case-insensitively with the wildcards supported.
 //
 // Setting a filter criteria for a column:
 //
@@ -306,6 +555,38 @@ func parseAutoFilterSet(formatSet string) (*formatAutoFilter, error) {
 //    col   < 2000
 //    Price < 2000
 //
+// Filter data by multiple columns with the columns settings, each column
+// could be filtered by one of the expression, a list of values, custom
+// filters or a top 10 filter. For example, filter the rows which the column B
+// is "West" or "East", the column C is greater than 1000 and less than 5000,
+// and the column D is in the top 10 percent:
+//
+//    err := f.AutoFilter("Sheet1", "A1", "D20", `{"columns":[
+//        {"column":"B","filters":["West","East"]},
+//        {"column":"C","and":true,"custom_filters":[
+//            {"operator":"greaterThan","val":"1000"},
+//            {"operator":"lessThan","val":"5000"}
+//        ]},
+//        {"column":"D","top10":{"top":true,"percent":true,"val":10}}
+//    ]}`)
+//
+// filters: The list of values to filter by, an empty string value matches
+// the blank cells.
+//
+// custom_filters: At most two custom filters joined by the "and" operator if
+// the "and" is true, or by the "or" operator otherwise. The operator of the
+// custom filter is one of the following:
+//
+//    equal
+//    notEqual
+//    greaterThan
+//    greaterThanOrEqual
+//    lessThan
+//    lessThanOrEqual
+//
+// top10: Filter the top or bottom items by the number of items, or by the
+// percent of the items if the "percent" is true.
+//
 func (f *File) AutoFilter(sheet, hcell, vcell, format string) error {
 	hcol, hrow, err := CellNameToCoordinates(hcell)
 	if err != nil {
@@ -373,66 +654,365 @@ func (f *File) autoFilter(sheet, ref string, refRange, col int, formatSet *forma
 		Ref: ref,
 	}
 	ws.AutoFilter = filter
-	if formatSet.Column == "" || formatSet.Expression == "" {
-		return nil
+	var columns []formatAutoFilterColumn
+	if formatSet.Column != "" && formatSet.Expression != "" {
+		columns = append(columns, formatAutoFilterColumn{Column: formatSet.Column, Expression: formatSet.Expression})
+	}
+	columns = append(columns, formatSet.Columns...)
+	for _, opts := range columns {
+		fsCol, err := ColumnNameToNumber(opts.Column)
+		if err != nil {
+			return err
+		}
+		offset := fsCol - col
+		if offset < 0 || offset > refRange {
+			return fmt.Errorf("incorrect index of column '%s'", opts.Column)
+		}
+		for _, filterColumn := range filter.FilterColumn {
+			if filterColumn.ColID == offset {
+				return fmt.Errorf("duplicate filter column '%s'", opts.Column)
+			}
+		}
+		filterColumn := &xlsxFilterColumn{ColID: offset}
+		if err = f.parseFilterColumn(filterColumn, &opts); err != nil {
+			return err
+		}
+		filter.FilterColumn = append(filter.FilterColumn, filterColumn)
 	}
+	if formatSet.Apply {
+		return f.applyAutoFilter(sheet, filter)
+	}
+	return nil
+}
+
+// filterCellValue directly maps the displayed and raw value of a cell in the
+// auto filter range.
+type filterCellValue struct {
+	text, raw string
+}
 
-	fsCol, err := ColumnNameToNumber(formatSet.Column)
+// applyAutoFilter provides a function to evaluate the filter criteria of the
+// auto filter by given worksheet name, and set the hidden attribute of the
+// rows in the auto filter range which don't match the criteria.
+func (f *File) applyAutoFilter(sheet string, filter *xlsxAutoFilter) error {
+	coordinates, err := areaRefToCoordinates(filter.Ref)
 	if err != nil {
 		return err
 	}
-	offset := fsCol - col
-	if offset < 0 || offset > refRange {
-		return fmt.Errorf("incorrect index of column '%s'", formatSet.Column)
+	col, hrow, vrow := coordinates[0], coordinates[1], coordinates[3]
+	matches := make([]bool, vrow-hrow)
+	for idx := range matches {
+		matches[idx] = true
 	}
+	for _, filterColumn := range filter.FilterColumn {
+		values := make([]filterCellValue, vrow-hrow)
+		for idx := range values {
+			cell, err := CoordinatesToCellName(col+filterColumn.ColID, hrow+idx+1)
+			if err != nil {
+				return err
+			}
+			if values[idx].text, err = f.GetCellValue(sheet, cell); err != nil {
+				return err
+			}
+			if values[idx].raw, err = f.GetCellValue(sheet, cell, Options{RawCellValue: true}); err != nil {
+				return err
+			}
+		}
+		if filterColumn.Top10 != nil {
+			setTop10FilterVal(filterColumn.Top10, values)
+		}
+		for idx, val := range values {
+			matches[idx] = matches[idx] && matchFilterColumn(filterColumn, val)
+		}
+	}
+	for idx, match := range matches {
+		if err = f.SetRowVisible(sheet, hrow+idx+1, match); err != nil {
+			return err
+		}
+	}
+	return err
+}
 
-	filter.FilterColumn = append(filter.FilterColumn, &xlsxFilterColumn{
-		ColID: offset,
-	})
-	re := regexp.MustCompile(`"(?:[^"]|"")*"|\S+`)
-	token := re.FindAllString(formatSet.Expression, -1)
-	if len(token) != 3 && len(token) != 7 {
-		return fmt.Errorf("incorrect number of tokens in criteria '%s'", formatSet.Expression)
+// setTop10FilterVal provides a function to calculate the boundary value of
+// the top 10 filter by given numeric cell values of the filter column.
+func setTop10FilterVal(top10 *xlsxTop10, values []filterCellValue) {
+	var nums []float64
+	for _, val := range values {
+		if num, err := strconv.ParseFloat(val.raw, 64); err == nil {
+			nums = append(nums, num)
+		}
+	}
+	if len(nums) == 0 {
+		return
+	}
+	sort.Float64s(nums)
+	count := int(top10.Val)
+	if top10.Percent {
+		count = int(float64(len(nums)) * top10.Val / 100)
+	}
+	if count < 1 {
+		count = 1
+	}
+	if count > len(nums) {
+		count = len(nums)
+	}
+	if top10.Top {
+		top10.FilterVal = nums[len(nums)-count]
+		return
+	}
+	top10.FilterVal = nums[count-1]
+}
+
+// matchFilterColumn provides a function to check if the cell value matches
+// the filter criteria of the filter column.
+func matchFilterColumn(filterColumn *xlsxFilterColumn, val filterCellValue) bool {
+	switch {
+	case filterColumn.Filters != nil:
+		if val.text == "" {
+			return filterColumn.Filters.Blank
+		}
+		for _, filter := range filterColumn.Filters.Filter {
+			if strings.EqualFold(filter.Val, val.text) {
+				return true
+			}
+		}
+		return false
+	case filterColumn.CustomFilters != nil:
+		for idx, customFilter := range filterColumn.CustomFilters.CustomFilter {
+			match := matchCustomFilter(customFilter, val)
+			if filterColumn.CustomFilters.And && !match {
+				return false
+			}
+			if !filterColumn.CustomFilters.And && match {
+				return true
+			}
+			if idx == len(filterColumn.CustomFilters.CustomFilter)-1 {
+				return match
+			}
+		}
+	case filterColumn.Top10 != nil:
+		num, err := strconv.ParseFloat(val.raw, 64)
+		if err != nil {
+			return false
+		}
+		if filterColumn.Top10.Top {
+			return num >= filterColumn.Top10.FilterVal
+		}
+		return num <= filterColumn.Top10.FilterVal
 	}
-	expressions, tokens, err := f.parseFilterExpression(formatSet.Expression, token)
+	return true
+}
+
+// matchCustomFilter provides a function to check if the cell value matches
+// the custom filter. The numeric criteria are compared with the numeric cell
+// value, and the text criteria are compared with the displayed cell value
+// case-insensitively, the equal and not equal operators support wildcards.
+func matchCustomFilter(customFilter *xlsxCustomFilter, val filterCellValue) bool {
+	operator := customFilter.Operator
+	if operator == "" {
+		operator = "equal"
+	}
+	if customFilter.Val == " " && (operator == "equal" || operator == "notEqual") {
+		// The blank cells criteria.
+		return (val.text == "") == (operator == "equal")
+	}
+	var cmp int
+	criteria, criteriaErr := strconv.ParseFloat(customFilter.Val, 64)
+	num, numErr := strconv.ParseFloat(val.raw, 64)
+	switch {
+	case criteriaErr == nil && numErr == nil:
+		if num < criteria {
+			cmp = -1
+		} else if num > criteria {
+			cmp = 1
+		}
+	case operator == "equal" || operator == "notEqual":
+		if matchFilterWildcard(customFilter.Val, val.text) {
+			return operator == "equal"
+		}
+		return operator == "notEqual"
+	case criteriaErr != nil && numErr != nil:
+		cmp = strings.Compare(strings.ToLower(val.text), strings.ToLower(customFilter.Val))
+	default:
+		return false
+	}
+	switch operator {
+	case "equal":
+		return cmp == 0
+	case "notEqual":
+		return cmp != 0
+	case "greaterThan":
+		return cmp > 0
+	case "greaterThanOrEqual":
+		return cmp >= 0
+	case "lessThan":
+		return cmp < 0
+	case "lessThanOrEqual":
+		return cmp <= 0
+	}
+	return false
+}
+
+// matchFilterWildcard provides a function to check if the text matches the
+// criteria case-insensitively, the '*' in the criteria matches any
+// characters, the '?' matches any single character, and the '~' escapes the
+// next character.
+func matchFilterWildcard(criteria, text string) bool {
+	var pattern strings.Builder
+	pattern.WriteString("(?is)^")
+	runes := []rune(criteria)
+	for i := 0; i < len(runes); i++ {
+		switch runes[i] {
+		case '*':
+			pattern.WriteString(".*")
+		case '?':
+			pattern.WriteString(".")
+		case '~':
+			if i+1 < len(runes) {
+				i++
+			}
+			pattern.WriteString(regexp.QuoteMeta(string(runes[i])))
+		default:
+			pattern.WriteString(regexp.QuoteMeta(string(runes[i])))
+		}
+	}
+	pattern.WriteString("$")
+	return regexp.MustCompile(pattern.String()).MatchString(text)
+}
+
+// GetAutoFilter provides the method to get the auto filter settings of a
+// worksheet by given worksheet name, returns the range of the auto filter and
+// the filter criteria of each filtered column. The blank cells criteria in
+// the list of values to filter by is returned as an empty string. For
+// example, get the auto filter of Sheet1:
+//
+//    opts, err := f.GetAutoFilter("Sheet1")
+//
+// It returns nil if the worksheet has no auto filter.
+func (f *File) GetAutoFilter(sheet string) (*AutoFilterOptions, error) {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil || ws.AutoFilter == nil {
+		return nil, err
+	}
+	opts := AutoFilterOptions{Range: strings.Replace(ws.AutoFilter.Ref, "$", "", -1)}
+	col, _, err := CellNameToCoordinates(strings.Split(opts.Range, ":")[0])
 	if err != nil {
-		return err
+		return nil, err
+	}
+	for _, filterColumn := range ws.AutoFilter.FilterColumn {
+		column := AutoFilterColumn{}
+		if column.Column, err = ColumnNumberToName(col + filterColumn.ColID); err != nil {
+			return nil, err
+		}
+		if filterColumn.Filters != nil {
+			column.Filters = []string{}
+			for _, filter := range filterColumn.Filters.Filter {
+				column.Filters = append(column.Filters, filter.Val)
+			}
+			if filterColumn.Filters.Blank {
+				column.Filters = append(column.Filters, "")
+			}
+		}
+		if filterColumn.CustomFilters != nil {
+			column.And = filterColumn.CustomFilters.And
+			for _, customFilter := range filterColumn.CustomFilters.CustomFilter {
+				operator := customFilter.Operator
+				if operator == "" {
+					operator = "equal"
+				}
+				column.CustomFilters = append(column.CustomFilters, AutoFilterCustomFilter{Operator: operator, Val: customFilter.Val})
+			}
+		}
+		if filterColumn.Top10 != nil {
+			column.Top10 = &AutoFilterTop10{Top: filterColumn.Top10.Top, Percent: filterColumn.Top10.Percent, Val: filterColumn.Top10.Val}
+		}
+		opts.Columns = append(opts.Columns, column)
+	}
+	return &opts, err
+}
+
+// parseFilterColumn provides a function to set the filter criteria of the
+// filter column by given column filter settings.
+func (f *File) parseFilterColumn(filterColumn *xlsxFilterColumn, opts *formatAutoFilterColumn) error {
+	switch {
+	case opts.Expression != "":
+		re := regexp.MustCompile(`"(?:[^"]|"")*"|\S+`)
+		token := re.FindAllString(opts.Expression, -1)
+		if len(token) != 3 && len(token) != 7 {
+			return fmt.Errorf("incorrect number of tokens in criteria '%s'", opts.Expression)
+		}
+		expressions, tokens, err := f.parseFilterExpression(opts.Expression, token)
+		if err != nil {
+			return err
+		}
+		f.writeAutoFilter(filterColumn, expressions, tokens)
+	case len(opts.Filters) > 0:
+		filterColumn.Filters = &xlsxFilters{}
+		for _, val := range opts.Filters {
+			if val == "" {
+				filterColumn.Filters.Blank = true
+				continue
+			}
+			filterColumn.Filters.Filter = append(filterColumn.Filters.Filter, &xlsxFilter{Val: val})
+		}
+	case len(opts.CustomFilters) > 0:
+		if len(opts.CustomFilters) > 2 {
+			return fmt.Errorf("at most two custom filters in column '%s'", opts.Column)
+		}
+		filterColumn.CustomFilters = &xlsxCustomFilters{And: opts.And && len(opts.CustomFilters) == 2}
+		for _, customFilter := range opts.CustomFilters {
+			if inStrSlice(customFilterOperators, customFilter.Operator) == -1 {
+				return fmt.Errorf("unknown operator: %s", customFilter.Operator)
+			}
+			filterColumn.CustomFilters.CustomFilter = append(filterColumn.CustomFilters.CustomFilter, &xlsxCustomFilter{
+				Operator: customFilter.Operator,
+				Val:      customFilter.Val,
+			})
+		}
+	case opts.Top10 != nil:
+		if opts.Top10.Val <= 0 || (opts.Top10.Percent && opts.Top10.Val > 100) {
+			return ErrParameterInvalid
+		}
+		filterColumn.Top10 = &xlsxTop10{Top: opts.Top10.Top, Percent: opts.Top10.Percent, Val: opts.Top10.Val}
+	default:
+		return fmt.Errorf("no criteria in column '%s'", opts.Column)
 	}
-	f.writeAutoFilter(filter, expressions, tokens)
-	ws.AutoFilter = filter
 	return nil
 }
 
+// customFilterOperators defined the operators of the custom filter.
+var customFilterOperators = []string{"equal", "notEqual", "greaterThan", "greaterThanOrEqual", "lessThan", "lessThanOrEqual"}
+
 // writeAutoFilter provides a function to check for single or double custom
 // filters as default filters and handle them accordingly.
-func (f *File) writeAutoFilter(filter *xlsxAutoFilter, exp []int, tokens []string) {
+func (f *File) writeAutoFilter(filterColumn *xlsxFilterColumn, exp []int, tokens []string) {
 	if len(exp) == 1 && exp[0] == 2 {
 		// Single equality.
 		var filters []*xlsxFilter
 		filters = append(filters, &xlsxFilter{Val: tokens[0]})
-		filter.FilterColumn[0].Filters = &xlsxFilters{Filter: filters}
+		filterColumn.Filters = &xlsxFilters{Filter: filters}
 	} else if len(exp) == 3 && exp[0] == 2 && exp[1] == 1 && exp[2] == 2 {
 		// Double equality with "or" operator.
 		filters := []*xlsxFilter{}
 		for _, v := range tokens {
 			filters = append(filters, &xlsxFilter{Val: v})
 		}
-		filter.FilterColumn[0].Filters = &xlsxFilters{Filter: filters}
+		filterColumn.Filters = &xlsxFilters{Filter: filters}
 	} else {
 		// Non default custom filter.
 		expRel := map[int]int{0: 0, 1: 2}
 		andRel := map[int]bool{0: true, 1: false}
 		for k, v := range tokens {
-			f.writeCustomFilter(filter, exp[expRel[k]], v)
+			f.writeCustomFilter(filterColumn, exp[expRel[k]], v)
 			if k == 1 {
-				filter.FilterColumn[0].CustomFilters.And = andRel[exp[k]]
+				filterColumn.CustomFilters.And = andRel[exp[k]]
 			}
 		}
 	}
 }
 
 // writeCustomFilter provides a function to write the <customFilter> element.
-func (f *File) writeCustomFilter(filter *xlsxAutoFilter, operator int, val string) {
+func (f *File) writeCustomFilter(filterColumn *xlsxFilterColumn, operator int, val string) {
 	operators := map[int]string{
 		1:  "lessThan",
 		2:  "equal",
@@ -446,12 +1026,12 @@ func (f *File) writeCustomFilter(filter *xlsxAutoFilter, operator int, val strin
 		Operator: operators[operator],
 		Val:      val,
 	}
-	if filter.FilterColumn[0].CustomFilters != nil {
-		filter.FilterColumn[0].CustomFilters.CustomFilter = append(filter.FilterColumn[0].CustomFilters.CustomFilter, &customFilter)
+	if filterColumn.CustomFilters != nil {
+		filterColumn.CustomFilters.CustomFilter = append(filterColumn.CustomFilters.CustomFilter, &customFilter)
 	} else {
 		customFilters := []*xlsxCustomFilter{}
 		customFilters = append(customFilters, &customFilter)
-		filter.FilterColumn[0].CustomFilters = &xlsxCustomFilters{CustomFilter: customFilters}
+		filterColumn.CustomFilters = &xlsxCustomFilters{CustomFilter: customFilters}
 	}
 }
 
@@ -45,6 +45,134 @@ func TestAddTable(t *testing.T) {
 	assert.EqualError(t, f.addTable("sheet1", "", 1, 1, 0, 0, 0, nil), "invalid cell coordinates [0, 0]")
 }
 
+func TestAddTableTotalsRow(t *testing.T) {
+	f := NewFile()
+	for idx, header := range []string{"Name", "Sales", "Price [USD]"} {
+		cell, _ := CoordinatesToCellName(idx+1, 1)
+		assert.NoError(t, f.SetCellStr("Sheet1", cell, header))
+	}
+	assert.NoError(t, f.AddTable("Sheet1", "A1", "C5", `{
+		"table_name": "Sales",
+		"show_total_row": true,
+		"columns": [
+			{"totals_row_label": "Total"},
+			{"totals_row_function": "sum"},
+			{"totals_row_function": "custom", "totals_row_formula": "COUNTBLANK(Sales[Price '[USD'])])"}
+		]
+	}`))
+	table := f.LoadTableID(1)
+	assert.Equal(t, "A1:C6", table.Ref)
+	assert.Equal(t, "A1:C5", table.AutoFilter.Ref)
+	assert.Equal(t, 1, table.TotalsRowCount)
+	assert.Equal(t, "Total", table.TableColumns.TableColumn[0].TotalsRowLabel)
+	assert.Equal(t, "sum", table.TableColumns.TableColumn[1].TotalsRowFunction)
+	assert.Equal(t, "custom", table.TableColumns.TableColumn[2].TotalsRowFunction)
+	assert.Equal(t, "COUNTBLANK(Sales[Price '[USD'])])", table.TableColumns.TableColumn[2].TotalsRowFormula)
+	val, err := f.GetCellValue("Sheet1", "A6")
+	assert.NoError(t, err)
+	assert.Equal(t, "Total", val)
+	formula, err := f.GetCellFormula("Sheet1", "B6")
+	assert.NoError(t, err)
+	assert.Equal(t, "SUBTOTAL(109,Sales[Sales])", formula)
+	formula, err = f.GetCellFormula("Sheet1", "C6")
+	assert.NoError(t, err)
+	assert.Equal(t, "COUNTBLANK(Sales[Price '[USD'])])", formula)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddTableTotalsRow.xlsx")))
+
+	// Test add table with invalid totals row function.
+	assert.EqualError(t, f.AddTable("Sheet1", "E1", "F5", `{"show_total_row":true,"columns":[{"totals_row_function":"median"}]}`), ErrParameterInvalid.Error())
+	// Test add table with custom totals row function without formula.
+	assert.EqualError(t, f.AddTable("Sheet1", "E1", "F5", `{"show_total_row":true,"columns":[{"totals_row_function":"custom"}]}`), ErrParameterRequired.Error())
+	// Test add table with totals row exceeds the maximum rows.
+	assert.EqualError(t, f.AddTable("Sheet1", "E1", fmt.Sprintf("F%d", TotalRows), `{"show_total_row":true}`), ErrMaxRows.Error())
+}
+
+func TestGetTables(t *testing.T) {
+	f := NewFile()
+	tables, err := f.GetTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []Table{}, tables)
+
+	assert.NoError(t, f.AddTable("Sheet1", "A1", "B5", `{"table_name":"Sales","table_style":"TableStyleMedium2","show_first_column":true,"show_total_row":true,"columns":[{"totals_row_label":"Total"},{"totals_row_function":"sum"}]}`))
+	assert.NoError(t, f.AddTable("Sheet1", "D1", "E3", `{}`))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGetTables.xlsx")))
+
+	f, err = OpenFile(filepath.Join("test", "TestGetTables.xlsx"))
+	assert.NoError(t, err)
+	tables, err = f.GetTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []Table{
+		{
+			Name:            "Sales",
+			Range:           "A1:B6",
+			ShowHeaderRow:   true,
+			TableStyle:      "TableStyleMedium2",
+			ShowFirstColumn: true,
+			ShowRowStripes:  true,
+			ShowTotalRow:    true,
+			Columns: []TableColumn{
+				{ID: 1, Name: "Column1", TotalsRowLabel: "Total"},
+				{ID: 2, Name: "Column2", TotalsRowFunction: "sum"},
+			},
+		},
+		{
+			Name:           "Table2",
+			Range:          "D1:E3",
+			ShowHeaderRow:  true,
+			ShowRowStripes: true,
+			Columns:        []TableColumn{{ID: 1, Name: "Column1"}, {ID: 2, Name: "Column2"}},
+		},
+	}, tables)
+	// Test get tables on not exists worksheet.
+	_, err = f.GetTables("SheetN")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+	// Test get tables with unsupported charset.
+	f.Pkg.Store("xl/tables/table1.xml", MacintoshCyrillicCharset)
+	_, err = f.GetTables("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestDeleteTable(t *testing.T) {
+	f := NewFile()
+	f.NewSheet("Sheet2")
+	assert.NoError(t, f.AddTable("Sheet1", "A1", "B5", `{"table_name":"Sales"}`))
+	assert.NoError(t, f.AddTable("Sheet2", "A1", "B5", `{"table_name":"Orders"}`))
+	assert.NoError(t, f.AddTable("Sheet2", "D1", "E5", `{"table_name":"Items"}`))
+	assert.NoError(t, f.SetCellValue("Sheet2", "A2", 100))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDeleteTable.xlsx")))
+
+	f, err := OpenFile(filepath.Join("test", "TestDeleteTable.xlsx"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.DeleteTable("orders"))
+	tables, err := f.GetTables("Sheet2")
+	assert.NoError(t, err)
+	if assert.Len(t, tables, 1) {
+		assert.Equal(t, "Items", tables[0].Name)
+	}
+	_, ok := f.Pkg.Load("xl/tables/table2.xml")
+	assert.False(t, ok)
+	// Test the cells of the deleted table should be kept.
+	val, err := f.GetCellValue("Sheet2", "A2")
+	assert.NoError(t, err)
+	assert.Equal(t, "100", val)
+	// Test the index of the deleted table should not be reused.
+	assert.NoError(t, f.AddTable("Sheet2", "A1", "B5", `{"table_name":"Orders"}`))
+	_, ok = f.Pkg.Load("xl/tables/table4.xml")
+	assert.True(t, ok)
+	assert.NoError(t, f.DeleteTable("Sales"))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Nil(t, ws.TableParts)
+	// Test delete not exists table.
+	assert.EqualError(t, f.DeleteTable("Sales"), "table Sales does not exist")
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDeleteTable.xlsx")))
+	// Test delete table with unsupported charset.
+	f.Pkg.Store("xl/tables/table3.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.DeleteTable("Items"), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
 func TestAutoFilter(t *testing.T) {
 	outFile := filepath.Join("test", "TestAutoFilter%d.xlsx")
 
@@ -77,6 +205,157 @@ func TestAutoFilter(t *testing.T) {
 	assert.EqualError(t, f.AutoFilter("Sheet1", "A1", "B", ""), `cannot convert cell "B" to coordinates: invalid cell name "B"`)
 }
 
+func TestAutoFilterMultipleColumns(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AutoFilter("Sheet1", "A1", "E20", `{"column":"A","expression":"x == 1","columns":[
+		{"column":"B","filters":["West","East",""]},
+		{"column":"C","and":true,"custom_filters":[
+			{"operator":"greaterThan","val":"1000"},
+			{"operator":"lessThan","val":"5000"}
+		]},
+		{"column":"D","custom_filters":[{"operator":"notEqual","val":"0"}]},
+		{"column":"E","top10":{"top":true,"percent":true,"val":10}}
+	]}`))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, &xlsxAutoFilter{
+		Ref: "$A$1:$E$20",
+		FilterColumn: []*xlsxFilterColumn{
+			{ColID: 0, Filters: &xlsxFilters{Filter: []*xlsxFilter{{Val: "1"}}}},
+			{ColID: 1, Filters: &xlsxFilters{Blank: true, Filter: []*xlsxFilter{{Val: "West"}, {Val: "East"}}}},
+			{ColID: 2, CustomFilters: &xlsxCustomFilters{And: true, CustomFilter: []*xlsxCustomFilter{
+				{Operator: "greaterThan", Val: "1000"},
+				{Operator: "lessThan", Val: "5000"},
+			}}},
+			{ColID: 3, CustomFilters: &xlsxCustomFilters{CustomFilter: []*xlsxCustomFilter{{Operator: "notEqual", Val: "0"}}}},
+			{ColID: 4, Top10: &xlsxTop10{Top: true, Percent: true, Val: 10}},
+		},
+	}, ws.AutoFilter)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAutoFilterMultipleColumns.xlsx")))
+
+	for _, c := range []struct {
+		format, err string
+	}{
+		{`{"columns":[{"column":"F","filters":["West"]}]}`, "incorrect index of column 'F'"},
+		{`{"columns":[{"column":"A","filters":["West"]},{"column":"A","filters":["East"]}]}`, "duplicate filter column 'A'"},
+		{`{"columns":[{"column":"A"}]}`, "no criteria in column 'A'"},
+		{`{"columns":[{"column":"A","expression":"x =="}]}`, "incorrect number of tokens in criteria 'x =='"},
+		{`{"columns":[{"column":"A","custom_filters":[{"operator":"between","val":"1"}]}]}`, "unknown operator: between"},
+		{`{"columns":[{"column":"A","custom_filters":[{"operator":"equal"},{"operator":"equal"},{"operator":"equal"}]}]}`, "at most two custom filters in column 'A'"},
+		{`{"columns":[{"column":"A","top10":{"percent":true,"val":101}}]}`, ErrParameterInvalid.Error()},
+		{`{"columns":[{"column":"A","top10":{"val":0}}]}`, ErrParameterInvalid.Error()},
+	} {
+		assert.EqualError(t, f.AutoFilter("Sheet1", "A1", "E20", c.format), c.err)
+	}
+}
+
+func TestAutoFilterApply(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{
+		{"Region", "Item", "Sales"},
+		{"West", "Apple", 1200},
+		{"East", "Banana", 800},
+		{"North", "apple pie", 3000},
+		{"South", "Cherry", 150},
+		{"West", "", 2500},
+		{"east", "A*B", 600},
+	} {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", idx+1), &row))
+	}
+	visible := func(t *testing.T, expected []bool) {
+		for idx, exp := range expected {
+			actual, err := f.GetRowVisible("Sheet1", idx+2)
+			assert.NoError(t, err)
+			assert.Equal(t, exp, actual, fmt.Sprintf("row %d", idx+2))
+		}
+	}
+	for _, c := range []struct {
+		format   string
+		expected []bool
+	}{
+		{`{"column":"C","expression":"x > 1000","apply":true}`, []bool{true, false, true, false, true, false}},
+		{`{"column":"C","expression":"x >= 600 and x <= 1200","apply":true}`, []bool{true, true, false, false, false, true}},
+		{`{"column":"B","expression":"x == a*","apply":true}`, []bool{true, false, true, false, false, true}},
+		{`{"column":"B","expression":"x != *e*","apply":true}`, []bool{false, true, false, false, true, true}},
+		{`{"column":"B","expression":"x == ?pple","apply":true}`, []bool{true, false, false, false, false, false}},
+		{`{"column":"B","expression":"x == A~*B","apply":true}`, []bool{false, false, false, false, false, true}},
+		{`{"column":"B","expression":"x == NonBlanks","apply":true}`, []bool{true, true, true, true, false, true}},
+		{`{"column":"B","expression":"x > b","apply":true}`, []bool{false, true, false, true, false, false}},
+		{`{"columns":[{"column":"A","filters":["EAST","West"]},{"column":"B","filters":["banana",""]}],"apply":true}`, []bool{false, true, false, false, true, false}},
+		{`{"columns":[{"column":"C","top10":{"top":true,"val":2}}],"apply":true}`, []bool{false, false, true, false, true, false}},
+		{`{"columns":[{"column":"C","top10":{"percent":true,"val":50}}],"apply":true}`, []bool{false, true, false, true, false, true}},
+		{`{"columns":[{"column":"A","custom_filters":[{"operator":"equal","val":"n*"},{"operator":"equal","val":"s*"}]}],"apply":true}`, []bool{false, false, true, true, false, false}},
+		{`{"columns":[{"column":"C","custom_filters":[{"operator":"greaterThan","val":"West"}]}],"apply":true}`, []bool{false, false, false, false, false, false}},
+		{`{"column":"C","expression":"x > 1000"}`, []bool{true, true, true, true, true, true}},
+	} {
+		for row := 2; row <= 7; row++ {
+			assert.NoError(t, f.SetRowVisible("Sheet1", row, true))
+		}
+		assert.NoError(t, f.AutoFilter("Sheet1", "A1", "C7", c.format))
+		visible(t, c.expected)
+	}
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, f.AutoFilter("Sheet1", "A1", "C7", `{"columns":[{"column":"C","top10":{"top":true,"val":2}}],"apply":true}`))
+	assert.Equal(t, 2500.0, ws.AutoFilter.FilterColumn[0].Top10.FilterVal)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAutoFilterApply.xlsx")))
+	// Test apply auto filter with invalid range reference.
+	assert.EqualError(t, f.applyAutoFilter("Sheet1", &xlsxAutoFilter{Ref: "A1"}), ErrParameterInvalid.Error())
+	assert.EqualError(t, f.applyAutoFilter("Sheet1", &xlsxAutoFilter{Ref: "XFD1:XFD2", FilterColumn: []*xlsxFilterColumn{{ColID: 1}}}), "column number exceeds maximum limit")
+	// Test apply auto filter on not exists worksheet.
+	assert.EqualError(t, f.applyAutoFilter("SheetN", &xlsxAutoFilter{Ref: "A1:A2"}), "sheet SheetN is not exist")
+	// Test apply top 10 filter without numeric cell values.
+	top10 := &xlsxTop10{Top: true, Val: 10}
+	setTop10FilterVal(top10, []filterCellValue{{text: "a", raw: "a"}})
+	assert.Equal(t, 0.0, top10.FilterVal)
+	assert.False(t, matchFilterColumn(&xlsxFilterColumn{Top10: top10}, filterCellValue{text: "a", raw: "a"}))
+	assert.False(t, matchCustomFilter(&xlsxCustomFilter{Operator: "unknown", Val: "1"}, filterCellValue{text: "1", raw: "1"}))
+	assert.True(t, matchCustomFilter(&xlsxCustomFilter{Val: " "}, filterCellValue{}))
+	assert.NoError(t, f.Close())
+}
+
+func TestGetAutoFilter(t *testing.T) {
+	f := NewFile()
+	opts, err := f.GetAutoFilter("Sheet1")
+	assert.NoError(t, err)
+	assert.Nil(t, opts)
+
+	assert.NoError(t, f.AutoFilter("Sheet1", "B2", "F20", `{"column":"B","expression":"x > 1 or x < -1","columns":[
+		{"column":"C","filters":["West","East",""]},
+		{"column":"F","top10":{"percent":true,"val":10}}
+	]}`))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGetAutoFilter.xlsx")))
+
+	f, err = OpenFile(filepath.Join("test", "TestGetAutoFilter.xlsx"))
+	assert.NoError(t, err)
+	opts, err = f.GetAutoFilter("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, &AutoFilterOptions{
+		Range: "B2:F20",
+		Columns: []AutoFilterColumn{
+			{Column: "B", CustomFilters: []AutoFilterCustomFilter{
+				{Operator: "greaterThan", Val: "1"},
+				{Operator: "lessThan", Val: "-1"},
+			}},
+			{Column: "C", Filters: []string{"West", "East", ""}},
+			{Column: "F", Top10: &AutoFilterTop10{Percent: true, Val: 10}},
+		},
+	}, opts)
+	// Test get auto filter on not exists worksheet.
+	_, err = f.GetAutoFilter("SheetN")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+	// Test get auto filter with invalid range reference.
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.AutoFilter.Ref = "B:F"
+	_, err = f.GetAutoFilter("Sheet1")
+	assert.EqualError(t, err, `cannot convert cell "B" to coordinates: invalid cell name "B"`)
+	ws.AutoFilter.Ref = "XFD1:XFD2"
+	_, err = f.GetAutoFilter("Sheet1")
+	assert.EqualError(t, err, "column number exceeds maximum limit")
+	assert.NoError(t, f.Close())
+}
+
 func TestAutoFilterError(t *testing.T) {
 	outFile := filepath.Join("test", "TestAutoFilterError%d.xlsx")
 
@@ -44,9 +44,10 @@ type xlsxIDmap struct {
 type xlsxShape struct {
 	XMLName     xml.Name `xml:"v:shape"`
 	ID          string   `xml:"id,attr"`
+	Spid        string   `xml:"o:spid,attr,omitempty"`
 	Type        string   `xml:"type,attr"`
 	Style       string   `xml:"style,attr"`
-	Fillcolor   string   `xml:"fillcolor,attr"`
+	Fillcolor   string   `xml:"fillcolor,attr,omitempty"`
 	Insetmode   string   `xml:"urn:schemas-microsoft-com:office:office insetmode,attr,omitempty"`
 	Strokecolor string   `xml:"strokecolor,attr,omitempty"`
 	Val         string   `xml:",innerxml"`
@@ -54,12 +55,17 @@ type xlsxShape struct {
 
 // xlsxShapetype directly maps the shapetype element.
 type xlsxShapetype struct {
-	ID        string      `xml:"id,attr"`
-	Coordsize string      `xml:"coordsize,attr"`
-	Spt       int         `xml:"o:spt,attr"`
-	Path      string      `xml:"path,attr"`
-	Stroke    *xlsxStroke `xml:"v:stroke"`
-	VPath     *vPath      `xml:"v:path"`
+	ID             string      `xml:"id,attr"`
+	Coordsize      string      `xml:"coordsize,attr"`
+	Spt            int         `xml:"o:spt,attr"`
+	Preferrelative string      `xml:"o:preferrelative,attr,omitempty"`
+	Path           string      `xml:"path,attr"`
+	Filled         string      `xml:"filled,attr,omitempty"`
+	Stroked        string      `xml:"stroked,attr,omitempty"`
+	Stroke         *xlsxStroke `xml:"v:stroke"`
+	Formulas       *vFormulas  `xml:"v:formulas"`
+	VPath          *vPath      `xml:"v:path"`
+	Lock           *oLock      `xml:"o:lock"`
 }
 
 // xlsxStroke directly maps the stroke element.
@@ -67,12 +73,40 @@ type xlsxStroke struct {
 	Joinstyle string `xml:"joinstyle,attr"`
 }
 
+// vFormulas directly maps the v:formulas element. This element contains the
+// formulas used to calculate the shape path.
+type vFormulas struct {
+	Formula []vFormula `xml:"v:f"`
+}
+
+// vFormula directly maps the v:f element.
+type vFormula struct {
+	Eqn string `xml:"eqn,attr"`
+}
+
 // vPath directly maps the v:path element.
 type vPath struct {
+	Extrusionok     string `xml:"o:extrusionok,attr,omitempty"`
 	Gradientshapeok string `xml:"gradientshapeok,attr,omitempty"`
 	Connecttype     string `xml:"o:connecttype,attr"`
 }
 
+// oLock directly maps the o:lock element.
+type oLock struct {
+	XMLName     xml.Name `xml:"o:lock"`
+	Ext         string   `xml:"v:ext,attr"`
+	Rotation    string   `xml:"rotation,attr,omitempty"`
+	Aspectratio string   `xml:"aspectratio,attr,omitempty"`
+}
+
+// vImageData directly maps the v:imagedata element. This element specifies
+// the image of the shape by the relationship ID.
+type vImageData struct {
+	XMLName xml.Name `xml:"v:imagedata"`
+	RelID   string   `xml:"o:relid,attr"`
+	Title   string   `xml:"o:title,attr"`
+}
+
 // vFill directly maps the v:fill element. This element must be defined within a
 // Shape element.
 type vFill struct {
@@ -116,13 +150,14 @@ type xlsxDiv struct {
 // child elements is appropriate. Relevant groups are identified for each child
 // element.
 type xClientData struct {
-	ObjectType    string `xml:"ObjectType,attr"`
-	MoveWithCells string `xml:"x:MoveWithCells,omitempty"`
-	SizeWithCells string `xml:"x:SizeWithCells,omitempty"`
-	Anchor        string `xml:"x:Anchor"`
-	AutoFill      string `xml:"x:AutoFill"`
-	Row           int    `xml:"x:Row"`
-	Column        int    `xml:"x:Column"`
+	ObjectType    string  `xml:"ObjectType,attr"`
+	MoveWithCells string  `xml:"x:MoveWithCells,omitempty"`
+	SizeWithCells string  `xml:"x:SizeWithCells,omitempty"`
+	Anchor        string  `xml:"x:Anchor"`
+	AutoFill      string  `xml:"x:AutoFill"`
+	Row           int     `xml:"x:Row"`
+	Column        int     `xml:"x:Column"`
+	Visible       *string `xml:"x:Visible"`
 }
 
 // decodeVmlDrawing defines the structure used to parse the file
@@ -133,7 +168,11 @@ type decodeVmlDrawing struct {
 
 // decodeShape defines the structure used to parse the particular shape element.
 type decodeShape struct {
-	Val string `xml:",innerxml"`
+	ID    string `xml:"id,attr"`
+	Spid  string `xml:"urn:schemas-microsoft-com:office:office spid,attr"`
+	Type  string `xml:"type,attr"`
+	Style string `xml:"style,attr"`
+	Val   string `xml:",innerxml"`
 }
 
 // encodeShape defines the structure used to re-serialization shape element.
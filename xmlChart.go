@@ -106,9 +106,18 @@ type aBodyPr struct {
 type aP struct {
 	PPr        *aPPr        `xml:"a:pPr"`
 	R          *aR          `xml:"a:r"`
+	Runs       []aPEl       `xml:",any"`
 	EndParaRPr *aEndParaRPr `xml:"a:endParaRPr"`
 }
 
+// aPEl directly maps the text run (a:r) and the vertical line break (a:br)
+// elements of the paragraph, which should be kept in order.
+type aPEl struct {
+	XMLName xml.Name
+	RPr     *aRPr   `xml:"a:rPr"`
+	T       *string `xml:"a:t"`
+}
+
 // aPPr (Paragraph Properties) directly maps the a:pPr element. This element
 // specifies a set of paragraph properties which shall be applied to the
 // contents of the parent paragraph after all style/numbering/table properties
@@ -301,45 +310,49 @@ type cView3D struct {
 // cPlotArea directly maps the plotArea element. This element specifies the
 // plot area of the chart.
 type cPlotArea struct {
-	Layout         *string  `xml:"layout"`
-	AreaChart      *cCharts `xml:"areaChart"`
-	Area3DChart    *cCharts `xml:"area3DChart"`
-	BarChart       *cCharts `xml:"barChart"`
-	Bar3DChart     *cCharts `xml:"bar3DChart"`
-	BubbleChart    *cCharts `xml:"bubbleChart"`
-	DoughnutChart  *cCharts `xml:"doughnutChart"`
-	LineChart      *cCharts `xml:"lineChart"`
-	PieChart       *cCharts `xml:"pieChart"`
-	Pie3DChart     *cCharts `xml:"pie3DChart"`
-	OfPieChart     *cCharts `xml:"ofPieChart"`
-	RadarChart     *cCharts `xml:"radarChart"`
-	ScatterChart   *cCharts `xml:"scatterChart"`
-	Surface3DChart *cCharts `xml:"surface3DChart"`
-	SurfaceChart   *cCharts `xml:"surfaceChart"`
-	CatAx          []*cAxs  `xml:"catAx"`
-	ValAx          []*cAxs  `xml:"valAx"`
-	SerAx          []*cAxs  `xml:"serAx"`
-	SpPr           *cSpPr   `xml:"spPr"`
+	Layout         *string    `xml:"layout"`
+	AreaChart      []*cCharts `xml:"areaChart"`
+	Area3DChart    []*cCharts `xml:"area3DChart"`
+	BarChart       []*cCharts `xml:"barChart"`
+	Bar3DChart     []*cCharts `xml:"bar3DChart"`
+	BubbleChart    []*cCharts `xml:"bubbleChart"`
+	DoughnutChart  []*cCharts `xml:"doughnutChart"`
+	LineChart      []*cCharts `xml:"lineChart"`
+	PieChart       []*cCharts `xml:"pieChart"`
+	Pie3DChart     []*cCharts `xml:"pie3DChart"`
+	OfPieChart     []*cCharts `xml:"ofPieChart"`
+	RadarChart     []*cCharts `xml:"radarChart"`
+	ScatterChart   []*cCharts `xml:"scatterChart"`
+	Surface3DChart []*cCharts `xml:"surface3DChart"`
+	SurfaceChart   []*cCharts `xml:"surfaceChart"`
+	CatAx          []*cAxs    `xml:"catAx"`
+	ValAx          []*cAxs    `xml:"valAx"`
+	SerAx          []*cAxs    `xml:"serAx"`
+	SpPr           *cSpPr     `xml:"spPr"`
 }
 
 // cCharts specifies the common element of the chart.
 type cCharts struct {
-	BarDir       *attrValString `xml:"barDir"`
-	BubbleScale  *attrValFloat  `xml:"bubbleScale"`
-	Grouping     *attrValString `xml:"grouping"`
-	RadarStyle   *attrValString `xml:"radarStyle"`
-	ScatterStyle *attrValString `xml:"scatterStyle"`
-	OfPieType    *attrValString `xml:"ofPieType"`
-	VaryColors   *attrValBool   `xml:"varyColors"`
-	Wireframe    *attrValBool   `xml:"wireframe"`
-	Ser          *[]cSer        `xml:"ser"`
-	SerLines     *attrValString `xml:"serLines"`
-	DLbls        *cDLbls        `xml:"dLbls"`
-	Shape        *attrValString `xml:"shape"`
-	HoleSize     *attrValInt    `xml:"holeSize"`
-	Smooth       *attrValBool   `xml:"smooth"`
-	Overlap      *attrValInt    `xml:"overlap"`
-	AxID         []*attrValInt  `xml:"axId"`
+	BarDir        *attrValString `xml:"barDir"`
+	BubbleScale   *attrValFloat  `xml:"bubbleScale"`
+	Grouping      *attrValString `xml:"grouping"`
+	RadarStyle    *attrValString `xml:"radarStyle"`
+	ScatterStyle  *attrValString `xml:"scatterStyle"`
+	OfPieType     *attrValString `xml:"ofPieType"`
+	VaryColors    *attrValBool   `xml:"varyColors"`
+	Wireframe     *attrValBool   `xml:"wireframe"`
+	Ser           *[]cSer        `xml:"ser"`
+	DLbls         *cDLbls        `xml:"dLbls"`
+	GapWidth      *attrValInt    `xml:"gapWidth"`
+	SplitType     *attrValString `xml:"splitType"`
+	SplitPos      *attrValFloat  `xml:"splitPos"`
+	SecondPieSize *attrValInt    `xml:"secondPieSize"`
+	Shape         *attrValString `xml:"shape"`
+	HoleSize      *attrValInt    `xml:"holeSize"`
+	Smooth        *attrValBool   `xml:"smooth"`
+	Overlap       *attrValInt    `xml:"overlap"`
+	SerLines      *attrValString `xml:"serLines"`
+	AxID          []*attrValInt  `xml:"axId"`
 }
 
 // cAxs directly maps the catAx and valAx element.
@@ -350,6 +363,7 @@ type cAxs struct {
 	AxPos          *attrValString `xml:"axPos"`
 	MajorGridlines *cChartLines   `xml:"majorGridlines"`
 	MinorGridlines *cChartLines   `xml:"minorGridlines"`
+	Title          *cTitle        `xml:"title"`
 	NumFmt         *cNumFmt       `xml:"numFmt"`
 	MajorTickMark  *attrValString `xml:"majorTickMark"`
 	MinorTickMark  *attrValString `xml:"minorTickMark"`
@@ -401,6 +415,8 @@ type cSer struct {
 	DLbls            *cDLbls      `xml:"dLbls"`
 	Marker           *cMarker     `xml:"marker"`
 	InvertIfNegative *attrValBool `xml:"invertIfNegative"`
+	Trendline        *cTrendline  `xml:"trendline"`
+	ErrBars          *cErrBars    `xml:"errBars"`
 	Cat              *cCat        `xml:"cat"`
 	Val              *cVal        `xml:"val"`
 	XVal             *cCat        `xml:"xVal"`
@@ -410,6 +426,28 @@ type cSer struct {
 	Bubble3D         *attrValBool `xml:"bubble3D"`
 }
 
+// cTrendline (Trendline) directly maps the trendline element. This element
+// specifies a trendline.
+type cTrendline struct {
+	TrendlineType *attrValString `xml:"trendlineType"`
+	Order         *attrValInt    `xml:"order"`
+	Period        *attrValInt    `xml:"period"`
+	DispRSqr      *attrValBool   `xml:"dispRSqr"`
+	DispEq        *attrValBool   `xml:"dispEq"`
+}
+
+// cErrBars (Error Bar) directly maps the errBars element. This element
+// specifies the error bars of the series.
+type cErrBars struct {
+	ErrDir     *attrValString `xml:"errDir"`
+	ErrBarType *attrValString `xml:"errBarType"`
+	ErrValType *attrValString `xml:"errValType"`
+	NoEndCap   *attrValBool   `xml:"noEndCap"`
+	Plus       *cVal          `xml:"plus"`
+	Minus      *cVal          `xml:"minus"`
+	Val        *attrValFloat  `xml:"val"`
+}
+
 // cMarker (Marker) directly maps the marker element. This element specifies a
 // data marker.
 type cMarker struct {
@@ -480,13 +518,15 @@ type cNumCache struct {
 // entire series or the entire chart. It contains child elements that specify
 // the specific formatting and positioning settings.
 type cDLbls struct {
-	ShowLegendKey   *attrValBool `xml:"showLegendKey"`
-	ShowVal         *attrValBool `xml:"showVal"`
-	ShowCatName     *attrValBool `xml:"showCatName"`
-	ShowSerName     *attrValBool `xml:"showSerName"`
-	ShowPercent     *attrValBool `xml:"showPercent"`
-	ShowBubbleSize  *attrValBool `xml:"showBubbleSize"`
-	ShowLeaderLines *attrValBool `xml:"showLeaderLines"`
+	NumFmt          *cNumFmt       `xml:"numFmt"`
+	DLblPos         *attrValString `xml:"dLblPos"`
+	ShowLegendKey   *attrValBool   `xml:"showLegendKey"`
+	ShowVal         *attrValBool   `xml:"showVal"`
+	ShowCatName     *attrValBool   `xml:"showCatName"`
+	ShowSerName     *attrValBool   `xml:"showSerName"`
+	ShowPercent     *attrValBool   `xml:"showPercent"`
+	ShowBubbleSize  *attrValBool   `xml:"showBubbleSize"`
+	ShowLeaderLines *attrValBool   `xml:"showLeaderLines"`
 }
 
 // cLegend (Legend) directly maps the legend element. This element specifies
@@ -521,6 +561,7 @@ type cPageMargins struct {
 // formatChartAxis directly maps the format settings of the chart axis.
 type formatChartAxis struct {
 	None                bool    `json:"none"`
+	Name                string  `json:"name"`
 	Crossing            string  `json:"crossing"`
 	MajorGridlines      bool    `json:"major_grid_lines"`
 	MinorGridlines      bool    `json:"minor_grid_lines"`
@@ -529,7 +570,9 @@ type formatChartAxis struct {
 	MinorUnitType       string  `json:"minor_unit_type"`
 	MajorUnit           float64 `json:"major_unit"`
 	MajorUnitType       string  `json:"major_unit_type"`
+	MinorUnit           float64 `json:"minor_unit"`
 	TickLabelSkip       int     `json:"tick_label_skip"`
+	TickLabelPosition   string  `json:"tick_label_position"`
 	DisplayUnits        string  `json:"display_units"`
 	DisplayUnitsVisible bool    `json:"display_units_visible"`
 	DateAxis            bool    `json:"date_axis"`
@@ -563,6 +606,7 @@ type formatChart struct {
 	VaryColors bool                 `json:"vary_colors"`
 	XAxis      formatChartAxis      `json:"x_axis"`
 	YAxis      formatChartAxis      `json:"y_axis"`
+	Y2Axis     formatChartAxis      `json:"y2_axis"`
 	Chartarea  struct {
 		Border struct {
 			None bool `json:"none"`
@@ -577,12 +621,14 @@ type formatChart struct {
 		} `json:"pattern"`
 	} `json:"chartarea"`
 	Plotarea struct {
-		ShowBubbleSize  bool `json:"show_bubble_size"`
-		ShowCatName     bool `json:"show_cat_name"`
-		ShowLeaderLines bool `json:"show_leader_lines"`
-		ShowPercent     bool `json:"show_percent"`
-		ShowSerName     bool `json:"show_series_name"`
-		ShowVal         bool `json:"show_val"`
+		ShowBubbleSize  bool   `json:"show_bubble_size"`
+		ShowCatName     bool   `json:"show_cat_name"`
+		ShowLeaderLines bool   `json:"show_leader_lines"`
+		ShowPercent     bool   `json:"show_percent"`
+		ShowSerName     bool   `json:"show_series_name"`
+		ShowVal         bool   `json:"show_val"`
+		LabelPosition   string `json:"label_position"`
+		LabelNumFormat  string `json:"label_num_format"`
 		Gradient        struct {
 			Colors []string `json:"colors"`
 		} `json:"gradient"`
@@ -600,7 +646,14 @@ type formatChart struct {
 	ShowHiddenData bool   `json:"show_hidden_data"`
 	SetRotation    int    `json:"set_rotation"`
 	SetHoleSize    int    `json:"set_hole_size"`
-	order          int
+	OfPie          struct {
+		SplitType      string  `json:"split_type"`
+		SplitValue     float64 `json:"split_value"`
+		SecondPlotSize int     `json:"second_plot_size"`
+		GapWidth       *int    `json:"gap_width"`
+	} `json:"of_pie"`
+	order     int
+	secondary bool
 }
 
 // formatChartLegend directly maps the format settings of the chart legend.
@@ -617,6 +670,8 @@ type formatChartLegend struct {
 // formatChartSeries directly maps the format settings of the chart series.
 type formatChartSeries struct {
 	Name       string `json:"name"`
+	Type       string `json:"type"`
+	Secondary  bool   `json:"secondary"`
 	Categories string `json:"categories"`
 	Values     string `json:"values"`
 	Line       struct {
@@ -637,6 +692,32 @@ type formatChartSeries struct {
 			None  bool   `json:"none"`
 		} `json:"fill"`
 	} `json:"marker"`
+	Trendline struct {
+		Type            string `json:"type"`
+		Order           int    `json:"order"`
+		Period          int    `json:"period"`
+		DisplayEquation bool   `json:"display_equation"`
+		DisplayRSquared bool   `json:"display_r_squared"`
+	} `json:"trendline"`
+	DataLabels *formatChartDataLabels `json:"data_labels"`
+	ErrorBars  struct {
+		Type      string  `json:"type"`
+		Direction string  `json:"direction"`
+		Value     float64 `json:"value"`
+		Plus      string  `json:"plus"`
+		Minus     string  `json:"minus"`
+		NoEndCap  bool    `json:"no_end_cap"`
+	} `json:"error_bars"`
+}
+
+// formatChartDataLabels directly maps the format settings of the data labels
+// of the chart series.
+type formatChartDataLabels struct {
+	ShowVal     bool   `json:"show_val"`
+	ShowCatName bool   `json:"show_cat_name"`
+	ShowSerName bool   `json:"show_series_name"`
+	Position    string `json:"position"`
+	NumFormat   string `json:"num_format"`
 }
 
 // formatChartTitle directly maps the format settings of the chart title.
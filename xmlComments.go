@@ -11,7 +11,10 @@
 
 package excelize
 
-import "encoding/xml"
+import (
+	"encoding/xml"
+	"time"
+)
 
 // xlsxComments directly maps the comments element from the namespace
 // http://schemas.openxmlformats.org/spreadsheetml/2006/main. A comment is a
@@ -74,14 +77,69 @@ type xlsxPhoneticRun struct {
 
 // formatComment directly maps the format settings of the comment.
 type formatComment struct {
-	Author string `json:"author"`
-	Text   string `json:"text"`
+	Author  string        `json:"author"`
+	Text    string        `json:"text"`
+	Runs    []RichTextRun `json:"runs"`
+	Visible bool          `json:"visible"`
+	Width   float64       `json:"width"`
+	Height  float64       `json:"height"`
 }
 
 // Comment directly maps the comment information.
 type Comment struct {
-	Author   string `json:"author"`
-	AuthorID int    `json:"author_id"`
-	Ref      string `json:"ref"`
-	Text     string `json:"text"`
+	Author   string        `json:"author"`
+	AuthorID int           `json:"author_id"`
+	Ref      string        `json:"ref"`
+	Text     string        `json:"text"`
+	Runs     []RichTextRun `json:"runs"`
+}
+
+// xlsxThreadedComments directly maps the ThreadedComments element in the
+// namespace http://schemas.microsoft.com/office/spreadsheetml/2018/threadedcomments.
+// This element is the root of the threaded comments part, which holds the
+// threaded comments and the replies of the threaded comments of a worksheet.
+type xlsxThreadedComments struct {
+	XMLName         xml.Name              `xml:"http://schemas.microsoft.com/office/spreadsheetml/2018/threadedcomments ThreadedComments"`
+	ThreadedComment []xlsxThreadedComment `xml:"threadedComment"`
+}
+
+// xlsxThreadedComment directly maps the threadedComment element. This element
+// represents a threaded comment or a reply of the threaded comment which
+// specified by the parentId attribute.
+type xlsxThreadedComment struct {
+	Ref      string `xml:"ref,attr"`
+	DT       string `xml:"dT,attr,omitempty"`
+	PersonID string `xml:"personId,attr"`
+	ID       string `xml:"id,attr"`
+	ParentID string `xml:"parentId,attr,omitempty"`
+	Done     bool   `xml:"done,attr,omitempty"`
+	Text     string `xml:"text"`
+}
+
+// xlsxPersonList directly maps the personList element in the namespace
+// http://schemas.microsoft.com/office/spreadsheetml/2018/threadedcomments.
+// This element holds the list of the authors of the threaded comments in the
+// workbook.
+type xlsxPersonList struct {
+	XMLName xml.Name     `xml:"http://schemas.microsoft.com/office/spreadsheetml/2018/threadedcomments personList"`
+	Person  []xlsxPerson `xml:"person"`
+}
+
+// xlsxPerson directly maps the person element. This element represents an
+// author of the threaded comments.
+type xlsxPerson struct {
+	DisplayName string `xml:"displayName,attr"`
+	ID          string `xml:"id,attr"`
+	UserID      string `xml:"userId,attr,omitempty"`
+	ProviderID  string `xml:"providerId,attr,omitempty"`
+}
+
+// ThreadedComment directly maps the threaded comment information.
+type ThreadedComment struct {
+	ID       string    `json:"id"`
+	ParentID string    `json:"parent_id"`
+	Ref      string    `json:"ref"`
+	Author   string    `json:"author"`
+	Text     string    `json:"text"`
+	DateTime time.Time `json:"date_time"`
 }
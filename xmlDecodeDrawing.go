@@ -18,12 +18,39 @@ import "encoding/xml"
 // specifies a two cell anchor placeholder for a group, a shape, or a drawing
 // element. It moves with cells and its extents are in EMU units.
 type decodeCellAnchor struct {
-	EditAs     string            `xml:"editAs,attr,omitempty"`
-	From       *decodeFrom       `xml:"from"`
-	To         *decodeTo         `xml:"to"`
-	Sp         *decodeSp         `xml:"sp"`
-	ClientData *decodeClientData `xml:"clientData"`
-	Content    string            `xml:",innerxml"`
+	EditAs       string              `xml:"editAs,attr,omitempty"`
+	From         *decodeFrom         `xml:"from"`
+	To           *decodeTo           `xml:"to"`
+	Sp           *decodeSp           `xml:"sp"`
+	GraphicFrame *decodeGraphicFrame `xml:"graphicFrame"`
+	ClientData   *decodeClientData   `xml:"clientData"`
+	Content      string              `xml:",innerxml"`
+}
+
+// decodeGraphicFrame directly maps the xdr:graphicFrame element. This element
+// describes a single graphical object frame for a spreadsheet which contains
+// a graphical object.
+type decodeGraphicFrame struct {
+	Graphic decodeGraphic `xml:"graphic"`
+}
+
+// decodeGraphic directly maps the a:graphic element. This element specifies
+// the existence of a single graphic object.
+type decodeGraphic struct {
+	GraphicData decodeGraphicData `xml:"graphicData"`
+}
+
+// decodeGraphicData directly maps the a:graphicData element. This element
+// specifies the reference to a graphic object within the document.
+type decodeGraphicData struct {
+	URI   string       `xml:"uri,attr"`
+	Chart *decodeChart `xml:"chart"`
+}
+
+// decodeChart directly maps the c:chart element. This element specifies the
+// relationship ID of the chart part.
+type decodeChart struct {
+	RID string `xml:"id,attr"`
 }
 
 // xdrSp (Shape) directly maps the sp element. This element specifies the
@@ -36,6 +63,26 @@ type decodeCellAnchor struct {
 type decodeSp struct {
 	NvSpPr *decodeNvSpPr `xml:"nvSpPr"`
 	SpPr   *decodeSpPr   `xml:"spPr"`
+	TxBody *decodeTxBody `xml:"txBody"`
+}
+
+// decodeTxBody directly maps the txBody (Shape Text Body). This element
+// specifies the existence of text to be contained within the corresponding
+// shape.
+type decodeTxBody struct {
+	P []decodeP `xml:"p"`
+}
+
+// decodeP directly maps the p (Text Paragraphs) element, the text runs and
+// line breaks of the paragraph are kept in order.
+type decodeP struct {
+	Runs []decodePEl `xml:",any"`
+}
+
+// decodePEl directly maps the child elements of the text paragraph.
+type decodePEl struct {
+	XMLName xml.Name
+	T       string `xml:"t"`
 }
 
 // decodeSp (Non-Visual Properties for a Shape) directly maps the nvSpPr
@@ -154,6 +201,7 @@ type decodePrstGeom struct {
 // frame. This transformation is applied to the graphic frame just as it would
 // be for a shape or group shape.
 type decodeXfrm struct {
+	Rot int       `xml:"rot,attr,omitempty"`
 	Off decodeOff `xml:"off"`
 	Ext decodeExt `xml:"ext"`
 }
@@ -29,6 +29,8 @@ var (
 	NameSpaceDrawingML                = xml.Attr{Name: xml.Name{Local: "a", Space: "xmlns"}, Value: "http://schemas.openxmlformats.org/drawingml/2006/main"}
 	NameSpaceDrawingMLChart           = xml.Attr{Name: xml.Name{Local: "c", Space: "xmlns"}, Value: "http://schemas.openxmlformats.org/drawingml/2006/chart"}
 	NameSpaceDrawingMLSpreadSheet     = xml.Attr{Name: xml.Name{Local: "xdr", Space: "xmlns"}, Value: "http://schemas.openxmlformats.org/drawingml/2006/spreadsheetDrawing"}
+	NameSpaceDrawingMLSlicer          = xml.Attr{Name: xml.Name{Local: "sle", Space: "xmlns"}, Value: "http://schemas.microsoft.com/office/drawing/2010/slicer"}
+	NameSpaceDrawingMLSlicerX15       = xml.Attr{Name: xml.Name{Local: "sle15", Space: "xmlns"}, Value: "http://schemas.microsoft.com/office/drawing/2012/slicer"}
 	NameSpaceSpreadSheetX15           = xml.Attr{Name: xml.Name{Local: "x15", Space: "xmlns"}, Value: "http://schemas.microsoft.com/office/spreadsheetml/2010/11/main"}
 	NameSpaceSpreadSheetExcel2006Main = xml.Attr{Name: xml.Name{Local: "xne", Space: "xmlns"}, Value: "http://schemas.microsoft.com/office/excel/2006/main"}
 	NameSpaceMacExcel2008Main         = xml.Attr{Name: xml.Name{Local: "mx", Space: "xmlns"}, Value: "http://schemas.microsoft.com/office/mac/excel/2008/main"}
@@ -49,9 +51,21 @@ const (
 	SourceRelationshipDialogsheet                = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/dialogsheet"
 	SourceRelationshipPivotTable                 = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/pivotTable"
 	SourceRelationshipPivotCache                 = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/pivotCacheDefinition"
+	SourceRelationshipPivotCacheRecords          = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/pivotCacheRecords"
 	SourceRelationshipSharedStrings              = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/sharedStrings"
 	SourceRelationshipVBAProject                 = "http://schemas.microsoft.com/office/2006/relationships/vbaProject"
+	SourceRelationshipSheetMetadata              = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/sheetMetadata"
+	SourceRelationshipRichValue                  = "http://schemas.microsoft.com/office/2017/06/relationships/rdRichValue"
+	SourceRelationshipRichValueStructure         = "http://schemas.microsoft.com/office/2017/06/relationships/rdRichValueStructure"
+	SourceRelationshipRichValueRel               = "http://schemas.microsoft.com/office/2022/10/relationships/richValueRel"
+	SourceRelationshipThreadedComment            = "http://schemas.microsoft.com/office/2017/10/relationships/threadedComment"
+	SourceRelationshipPerson                     = "http://schemas.microsoft.com/office/2017/10/relationships/person"
+	SourceRelationshipSlicer                     = "http://schemas.microsoft.com/office/2007/relationships/slicer"
+	SourceRelationshipSlicerCache                = "http://schemas.microsoft.com/office/2007/relationships/slicerCache"
 	NameSpaceXML                                 = "http://www.w3.org/XML/1998/namespace"
+	NameSpaceRichData                            = "http://schemas.microsoft.com/office/spreadsheetml/2017/richdata"
+	NameSpaceDynamicArray                        = "http://schemas.microsoft.com/office/spreadsheetml/2017/dynamicarray"
+	NameSpaceThreadedComments                    = "http://schemas.microsoft.com/office/spreadsheetml/2018/threadedcomments"
 	NameSpaceXMLSchemaInstance                   = "http://www.w3.org/2001/XMLSchema-instance"
 	StrictSourceRelationship                     = "http://purl.oclc.org/ooxml/officeDocument/relationships"
 	StrictSourceRelationshipOfficeDocument       = "http://purl.oclc.org/ooxml/officeDocument/relationships/officeDocument"
@@ -65,13 +79,22 @@ const (
 	ContentTypeDrawing                           = "application/vnd.openxmlformats-officedocument.drawing+xml"
 	ContentTypeDrawingML                         = "application/vnd.openxmlformats-officedocument.drawingml.chart+xml"
 	ContentTypeMacro                             = "application/vnd.ms-excel.sheet.macroEnabled.main+xml"
+	ContentTypePerson                            = "application/vnd.ms-excel.person+xml"
+	ContentTypeRichValue                         = "application/vnd.ms-excel.rdrichvalue+xml"
+	ContentTypeRichValueRel                      = "application/vnd.ms-excel.richvaluerel+xml"
+	ContentTypeRichValueStructure                = "application/vnd.ms-excel.rdrichvaluestructure+xml"
+	ContentTypeSheetMetadata                     = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheetMetadata+xml"
 	ContentTypeSpreadSheetMLChartsheet           = "application/vnd.openxmlformats-officedocument.spreadsheetml.chartsheet+xml"
 	ContentTypeSpreadSheetMLComments             = "application/vnd.openxmlformats-officedocument.spreadsheetml.comments+xml"
 	ContentTypeSpreadSheetMLPivotCacheDefinition = "application/vnd.openxmlformats-officedocument.spreadsheetml.pivotCacheDefinition+xml"
+	ContentTypeSpreadSheetMLPivotCacheRecords    = "application/vnd.openxmlformats-officedocument.spreadsheetml.pivotCacheRecords+xml"
 	ContentTypeSpreadSheetMLPivotTable           = "application/vnd.openxmlformats-officedocument.spreadsheetml.pivotTable+xml"
 	ContentTypeSpreadSheetMLSharedStrings        = "application/vnd.openxmlformats-officedocument.spreadsheetml.sharedStrings+xml"
 	ContentTypeSpreadSheetMLTable                = "application/vnd.openxmlformats-officedocument.spreadsheetml.table+xml"
 	ContentTypeSpreadSheetMLWorksheet            = "application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"
+	ContentTypeThreadedComments                  = "application/vnd.ms-excel.threadedcomments+xml"
+	ContentTypeSlicer                            = "application/vnd.ms-excel.slicer+xml"
+	ContentTypeSlicerCache                       = "application/vnd.ms-excel.slicerCache+xml"
 	ContentTypeVBA                               = "application/vnd.ms-office.vbaProject"
 	ContentTypeVML                               = "application/vnd.openxmlformats-officedocument.vmlDrawing"
 	// ExtURIConditionalFormattings is the extLst child element
@@ -84,12 +107,15 @@ const (
 	ExtURISlicerListX14          = "{A8765BA9-456A-4DAB-B4F3-ACF838C121DE}"
 	ExtURISlicerCachesListX14    = "{BBE1A952-AA13-448e-AADC-164F8A28A991}"
 	ExtURISlicerListX15          = "{3A4CF648-6AED-40f4-86FF-DC5316D8AED3}"
+	ExtURISlicerCachesX15        = "{46BE6895-7355-4a93-B00E-2C351335B9C9}"
+	ExtURITableSlicerCache       = "{2F2917AC-EB37-4324-AD4E-5DD8C200BD13}"
 	ExtURIProtectedRanges        = "{FC87AEE6-9EDD-4A0A-B7FB-166176984837}"
 	ExtURIIgnoredErrors          = "{01252117-D84E-4E92-8308-4BE1C098FCBB}"
 	ExtURIWebExtensions          = "{F7C9EE02-42E1-4005-9D12-6889AFFD525C}"
 	ExtURITimelineRefs           = "{7E03D99C-DC04-49d9-9315-930204A7B6E9}"
 	ExtURIDrawingBlip            = "{28A0092B-C50C-407E-A947-70E740481C1C}"
 	ExtURIMacExcelMX             = "{64002731-A6B0-56B0-2670-7721B7C09600}"
+	ExtURIRichValueBlock         = "{3e2802c4-a4d2-4d8b-9148-e3be6c30e623}"
 )
 
 // Excel specifications and limits
@@ -113,7 +139,12 @@ const (
 	pivotTableVersion = 3
 )
 
-var supportImageTypes = map[string]string{".gif": ".gif", ".jpg": ".jpeg", ".jpeg": ".jpeg", ".png": ".png", ".tif": ".tiff", ".tiff": ".tiff"}
+var supportImageTypes = map[string]string{".gif": ".gif", ".jpg": ".jpeg", ".jpeg": ".jpeg", ".png": ".png", ".tif": ".tiff", ".tiff": ".tiff", ".webp": ".webp"}
+
+// convertImageTypes defined the image types which could not be rendered by
+// Excel natively, and would be converted to PNG with the "auto_convert"
+// option.
+var convertImageTypes = map[string]bool{".webp": true}
 
 // xlsxCNvPr directly maps the cNvPr (Non-Visual Drawing Properties). This
 // element specifies non-visual canvas properties. This allows for additional
@@ -202,8 +233,11 @@ type xlsxPrstGeom struct {
 // frame. This transformation is applied to the graphic frame just as it would
 // be for a shape or group shape.
 type xlsxXfrm struct {
-	Off xlsxOff `xml:"a:off"`
-	Ext xlsxExt `xml:"a:ext"`
+	Rot   int     `xml:"rot,attr,omitempty"`
+	FlipH bool    `xml:"flipH,attr,omitempty"`
+	FlipV bool    `xml:"flipV,attr,omitempty"`
+	Off   xlsxOff `xml:"a:off"`
+	Ext   xlsxExt `xml:"a:ext"`
 }
 
 // xlsxCNvPicPr directly maps the cNvPicPr (Non-Visual Picture Drawing
@@ -231,15 +265,35 @@ type xlsxNvPicPr struct {
 // picture has a picture fill already by default, it is possible to have two
 // fills specified for a picture object.
 type xlsxBlipFill struct {
-	Blip    xlsxBlip    `xml:"a:blip"`
-	Stretch xlsxStretch `xml:"a:stretch"`
+	Blip    xlsxBlip     `xml:"a:blip"`
+	SrcRect *xlsxSrcRect `xml:"a:srcRect"`
+	Stretch xlsxStretch  `xml:"a:stretch"`
+}
+
+// xlsxSrcRect directly maps the srcRect (Source Rectangle). This element
+// specifies a portion of the blip used for the fill. Each edge of the source
+// rectangle is defined by a percentage offset from the corresponding edge of
+// the bounding box, the values are in 1000th of a percent.
+type xlsxSrcRect struct {
+	L int `xml:"l,attr,omitempty"`
+	T int `xml:"t,attr,omitempty"`
+	R int `xml:"r,attr,omitempty"`
+	B int `xml:"b,attr,omitempty"`
 }
 
 // xlsxLineProperties specifies the width of a line in EMUs. This simple type
 // has a minimum value of greater than or equal to 0. This simple type has a
 // maximum value of less than or equal to 20116800.
 type xlsxLineProperties struct {
-	W int `xml:"w,attr,omitempty"`
+	W       int       `xml:"w,attr,omitempty"`
+	HeadEnd *aLineEnd `xml:"a:headEnd"`
+	TailEnd *aLineEnd `xml:"a:tailEnd"`
+}
+
+// aLineEnd directly maps the a:headEnd and a:tailEnd element. This element
+// specifies decorations which can be added to the head or tail of a line.
+type aLineEnd struct {
+	Type string `xml:"type,attr,omitempty"`
 }
 
 // xlsxSpPr directly maps the spPr (Shape Properties). This element specifies
@@ -250,9 +304,42 @@ type xlsxLineProperties struct {
 type xlsxSpPr struct {
 	Xfrm     xlsxXfrm           `xml:"a:xfrm"`
 	PrstGeom xlsxPrstGeom       `xml:"a:prstGeom"`
+	GradFill *aGradFill         `xml:"a:gradFill"`
 	Ln       xlsxLineProperties `xml:"a:ln"`
 }
 
+// aGradFill (Gradient Fill) directly maps the a:gradFill element. This
+// element defines a gradient fill, a gradient fill is a fill which is
+// characterized by a smooth gradual transition from one color to the next.
+type aGradFill struct {
+	RotWithShape bool    `xml:"rotWithShape,attr,omitempty"`
+	GsLst        *aGsLst `xml:"a:gsLst"`
+	Lin          *aLin   `xml:"a:lin"`
+}
+
+// aGsLst (Gradient Stop List) directly maps the a:gsLst element. The list of
+// gradient stops that specifies the gradient colors and their relative
+// positions in the color band.
+type aGsLst struct {
+	Gs []*aGs `xml:"a:gs"`
+}
+
+// aGs (Gradient stops) directly maps the a:gs element. This element defines
+// a gradient stop, the pos attribute specifies where this gradient stop
+// should appear in the color band.
+type aGs struct {
+	Pos     int            `xml:"pos,attr"`
+	SrgbClr *attrValString `xml:"a:srgbClr"`
+}
+
+// aLin (Linear Gradient Fill) directly maps the a:lin element. This element
+// specifies a linear gradient, the ang attribute specifies the direction of
+// color change for the gradient.
+type aLin struct {
+	Ang    int  `xml:"ang,attr"`
+	Scaled bool `xml:"scaled,attr"`
+}
+
 // xlsxPic elements encompass the definition of pictures within the DrawingML
 // framework. While pictures are in many ways very similar to shapes they have
 // specific properties that are unique in order to optimize for picture-
@@ -301,6 +388,7 @@ type xdrCellAnchor struct {
 	To           *xlsxTo        `xml:"xdr:to"`
 	Ext          *xlsxExt       `xml:"xdr:ext"`
 	Sp           *xdrSp         `xml:"xdr:sp"`
+	CxnSp        *xdrCxnSp      `xml:"xdr:cxnSp"`
 	Pic          *xlsxPic       `xml:"xdr:pic,omitempty"`
 	GraphicFrame string         `xml:",innerxml"`
 	ClientData   *xdrClientData `xml:"xdr:clientData"`
@@ -366,6 +454,7 @@ type xlsxGraphic struct {
 type xlsxGraphicData struct {
 	URI   string     `xml:"uri,attr"`
 	Chart *xlsxChart `xml:"c:chart,omitempty"`
+	Sle   *xlsxSle   `xml:"sle:slicer,omitempty"`
 }
 
 // xlsxChart (Chart) directly maps the c:chart element.
@@ -391,6 +480,24 @@ type xdrSp struct {
 	TxBody   *xdrTxBody `xml:"xdr:txBody"`
 }
 
+// xdrCxnSp (Connection Shape) directly maps the xdr:cxnSp element. This
+// element specifies a connection shape that is used to connect two shapes or
+// two points in the drawing.
+type xdrCxnSp struct {
+	Macro     string        `xml:"macro,attr"`
+	NvCxnSpPr *xdrNvCxnSpPr `xml:"xdr:nvCxnSpPr"`
+	SpPr      *xlsxSpPr     `xml:"xdr:spPr"`
+	Style     *xdrStyle     `xml:"xdr:style"`
+}
+
+// xdrNvCxnSpPr (Non-Visual Properties for a Connection Shape) directly maps
+// the xdr:nvCxnSpPr element. This element specifies all non-visual
+// properties for a connection shape.
+type xdrNvCxnSpPr struct {
+	CNvPr      *xlsxCNvPr `xml:"xdr:cNvPr"`
+	CNvCxnSpPr string     `xml:"xdr:cNvCxnSpPr"`
+}
+
 // xdrNvSpPr (Non-Visual Properties for a Shape) directly maps the xdr:nvSpPr
 // element. This element specifies all non-visual properties for a shape. This
 // element is a container for the non-visual identification properties, shape
@@ -469,34 +576,85 @@ type formatPicture struct {
 	Hyperlink        string  `json:"hyperlink"`
 	HyperlinkType    string  `json:"hyperlink_type"`
 	Positioning      string  `json:"positioning"`
+	CropLeft         float64 `json:"crop_left"`
+	CropRight        float64 `json:"crop_right"`
+	CropTop          float64 `json:"crop_top"`
+	CropBottom       float64 `json:"crop_bottom"`
+	Rotation         int     `json:"rotation"`
+	AutoConvert      bool    `json:"auto_convert"`
+}
+
+// Shape directly maps the shape in the worksheet, the width and height of
+// the shape are in pixels.
+type Shape struct {
+	Type   string
+	Cell   string
+	Width  int
+	Height int
+	Text   string
 }
 
 // formatShape directly maps the format settings of the shape.
 type formatShape struct {
-	Type      string                 `json:"type"`
-	Width     int                    `json:"width"`
-	Height    int                    `json:"height"`
-	Format    formatPicture          `json:"format"`
-	Color     formatShapeColor       `json:"color"`
-	Line      formatLine             `json:"line"`
-	Paragraph []formatShapeParagraph `json:"paragraph"`
+	Type         string                 `json:"type"`
+	Width        int                    `json:"width"`
+	Height       int                    `json:"height"`
+	Format       formatPicture          `json:"format"`
+	Color        formatShapeColor       `json:"color"`
+	Line         formatLine             `json:"line"`
+	Paragraph    []formatShapeParagraph `json:"paragraph"`
+	TextRotation int                    `json:"text_rotation"`
+	TextVertical string                 `json:"text_vertical"`
 }
 
 // formatShapeParagraph directly maps the format settings of the paragraph in
 // the shape.
 type formatShapeParagraph struct {
-	Font Font   `json:"font"`
+	Font Font             `json:"font"`
+	Text string           `json:"text"`
+	Runs []formatShapeRun `json:"runs"`
+}
+
+// formatShapeRun directly maps the format settings of the text run in the
+// paragraph of the shape.
+type formatShapeRun struct {
+	Font *Font  `json:"font"`
 	Text string `json:"text"`
 }
 
 // formatShapeColor directly maps the color settings of the shape.
 type formatShapeColor struct {
-	Line   string `json:"line"`
-	Fill   string `json:"fill"`
-	Effect string `json:"effect"`
+	Line     string               `json:"line"`
+	Fill     string               `json:"fill"`
+	Effect   string               `json:"effect"`
+	Gradient *formatShapeGradient `json:"gradient"`
+}
+
+// formatShapeGradient directly maps the linear gradient fill settings of the
+// shape.
+type formatShapeGradient struct {
+	Stops []formatShapeGradientStop `json:"stops"`
+	Angle float64                   `json:"angle"`
+}
+
+// formatShapeGradientStop directly maps the settings of the gradient stop,
+// the position is a percentage in the color band.
+type formatShapeGradientStop struct {
+	Color    string  `json:"color"`
+	Position float64 `json:"position"`
 }
 
 // formatLine directly maps the line settings of the shape.
 type formatLine struct {
-	Width float64 `json:"width"`
+	Width   float64 `json:"width"`
+	HeadEnd string  `json:"head_end"`
+	TailEnd string  `json:"tail_end"`
+}
+
+// formatConnector directly maps the format settings of the connector.
+type formatConnector struct {
+	Type   string           `json:"type"`
+	Format formatPicture    `json:"format"`
+	Color  formatShapeColor `json:"color"`
+	Line   formatLine       `json:"line"`
 }
@@ -0,0 +1,187 @@
+// Copyright 2016 - 2021 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to
+// and read from XLSX / XLSM / XLTM files. Supports reading and writing
+// spreadsheet documents generated by Microsoft Excel™ 2007 and later. Supports
+// complex components by high compatibility, and provided streaming API for
+// generating or reading data from a worksheet with huge amounts of data. This
+// library needs Go version 1.15 or later.
+
+package excelize
+
+import "encoding/xml"
+
+// xlsxMetadata directly maps the metadata element. A cell in a spreadsheet
+// application can have metadata associated with it. Metadata is just a set of
+// additional properties about the particular cell, and this metadata is
+// stored in the metadata xml part.
+type xlsxMetadata struct {
+	XMLName         xml.Name             `xml:"http://schemas.openxmlformats.org/spreadsheetml/2006/main metadata"`
+	XMLNSXlrd       string               `xml:"xmlns:xlrd,attr,omitempty"`
+	XMLNSXda        string               `xml:"xmlns:xda,attr,omitempty"`
+	MetadataTypes   *xlsxMetadataTypes   `xml:"metadataTypes"`
+	MetadataStrings *xlsxInnerXML        `xml:"metadataStrings"`
+	MdxMetadata     *xlsxInnerXML        `xml:"mdxMetadata"`
+	FutureMetadata  []xlsxFutureMetadata `xml:"futureMetadata"`
+	CellMetadata    *xlsxMetadataBlocks  `xml:"cellMetadata"`
+	ValueMetadata   *xlsxMetadataBlocks  `xml:"valueMetadata"`
+	ExtLst          *xlsxInnerXML        `xml:"extLst"`
+}
+
+// xlsxMetadataTypes directly maps the metadataTypes element. This element
+// represents the list of metadata types, each type defines how the metadata
+// attached to the cells behaves on edit operations.
+type xlsxMetadataTypes struct {
+	Count        int                `xml:"count,attr"`
+	MetadataType []xlsxMetadataType `xml:"metadataType"`
+}
+
+// xlsxMetadataType directly maps the metadataType element. This element
+// represents a single set of metadata information for a particular type of
+// metadata. The attributes specify the actions to take on the cell metadata
+// when particular operations are performed on the cell.
+type xlsxMetadataType struct {
+	Name                string `xml:"name,attr"`
+	MinSupportedVersion int    `xml:"minSupportedVersion,attr"`
+	GhostRow            bool   `xml:"ghostRow,attr,omitempty"`
+	GhostCol            bool   `xml:"ghostCol,attr,omitempty"`
+	Edit                bool   `xml:"edit,attr,omitempty"`
+	Delete              bool   `xml:"delete,attr,omitempty"`
+	Copy                bool   `xml:"copy,attr,omitempty"`
+	PasteAll            bool   `xml:"pasteAll,attr,omitempty"`
+	PasteFormulas       bool   `xml:"pasteFormulas,attr,omitempty"`
+	PasteValues         bool   `xml:"pasteValues,attr,omitempty"`
+	PasteFormats        bool   `xml:"pasteFormats,attr,omitempty"`
+	PasteComments       bool   `xml:"pasteComments,attr,omitempty"`
+	PasteDataValidation bool   `xml:"pasteDataValidation,attr,omitempty"`
+	PasteBorders        bool   `xml:"pasteBorders,attr,omitempty"`
+	PasteColWidths      bool   `xml:"pasteColWidths,attr,omitempty"`
+	PasteNumberFormats  bool   `xml:"pasteNumberFormats,attr,omitempty"`
+	Merge               bool   `xml:"merge,attr,omitempty"`
+	SplitFirst          bool   `xml:"splitFirst,attr,omitempty"`
+	SplitAll            bool   `xml:"splitAll,attr,omitempty"`
+	RowColShift         bool   `xml:"rowColShift,attr,omitempty"`
+	ClearAll            bool   `xml:"clearAll,attr,omitempty"`
+	ClearFormats        bool   `xml:"clearFormats,attr,omitempty"`
+	ClearContents       bool   `xml:"clearContents,attr,omitempty"`
+	ClearComments       bool   `xml:"clearComments,attr,omitempty"`
+	Assign              bool   `xml:"assign,attr,omitempty"`
+	Coerce              bool   `xml:"coerce,attr,omitempty"`
+	Adjust              bool   `xml:"adjust,attr,omitempty"`
+	CellMeta            bool   `xml:"cellMeta,attr,omitempty"`
+}
+
+// xlsxFutureMetadata directly maps the futureMetadata element. This element
+// represents future metadata information, the name attribute specifies the
+// metadata type which the blocks belongs to.
+type xlsxFutureMetadata struct {
+	Name   string                    `xml:"name,attr"`
+	Count  int                       `xml:"count,attr,omitempty"`
+	Bk     []xlsxFutureMetadataBlock `xml:"bk"`
+	ExtLst *xlsxInnerXML             `xml:"extLst"`
+}
+
+// xlsxFutureMetadataBlock directly maps the bk element of the futureMetadata.
+// This element represents a block of future metadata information, the
+// content of the block stored in the extension list.
+type xlsxFutureMetadataBlock struct {
+	ExtLst *xlsxInnerXML `xml:"extLst"`
+}
+
+// xlsxMetadataBlocks directly maps the cellMetadata and valueMetadata
+// element. This element represents the cell metadata and the value metadata
+// information, the cell references them by the cm and vm attributes with a
+// one-based index.
+type xlsxMetadataBlocks struct {
+	Count int                 `xml:"count,attr,omitempty"`
+	Bk    []xlsxMetadataBlock `xml:"bk"`
+}
+
+// xlsxMetadataBlock directly maps the bk element. This element represents a
+// block of metadata records.
+type xlsxMetadataBlock struct {
+	Rc []xlsxMetadataRecord `xml:"rc"`
+}
+
+// xlsxMetadataRecord directly maps the rc element. This element represents
+// the reference to a metadata record, the t attribute specifies one-based
+// index of the metadata type and the v attribute specifies zero-based index
+// of the metadata record of this type.
+type xlsxMetadataRecord struct {
+	T int `xml:"t,attr"`
+	V int `xml:"v,attr"`
+}
+
+// xlsxRichValueBlock directly maps the xlrd:rvb element in the extension of
+// the future metadata block. The i attribute specifies zero-based index of
+// the rich value in the rich value data.
+type xlsxRichValueBlock struct {
+	I int `xml:"i,attr"`
+}
+
+// decodeFutureMetadataExtLst directly maps the extension list of the future
+// metadata block for deserialization.
+type decodeFutureMetadataExtLst struct {
+	Ext []struct {
+		URI string              `xml:"uri,attr"`
+		Rvb *xlsxRichValueBlock `xml:"rvb"`
+	} `xml:"ext"`
+}
+
+// xlsxRichValueData directly maps the rvData element that specifies rich
+// value data.
+type xlsxRichValueData struct {
+	XMLName xml.Name        `xml:"http://schemas.microsoft.com/office/spreadsheetml/2017/richdata rvData"`
+	Count   int             `xml:"count,attr,omitempty"`
+	Rv      []xlsxRichValue `xml:"rv"`
+	ExtLst  *xlsxInnerXML   `xml:"extLst"`
+}
+
+// xlsxRichValue directly maps the rv element that specifies rich value data
+// information for a single rich value, the s attribute specifies zero-based
+// index of the rich value structure.
+type xlsxRichValue struct {
+	S  int           `xml:"s,attr"`
+	V  []string      `xml:"v"`
+	Fb *xlsxInnerXML `xml:"fb"`
+}
+
+// xlsxRichValueStructures directly maps the rvStructures element that
+// specifies rich value structure data.
+type xlsxRichValueStructures struct {
+	XMLName xml.Name                 `xml:"http://schemas.microsoft.com/office/spreadsheetml/2017/richdata rvStructures"`
+	Count   int                      `xml:"count,attr,omitempty"`
+	S       []xlsxRichValueStructure `xml:"s"`
+	ExtLst  *xlsxInnerXML            `xml:"extLst"`
+}
+
+// xlsxRichValueStructure directly maps the s element that specifies a single
+// rich value structure.
+type xlsxRichValueStructure struct {
+	T string                      `xml:"t,attr"`
+	K []xlsxRichValueStructureKey `xml:"k"`
+}
+
+// xlsxRichValueStructureKey directly maps the k element that specifies a key
+// of the rich value structure.
+type xlsxRichValueStructureKey struct {
+	N string `xml:"n,attr"`
+	T string `xml:"t,attr,omitempty"`
+}
+
+// xlsxRichValueRels directly maps the richValueRels element. This element
+// specifies a list of rich value relationships.
+type xlsxRichValueRels struct {
+	XMLName xml.Name                   `xml:"http://schemas.microsoft.com/office/spreadsheetml/2022/richvaluerel richValueRels"`
+	XMLNSR  string                     `xml:"xmlns:r,attr,omitempty"`
+	Rels    []xlsxRichValueRelRelation `xml:"rel"`
+	ExtLst  *xlsxInnerXML              `xml:"extLst"`
+}
+
+// xlsxRichValueRelRelation directly maps the rel element. This element
+// specifies a relationship for a rich value property.
+type xlsxRichValueRelRelation struct {
+	ID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+}
@@ -105,7 +105,7 @@ type xlsxCacheField struct {
 	SQLType             int              `xml:"sqlType,attr,omitempty"`
 	Hierarchy           int              `xml:"hierarchy,attr,omitempty"`
 	Level               int              `xml:"level,attr,omitempty"`
-	DatabaseField       bool             `xml:"databaseField,attr,omitempty"`
+	DatabaseField       *bool            `xml:"databaseField,attr"`
 	MappingCount        int              `xml:"mappingCount,attr,omitempty"`
 	MemberPropertyField bool             `xml:"memberPropertyField,attr,omitempty"`
 	SharedItems         *xlsxSharedItems `xml:"sharedItems"`
@@ -121,26 +121,44 @@ type xlsxCacheField struct {
 // those values that are referenced in multiple places across all the
 // PivotTable parts.
 type xlsxSharedItems struct {
-	ContainsSemiMixedTypes bool          `xml:"containsSemiMixedTypes,attr,omitempty"`
-	ContainsNonDate        bool          `xml:"containsNonDate,attr,omitempty"`
-	ContainsDate           bool          `xml:"containsDate,attr,omitempty"`
-	ContainsString         bool          `xml:"containsString,attr,omitempty"`
-	ContainsBlank          bool          `xml:"containsBlank,attr,omitempty"`
-	ContainsMixedTypes     bool          `xml:"containsMixedTypes,attr,omitempty"`
-	ContainsNumber         bool          `xml:"containsNumber,attr,omitempty"`
-	ContainsInteger        bool          `xml:"containsInteger,attr,omitempty"`
-	MinValue               float64       `xml:"minValue,attr,omitempty"`
-	MaxValue               float64       `xml:"maxValue,attr,omitempty"`
-	MinDate                string        `xml:"minDate,attr,omitempty"`
-	MaxDate                string        `xml:"maxDate,attr,omitempty"`
-	Count                  int           `xml:"count,attr"`
-	LongText               bool          `xml:"longText,attr,omitempty"`
-	M                      *xlsxMissing  `xml:"m"`
-	N                      *xlsxNumber   `xml:"n"`
-	B                      *xlsxBoolean  `xml:"b"`
-	E                      *xlsxError    `xml:"e"`
-	S                      *xlsxString   `xml:"s"`
-	D                      *xlsxDateTime `xml:"d"`
+	ContainsSemiMixedTypes *bool                `xml:"containsSemiMixedTypes,attr"`
+	ContainsNonDate        *bool                `xml:"containsNonDate,attr"`
+	ContainsDate           bool                 `xml:"containsDate,attr,omitempty"`
+	ContainsString         *bool                `xml:"containsString,attr"`
+	ContainsBlank          bool                 `xml:"containsBlank,attr,omitempty"`
+	ContainsMixedTypes     bool                 `xml:"containsMixedTypes,attr,omitempty"`
+	ContainsNumber         bool                 `xml:"containsNumber,attr,omitempty"`
+	ContainsInteger        bool                 `xml:"containsInteger,attr,omitempty"`
+	MinValue               *float64             `xml:"minValue,attr"`
+	MaxValue               *float64             `xml:"maxValue,attr"`
+	MinDate                string               `xml:"minDate,attr,omitempty"`
+	MaxDate                string               `xml:"maxDate,attr,omitempty"`
+	Count                  int                  `xml:"count,attr"`
+	LongText               bool                 `xml:"longText,attr,omitempty"`
+	Items                  []xlsxPivotCacheItem `xml:",any"`
+}
+
+// xlsxPivotCacheItem represents a value of the shared items or the pivot
+// cache records, the name of the element is one of the m (missing), n
+// (number), b (boolean), e (error), s (string), d (date time) and x (index of
+// the shared items).
+type xlsxPivotCacheItem struct {
+	XMLName xml.Name
+	V       *string `xml:"v,attr"`
+}
+
+// xlsxPivotCacheRecords represents the collection of records in the
+// PivotCache. This part is referenced by the pivot cache definition and
+// stores the source data of the PivotTable.
+type xlsxPivotCacheRecords struct {
+	XMLName xml.Name                `xml:"http://schemas.openxmlformats.org/spreadsheetml/2006/main pivotCacheRecords"`
+	Count   int                     `xml:"count,attr"`
+	R       []*xlsxPivotCacheRecord `xml:"r"`
+}
+
+// xlsxPivotCacheRecord represents a single record of data in the PivotCache.
+type xlsxPivotCacheRecord struct {
+	Items []xlsxPivotCacheItem `xml:",any"`
 }
 
 // xlsxMissing represents a value that was not specified.
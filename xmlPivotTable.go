@@ -273,8 +273,8 @@ type xlsxDataField struct {
 	Fld        int         `xml:"fld,attr"`
 	Subtotal   string      `xml:"subtotal,attr,omitempty"`
 	ShowDataAs string      `xml:"showDataAs,attr,omitempty"`
-	BaseField  int         `xml:"baseField,attr,omitempty"`
-	BaseItem   int64       `xml:"baseItem,attr,omitempty"`
+	BaseField  *int        `xml:"baseField,attr"`
+	BaseItem   *int64      `xml:"baseItem,attr"`
 	NumFmtID   string      `xml:"numFmtId,attr,omitempty"`
 	ExtLst     *xlsxExtLst `xml:"extLst"`
 }
@@ -0,0 +1,141 @@
+// Copyright 2016 - 2021 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to
+// and read from XLSX / XLSM / XLTM files. Supports reading and writing
+// spreadsheet documents generated by Microsoft Excel™ 2007 and later. Supports
+// complex components by high compatibility, and provided streaming API for
+// generating or reading data from a worksheet with huge amounts of data. This
+// library needs Go version 1.15 or later.
+
+package excelize
+
+import "encoding/xml"
+
+// xlsxSlicers directly maps the slicers element that specifies a slicer view
+// on the worksheet.
+type xlsxSlicers struct {
+	XMLName  xml.Name      `xml:"http://schemas.microsoft.com/office/spreadsheetml/2009/9/main slicers"`
+	XMLNSXMC string        `xml:"xmlns:mc,attr"`
+	XMLNSX   string        `xml:"xmlns:x,attr"`
+	Slicer   []*xlsxSlicer `xml:"slicer"`
+}
+
+// xlsxSlicer is a complex type that specifies a slicer view.
+type xlsxSlicer struct {
+	Name           string `xml:"name,attr"`
+	Cache          string `xml:"cache,attr"`
+	Caption        string `xml:"caption,attr,omitempty"`
+	StartItem      *int   `xml:"startItem,attr"`
+	ColumnCount    *int   `xml:"columnCount,attr"`
+	ShowCaption    *bool  `xml:"showCaption,attr"`
+	Level          int    `xml:"level,attr,omitempty"`
+	Style          string `xml:"style,attr,omitempty"`
+	LockedPosition bool   `xml:"lockedPosition,attr,omitempty"`
+	RowHeight      int    `xml:"rowHeight,attr"`
+}
+
+// xlsxSlicerCacheDefinition directly maps the slicerCacheDefinition element
+// that specifies a slicer cache.
+type xlsxSlicerCacheDefinition struct {
+	XMLName     xml.Name      `xml:"http://schemas.microsoft.com/office/spreadsheetml/2009/9/main slicerCacheDefinition"`
+	XMLNSXMC    string        `xml:"xmlns:mc,attr"`
+	McIgnorable string        `xml:"mc:Ignorable,attr"`
+	XMLNSX      string        `xml:"xmlns:x,attr"`
+	Name        string        `xml:"name,attr"`
+	SourceName  string        `xml:"sourceName,attr"`
+	PivotTables *xlsxInnerXML `xml:"pivotTables"`
+	Data        *xlsxInnerXML `xml:"data"`
+	ExtLst      *xlsxExtLst   `xml:"extLst"`
+}
+
+// xlsxX15TableSlicerCache directly maps the x15:tableSlicerCache element that
+// specifies a table data source for the slicer cache.
+type xlsxX15TableSlicerCache struct {
+	XMLName   xml.Name `xml:"x15:tableSlicerCache"`
+	TableID   int      `xml:"tableId,attr"`
+	Column    int      `xml:"column,attr"`
+	SortOrder string   `xml:"sortOrder,attr,omitempty"`
+}
+
+// xlsxX14SlicerList directly maps the x14:slicerList element in the worksheet
+// extension list.
+type xlsxX14SlicerList struct {
+	XMLName  xml.Name                 `xml:"x14:slicerList"`
+	XMLNSX14 string                   `xml:"xmlns:x14,attr"`
+	Slicer   []*xlsxX14SlicerListItem `xml:"x14:slicer"`
+}
+
+// xlsxX14SlicerListItem directly maps the x14:slicer element in the slicer
+// list of the worksheet, which specifies the relationship ID of the slicer
+// part.
+type xlsxX14SlicerListItem struct {
+	RID string `xml:"r:id,attr"`
+}
+
+// xlsxX14SlicerCache directly maps the x14:slicerCache element in the slicer
+// caches of the workbook extension list.
+type xlsxX14SlicerCache struct {
+	XMLName xml.Name `xml:"x14:slicerCache"`
+	RID     string   `xml:"r:id,attr"`
+}
+
+// xlsxX15SlicerCaches directly maps the x15:slicerCaches element in the
+// workbook extension list, which specifies the table slicer caches.
+type xlsxX15SlicerCaches struct {
+	XMLName  xml.Name `xml:"x15:slicerCaches"`
+	XMLNSX14 string   `xml:"xmlns:x14,attr"`
+	Content  string   `xml:",innerxml"`
+}
+
+// xlsxSlicerCacheExt directly maps the x:ext element in the extension list
+// of the slicer cache definition.
+type xlsxSlicerCacheExt struct {
+	XMLName  xml.Name `xml:"x:ext"`
+	URI      string   `xml:"uri,attr"`
+	XMLNSX15 string   `xml:"xmlns:x15,attr"`
+	Content  string   `xml:",innerxml"`
+}
+
+// decodeSlicerCaches defined the structure used to parse the x15:slicerCaches
+// element in the workbook extension list.
+type decodeSlicerCaches struct {
+	XMLName xml.Name `xml:"slicerCaches"`
+	Content string   `xml:",innerxml"`
+}
+
+// xlsxSlicerAlternateContent directly maps the mc:AlternateContent element
+// which wraps the graphic frame of the slicer in the drawing part.
+type xlsxSlicerAlternateContent struct {
+	XMLName xml.Name          `xml:"mc:AlternateContent"`
+	XMLNSMC string            `xml:"xmlns:mc,attr"`
+	Choice  *xlsxSlicerChoice `xml:"mc:Choice"`
+}
+
+// xlsxSlicerChoice directly maps the mc:Choice element which contains the
+// graphic frame of the slicer.
+type xlsxSlicerChoice struct {
+	XMLNSSle15   string            `xml:"xmlns:sle15,attr"`
+	Requires     string            `xml:"Requires,attr"`
+	GraphicFrame *xlsxGraphicFrame `xml:"xdr:graphicFrame"`
+}
+
+// xlsxSle directly maps the sle:slicer element in the graphic data of the
+// drawing part, which specifies the name of the slicer.
+type xlsxSle struct {
+	XMLNSSle string `xml:"xmlns:sle,attr"`
+	Name     string `xml:"name,attr"`
+}
+
+// SlicerOptions directly maps the settings of the slicer.
+type SlicerOptions struct {
+	Name        string
+	Cell        string
+	TableName   string
+	Caption     string
+	Style       string
+	ColumnCount int
+	Width       int
+	Height      int
+}
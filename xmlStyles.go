@@ -87,20 +87,21 @@ type xlsxFonts struct {
 // xlsxFont directly maps the font element. This element defines the
 // properties for one of the fonts used in this workbook.
 type xlsxFont struct {
-	B        *attrValBool   `xml:"b,omitempty"`
-	I        *attrValBool   `xml:"i,omitempty"`
-	Strike   *attrValBool   `xml:"strike,omitempty"`
-	Outline  *attrValBool   `xml:"outline,omitempty"`
-	Shadow   *attrValBool   `xml:"shadow,omitempty"`
-	Condense *attrValBool   `xml:"condense,omitempty"`
-	Extend   *attrValBool   `xml:"extend,omitempty"`
-	U        *attrValString `xml:"u"`
-	Sz       *attrValFloat  `xml:"sz"`
-	Color    *xlsxColor     `xml:"color"`
-	Name     *attrValString `xml:"name"`
-	Family   *attrValInt    `xml:"family"`
-	Charset  *attrValInt    `xml:"charset"`
-	Scheme   *attrValString `xml:"scheme"`
+	B         *attrValBool   `xml:"b,omitempty"`
+	I         *attrValBool   `xml:"i,omitempty"`
+	Strike    *attrValBool   `xml:"strike,omitempty"`
+	Outline   *attrValBool   `xml:"outline,omitempty"`
+	Shadow    *attrValBool   `xml:"shadow,omitempty"`
+	Condense  *attrValBool   `xml:"condense,omitempty"`
+	Extend    *attrValBool   `xml:"extend,omitempty"`
+	U         *attrValString `xml:"u"`
+	VertAlign *attrValString `xml:"vertAlign"`
+	Sz        *attrValFloat  `xml:"sz"`
+	Color     *xlsxColor     `xml:"color"`
+	Name      *attrValString `xml:"name"`
+	Family    *attrValInt    `xml:"family"`
+	Charset   *attrValInt    `xml:"charset"`
+	Scheme    *attrValString `xml:"scheme"`
 }
 
 // xlsxFills directly maps the fills element. This element defines the cell
@@ -341,6 +342,7 @@ type Font struct {
 	Size      float64 `json:"size"`
 	Strike    bool    `json:"strike"`
 	Color     string  `json:"color"`
+	VertAlign string  `json:"vert_align"`
 }
 
 // Fill directly maps the fill settings of the cells.
@@ -368,5 +370,6 @@ type Style struct {
 	DecimalPlaces int         `json:"decimal_places"`
 	CustomNumFmt  *string     `json:"custom_number_format"`
 	Lang          string      `json:"lang"`
+	NumFmtLocale  bool        `json:"number_format_locale"`
 	NegRed        bool        `json:"negred"`
 }
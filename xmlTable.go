@@ -26,7 +26,7 @@ type xlsxTable struct {
 	DisplayName          string              `xml:"displayName,attr,omitempty"`
 	HeaderRowBorderDxfID int                 `xml:"headerRowBorderDxfId,attr,omitempty"`
 	HeaderRowCellStyle   string              `xml:"headerRowCellStyle,attr,omitempty"`
-	HeaderRowCount       int                 `xml:"headerRowCount,attr,omitempty"`
+	HeaderRowCount       *int                `xml:"headerRowCount,attr"`
 	HeaderRowDxfID       int                 `xml:"headerRowDxfId,attr,omitempty"`
 	ID                   int                 `xml:"id,attr"`
 	InsertRow            bool                `xml:"insertRow,attr,omitempty"`
@@ -183,6 +183,7 @@ type xlsxTableColumn struct {
 	TotalsRowFunction  string `xml:"totalsRowFunction,attr,omitempty"`
 	TotalsRowLabel     string `xml:"totalsRowLabel,attr,omitempty"`
 	UniqueName         string `xml:"uniqueName,attr,omitempty"`
+	TotalsRowFormula   string `xml:"totalsRowFormula,omitempty"`
 }
 
 // xlsxTableStyleInfo directly maps the tableStyleInfo element. This element
@@ -198,12 +199,44 @@ type xlsxTableStyleInfo struct {
 
 // formatTable directly maps the format settings of the table.
 type formatTable struct {
-	TableName         string `json:"table_name"`
-	TableStyle        string `json:"table_style"`
-	ShowFirstColumn   bool   `json:"show_first_column"`
-	ShowLastColumn    bool   `json:"show_last_column"`
-	ShowRowStripes    bool   `json:"show_row_stripes"`
-	ShowColumnStripes bool   `json:"show_column_stripes"`
+	TableName         string              `json:"table_name"`
+	TableStyle        string              `json:"table_style"`
+	ShowFirstColumn   bool                `json:"show_first_column"`
+	ShowLastColumn    bool                `json:"show_last_column"`
+	ShowRowStripes    bool                `json:"show_row_stripes"`
+	ShowColumnStripes bool                `json:"show_column_stripes"`
+	ShowTotalRow      bool                `json:"show_total_row"`
+	Columns           []formatTableColumn `json:"columns"`
+}
+
+// Table directly maps the table settings.
+type Table struct {
+	Name              string
+	Range             string
+	ShowHeaderRow     bool
+	TableStyle        string
+	ShowFirstColumn   bool
+	ShowLastColumn    bool
+	ShowRowStripes    bool
+	ShowColumnStripes bool
+	ShowTotalRow      bool
+	Columns           []TableColumn
+}
+
+// TableColumn directly maps the column settings of the table.
+type TableColumn struct {
+	ID                int
+	Name              string
+	TotalsRowFunction string
+	TotalsRowLabel    string
+	TotalsRowFormula  string
+}
+
+// formatTableColumn directly maps the format settings of the table column.
+type formatTableColumn struct {
+	TotalsRowFunction string `json:"totals_row_function"`
+	TotalsRowLabel    string `json:"totals_row_label"`
+	TotalsRowFormula  string `json:"totals_row_formula"`
 }
 
 // formatAutoFilter directly maps the auto filter settings.
@@ -214,4 +247,48 @@ type formatAutoFilter struct {
 		Column string `json:"column"`
 		Value  []int  `json:"value"`
 	} `json:"filter_list"`
+	Columns []formatAutoFilterColumn `json:"columns"`
+	Apply   bool                     `json:"apply"`
+}
+
+// formatAutoFilterColumn directly maps the filter criteria settings of a
+// column in the auto filter.
+type formatAutoFilterColumn struct {
+	Column        string                   `json:"column"`
+	Expression    string                   `json:"expression"`
+	Filters       []string                 `json:"filters"`
+	CustomFilters []AutoFilterCustomFilter `json:"custom_filters"`
+	And           bool                     `json:"and"`
+	Top10         *AutoFilterTop10         `json:"top10"`
+}
+
+// AutoFilterOptions directly maps the auto filter settings of the worksheet.
+type AutoFilterOptions struct {
+	Range   string             `json:"range"`
+	Columns []AutoFilterColumn `json:"columns"`
+}
+
+// AutoFilterColumn directly maps the filter criteria of a column in the auto
+// filter.
+type AutoFilterColumn struct {
+	Column        string                   `json:"column"`
+	Filters       []string                 `json:"filters"`
+	CustomFilters []AutoFilterCustomFilter `json:"custom_filters"`
+	And           bool                     `json:"and"`
+	Top10         *AutoFilterTop10         `json:"top10"`
+}
+
+// AutoFilterCustomFilter directly maps the custom filter settings of a column
+// in the auto filter.
+type AutoFilterCustomFilter struct {
+	Operator string `json:"operator"`
+	Val      string `json:"val"`
+}
+
+// AutoFilterTop10 directly maps the top 10 filter settings of a column in the
+// auto filter.
+type AutoFilterTop10 struct {
+	Top     bool    `json:"top"`
+	Percent bool    `json:"percent"`
+	Val     float64 `json:"val"`
 }
@@ -79,6 +79,8 @@ type xlsxFileRecoveryPr struct {
 // there is a leading BOM character (U+FEFF) in the encoded password it is
 // removed before hash calculation.
 type xlsxWorkbookProtection struct {
+	WorkbookPassword       string `xml:"workbookPassword,attr,omitempty"`
+	RevisionsPassword      string `xml:"revisionsPassword,attr,omitempty"`
 	LockRevision           bool   `xml:"lockRevision,attr,omitempty"`
 	LockStructure          bool   `xml:"lockStructure,attr,omitempty"`
 	LockWindows            bool   `xml:"lockWindows,attr,omitempty"`
@@ -311,3 +313,16 @@ type DefinedName struct {
 	RefersTo string
 	Scope    string
 }
+
+// FormatWorkbookProtection directly maps the settings of workbook protection.
+type FormatWorkbookProtection struct {
+	AlgorithmName       string
+	Password            string
+	ProtectionAlgorithm string
+	HashValue           string
+	SaltValue           string
+	SpinCount           int
+	WorkbookPassword    string
+	LockStructure       bool
+	LockWindows         bool
+}
@@ -463,9 +463,11 @@ type xlsxC struct {
 	R        string   `xml:"r,attr,omitempty"` // Cell ID, e.g. A1
 	S        int      `xml:"s,attr,omitempty"` // Style reference.
 	// Str string `xml:"str,attr,omitempty"` // Style reference.
-	T  string  `xml:"t,attr,omitempty"` // Type.
-	F  *xlsxF  `xml:"f,omitempty"`      // Formula
-	V  string  `xml:"v,omitempty"`      // Value
+	T  string  `xml:"t,attr,omitempty"`  // Type.
+	Cm int     `xml:"cm,attr,omitempty"` // Cell metadata index.
+	Vm int     `xml:"vm,attr,omitempty"` // Value metadata index.
+	F  *xlsxF  `xml:"f,omitempty"`       // Formula
+	V  string  `xml:"v,omitempty"`       // Value
 	IS *xlsxSI `xml:"is"`
 }
 
@@ -499,18 +501,18 @@ type xlsxSheetProtection struct {
 	Sheet               bool     `xml:"sheet,attr"`
 	Objects             bool     `xml:"objects,attr"`
 	Scenarios           bool     `xml:"scenarios,attr"`
-	FormatCells         bool     `xml:"formatCells,attr"`
-	FormatColumns       bool     `xml:"formatColumns,attr"`
-	FormatRows          bool     `xml:"formatRows,attr"`
-	InsertColumns       bool     `xml:"insertColumns,attr"`
-	InsertRows          bool     `xml:"insertRows,attr"`
-	InsertHyperlinks    bool     `xml:"insertHyperlinks,attr"`
-	DeleteColumns       bool     `xml:"deleteColumns,attr"`
-	DeleteRows          bool     `xml:"deleteRows,attr"`
+	FormatCells         *bool    `xml:"formatCells,attr"`
+	FormatColumns       *bool    `xml:"formatColumns,attr"`
+	FormatRows          *bool    `xml:"formatRows,attr"`
+	InsertColumns       *bool    `xml:"insertColumns,attr"`
+	InsertRows          *bool    `xml:"insertRows,attr"`
+	InsertHyperlinks    *bool    `xml:"insertHyperlinks,attr"`
+	DeleteColumns       *bool    `xml:"deleteColumns,attr"`
+	DeleteRows          *bool    `xml:"deleteRows,attr"`
 	SelectLockedCells   bool     `xml:"selectLockedCells,attr"`
-	Sort                bool     `xml:"sort,attr"`
-	AutoFilter          bool     `xml:"autoFilter,attr"`
-	PivotTables         bool     `xml:"pivotTables,attr"`
+	Sort                *bool    `xml:"sort,attr"`
+	AutoFilter          *bool    `xml:"autoFilter,attr"`
+	PivotTables         *bool    `xml:"pivotTables,attr"`
 	SelectUnlockedCells bool     `xml:"selectUnlockedCells,attr"`
 }
 
@@ -710,9 +712,52 @@ type decodeWorksheetExt struct {
 
 // decodeX14SparklineGroups directly maps the sparklineGroups element.
 type decodeX14SparklineGroups struct {
-	XMLName xml.Name `xml:"sparklineGroups"`
-	XMLNSXM string   `xml:"xmlns:xm,attr"`
-	Content string   `xml:",innerxml"`
+	XMLName         xml.Name                   `xml:"sparklineGroups"`
+	XMLNSXM         string                     `xml:"xmlns:xm,attr"`
+	SparklineGroups []*decodeX14SparklineGroup `xml:"sparklineGroup"`
+	Content         string                     `xml:",innerxml"`
+}
+
+// decodeX14SparklineGroup directly maps the sparklineGroup element.
+type decodeX14SparklineGroup struct {
+	ManualMax           float64             `xml:"manualMax,attr"`
+	ManualMin           float64             `xml:"manualMin,attr"`
+	LineWeight          float64             `xml:"lineWeight,attr"`
+	Type                string              `xml:"type,attr"`
+	DateAxis            bool                `xml:"dateAxis,attr"`
+	DisplayEmptyCellsAs string              `xml:"displayEmptyCellsAs,attr"`
+	Markers             bool                `xml:"markers,attr"`
+	High                bool                `xml:"high,attr"`
+	Low                 bool                `xml:"low,attr"`
+	First               bool                `xml:"first,attr"`
+	Last                bool                `xml:"last,attr"`
+	Negative            bool                `xml:"negative,attr"`
+	DisplayXAxis        bool                `xml:"displayXAxis,attr"`
+	DisplayHidden       bool                `xml:"displayHidden,attr"`
+	MinAxisType         string              `xml:"minAxisType,attr"`
+	MaxAxisType         string              `xml:"maxAxisType,attr"`
+	RightToLeft         bool                `xml:"rightToLeft,attr"`
+	ColorSeries         *xlsxTabColor       `xml:"colorSeries"`
+	ColorNegative       *xlsxTabColor       `xml:"colorNegative"`
+	ColorAxis           *xlsxTabColor       `xml:"colorAxis"`
+	ColorMarkers        *xlsxTabColor       `xml:"colorMarkers"`
+	ColorFirst          *xlsxTabColor       `xml:"colorFirst"`
+	ColorLast           *xlsxTabColor       `xml:"colorLast"`
+	ColorHigh           *xlsxTabColor       `xml:"colorHigh"`
+	ColorLow            *xlsxTabColor       `xml:"colorLow"`
+	F                   string              `xml:"f"`
+	Sparklines          decodeX14Sparklines `xml:"sparklines"`
+}
+
+// decodeX14Sparklines directly maps the sparklines element.
+type decodeX14Sparklines struct {
+	Sparkline []*decodeX14Sparkline `xml:"sparkline"`
+}
+
+// decodeX14Sparkline directly maps the sparkline element.
+type decodeX14Sparkline struct {
+	F     string `xml:"f"`
+	Sqref string `xml:"sqref"`
 }
 
 // xlsxX14SparklineGroups directly maps the sparklineGroups element.
@@ -726,8 +771,8 @@ type xlsxX14SparklineGroups struct {
 // xlsxX14SparklineGroup directly maps the sparklineGroup element.
 type xlsxX14SparklineGroup struct {
 	XMLName             xml.Name          `xml:"x14:sparklineGroup"`
-	ManualMax           int               `xml:"manualMax,attr,omitempty"`
-	ManualMin           int               `xml:"manualMin,attr,omitempty"`
+	ManualMax           *float64          `xml:"manualMax,attr"`
+	ManualMin           *float64          `xml:"manualMin,attr"`
 	LineWeight          float64           `xml:"lineWeight,attr,omitempty"`
 	Type                string            `xml:"type,attr,omitempty"`
 	DateAxis            bool              `xml:"dateAxis,attr,omitempty"`
@@ -751,6 +796,7 @@ type xlsxX14SparklineGroup struct {
 	ColorLast           *xlsxTabColor     `xml:"x14:colorLast"`
 	ColorHigh           *xlsxTabColor     `xml:"x14:colorHigh"`
 	ColorLow            *xlsxTabColor     `xml:"x14:colorLow"`
+	F                   string            `xml:"xm:f,omitempty"`
 	Sparklines          xlsxX14Sparklines `xml:"x14:sparklines"`
 }
 
@@ -765,17 +811,193 @@ type xlsxX14Sparkline struct {
 	Sqref string `xml:"xm:sqref"`
 }
 
+// decodeX14DataValidations directly maps the dataValidations element.
+type decodeX14DataValidations struct {
+	XMLName        xml.Name                   `xml:"dataValidations"`
+	Count          int                        `xml:"count,attr"`
+	DataValidation []*decodeX14DataValidation `xml:"dataValidation"`
+	Content        string                     `xml:",innerxml"`
+}
+
+// decodeX14DataValidation directly maps the dataValidation element.
+type decodeX14DataValidation struct {
+	AllowBlank       bool              `xml:"allowBlank,attr"`
+	Error            *string           `xml:"error,attr"`
+	ErrorStyle       *string           `xml:"errorStyle,attr"`
+	ErrorTitle       *string           `xml:"errorTitle,attr"`
+	Operator         string            `xml:"operator,attr"`
+	Prompt           *string           `xml:"prompt,attr"`
+	PromptTitle      *string           `xml:"promptTitle,attr"`
+	ShowDropDown     bool              `xml:"showDropDown,attr"`
+	ShowErrorMessage bool              `xml:"showErrorMessage,attr"`
+	ShowInputMessage bool              `xml:"showInputMessage,attr"`
+	Type             string            `xml:"type,attr"`
+	Formula1         *decodeX14Formula `xml:"formula1"`
+	Formula2         *decodeX14Formula `xml:"formula2"`
+	Sqref            string            `xml:"sqref"`
+}
+
+// decodeX14Formula directly maps the formula1 and formula2 element.
+type decodeX14Formula struct {
+	F string `xml:"f"`
+}
+
+// xlsxX14DataValidations directly maps the dataValidations element.
+type xlsxX14DataValidations struct {
+	XMLName xml.Name `xml:"x14:dataValidations"`
+	Count   int      `xml:"count,attr"`
+	XMLNSXM string   `xml:"xmlns:xm,attr"`
+	Content string   `xml:",innerxml"`
+}
+
+// xlsxX14DataValidation directly maps the dataValidation element.
+type xlsxX14DataValidation struct {
+	XMLName          xml.Name        `xml:"x14:dataValidation"`
+	AllowBlank       bool            `xml:"allowBlank,attr"`
+	Error            *string         `xml:"error,attr"`
+	ErrorStyle       *string         `xml:"errorStyle,attr"`
+	ErrorTitle       *string         `xml:"errorTitle,attr"`
+	Operator         string          `xml:"operator,attr,omitempty"`
+	Prompt           *string         `xml:"prompt,attr"`
+	PromptTitle      *string         `xml:"promptTitle,attr"`
+	ShowDropDown     bool            `xml:"showDropDown,attr,omitempty"`
+	ShowErrorMessage bool            `xml:"showErrorMessage,attr,omitempty"`
+	ShowInputMessage bool            `xml:"showInputMessage,attr,omitempty"`
+	Type             string          `xml:"type,attr,omitempty"`
+	Formula1         *xlsxX14Formula `xml:"x14:formula1"`
+	Formula2         *xlsxX14Formula `xml:"x14:formula2"`
+	Sqref            string          `xml:"xm:sqref"`
+}
+
+// xlsxX14Formula directly maps the formula1 and formula2 element.
+type xlsxX14Formula struct {
+	F string `xml:"xm:f"`
+}
+
+// decodeX14ProtectedRanges directly maps the protectedRanges element.
+type decodeX14ProtectedRanges struct {
+	XMLName        xml.Name                   `xml:"protectedRanges"`
+	ProtectedRange []*decodeX14ProtectedRange `xml:"protectedRange"`
+	Content        string                     `xml:",innerxml"`
+}
+
+// decodeX14ProtectedRange directly maps the protectedRange element.
+type decodeX14ProtectedRange struct {
+	Name  string `xml:"name,attr"`
+	Sqref string `xml:"sqref"`
+}
+
+// xlsxX14ProtectedRanges directly maps the protectedRanges element.
+type xlsxX14ProtectedRanges struct {
+	XMLName xml.Name `xml:"x14:protectedRanges"`
+	XMLNSXM string   `xml:"xmlns:xm,attr"`
+	Content string   `xml:",innerxml"`
+}
+
+// xlsxX14ProtectedRange directly maps the protectedRange element. This
+// element specifies a range which can be edited after entering the password
+// of the range when the worksheet is protected.
+type xlsxX14ProtectedRange struct {
+	XMLName            xml.Name `xml:"x14:protectedRange"`
+	Password           string   `xml:"password,attr,omitempty"`
+	AlgorithmName      string   `xml:"algorithmName,attr,omitempty"`
+	HashValue          string   `xml:"hashValue,attr,omitempty"`
+	SaltValue          string   `xml:"saltValue,attr,omitempty"`
+	SpinCount          int      `xml:"spinCount,attr,omitempty"`
+	Name               string   `xml:"name,attr"`
+	SecurityDescriptor string   `xml:"securityDescriptor,attr,omitempty"`
+	Sqref              string   `xml:"xm:sqref"`
+}
+
+// decodeX14ConditionalFormattings directly maps the conditionalFormattings
+// element.
+type decodeX14ConditionalFormattings struct {
+	XMLName               xml.Name                          `xml:"conditionalFormattings"`
+	ConditionalFormatting []*decodeX14ConditionalFormatting `xml:"conditionalFormatting"`
+	Content               string                            `xml:",innerxml"`
+}
+
+// decodeX14ConditionalFormatting directly maps the conditionalFormatting
+// element.
+type decodeX14ConditionalFormatting struct {
+	CfRule []*decodeX14CfRule `xml:"cfRule"`
+	SQRef  string             `xml:"sqref"`
+}
+
+// decodeX14CfRule directly maps the cfRule element.
+type decodeX14CfRule struct {
+	Type       string            `xml:"type,attr"`
+	Priority   int               `xml:"priority,attr"`
+	StopIfTrue bool              `xml:"stopIfTrue,attr"`
+	ID         string            `xml:"id,attr"`
+	IconSet    *decodeX14IconSet `xml:"iconSet"`
+}
+
+// decodeX14IconSet directly maps the iconSet element.
+type decodeX14IconSet struct {
+	IconSet string           `xml:"iconSet,attr"`
+	Reverse bool             `xml:"reverse,attr"`
+	Cfvo    []*decodeX14Cfvo `xml:"cfvo"`
+}
+
+// decodeX14Cfvo directly maps the cfvo element.
+type decodeX14Cfvo struct {
+	Type string `xml:"type,attr"`
+	F    string `xml:"f"`
+}
+
+// xlsxX14ConditionalFormattings directly maps the conditionalFormattings
+// element.
+type xlsxX14ConditionalFormattings struct {
+	XMLName xml.Name `xml:"x14:conditionalFormattings"`
+	Content string   `xml:",innerxml"`
+}
+
+// xlsxX14ConditionalFormatting directly maps the conditionalFormatting
+// element.
+type xlsxX14ConditionalFormatting struct {
+	XMLName xml.Name         `xml:"x14:conditionalFormatting"`
+	XMLNSXM string           `xml:"xmlns:xm,attr"`
+	CfRule  []*xlsxX14CfRule `xml:"x14:cfRule"`
+	SQRef   string           `xml:"xm:sqref"`
+}
+
+// xlsxX14CfRule directly maps the cfRule element.
+type xlsxX14CfRule struct {
+	Type       string          `xml:"type,attr,omitempty"`
+	Priority   int             `xml:"priority,attr,omitempty"`
+	StopIfTrue bool            `xml:"stopIfTrue,attr,omitempty"`
+	ID         string          `xml:"id,attr,omitempty"`
+	IconSet    *xlsxX14IconSet `xml:"x14:iconSet"`
+}
+
+// xlsxX14IconSet directly maps the iconSet element.
+type xlsxX14IconSet struct {
+	IconSet string         `xml:"iconSet,attr,omitempty"`
+	Reverse bool           `xml:"reverse,attr,omitempty"`
+	Cfvo    []*xlsxX14Cfvo `xml:"x14:cfvo"`
+}
+
+// xlsxX14Cfvo directly maps the cfvo element.
+type xlsxX14Cfvo struct {
+	Type string `xml:"type,attr"`
+	F    string `xml:"xm:f"`
+}
+
 // SparklineOption directly maps the settings of the sparkline.
 type SparklineOption struct {
 	Location      []string
 	Range         []string
-	Max           int
+	Max           float64
 	CustMax       int
-	Min           int
+	Min           float64
 	CustMin       int
+	MinAxisType   string
+	MaxAxisType   string
 	Type          string
 	Weight        float64
 	DateAxis      bool
+	DateRange     string
 	Markers       bool
 	High          bool
 	Low           bool
@@ -811,33 +1033,54 @@ type formatPanes struct {
 	} `json:"panes"`
 }
 
+// FreezePanesOptions directly maps the settings of the freeze panes. Cols and
+// Rows specify the number of frozen columns and rows, TopLeftCell specifies
+// the top left visible cell in the bottom right pane.
+type FreezePanesOptions struct {
+	Cols        int
+	Rows        int
+	TopLeftCell string
+}
+
 // formatConditional directly maps the conditional format settings of the cells.
 type formatConditional struct {
-	Type         string `json:"type"`
-	AboveAverage bool   `json:"above_average"`
-	Percent      bool   `json:"percent"`
-	Format       int    `json:"format"`
-	Criteria     string `json:"criteria"`
-	Value        string `json:"value,omitempty"`
-	Minimum      string `json:"minimum,omitempty"`
-	Maximum      string `json:"maximum,omitempty"`
-	MinType      string `json:"min_type,omitempty"`
-	MidType      string `json:"mid_type,omitempty"`
-	MaxType      string `json:"max_type,omitempty"`
-	MinValue     string `json:"min_value,omitempty"`
-	MidValue     string `json:"mid_value,omitempty"`
-	MaxValue     string `json:"max_value,omitempty"`
-	MinColor     string `json:"min_color,omitempty"`
-	MidColor     string `json:"mid_color,omitempty"`
-	MaxColor     string `json:"max_color,omitempty"`
-	MinLength    string `json:"min_length,omitempty"`
-	MaxLength    string `json:"max_length,omitempty"`
-	MultiRange   string `json:"multi_range,omitempty"`
-	BarColor     string `json:"bar_color,omitempty"`
+	Type         string                       `json:"type"`
+	AboveAverage bool                         `json:"above_average"`
+	Percent      bool                         `json:"percent"`
+	Format       int                          `json:"format"`
+	Criteria     string                       `json:"criteria"`
+	Value        string                       `json:"value,omitempty"`
+	Minimum      string                       `json:"minimum,omitempty"`
+	Maximum      string                       `json:"maximum,omitempty"`
+	MinType      string                       `json:"min_type,omitempty"`
+	MidType      string                       `json:"mid_type,omitempty"`
+	MaxType      string                       `json:"max_type,omitempty"`
+	MinValue     string                       `json:"min_value,omitempty"`
+	MidValue     string                       `json:"mid_value,omitempty"`
+	MaxValue     string                       `json:"max_value,omitempty"`
+	MinColor     string                       `json:"min_color,omitempty"`
+	MidColor     string                       `json:"mid_color,omitempty"`
+	MaxColor     string                       `json:"max_color,omitempty"`
+	MinLength    string                       `json:"min_length,omitempty"`
+	MaxLength    string                       `json:"max_length,omitempty"`
+	MultiRange   string                       `json:"multi_range,omitempty"`
+	BarColor     string                       `json:"bar_color,omitempty"`
+	IconStyle    string                       `json:"icon_style,omitempty"`
+	Reverse      bool                         `json:"reverse,omitempty"`
+	Thresholds   []formatConditionalThreshold `json:"thresholds,omitempty"`
+	StopIfTrue   bool                         `json:"stop_if_true,omitempty"`
+}
+
+// formatConditionalThreshold directly maps the threshold settings of the icon
+// set conditional format.
+type formatConditionalThreshold struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
 }
 
 // FormatSheetProtection directly maps the settings of worksheet protection.
 type FormatSheetProtection struct {
+	AlgorithmName       string
 	AutoFilter          bool
 	DeleteColumns       bool
 	DeleteRows          bool
@@ -851,11 +1094,20 @@ type FormatSheetProtection struct {
 	InsertRows          bool
 	Password            string
 	PivotTables         bool
+	ProtectionAlgorithm string
 	SelectLockedCells   bool
 	SelectUnlockedCells bool
 	Sort                bool
 }
 
+// ProtectedRangeOptions directly maps the settings of the protected range
+// of the worksheet.
+type ProtectedRangeOptions struct {
+	Name     string
+	Range    string
+	Password string
+}
+
 // FormatHeaderFooter directly maps the settings of header and footer.
 type FormatHeaderFooter struct {
 	AlignWithMargins bool
@@ -870,6 +1122,16 @@ type FormatHeaderFooter struct {
 	FirstHeader      string
 }
 
+// HeaderFooterImageOptions directly maps the settings of the image in the
+// header or footer of the worksheet. Position specifies the section of the
+// header or footer, the possible values are "left", "center" and "right".
+type HeaderFooterImageOptions struct {
+	Position  string
+	IsFooter  bool
+	Extension string
+	File      []byte
+}
+
 // FormatPageMargins directly maps the settings of page margins
 type FormatPageMargins struct {
 	Bottom string